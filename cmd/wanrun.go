@@ -4,6 +4,12 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -14,6 +20,17 @@ import (
 	"github.com/wanrun-develop/wanrun/configs"
 	"github.com/wanrun-develop/wanrun/internal"
 
+	//admin
+	adminRepository "github.com/wanrun-develop/wanrun/internal/admin/adapters/repository"
+	adminController "github.com/wanrun-develop/wanrun/internal/admin/controller"
+	adminHandler "github.com/wanrun-develop/wanrun/internal/admin/core/handler"
+
+	//audit
+	auditRepository "github.com/wanrun-develop/wanrun/internal/audit/adapters/repository"
+	auditController "github.com/wanrun-develop/wanrun/internal/audit/controller"
+	auditHandler "github.com/wanrun-develop/wanrun/internal/audit/core/handler"
+	auditFacade "github.com/wanrun-develop/wanrun/internal/audit/facade"
+
 	//auth
 	authRepository "github.com/wanrun-develop/wanrun/internal/auth/adapters/repository"
 	authController "github.com/wanrun-develop/wanrun/internal/auth/controller"
@@ -23,9 +40,12 @@ import (
 
 	//cms
 	cmsAWS "github.com/wanrun-develop/wanrun/internal/cms/adapters/aws"
+	cmsLocal "github.com/wanrun-develop/wanrun/internal/cms/adapters/local"
 	cmsRepository "github.com/wanrun-develop/wanrun/internal/cms/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/cms/adapters/storage"
 	cmsController "github.com/wanrun-develop/wanrun/internal/cms/controller"
 	cmsHandler "github.com/wanrun-develop/wanrun/internal/cms/core/handler"
+	cmsFacade "github.com/wanrun-develop/wanrun/internal/cms/facade"
 
 	//dog
 	dogRepository "github.com/wanrun-develop/wanrun/internal/dog/adapters/repository"
@@ -39,7 +59,12 @@ import (
 	dogOwnerHandler "github.com/wanrun-develop/wanrun/internal/dogowner/core/handler"
 
 	//dogrun
+	dogrunGeocoder "github.com/wanrun-develop/wanrun/internal/dogrun/adapters/geocoder"
+	dogrunGeocoderGoogle "github.com/wanrun-develop/wanrun/internal/dogrun/adapters/geocoder/google"
+	dogrunGeocoderLocal "github.com/wanrun-develop/wanrun/internal/dogrun/adapters/geocoder/local"
 	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/googleplace"
+	dogrunPayment "github.com/wanrun-develop/wanrun/internal/dogrun/adapters/payment"
+	dogrunPaymentFake "github.com/wanrun-develop/wanrun/internal/dogrun/adapters/payment/fake"
 	dogrunR "github.com/wanrun-develop/wanrun/internal/dogrun/adapters/repository"
 	dogrunC "github.com/wanrun-develop/wanrun/internal/dogrun/controller"
 	dogrunH "github.com/wanrun-develop/wanrun/internal/dogrun/core/handler"
@@ -49,6 +74,8 @@ import (
 	dogrunmgRepository "github.com/wanrun-develop/wanrun/internal/dogrunmg/adapters/repository"
 
 	//org
+	orgMail "github.com/wanrun-develop/wanrun/internal/org/adapters/mail"
+	orgMailConsole "github.com/wanrun-develop/wanrun/internal/org/adapters/mail/console"
 	orgRepository "github.com/wanrun-develop/wanrun/internal/org/adapters/repository"
 	orgController "github.com/wanrun-develop/wanrun/internal/org/controller"
 	orgHandler "github.com/wanrun-develop/wanrun/internal/org/core/handler"
@@ -105,12 +132,49 @@ func Main() {
 	e.GET("/test", internal.Test, authMW.RoleAuthorization(authMW.ALL))
 
 	// 最大リクエストボディサイズの指定
-	e.Use(middleware.BodyLimit("10M")) // 最大10MB
-
-	e.Logger.Fatal(e.Start(":8080"))
+	e.Use(middleware.BodyLimitWithConfig(middleware.BodyLimitConfig{
+		Limit: "10M", // 最大10MB
+		// ローカルストレージへの署名付きURLでのアップロードは対象外
+		Skipper: func(c echo.Context) bool {
+			return strings.HasPrefix(c.Request().URL.Path, cmsLocal.SIGNED_URL_PATH)
+		},
+	}))
+
+	// 停止シグナルでキャンセルされるコンテキスト
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// google place情報の定期同期
+	var workers sync.WaitGroup
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		newDogrunSync(dbConn).StartSyncWorker(ctx, time.Duration(configs.FetchConfigInt("dogrun.sync.interval"))*time.Minute)
+	}()
+
+	go func() {
+		if err := e.Start(":8080"); err != nil && err != http.ErrServerClosed {
+			e.Logger.Fatal(err)
+		}
+	}()
+
+	// 停止シグナルを受けたら、処理中のリクエストと定期実行の完了を待って終了する
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		e.Logger.Error(err)
+	}
+	workers.Wait()
 }
 
 func newRouter(e *echo.Echo, dbConn *gorm.DB) {
+	// ファイル保存先
+	objectStorage := newObjectStorage(e)
+	// 決済プロバイダ
+	paymentProvider := newPaymentProvider()
+	geocoder := newGeocoder()
+
 	// dog関連
 	dogController := newDog(dbConn)
 	dog := e.Group("dog")
@@ -118,19 +182,89 @@ func newRouter(e *echo.Echo, dbConn *gorm.DB) {
 	dog.GET("/detail/:dogID", dogController.GetDogByID, authMW.RoleAuthorization(authMW.DOG_MANAGE))
 	dog.GET("/owned/:dogOwnerId", dogController.GetDogByDogOwnerID, authMW.RoleAuthorization(authMW.DOG_MANAGE))
 	dog.GET("/mst/dogType", dogController.GetDogTypeMst, authMW.RoleAuthorization(authMW.ALL))
+	dog.GET("/mst/temperament", dogController.GetTemperamentMst, authMW.RoleAuthorization(authMW.ALL))
+	dog.POST("/search", dogController.SearchDogs, authMW.RoleAuthorization(authMW.DOGRUN_REFER))
 	dog.POST("", dogController.CreateDog, authMW.RoleAuthorization(authMW.DOG_MANAGE))
 	dog.PUT("", dogController.UpdateDog, authMW.RoleAuthorization(authMW.DOG_MANAGE))
 	dog.DELETE("", dogController.DeleteDog, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.GET("/:dogID/owners", dogController.GetDogOwners, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.POST("/:dogID/coOwner/invite", dogController.InviteCoOwner, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.DELETE("/:dogID/coOwner/:dogOwnerID", dogController.RemoveCoOwner, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.POST("/:dogID/transfer", dogController.RequestTransfer, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.GET("/invitation", dogController.GetOwnershipInvitations, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.POST("/invitation/:invitationID/accept", dogController.AcceptOwnershipInvitation, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.POST("/invitation/:invitationID/decline", dogController.DeclineOwnershipInvitation, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.DELETE("/invitation/:invitationID", dogController.CancelOwnershipInvitation, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.GET("/:dogID/profile", dogController.GetDogProfile, authMW.RoleAuthorization(authMW.ALL))
+	dog.GET("/:dogID/profile/settings", dogController.GetDogProfileSettings, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.PUT("/:dogID/profile/settings", dogController.SaveDogProfileSettings, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.GET("/:dogID/friend", dogController.GetFriends, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.POST("/:dogID/friend", dogController.RequestFriend, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.GET("/friend/request", dogController.GetFriendRequests, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.POST("/friend/:friendshipID/accept", dogController.AcceptFriendRequest, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.POST("/friend/:friendshipID/decline", dogController.DeclineFriendRequest, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dog.DELETE("/friend/:friendshipID", dogController.DeleteFriendship, authMW.RoleAuthorization(authMW.DOG_MANAGE))
 	// dog.PUT("/:dogID", dogController.UpdateDog)
 
 	// dogrun関連
-	dogrunController := newDogrun(dbConn)
+	dogrunController, dogrunFacade := newDogrun(dbConn, objectStorage, paymentProvider, geocoder)
 	dogrun := e.Group("dogrun")
 	dogrun.GET("/detail/:placeId", dogrunController.GetDogrunDetail, authMW.RoleAuthorization(authMW.DOGRUN_REFER))
 	dogrun.GET("/:id", dogrunController.GetDogrun, authMW.RoleAuthorization(authMW.DOGRUN_REFER))
 	dogrun.GET("/photo/src", dogrunController.GetDogrunPhoto, authMW.RoleAuthorization(authMW.DOGRUN_REFER))
 	dogrun.GET("/mst/tag", dogrunController.GetDogrunTagMst, authMW.RoleAuthorization(authMW.ALL))
 	dogrun.POST("/search", dogrunController.SearchAroundDogruns, authMW.RoleAuthorization(authMW.DOGRUN_SEARCH))
+	dogrun.POST("/search/facet", dogrunController.SearchDogrunTagFacets, authMW.RoleAuthorization(authMW.DOGRUN_SEARCH))
+	dogrun.GET("/:id/image", dogrunController.GetDogrunImages, authMW.RoleAuthorization(authMW.DOGRUN_REFER))
+	dogrun.POST("/:id/image", dogrunController.UploadDogrunImage, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.PUT("/:id/image/sort", dogrunController.SortDogrunImages, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.DELETE("/:id/image/:imageId", dogrunController.DeleteDogrunImage, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.PUT("/:id/info", dogrunController.SaveDogrunInfo, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.POST("/geocode/repair", dogrunController.RepairDogrunLocations, authMW.RoleAuthorization(authMW.SYSTEM))
+	dogrun.POST("/sync", dogrunController.SyncDogrunPlaces, authMW.RoleAuthorization(authMW.SYSTEM))
+	dogrun.GET("/:id/tag", dogrunController.GetDogrunTags, authMW.RoleAuthorization(authMW.DOGRUN_REFER))
+	dogrun.PUT("/:id/tag", dogrunController.SaveDogrunTags, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.GET("/:id/entryCriteria", dogrunController.GetDogrunEntryCriteria, authMW.RoleAuthorization(authMW.DOGRUN_REFER))
+	dogrun.PUT("/:id/entryCriteria", dogrunController.SaveDogrunEntryCriteria, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.GET("/:id/event", dogrunController.GetDogrunEvents, authMW.RoleAuthorization(authMW.DOGRUN_REFER))
+	dogrun.POST("/:id/event", dogrunController.CreateDogrunMeetup, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dogrun.POST("/:id/event/official", dogrunController.CreateDogrunOfficialEvent, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.GET("/event/:eventId", dogrunController.GetDogrunEvent, authMW.RoleAuthorization(authMW.DOGRUN_REFER))
+	dogrun.DELETE("/event/:eventId", dogrunController.CancelDogrunEvent, authMW.RoleAuthorization(authMW.DOGRUN_EVENT_MANAGE))
+	dogrun.POST("/event/:eventId/rsvp", dogrunController.RsvpDogrunEvent, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dogrun.DELETE("/event/:eventId/rsvp", dogrunController.CancelDogrunEventRsvp, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dogrun.GET("/:id/booking/setting", dogrunController.GetDogrunBookingSetting, authMW.RoleAuthorization(authMW.DOGRUN_REFER))
+	dogrun.PUT("/:id/booking/setting", dogrunController.SaveDogrunBookingSetting, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.POST("/:id/booking/slot/generate", dogrunController.GenerateDogrunBookingSlots, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.GET("/:id/booking/slot", dogrunController.GetDogrunBookingSlots, authMW.RoleAuthorization(authMW.DOGRUN_REFER))
+	dogrun.GET("/:id/booking/reservation", dogrunController.GetDogrunReservations, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.POST("/booking/reservation", dogrunController.ReserveDogrunBookingSlot, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dogrun.GET("/booking/reservation", dogrunController.GetMyDogrunReservations, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dogrun.DELETE("/booking/reservation/:reservationId", dogrunController.CancelDogrunReservation, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dogrun.POST("/booking/reservation/:reservationId/noShow", dogrunController.MarkDogrunReservationNoShow, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.GET("/:id/fee", dogrunController.GetDogrunFeePlans, authMW.RoleAuthorization(authMW.DOGRUN_REFER))
+	dogrun.POST("/:id/fee", dogrunController.CreateDogrunFeePlan, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.PUT("/fee/:feePlanId", dogrunController.UpdateDogrunFeePlan, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.DELETE("/fee/:feePlanId", dogrunController.DeactivateDogrunFeePlan, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.GET("/:id/payment/setting", dogrunController.GetDogrunPaymentSetting, authMW.RoleAuthorization(authMW.DOGRUN_REFER))
+	dogrun.PUT("/:id/payment/setting", dogrunController.SaveDogrunPaymentSetting, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.GET("/:id/payment/report", dogrunController.GetDogrunPaymentReport, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.POST("/payment", dogrunController.PayDogrunFee, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dogrun.GET("/payment", dogrunController.GetMyDogrunPayments, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dogrun.POST("/:id/membership", dogrunController.ApplyDogrunMembership, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dogrun.GET("/:id/membership", dogrunController.GetDogrunMemberships, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.GET("/membership", dogrunController.GetMyDogrunMemberships, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dogrun.DELETE("/membership/:membershipId", dogrunController.WithdrawDogrunMembership, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	dogrun.GET("/membership/:membershipId", dogrunController.GetManagedDogrunMembership, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.POST("/membership/:membershipId/approve", dogrunController.ApproveDogrunMembership, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.POST("/membership/:membershipId/reject", dogrunController.RejectDogrunMembership, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	dogrun.POST("/claim", dogrunController.SubmitDogrunClaim, authMW.RoleAuthorization(authMW.DOGRUN_SUPER_MANAGE))
+	dogrun.GET("/claim", dogrunController.GetMyDogrunClaims, authMW.RoleAuthorization(authMW.DOGRUN_SUPER_MANAGE))
+	dogrun.DELETE("/claim/:claimId", dogrunController.WithdrawDogrunClaim, authMW.RoleAuthorization(authMW.DOGRUN_SUPER_MANAGE))
+	dogrun.GET("/claim/review", dogrunController.GetDogrunClaims, authMW.RoleAuthorization(authMW.SYSTEM))
+	dogrun.GET("/claim/review/:claimId", dogrunController.GetDogrunClaim, authMW.RoleAuthorization(authMW.SYSTEM))
+	dogrun.POST("/claim/review/:claimId/approve", dogrunController.ApproveDogrunClaim, authMW.RoleAuthorization(authMW.SYSTEM))
+	dogrun.POST("/claim/review/:claimId/reject", dogrunController.RejectDogrunClaim, authMW.RoleAuthorization(authMW.SYSTEM))
 
 	// dogOwner関連
 	dogOwnerController := newDogOwner(dbConn)
@@ -149,23 +283,37 @@ func newRouter(e *echo.Echo, dbConn *gorm.DB) {
 	auth.POST("/dogrunmg/revoke", authController.RevokeDogrunmg, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
 	//general
 	auth.GET("/general/token", authController.IssueGeneralUserToken)
+	// system
+	auth.POST("/system/token", authController.LogInSystemOperator)
+	auth.POST("/system/revoke", authController.RevokeSystemOperator, authMW.RoleAuthorization(authMW.SYSTEM))
 
 	//interaction関連
-	interactionController := newInteraction(dbConn)
+	interactionController := newInteraction(dbConn, dogrunFacade)
 	bookmark := e.Group("bookmark")
+	bookmark.GET("/dogrun", interactionController.GetBookmarkedDogruns, authMW.RoleAuthorization(authMW.DOGRUN_SEARCH))
 	bookmark.POST("/dogrun", interactionController.AddBookmark, authMW.RoleAuthorization(authMW.DOGRUN_SEARCH))
+	bookmark.PUT("/dogrun", interactionController.UpdateBookmark, authMW.RoleAuthorization(authMW.DOGRUN_SEARCH))
 	bookmark.DELETE("/dogrun", interactionController.DeleteBookmarks, authMW.RoleAuthorization(authMW.DOGRUN_SEARCH))
+	bookmark.GET("/folder", interactionController.GetBookmarkFolders, authMW.RoleAuthorization(authMW.DOGRUN_SEARCH))
+	bookmark.POST("/folder", interactionController.CreateBookmarkFolder, authMW.RoleAuthorization(authMW.DOGRUN_SEARCH))
+	bookmark.PUT("/folder", interactionController.UpdateBookmarkFolder, authMW.RoleAuthorization(authMW.DOGRUN_SEARCH))
+	bookmark.DELETE("/folder", interactionController.DeleteBookmarkFolder, authMW.RoleAuthorization(authMW.DOGRUN_SEARCH))
 
 	access := e.Group("access")
 	access.GET("/today/checkins", interactionController.GetTodayCheckins, authMW.RoleAuthorization(authMW.DOG_MANAGE))
+	access.GET("/today/friends", interactionController.GetTodayFriendCheckins, authMW.RoleAuthorization(authMW.DOG_MANAGE))
 	access.POST("/checkin", interactionController.CheckinDogrun, authMW.RoleAuthorization(authMW.DOG_MANAGE))
 	access.DELETE("/checkout", interactionController.CheckoutDogrun, authMW.RoleAuthorization(authMW.DOG_MANAGE))
 
 	// cms関連
-	cmsController := newCms(dbConn)
+	cmsController := newCms(dbConn, objectStorage)
 	cms := e.Group("cms")
 	cms.POST("/upload/file", cmsController.UploadFile, authMW.RoleAuthorization(authMW.ALL))
+	cms.POST("/upload/url", cmsController.IssueUploadURL, authMW.RoleAuthorization(authMW.ALL))
+	cms.GET("/file/:fileId/url", cmsController.GetFileURL, authMW.RoleAuthorization(authMW.ALL))
 	cms.DELETE("", cmsController.DeleteFile, authMW.RoleAuthorization(authMW.ALL))
+	cms.GET("/quota", cmsController.GetQuota, authMW.RoleAuthorization(authMW.ALL))
+	cms.POST("/gc", cmsController.RunOrphanGC, authMW.RoleAuthorization(authMW.SYSTEM))
 
 	// ヘルスチェック
 	e.GET("/health", func(c echo.Context) error {
@@ -173,36 +321,134 @@ func newRouter(e *echo.Echo, dbConn *gorm.DB) {
 	})
 
 	// org関連
-	orgController := newOrg(dbConn)
+	orgController := newOrg(dbConn, newMailSender())
 	org := e.Group("org")
 	org.POST("/contract", orgController.OrgSignUp)
+	org.GET("", orgController.GetOrg, authMW.RoleAuthorization(authMW.DOGRUN_MANAGE))
+	org.PUT("", orgController.UpdateOrg, authMW.RoleAuthorization(authMW.DOGRUN_SUPER_MANAGE))
+	org.DELETE("", orgController.CloseOrg, authMW.RoleAuthorization(authMW.DOGRUN_SUPER_MANAGE))
+	org.GET("/manager", orgController.GetManagers, authMW.RoleAuthorization(authMW.DOGRUN_SUPER_MANAGE))
+	org.POST("/manager/:managerId/deactivate", orgController.DeactivateManager, authMW.RoleAuthorization(authMW.DOGRUN_SUPER_MANAGE))
+	org.POST("/manager/:managerId/promote", orgController.PromoteManager, authMW.RoleAuthorization(authMW.DOGRUN_SUPER_MANAGE))
+	org.POST("/dogrun/:dogrunId/transfer", orgController.TransferDogrun, authMW.RoleAuthorization(authMW.DOGRUN_SUPER_MANAGE))
+	org.GET("/invitation", orgController.GetInvitations, authMW.RoleAuthorization(authMW.DOGRUN_SUPER_MANAGE))
+	org.POST("/invitation", orgController.InviteManager, authMW.RoleAuthorization(authMW.DOGRUN_SUPER_MANAGE))
+	org.DELETE("/invitation/:invitationId", orgController.CancelInvitation, authMW.RoleAuthorization(authMW.DOGRUN_SUPER_MANAGE))
+	org.POST("/invitation/accept", orgController.AcceptInvitation)
+
+	// 運営者の管理画面関連
+	adminController := newAdmin(dbConn, objectStorage)
+	admin := e.Group("admin")
+	admin.GET("/dogowner", adminController.SearchDogOwners, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.GET("/dogowner/:dogOwnerId", adminController.GetDogOwner, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.POST("/dogowner/:dogOwnerId/suspend", adminController.SuspendDogOwner, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.POST("/dogowner/:dogOwnerId/unsuspend", adminController.UnsuspendDogOwner, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.GET("/dogrunmg", adminController.SearchDogrunmgs, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.GET("/dogrunmg/:dogrunmgId", adminController.GetDogrunmg, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.POST("/dogrunmg/:dogrunmgId/suspend", adminController.SuspendDogrunmg, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.POST("/dogrunmg/:dogrunmgId/unsuspend", adminController.UnsuspendDogrunmg, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.GET("/dog", adminController.SearchDogs, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.GET("/dog/:dogId", adminController.GetDog, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.GET("/dogrun", adminController.SearchDogruns, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.GET("/dogrun/:dogrunId", adminController.GetDogrun, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.POST("/dogrun/:dogrunId/merge", adminController.MergeDogrun, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.GET("/org", adminController.SearchOrgs, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.GET("/org/:orgId", adminController.GetOrg, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.GET("/upload", adminController.SearchUploads, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.GET("/upload/:fileId", adminController.GetUpload, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.GET("/mst/tag", adminController.GetTagMsts, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.POST("/mst/tag", adminController.CreateTagMst, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.PUT("/mst/tag/:tagId", adminController.UpdateTagMst, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.DELETE("/mst/tag/:tagId", adminController.DeleteTagMst, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.GET("/mst/dogType", adminController.GetDogTypeMsts, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.POST("/mst/dogType", adminController.CreateDogTypeMst, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.PUT("/mst/dogType/:dogTypeId", adminController.UpdateDogTypeMst, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.DELETE("/mst/dogType/:dogTypeId", adminController.DeleteDogTypeMst, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.POST("/moderation", adminController.Moderate, authMW.RoleAuthorization(authMW.SYSTEM))
+	admin.POST("/operator", adminController.CreateOperator, authMW.RoleAuthorization(authMW.SYSTEM))
+
+	// 監査ログ関連
+	auditController := newAudit(dbConn)
+	admin.GET("/audit", auditController.GetAuditLogs, authMW.RoleAuthorization(authMW.SYSTEM))
 }
 
 // dogの初期化
 func newDog(dbConn *gorm.DB) dogController.IDogController {
 	dogRepository := dogRepository.NewDogRepository(dbConn)
 	dogOwnerRepository := dogOwnerRepository.NewDogRepository(dbConn)
-	dogHandler := dogHandler.NewDogHandler(dogRepository, dogOwnerRepository)
-	dogController := dogController.NewDogController(dogHandler)
+	dogOwnershipHandler := dogHandler.NewDogOwnershipHandler(dogRepository, dogOwnerRepository)
+	dogSocialHandler := dogHandler.NewDogSocialHandler(dogRepository)
+	dogHandler := dogHandler.NewDogHandler(
+		dogRepository,
+		dogOwnerRepository,
+		dogrunR.NewDogrunReservationScopeRepository(),
+		dogrunR.NewDogrunMembershipScopeRepository(),
+		dogrunR.NewDogrunEventScopeRepository(),
+		transaction.NewTransactionManager(dbConn),
+		newAuditFacade(dbConn),
+	)
+	dogController := dogController.NewDogController(dogHandler, dogOwnershipHandler, dogSocialHandler)
 	return dogController
 }
 
-func newDogrun(dbConn *gorm.DB) dogrunC.IDogrunController {
+// dogrunの初期化。他ドメインで使うdogrun facadeも同じhandlerから作成して返す
+func newDogrun(dbConn *gorm.DB, objectStorage storage.IObjectStorage, paymentProvider dogrunPayment.IPaymentProvider, geocoder dogrunGeocoder.IGeocoder) (dogrunC.IDogrunController, dogrunF.IDogrunFacade) {
 	//facadeの準備
 	interactionRepository := interactionR.NewBookmarkRepository(dbConn)
-	dogrunFacade := interactionFacade.NewBookmarkFacade(interactionRepository)
+	bookmarkFacade := interactionFacade.NewBookmarkFacade(interactionRepository)
 
 	dogrunRest := googleplace.NewRest()
 	dogrunRepository := dogrunR.NewDogrunRepository(dbConn)
-	dogrunHandler := dogrunH.NewDogrunHandler(dogrunRest, dogrunRepository, dogrunFacade)
-	return dogrunC.NewDogrunController(dogrunHandler)
+	dogrunHandler := dogrunH.NewDogrunHandler(dogrunRest, dogrunRepository, bookmarkFacade)
+	cmsFacade := newCmsFacade(dbConn, objectStorage)
+	dogrunImageHandler := dogrunH.NewDogrunImageHandler(dogrunRepository, cmsFacade)
+	dogrunEntryHandler := dogrunH.NewDogrunEntryHandler(dogrunRepository)
+	dogRepository := dogRepository.NewDogRepository(dbConn)
+	dogFacade := dogF.NewDogFacade(dogRepository, dogHandler.NewDogSocialHandler(dogRepository))
+	dogrunEventHandler := dogrunH.NewDogrunEventHandler(dogrunRepository, dogrunR.NewDogrunEventRepository(dbConn), dogFacade)
+	dogrunPaymentRepository := dogrunR.NewDogrunPaymentRepository(dbConn)
+	dogrunReservationHandler := dogrunH.NewDogrunReservationHandler(
+		dogrunRepository,
+		dogrunR.NewDogrunReservationRepository(dbConn),
+		dogrunR.NewDogrunReservationScopeRepository(),
+		dogrunPaymentRepository,
+		transaction.NewTransactionManager(dbConn),
+		dogFacade,
+	)
+	dogrunPaymentHandler := dogrunH.NewDogrunPaymentHandler(dogrunRepository, dogrunPaymentRepository, paymentProvider, dogFacade)
+	dogrunMembershipHandler := dogrunH.NewDogrunMembershipHandler(dogrunRepository, dogrunR.NewDogrunMembershipRepository(dbConn), cmsFacade, dogFacade)
+	dogrunClaimHandler := dogrunH.NewDogrunClaimHandler(
+		dogrunRepository,
+		dogrunHandler,
+		dogrunR.NewDogrunClaimRepository(dbConn),
+		dogrunR.NewDogrunClaimScopeRepository(),
+		transaction.NewTransactionManager(dbConn),
+		cmsFacade,
+	)
+	dogrunTagHandler := dogrunH.NewDogrunTagHandler(dogrunRepository)
+	dogrunGeocodeHandler := dogrunH.NewDogrunGeocodeHandler(dogrunRepository, geocoder)
+	dogrunSyncHandler := newDogrunSync(dbConn)
+	dogrunController := dogrunC.NewDogrunController(dogrunHandler, dogrunImageHandler, dogrunEntryHandler, dogrunEventHandler, dogrunReservationHandler, dogrunPaymentHandler, dogrunMembershipHandler, dogrunClaimHandler, dogrunTagHandler, dogrunGeocodeHandler, dogrunSyncHandler)
+	dogrunFacade := dogrunF.NewDogrunFacade(dogrunRepository, dogrunHandler, dogrunPaymentHandler, dogrunMembershipHandler)
+	return dogrunController, dogrunFacade
+}
+
+// google place情報の同期の初期化。APIと定期同期で使用する
+func newDogrunSync(dbConn *gorm.DB) dogrunH.IDogrunSyncHandler {
+	return dogrunH.NewDogrunSyncHandler(
+		googleplace.NewRest(),
+		dogrunR.NewDogrunRepository(dbConn),
+		dogrunR.NewDogrunPlaceSyncScopeRepository(),
+		transaction.NewTransactionManager(dbConn),
+		configs.FetchConfigInt("dogrun.sync.batch.size"),
+	)
 }
 
 func newAuth(dbConn *gorm.DB) authController.IAuthController {
 	authRepository := authRepository.NewAuthRepository(dbConn)
 	// googleOAuth := google.NewOAuthGoogle()
 	// authHandler := authHandler.NewAuthHandler(authRepository, googleOAuth)
-	authHandler := authHandler.NewAuthHandler(authRepository)
+	authHandler := authHandler.NewAuthHandler(authRepository, newAuditFacade(dbConn))
 	authController := authController.NewAuthController(authHandler)
 	return authController
 }
@@ -212,17 +458,17 @@ func newAuthMiddleware(dbConn *gorm.DB) authMW.IAuthJwt {
 	return authMW.NewAuthJwt(authRepository)
 }
 
-func newInteraction(dbConn *gorm.DB) interactionC.IInteractionController {
-	//dogrun facadeの準備
-	dogrunRepository := dogrunR.NewDogrunRepository(dbConn)
-	dogrunFacade := dogrunF.NewDogrunFacade(dogrunRepository)
+// interactionの初期化。dogrun facadeはnewDogrunで作成したものを使う
+func newInteraction(dbConn *gorm.DB, dogrunFacade dogrunF.IDogrunFacade) interactionC.IInteractionController {
 	//dog facadeの準備
 	dogRepository := dogRepository.NewDogRepository(dbConn)
-	dogFacade := dogF.NewDogFacade(dogRepository)
+	dogFacade := dogF.NewDogFacade(dogRepository, dogHandler.NewDogSocialHandler(dogRepository))
 
 	//bookmark
 	bookmarkRepository := interactionR.NewBookmarkRepository(dbConn)
-	bookmarkHandler := interactionH.NewBookmarkHandler(bookmarkRepository, dogrunFacade)
+	bookmarkScopeRepository := interactionR.NewBookmarkScopeRepository()
+	transactionManager := transaction.NewTransactionManager(dbConn)
+	bookmarkHandler := interactionH.NewBookmarkHandler(bookmarkRepository, bookmarkScopeRepository, transactionManager, dogrunFacade)
 	//checkinout
 	checkInOutRepository := interactionR.NewCheckInOutRepository(dbConn)
 	checkInOutHandler := interactionH.NewCheckInOutHandler(checkInOutRepository, dogrunFacade, dogFacade)
@@ -243,32 +489,110 @@ func newDogOwner(dbConn *gorm.DB) dogOwnerController.IDogOwnerController {
 	dosr := dogOwnerRepository.NewDogOwnerScopeRepository()
 	asr := authRepository.NewAuthScopeRepository()
 
+	// facade層
+	auditFacade := newAuditFacade(dbConn)
+
 	// handler層
-	authHandler := authHandler.NewAuthHandler(ar)
+	authHandler := authHandler.NewAuthHandler(ar, auditFacade)
 	dogOwnerHandler := dogOwnerHandler.NewDogOwnerHandler(
 		dosr,
 		transactionManager,
 		asr,
 		dor,
 		ar,
+		auditFacade,
 	)
 
 	// controller層
 	return dogOwnerController.NewDogOwnerController(dogOwnerHandler, authHandler)
 }
 
-func newCms(dbConn *gorm.DB) cmsController.ICmsController {
+func newCms(dbConn *gorm.DB, objectStorage storage.IObjectStorage) cmsController.ICmsController {
 	cmsRepository := cmsRepository.NewCmsRepository(dbConn)
+	cmsHandler := cmsHandler.NewCmsHandler(objectStorage, cmsRepository, newAuditFacade(dbConn))
+	cmsController := cmsController.NewCmsController(cmsHandler)
+	return cmsController
+}
+
+// 他ドメインから利用するcms facadeの初期化。アップロードはs3_file_infoへの登録を伴う
+func newCmsFacade(dbConn *gorm.DB, objectStorage storage.IObjectStorage) cmsFacade.ICmsFacade {
+	cmsRepository := cmsRepository.NewCmsRepository(dbConn)
+	cmsHandler := cmsHandler.NewCmsHandler(objectStorage, cmsRepository, newAuditFacade(dbConn))
+	return cmsFacade.NewCmsFacade(objectStorage, cmsRepository, cmsHandler)
+}
+
+// ファイル保存先の初期化。設定によりS3かローカルを選択する
+func newObjectStorage(e *echo.Echo) storage.IObjectStorage {
+	if configs.FetchConfigStr("cms.storage.type") == storage.STORAGE_TYPE_LOCAL {
+		// 署名用の秘密鍵はjwtと分けて、専用の鍵を必須とする
+		signKey := configs.FetchConfigStr("cms.storage.sign.key")
+		if signKey == "" {
+			log.Fatalf("ローカル保存の署名用の秘密鍵(CMS_STORAGE_SIGN_KEY)が未設定")
+		}
+		localStorage := cmsLocal.NewLocalStorage(
+			configs.FetchConfigStr("cms.storage.local.dir"),
+			configs.FetchConfigStr("cms.storage.base.url"),
+			signKey,
+		)
+		// 署名付きURLでの取得・アップロード
+		e.GET(cmsLocal.SIGNED_URL_PATH+"*", localStorage.ServeSignedObject)
+		e.PUT(cmsLocal.SIGNED_URL_PATH+"*", localStorage.ServeSignedObject)
+		return localStorage
+	}
+
 	// aws設定
 	sdkCfg, err := loadAWSConfig()
 
 	if err != nil {
 		log.Fatalf("AWSのクレデンシャル取得に失敗: %v", err)
 	}
-	cmsAWS := cmsAWS.NewS3Provider(sdkCfg)
-	cmsHandler := cmsHandler.NewCmsHandler(cmsAWS, cmsRepository)
-	cmsController := cmsController.NewCmsController(cmsHandler)
-	return cmsController
+	return cmsAWS.NewS3Provider(sdkCfg)
+}
+
+// 決済プロバイダの初期化。現状は疑似決済のみ対応
+func newPaymentProvider() dogrunPayment.IPaymentProvider {
+	providerType := configs.FetchConfigStr("payment.provider.type")
+	if providerType == "" {
+		log.Fatalf("決済プロバイダが設定されていません(PAYMENT_PROVIDER_TYPE)")
+	}
+	if providerType == dogrunPayment.PROVIDER_TYPE_FAKE {
+		return dogrunPaymentFake.NewFakePaymentProvider()
+	}
+	log.Fatalf("未対応の決済プロバイダ: %s", providerType)
+	return nil
+}
+
+// ジオコーディングの初期化。設定によりGoogle Geocoding APIか郵便番号CSVを選択する
+func newGeocoder() dogrunGeocoder.IGeocoder {
+	geocoderType := configs.FetchConfigStr("geocoder.type")
+	switch geocoderType {
+	case dogrunGeocoder.GEOCODER_TYPE_GOOGLE:
+		return dogrunGeocoderGoogle.NewGoogleGeocoder(configs.FetchConfigStr("google.place.api.key"))
+	case dogrunGeocoder.GEOCODER_TYPE_LOCAL:
+		postcodeGeocoder, err := dogrunGeocoderLocal.NewPostcodeGeocoder(configs.FetchConfigStr("geocoder.postcode.csv"))
+		if err != nil {
+			log.Fatalf("郵便番号CSVの読み込みに失敗: %v", err)
+		}
+		if !postcodeGeocoder.HasLocations() {
+			log.Println("郵便番号CSVに緯度・経度の列がないため、座標は補完されません(misc/postcode/README.md参照)")
+		}
+		return postcodeGeocoder
+	}
+	log.Fatalf("未対応のジオコーディングの方法: %s", geocoderType)
+	return nil
+}
+
+// メール送信方法の初期化。現状はログへの出力のみ対応
+func newMailSender() orgMail.IMailSender {
+	senderType := configs.FetchConfigStr("mail.sender.type")
+	if senderType == "" {
+		log.Fatalf("メール送信方法が設定されていません(MAIL_SENDER_TYPE)")
+	}
+	if senderType == orgMail.SENDER_TYPE_CONSOLE {
+		return orgMailConsole.NewConsoleMailSender()
+	}
+	log.Fatalf("未対応のメール送信方法: %s", senderType)
+	return nil
 }
 
 func loadAWSConfig() (aws.Config, error) {
@@ -292,9 +616,9 @@ func loadAWSConfig() (aws.Config, error) {
 	)
 }
 
-func newOrg(dbConn *gorm.DB) orgController.IOrgController {
+func newOrg(dbConn *gorm.DB, mailSender orgMail.IMailSender) orgController.IOrgController {
 	// repository層
-	// orgRepository := orgRepository.NewOrgRepository(dbConn)
+	orgRepo := orgRepository.NewOrgRepository(dbConn)
 	ar := authRepository.NewAuthRepository(dbConn)
 
 	// scopeRepository層
@@ -306,17 +630,69 @@ func newOrg(dbConn *gorm.DB) orgController.IOrgController {
 	transactionManager := transaction.NewTransactionManager(dbConn)
 
 	// facade層
-	authFacade := authFacade.NewAuthFacade(ar)
+	auditFacade := newAuditFacade(dbConn)
+	authFacade := authFacade.NewAuthFacade(ar, auditFacade)
 
 	// handler層
+	orgManagerHandler := orgHandler.NewOrgManagerHandler(
+		orgRepo,
+		orgScopeRepository,
+		transactionManager,
+		dogrunmgScopeRepository,
+		authScopeRepository,
+		authFacade,
+		mailSender,
+		auditFacade,
+	)
 	orgHandler := orgHandler.NewOrgHandler(
+		orgRepo,
 		orgScopeRepository,
 		transactionManager,
 		dogrunmgScopeRepository,
 		authScopeRepository,
 		authFacade,
+		auditFacade,
 	)
 
 	// controller層
-	return orgController.NewOrgController(orgHandler)
+	return orgController.NewOrgController(orgHandler, orgManagerHandler)
+}
+
+func newAdmin(dbConn *gorm.DB, objectStorage storage.IObjectStorage) adminController.IAdminController {
+	// repository層
+	adminRepo := adminRepository.NewAdminRepository(dbConn)
+	ar := authRepository.NewAuthRepository(dbConn)
+
+	// scopeRepository層
+	adminScopeRepository := adminRepository.NewAdminScopeRepository()
+
+	// transaction層
+	transactionManager := transaction.NewTransactionManager(dbConn)
+
+	// facade層
+	auditFacade := newAuditFacade(dbConn)
+	authFacade := authFacade.NewAuthFacade(ar, auditFacade)
+	cmsFacade := newCmsFacade(dbConn, objectStorage)
+
+	// handler層
+	adminAccountHandler := adminHandler.NewAdminAccountHandler(adminRepo, adminScopeRepository, transactionManager, authFacade, auditFacade)
+	adminContentHandler := adminHandler.NewAdminContentHandler(adminRepo, adminScopeRepository, transactionManager, auditFacade)
+	adminHandler := adminHandler.NewAdminHandler(adminRepo, cmsFacade)
+
+	// controller層
+	return adminController.NewAdminController(adminHandler, adminAccountHandler, adminContentHandler)
+}
+
+func newAudit(dbConn *gorm.DB) auditController.IAuditController {
+	auditRepository := auditRepository.NewAuditRepository(dbConn)
+	auditHandler := auditHandler.NewAuditHandler(auditRepository)
+	return auditController.NewAuditController(auditHandler)
+}
+
+// 監査ログの記録の初期化。呼び出し元のトランザクションに参加して記録する
+func newAuditFacade(dbConn *gorm.DB) auditFacade.IAuditFacade {
+	return auditFacade.NewAuditFacade(
+		auditRepository.NewAuditScopeRepository(),
+		transaction.NewTransactionManager(dbConn),
+	)
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"runtime"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
@@ -42,6 +44,7 @@ func LoadConfig() error {
 	v.SetConfigName("config-" + profile)     // 設定ファイル名を拡張子抜きで指定する
 	v.AddConfigPath("./configs/")            // 設定ファイルの探索パスを指定する
 	v.AddConfigPath(".")                     // 現在のワーキングディレクトリを探索することもできる
+	v.AddConfigPath(sourceDir())             // パッケージのディレクトリで実行するテスト用に、このファイルのディレクトリも探索する
 	if err := v.ReadInConfig(); err != nil { // 設定ファイルを探索して読み取る
 		return err
 	}
@@ -58,6 +61,17 @@ func LoadConfig() error {
 	return nil
 }
 
+/*
+このファイルのあるディレクトリ。取得できない場合はカレントディレクトリ
+*/
+func sourceDir() string {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		return "."
+	}
+	return filepath.Dir(file)
+}
+
 // クロージャーのエラーを外に出すよう
 func CheckConfigChangeError() error {
 	return configChangeError
@@ -83,6 +97,32 @@ func bindEnvs() {
 	_ = v.BindEnv("aws.access.key", "AWS_ACCESS_KEY")               // awsのアクセスキー
 	_ = v.BindEnv("aws.secret.access.key", "AWS_SECRET_ACCESS_KEY") // awsのシークレットアクセスキー
 	_ = v.BindEnv("aws.s3.bucket.name", "AWS_S3_BUCKET_NAME")       // awsのbucket名
+	_ = v.BindEnv("cms.storage.type", "CMS_STORAGE_TYPE")           // ファイル保存先(s3 or local)
+	_ = v.BindEnv("cms.storage.local.dir", "CMS_STORAGE_LOCAL_DIR") // localの場合の保存ディレクトリ
+	_ = v.BindEnv("cms.storage.base.url", "CMS_STORAGE_BASE_URL")   // localの場合の署名付きURLのベースURL
+	_ = v.BindEnv("cms.storage.sign.key", "CMS_STORAGE_SIGN_KEY")   // localの場合の署名付きURLの署名用の秘密鍵
+	_ = v.BindEnv("cms.upload.max.bytes", "CMS_UPLOAD_MAX_BYTES")   // アップロードファイルの最大サイズ
+	_ = v.BindEnv("cms.image.max.pixels", "CMS_IMAGE_MAX_PIXELS")   // アップロード画像の最大画素数(幅x高さ)
+	_ = v.BindEnv("cms.presign.max.bytes", "CMS_PRESIGN_MAX_BYTES") // 署名付きURLでのアップロードの最大サイズ
+	_ = v.BindEnv("cms.presign.expires", "CMS_PRESIGN_EXPIRES")     // 署名付きURLの有効期間(秒)
+	_ = v.BindEnv("cms.gc.grace.hours", "CMS_GC_GRACE_HOURS")       // 孤立ファイル削除までの猶予期間(時間)
+
+	_ = v.BindEnv("cms.quota.dogowner.bytes", "CMS_QUOTA_DOGOWNER_BYTES") // dogownerの容量上限
+	_ = v.BindEnv("cms.quota.dogrunmg.bytes", "CMS_QUOTA_DOGRUNMG_BYTES") // dogrunmgの容量上限
+	_ = v.BindEnv("cms.quota.org.bytes", "CMS_QUOTA_ORG_BYTES")           // orgの容量上限
+	_ = v.BindEnv("payment.provider.type", "PAYMENT_PROVIDER_TYPE")       // 決済プロバイダ(fake)。未設定の場合は起動しない
+	_ = v.BindEnv("mail.sender.type", "MAIL_SENDER_TYPE")                 // メール送信方法(console)。未設定の場合は起動しない
+	_ = v.BindEnv("org.invitation.url", "ORG_INVITATION_URL")             // マネージャー招待の承諾画面のURL
+	_ = v.BindEnv("geocoder.type", "GEOCODER_TYPE")                       // ジオコーディングの方法(google or local)
+	_ = v.BindEnv("geocoder.postcode.csv", "GEOCODER_POSTCODE_CSV")       // localの場合の郵便番号CSVのパス
+	_ = v.BindEnv("dogrun.sync.interval", "DOGRUN_SYNC_INTERVAL")         // google place情報の定期同期の間隔(分)。0で無効
+	_ = v.BindEnv("dogrun.sync.batch.size", "DOGRUN_SYNC_BATCH_SIZE")     // google place情報の1回の同期件数
+
+	// ドッグラン情報のマージ。項目ごとの優先順は設定ファイルの dogrun.merge.field.<項目>.priority で上書きできる
+	_ = v.BindEnv("dogrun.merge.priority", "DOGRUN_MERGE_PRIORITY")                       // 優先する出所の順(カンマ区切り)
+	_ = v.BindEnv("dogrun.merge.stale.days.manager", "DOGRUN_MERGE_STALE_DAYS_MANAGER")   // マネージャーの値を古いとみなす日数。0で無期限
+	_ = v.BindEnv("dogrun.merge.stale.days.imported", "DOGRUN_MERGE_STALE_DAYS_IMPORTED") // 取り込みの値を古いとみなす日数。0で無期限
+	_ = v.BindEnv("dogrun.merge.stale.days.google", "DOGRUN_MERGE_STALE_DAYS_GOOGLE")     // googleの値を古いとみなす日数。0で無期限
 }
 
 /*
@@ -94,6 +134,26 @@ func setDefaults() {
 	v.SetDefault("postgres.user", "wanrun")
 	v.SetDefault("postgres.password", "__dummdy__")
 	v.SetDefault("postgres.dbname", "dbname")
+	v.SetDefault("cms.storage.type", "s3")
+	v.SetDefault("cms.storage.local.dir", "./storage")
+	v.SetDefault("cms.storage.base.url", "http://localhost:8080")
+	v.SetDefault("cms.upload.max.bytes", 10*1024*1024)
+	v.SetDefault("cms.image.max.pixels", 40*1000*1000)
+	v.SetDefault("cms.presign.max.bytes", 100*1024*1024)
+	v.SetDefault("cms.presign.expires", 300)
+	v.SetDefault("cms.gc.grace.hours", 24)
+	v.SetDefault("cms.quota.dogowner.bytes", 500*1024*1024)
+	v.SetDefault("cms.quota.dogrunmg.bytes", 1024*1024*1024)
+	v.SetDefault("cms.quota.org.bytes", 5*1024*1024*1024)
+	v.SetDefault("org.invitation.url", "http://localhost:3000/org/invitation/accept")
+	v.SetDefault("geocoder.type", "google")
+	v.SetDefault("geocoder.postcode.csv", "./misc/postcode/utf_ken_all.csv")
+	v.SetDefault("dogrun.sync.interval", 0)
+	v.SetDefault("dogrun.sync.batch.size", 100)
+	v.SetDefault("dogrun.merge.priority", "manager,imported,google")
+	v.SetDefault("dogrun.merge.stale.days.manager", 0)
+	v.SetDefault("dogrun.merge.stale.days.imported", 365)
+	v.SetDefault("dogrun.merge.stale.days.google", 0)
 }
 
 // 環境変数の取得
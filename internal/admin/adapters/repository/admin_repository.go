@@ -0,0 +1,561 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+)
+
+type IAdminRepository interface {
+	SearchDogOwners(echo.Context, string, int, int) ([]model.DogOwner, error)
+	FindDogOwnerByID(echo.Context, int64) (model.DogOwner, error)
+	FindDogOwnerCredentials(echo.Context, int64) ([]model.DogOwnerCredential, error)
+	FindDogsByDogOwnerID(echo.Context, int64) ([]model.Dog, error)
+	SearchDogrunmgs(echo.Context, string, int, int) ([]model.DogrunmgCredential, error)
+	FindDogrunmgCredential(echo.Context, int64) (model.DogrunmgCredential, error)
+	FindDogrunmgCredentialsByOrgID(echo.Context, int64) ([]model.DogrunmgCredential, error)
+	SearchDogs(echo.Context, string, int, int) ([]model.Dog, error)
+	FindDogByID(echo.Context, int64) (model.Dog, error)
+	SearchDogruns(echo.Context, string, int, int) ([]model.Dogrun, error)
+	FindDogrunByID(echo.Context, int64) (model.Dogrun, error)
+	FindDogrunsByOrgID(echo.Context, int64) ([]model.Dogrun, error)
+	SearchOrgs(echo.Context, string, int, int) ([]model.Organization, error)
+	FindOrgByID(echo.Context, int64) (model.Organization, error)
+	SearchUploads(echo.Context, string, int64, int, int) ([]model.S3FileInfo, error)
+	FindUploadByFileID(echo.Context, string) (model.S3FileInfo, error)
+	FindTagMsts(echo.Context) ([]model.TagMst, error)
+	FindDogTypeMsts(echo.Context) ([]model.DogTypeMst, error)
+	FindDogrunEventByID(echo.Context, int64) (model.DogrunEvent, error)
+	FindDogrunImageByID(echo.Context, int64) (model.DogrunImage, error)
+	CountSystemOperatorsByEmail(echo.Context, string) (int64, error)
+}
+
+type adminRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminRepository(db *gorm.DB) IAdminRepository {
+	return &adminRepository{db}
+}
+
+// SearchDogOwners: dogownerの検索
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - string:	キーワード(名前の部分一致、メールアドレス・電話番号の部分一致)。空の場合は全て
+//   - int:	取得件数
+//   - int:	取得開始位置
+//
+// return:
+//   - []model.DogOwner:	登録の新しい順のdogowner
+//   - error:	エラー
+func (r *adminRepository) SearchDogOwners(c echo.Context, keyword string, limit int, offset int) ([]model.DogOwner, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	query := r.db.Model(&model.DogOwner{})
+	if keyword != "" {
+		pattern := likePattern(keyword)
+		credentialOwners := r.db.Table("auth_dog_owners").
+			Select("auth_dog_owners.dog_owner_id").
+			Joins("JOIN dog_owner_credentials ON dog_owner_credentials.auth_dog_owner_id = auth_dog_owners.auth_dog_owner_id").
+			Where("dog_owner_credentials.email ILIKE ? OR dog_owner_credentials.phone_number LIKE ?", pattern, pattern)
+		query = query.Where("name ILIKE ? OR dog_owner_id IN (?)", pattern, credentialOwners)
+	}
+
+	dogOwners := []model.DogOwner{}
+	if err := paginate(query.Order("dog_owner_id DESC"), limit, offset).Find(&dogOwners).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "dogownerの検索に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return dogOwners, nil
+}
+
+// FindDogOwnerByID: dogownerの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogOwnerID
+//
+// return:
+//   - model.DogOwner:	dogowner。存在しない場合は空
+//   - error:	エラー
+func (r *adminRepository) FindDogOwnerByID(c echo.Context, dogOwnerID int64) (model.DogOwner, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogOwner := model.DogOwner{}
+	if err := r.db.Where("dog_owner_id = ?", dogOwnerID).Find(&dogOwner).Error; err != nil {
+		logger.Error(err)
+		return model.DogOwner{}, errors.NewWRError(err, "dogownerの取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return dogOwner, nil
+}
+
+// FindDogOwnerCredentials: dogownerのクレデンシャルの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogOwnerID
+//
+// return:
+//   - []model.DogOwnerCredential:	クレデンシャル(ログイン方法ごと)
+//   - error:	エラー
+func (r *adminRepository) FindDogOwnerCredentials(c echo.Context, dogOwnerID int64) ([]model.DogOwnerCredential, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	credentials := []model.DogOwnerCredential{}
+	if err := r.db.Joins("JOIN auth_dog_owners ON auth_dog_owners.auth_dog_owner_id = dog_owner_credentials.auth_dog_owner_id").
+		Where("auth_dog_owners.dog_owner_id = ?", dogOwnerID).
+		Order("dog_owner_credentials.credential_id").
+		Find(&credentials).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "dogownerのクレデンシャルの取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return credentials, nil
+}
+
+// FindDogsByDogOwnerID: dogownerが飼い主のdogの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogOwnerID
+//
+// return:
+//   - []model.Dog:	dog
+//   - error:	エラー
+func (r *adminRepository) FindDogsByDogOwnerID(c echo.Context, dogOwnerID int64) ([]model.Dog, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogs := []model.Dog{}
+	if err := r.db.Where("dog_owner_id = ?", dogOwnerID).Order("dog_id").Find(&dogs).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "dogの取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return dogs, nil
+}
+
+// SearchDogrunmgs: dogrunmgの検索
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - string:	キーワード(名前、メールアドレスの部分一致)。空の場合は全て
+//   - int:	取得件数
+//   - int:	取得開始位置
+//
+// return:
+//   - []model.DogrunmgCredential:	登録の新しい順のdogrunmg(organizationを含む)
+//   - error:	エラー
+func (r *adminRepository) SearchDogrunmgs(c echo.Context, keyword string, limit int, offset int) ([]model.DogrunmgCredential, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	query := joinDogrunmgCredentials(r.db)
+	if keyword != "" {
+		pattern := likePattern(keyword)
+		query = query.Where("dogrun_manager_credentials.email ILIKE ? OR dogrun_managers.name ILIKE ?", pattern, pattern)
+	}
+
+	credentials := []model.DogrunmgCredential{}
+	if err := paginate(query.Order("dogrun_managers.dogrun_manager_id DESC"), limit, offset).Find(&credentials).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "dogrunmgの検索に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return credentials, nil
+}
+
+// FindDogrunmgCredential: dogrunmgのクレデンシャルの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunmgID
+//
+// return:
+//   - model.DogrunmgCredential:	dogrunmg、organizationを含むクレデンシャル。存在しない場合は空
+//   - error:	エラー
+func (r *adminRepository) FindDogrunmgCredential(c echo.Context, dmID int64) (model.DogrunmgCredential, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	credential := model.DogrunmgCredential{}
+	if err := joinDogrunmgCredentials(r.db).
+		Where("dogrun_managers.dogrun_manager_id = ?", dmID).
+		Find(&credential).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunmgCredential{}, errors.NewWRError(err, "dogrunmgの取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return credential, nil
+}
+
+// FindDogrunmgCredentialsByOrgID: organizationに所属するdogrunmgのクレデンシャルの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	organizationID
+//
+// return:
+//   - []model.DogrunmgCredential:	dogrunmgを含むクレデンシャル
+//   - error:	エラー
+func (r *adminRepository) FindDogrunmgCredentialsByOrgID(c echo.Context, orgID int64) ([]model.DogrunmgCredential, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	credentials := []model.DogrunmgCredential{}
+	if err := joinDogrunmgCredentials(r.db).
+		Where("dogrun_managers.organization_id = ?", orgID).
+		Order("dogrun_managers.dogrun_manager_id").
+		Find(&credentials).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "organizationのdogrunmgの取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return credentials, nil
+}
+
+// SearchDogs: dogの検索
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - string:	キーワード(名前の部分一致、マイクロチップIDの完全一致)。空の場合は全て
+//   - int:	取得件数
+//   - int:	取得開始位置
+//
+// return:
+//   - []model.Dog:	登録の新しい順のdog(飼い主を含む)
+//   - error:	エラー
+func (r *adminRepository) SearchDogs(c echo.Context, keyword string, limit int, offset int) ([]model.Dog, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	query := r.db.Preload("DogOwner")
+	if keyword != "" {
+		query = query.Where("name ILIKE ? OR microchip_id = ?", likePattern(keyword), keyword)
+	}
+
+	dogs := []model.Dog{}
+	if err := paginate(query.Order("dog_id DESC"), limit, offset).Find(&dogs).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "dogの検索に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return dogs, nil
+}
+
+// FindDogByID: dogの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogID
+//
+// return:
+//   - model.Dog:	飼い主、共同飼い主、犬種、プロフィールを含むdog。存在しない場合は空
+//   - error:	エラー
+func (r *adminRepository) FindDogByID(c echo.Context, dogID int64) (model.Dog, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dog := model.Dog{}
+	if err := r.db.Preload("DogOwner").
+		Preload("DogCoOwners.DogOwner").
+		Preload("DogDogTypes").
+		Preload("DogSocialProfile").
+		Where("dog_id = ?", dogID).
+		Find(&dog).Error; err != nil {
+		logger.Error(err)
+		return model.Dog{}, errors.NewWRError(err, "dogの取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return dog, nil
+}
+
+// SearchDogruns: ドッグランの検索
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - string:	キーワード(名前、住所の部分一致、PlaceIDの完全一致)。空の場合は全て
+//   - int:	取得件数
+//   - int:	取得開始位置
+//
+// return:
+//   - []model.Dogrun:	登録の新しい順のドッグラン(統合済みを含む)
+//   - error:	エラー
+func (r *adminRepository) SearchDogruns(c echo.Context, keyword string, limit int, offset int) ([]model.Dogrun, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	query := r.db.Model(&model.Dogrun{})
+	if keyword != "" {
+		pattern := likePattern(keyword)
+		query = query.Where("name ILIKE ? OR address ILIKE ? OR place_id = ?", pattern, pattern, keyword)
+	}
+
+	dogruns := []model.Dogrun{}
+	if err := paginate(query.Order("dogrun_id DESC"), limit, offset).Find(&dogruns).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "ドッグランの検索に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return dogruns, nil
+}
+
+// FindDogrunByID: ドッグランの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - model.Dogrun:	タグ、画像を含むドッグラン。存在しない場合は空
+//   - error:	エラー
+func (r *adminRepository) FindDogrunByID(c echo.Context, dogrunID int64) (model.Dogrun, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrun := model.Dogrun{}
+	if err := r.db.Preload("DogrunTags").
+		Preload("DogrunImages", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC")
+		}).
+		Where("dogrun_id = ?", dogrunID).
+		Find(&dogrun).Error; err != nil {
+		logger.Error(err)
+		return model.Dogrun{}, errors.NewWRError(err, "ドッグランの取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return dogrun, nil
+}
+
+// FindDogrunsByOrgID: organizationのマネージャーが管理するドッグランの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	organizationID
+//
+// return:
+//   - []model.Dogrun:	ドッグラン
+//   - error:	エラー
+func (r *adminRepository) FindDogrunsByOrgID(c echo.Context, orgID int64) ([]model.Dogrun, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogruns := []model.Dogrun{}
+	if err := r.db.Joins("JOIN dogrun_managers ON dogrun_managers.dogrun_manager_id = dogruns.dogrun_manager_id").
+		Where("dogrun_managers.organization_id = ?", orgID).
+		Order("dogruns.dogrun_id").
+		Find(&dogruns).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "organizationのドッグランの取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return dogruns, nil
+}
+
+// SearchOrgs: organizationの検索
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - string:	キーワード(組織名、連絡先メールアドレスの部分一致)。空の場合は全て
+//   - int:	取得件数
+//   - int:	取得開始位置
+//
+// return:
+//   - []model.Organization:	登録の新しい順のorganization(退会済みを含む)
+//   - error:	エラー
+func (r *adminRepository) SearchOrgs(c echo.Context, keyword string, limit int, offset int) ([]model.Organization, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	query := r.db.Model(&model.Organization{})
+	if keyword != "" {
+		pattern := likePattern(keyword)
+		query = query.Where("organization_name ILIKE ? OR contact_email ILIKE ?", pattern, pattern)
+	}
+
+	orgs := []model.Organization{}
+	if err := paginate(query.Order("organization_id DESC"), limit, offset).Find(&orgs).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "organizationの検索に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return orgs, nil
+}
+
+// FindOrgByID: organizationの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	organizationID
+//
+// return:
+//   - model.Organization:	organization。存在しない場合は空
+//   - error:	エラー
+func (r *adminRepository) FindOrgByID(c echo.Context, orgID int64) (model.Organization, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	org := model.Organization{}
+	if err := r.db.Where("organization_id = ?", orgID).Find(&org).Error; err != nil {
+		logger.Error(err)
+		return model.Organization{}, errors.NewWRError(err, "organizationの取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return org, nil
+}
+
+// SearchUploads: アップロードされたファイルの検索
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - string:	所有者の種別。空の場合は全て
+//   - int64:	所有者のID。0の場合は全て
+//   - int:	取得件数
+//   - int:	取得開始位置
+//
+// return:
+//   - []model.S3FileInfo:	アップロードの新しい順のファイル
+//   - error:	エラー
+func (r *adminRepository) SearchUploads(c echo.Context, ownerType string, ownerID int64, limit int, offset int) ([]model.S3FileInfo, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	query := r.db.Model(&model.S3FileInfo{})
+	if ownerType != "" {
+		query = query.Where("owner_type = ?", ownerType)
+	}
+	if ownerID != 0 {
+		query = query.Where("owner_id = ?", ownerID)
+	}
+
+	files := []model.S3FileInfo{}
+	if err := paginate(query.Order("s3_file_info_id DESC"), limit, offset).Find(&files).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "ファイルの検索に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return files, nil
+}
+
+// FindUploadByFileID: アップロードされたファイルの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - string:	fileID
+//
+// return:
+//   - model.S3FileInfo:	バリエーションを含むファイル。存在しない場合は空
+//   - error:	エラー
+func (r *adminRepository) FindUploadByFileID(c echo.Context, fileID string) (model.S3FileInfo, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	file := model.S3FileInfo{}
+	if err := r.db.Preload("Variants").Where("file_id = ?", fileID).Find(&file).Error; err != nil {
+		logger.Error(err)
+		return model.S3FileInfo{}, errors.NewWRError(err, "ファイルの取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return file, nil
+}
+
+// FindTagMsts: tag_mstの全件取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - []model.TagMst:	ID順のマスター
+//   - error:	エラー
+func (r *adminRepository) FindTagMsts(c echo.Context) ([]model.TagMst, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	tagMsts := []model.TagMst{}
+	if err := r.db.Order("tag_id").Find(&tagMsts).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "tag_mstの取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return tagMsts, nil
+}
+
+// FindDogTypeMsts: dog_type_mstの全件取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - []model.DogTypeMst:	ID順のマスター
+//   - error:	エラー
+func (r *adminRepository) FindDogTypeMsts(c echo.Context) ([]model.DogTypeMst, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogTypeMsts := []model.DogTypeMst{}
+	if err := r.db.Order("dog_type_id").Find(&dogTypeMsts).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "dog_type_mstの取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return dogTypeMsts, nil
+}
+
+// FindDogrunEventByID: イベントの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunEventID
+//
+// return:
+//   - model.DogrunEvent:	イベント。存在しない場合は空
+//   - error:	エラー
+func (r *adminRepository) FindDogrunEventByID(c echo.Context, eventID int64) (model.DogrunEvent, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	event := model.DogrunEvent{}
+	if err := r.db.Where("dogrun_event_id = ?", eventID).Find(&event).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunEvent{}, errors.NewWRError(err, "イベントの取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return event, nil
+}
+
+// FindDogrunImageByID: ドッグラン画像の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunImageID
+//
+// return:
+//   - model.DogrunImage:	ドッグラン画像。存在しない場合は空
+//   - error:	エラー
+func (r *adminRepository) FindDogrunImageByID(c echo.Context, imageID int64) (model.DogrunImage, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	image := model.DogrunImage{}
+	if err := r.db.Where("dogrun_image_id = ?", imageID).Find(&image).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunImage{}, errors.NewWRError(err, "ドッグラン画像の取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return image, nil
+}
+
+// CountSystemOperatorsByEmail: メールアドレスが一致する運営者数の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - string:	メールアドレス
+//
+// return:
+//   - int64:	運営者数
+//   - error:	エラー
+func (r *adminRepository) CountSystemOperatorsByEmail(c echo.Context, email string) (int64, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	var count int64
+	if err := r.db.Model(&model.SystemOperator{}).Where("email = ?", email).Count(&count).Error; err != nil {
+		logger.Error(err)
+		return 0, errors.NewWRError(err, "運営者の取得に失敗しました。", errors.NewAdminServerErrorEType())
+	}
+	return count, nil
+}
+
+/*
+dogrunmgのクレデンシャルにauth、dogrunmg、organizationを結合
+*/
+func joinDogrunmgCredentials(db *gorm.DB) *gorm.DB {
+	return db.Model(&model.DogrunmgCredential{}).
+		Joins("JOIN auth_dogrun_managers ON auth_dogrun_managers.auth_dogrun_manager_id = dogrun_manager_credentials.auth_dogrun_manager_id").
+		Joins("JOIN dogrun_managers ON dogrun_managers.dogrun_manager_id = auth_dogrun_managers.dogrun_manager_id").
+		Preload("AuthDogrunmg.Dogrunmg.Organization")
+}
+
+/*
+ページングの指定
+*/
+func paginate(db *gorm.DB, limit int, offset int) *gorm.DB {
+	return db.Limit(limit).Offset(offset)
+}
+
+/*
+部分一致検索のパターン。ワイルドカードの文字はエスケープする
+*/
+func likePattern(keyword string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(keyword)
+	return "%" + escaped + "%"
+}
@@ -0,0 +1,595 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IAdminScopeRepository interface {
+	SuspendDogOwner(tx *gorm.DB, c echo.Context, dogOwnerID int64, reason string, now time.Time) (bool, error)
+	UnsuspendDogOwner(tx *gorm.DB, c echo.Context, dogOwnerID int64) (bool, error)
+	SuspendDogrunmg(tx *gorm.DB, c echo.Context, dmID int64, reason string, now time.Time) (bool, error)
+	UnsuspendDogrunmg(tx *gorm.DB, c echo.Context, dmID int64) (bool, error)
+	FindDogrunsForUpdate(tx *gorm.DB, c echo.Context, dogrunIDs []int64) ([]model.Dogrun, error)
+	CountPendingClaims(tx *gorm.DB, c echo.Context, dogrunID int64) (int64, error)
+	MergeDogrun(tx *gorm.DB, c echo.Context, sourceID int64, targetID int64) (map[string]int64, error)
+	CreateTagMst(tx *gorm.DB, c echo.Context, tagMst *model.TagMst) error
+	UpdateTagMst(tx *gorm.DB, c echo.Context, tagID int64, updates map[string]any) (bool, error)
+	DeleteTagMst(tx *gorm.DB, c echo.Context, tagID int64) (bool, error)
+	CountDogrunTagsByTagID(tx *gorm.DB, c echo.Context, tagID int64) (int64, error)
+	CreateDogTypeMst(tx *gorm.DB, c echo.Context, dogTypeMst *model.DogTypeMst) error
+	UpdateDogTypeMst(tx *gorm.DB, c echo.Context, dogTypeID int64, name string) (bool, error)
+	DeleteDogTypeMst(tx *gorm.DB, c echo.Context, dogTypeID int64) (bool, error)
+	CountDogsByDogTypeID(tx *gorm.DB, c echo.Context, dogTypeID int64) (int64, error)
+	CancelDogrunEvent(tx *gorm.DB, c echo.Context, eventID int64) (bool, error)
+	DeleteDogrunImage(tx *gorm.DB, c echo.Context, imageID int64) (bool, error)
+	ClearDogProfileBio(tx *gorm.DB, c echo.Context, dogID int64) (bool, error)
+	ClearDogImage(tx *gorm.DB, c echo.Context, dogID int64) (bool, error)
+	ClearDogOwnerImage(tx *gorm.DB, c echo.Context, dogOwnerID int64) (bool, error)
+	CreateSystemOperator(tx *gorm.DB, c echo.Context, operator *model.SystemOperator) error
+}
+
+type adminScopeRepository struct {
+}
+
+func NewAdminScopeRepository() IAdminScopeRepository {
+	return &adminScopeRepository{}
+}
+
+// SuspendDogOwner: dogownerの利用停止
+// 利用停止中でない場合のみ更新する
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogOwnerID
+//   - string: 利用停止の理由
+//   - time.Time: 利用停止日時
+//
+// return:
+//   - bool: 更新したか
+//   - error: error情報
+func (asr *adminScopeRepository) SuspendDogOwner(
+	tx *gorm.DB,
+	c echo.Context,
+	dogOwnerID int64,
+	reason string,
+	now time.Time,
+) (bool, error) {
+	return updateWhere(tx, c, &model.DogOwner{}, "dog_owner_id = ? AND suspended_at IS NULL", dogOwnerID, map[string]any{
+		"suspended_at":      now,
+		"suspension_reason": reason,
+	}, "dogownerの利用停止に失敗しました。")
+}
+
+// UnsuspendDogOwner: dogownerの利用停止の解除
+// 利用停止中の場合のみ更新する
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogOwnerID
+//
+// return:
+//   - bool: 更新したか
+//   - error: error情報
+func (asr *adminScopeRepository) UnsuspendDogOwner(
+	tx *gorm.DB,
+	c echo.Context,
+	dogOwnerID int64,
+) (bool, error) {
+	return updateWhere(tx, c, &model.DogOwner{}, "dog_owner_id = ? AND suspended_at IS NOT NULL", dogOwnerID, map[string]any{
+		"suspended_at":      nil,
+		"suspension_reason": nil,
+	}, "dogownerの利用停止の解除に失敗しました。")
+}
+
+// SuspendDogrunmg: dogrunmgの利用停止
+// 利用停止中でない場合のみ更新する
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunmgID
+//   - string: 利用停止の理由
+//   - time.Time: 利用停止日時
+//
+// return:
+//   - bool: 更新したか
+//   - error: error情報
+func (asr *adminScopeRepository) SuspendDogrunmg(
+	tx *gorm.DB,
+	c echo.Context,
+	dmID int64,
+	reason string,
+	now time.Time,
+) (bool, error) {
+	return updateWhere(tx, c, &model.Dogrunmg{}, "dogrun_manager_id = ? AND suspended_at IS NULL", dmID, map[string]any{
+		"suspended_at":      now,
+		"suspension_reason": reason,
+	}, "dogrunmgの利用停止に失敗しました。")
+}
+
+// UnsuspendDogrunmg: dogrunmgの利用停止の解除
+// 利用停止中の場合のみ更新する
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunmgID
+//
+// return:
+//   - bool: 更新したか
+//   - error: error情報
+func (asr *adminScopeRepository) UnsuspendDogrunmg(
+	tx *gorm.DB,
+	c echo.Context,
+	dmID int64,
+) (bool, error) {
+	return updateWhere(tx, c, &model.Dogrunmg{}, "dogrun_manager_id = ? AND suspended_at IS NOT NULL", dmID, map[string]any{
+		"suspended_at":      nil,
+		"suspension_reason": nil,
+	}, "dogrunmgの利用停止の解除に失敗しました。")
+}
+
+// FindDogrunsForUpdate: ドッグランを行ロックして取得
+// 同じドッグランへの統合はトランザクション終了まで待機させる。デッドロックを避けるためID順にロックする
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - []int64: dogrunID
+//
+// return:
+//   - []model.Dogrun: ID順のドッグラン
+//   - error: error情報
+func (asr *adminScopeRepository) FindDogrunsForUpdate(
+	tx *gorm.DB,
+	c echo.Context,
+	dogrunIDs []int64,
+) ([]model.Dogrun, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogruns := []model.Dogrun{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("dogrun_id IN ?", dogrunIDs).
+		Order("dogrun_id").
+		Find(&dogruns).Error; err != nil {
+		logger.Error("Failed to lock Dogruns: ", err)
+		return nil, wrErrors.NewWRError(
+			err,
+			"ドッグランの取得に失敗しました。",
+			wrErrors.NewAdminServerErrorEType(),
+		)
+	}
+	return dogruns, nil
+}
+
+// CountPendingClaims: ドッグランの審査中の管理申請数の取得
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunID
+//
+// return:
+//   - int64: 審査中の管理申請数
+//   - error: error情報
+func (asr *adminScopeRepository) CountPendingClaims(
+	tx *gorm.DB,
+	c echo.Context,
+	dogrunID int64,
+) (int64, error) {
+	return countWhere(tx, c, &model.DogrunClaim{}, "dogrun_id = ? AND status = ?", []any{dogrunID, model.DOGRUN_CLAIM_STATUS_PENDING}, "管理申請の取得に失敗しました。")
+}
+
+// MergeDogrun: 重複したドッグランの統合
+// ユーザーの投稿・利用履歴(ブックマーク、タグ、画像、チェックイン、イベント)を統合先に付け替え、統合元に統合先を設定する。
+// 統合先に同じブックマーク・タグがある場合は統合元の方を削除する
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: 統合元のdogrunID
+//   - int64: 統合先のdogrunID
+//
+// return:
+//   - map[string]int64: テーブルごとの付け替えた件数
+//   - error: error情報
+func (asr *adminScopeRepository) MergeDogrun(
+	tx *gorm.DB,
+	c echo.Context,
+	sourceID int64,
+	targetID int64,
+) (map[string]int64, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	handleError := func(err error) (map[string]int64, error) {
+		logger.Error("Failed to merge Dogrun: ", err)
+		return nil, wrErrors.NewWRError(
+			err,
+			"ドッグランの統合に失敗しました。",
+			wrErrors.NewAdminServerErrorEType(),
+		)
+	}
+
+	// 統合先に既にあるブックマーク・タグは削除
+	if err := tx.Exec(`DELETE FROM dogrun_bookmarks s WHERE s.dogrun_id = ?
+		AND EXISTS (SELECT 1 FROM dogrun_bookmarks t WHERE t.dogrun_id = ? AND t.dog_owner_id = s.dog_owner_id)`,
+		sourceID, targetID).Error; err != nil {
+		return handleError(err)
+	}
+	if err := tx.Exec(`DELETE FROM dogrun_tags s WHERE s.dogrun_id = ?
+		AND EXISTS (SELECT 1 FROM dogrun_tags t WHERE t.dogrun_id = ? AND t.tag_id = s.tag_id)`,
+		sourceID, targetID).Error; err != nil {
+		return handleError(err)
+	}
+
+	// 画像は統合先の画像の後ろに並べる
+	if err := tx.Exec(`UPDATE dogrun_images SET dogrun_id = ?,
+		sort_order = sort_order + (SELECT COALESCE(MAX(sort_order), 0) FROM dogrun_images WHERE dogrun_id = ?)
+		WHERE dogrun_id = ?`,
+		targetID, targetID, sourceID).Error; err != nil {
+		return handleError(err)
+	}
+
+	moved := map[string]int64{}
+	for _, table := range []string{"dogrun_bookmarks", "dogrun_tags", "dogrun_checkin", "dogrun_checkout", "dogrun_events"} {
+		result := tx.Table(table).Where("dogrun_id = ?", sourceID).Update("dogrun_id", targetID)
+		if result.Error != nil {
+			return handleError(result.Error)
+		}
+		moved[table] = result.RowsAffected
+	}
+
+	// 統合元に統合されていたドッグランも統合先に付け替え
+	if err := tx.Model(&model.Dogrun{}).
+		Where("merged_into_dogrun_id = ?", sourceID).
+		Update("merged_into_dogrun_id", targetID).Error; err != nil {
+		return handleError(err)
+	}
+	if err := tx.Model(&model.Dogrun{}).
+		Where("dogrun_id = ?", sourceID).
+		Update("merged_into_dogrun_id", targetID).Error; err != nil {
+		return handleError(err)
+	}
+
+	return moved, nil
+}
+
+// CreateTagMst: tag_mstの登録
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - *model.TagMst: マスター
+//
+// return:
+//   - error: error情報
+func (asr *adminScopeRepository) CreateTagMst(
+	tx *gorm.DB,
+	c echo.Context,
+	tagMst *model.TagMst,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := tx.Create(tagMst).Error; err != nil {
+		logger.Error("Failed to create TagMst: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"tag_mstの登録に失敗しました。",
+			wrErrors.NewAdminServerErrorEType(),
+		)
+	}
+	return nil
+}
+
+// UpdateTagMst: tag_mstの更新
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: tagID
+//   - map[string]any: 更新内容
+//
+// return:
+//   - bool: 更新したか
+//   - error: error情報
+func (asr *adminScopeRepository) UpdateTagMst(
+	tx *gorm.DB,
+	c echo.Context,
+	tagID int64,
+	updates map[string]any,
+) (bool, error) {
+	return updateWhere(tx, c, &model.TagMst{}, "tag_id = ?", tagID, updates, "tag_mstの更新に失敗しました。")
+}
+
+// DeleteTagMst: tag_mstの削除
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: tagID
+//
+// return:
+//   - bool: 削除したか
+//   - error: error情報
+func (asr *adminScopeRepository) DeleteTagMst(
+	tx *gorm.DB,
+	c echo.Context,
+	tagID int64,
+) (bool, error) {
+	return deleteWhere(tx, c, &model.TagMst{}, "tag_id = ?", tagID, "tag_mstの削除に失敗しました。")
+}
+
+// CountDogrunTagsByTagID: タグが設定されているドッグラン数の取得
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: tagID
+//
+// return:
+//   - int64: ドッグラン数
+//   - error: error情報
+func (asr *adminScopeRepository) CountDogrunTagsByTagID(
+	tx *gorm.DB,
+	c echo.Context,
+	tagID int64,
+) (int64, error) {
+	return countWhere(tx, c, &model.DogrunTag{}, "tag_id = ?", []any{tagID}, "ドッグランのタグの取得に失敗しました。")
+}
+
+// CreateDogTypeMst: dog_type_mstの登録
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - *model.DogTypeMst: マスター
+//
+// return:
+//   - error: error情報
+func (asr *adminScopeRepository) CreateDogTypeMst(
+	tx *gorm.DB,
+	c echo.Context,
+	dogTypeMst *model.DogTypeMst,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := tx.Create(dogTypeMst).Error; err != nil {
+		logger.Error("Failed to create DogTypeMst: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"dog_type_mstの登録に失敗しました。",
+			wrErrors.NewAdminServerErrorEType(),
+		)
+	}
+	return nil
+}
+
+// UpdateDogTypeMst: dog_type_mstの更新
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogTypeID
+//   - string: 犬種名
+//
+// return:
+//   - bool: 更新したか
+//   - error: error情報
+func (asr *adminScopeRepository) UpdateDogTypeMst(
+	tx *gorm.DB,
+	c echo.Context,
+	dogTypeID int64,
+	name string,
+) (bool, error) {
+	return updateWhere(tx, c, &model.DogTypeMst{}, "dog_type_id = ?", dogTypeID, map[string]any{
+		"name": name,
+	}, "dog_type_mstの更新に失敗しました。")
+}
+
+// DeleteDogTypeMst: dog_type_mstの削除
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogTypeID
+//
+// return:
+//   - bool: 削除したか
+//   - error: error情報
+func (asr *adminScopeRepository) DeleteDogTypeMst(
+	tx *gorm.DB,
+	c echo.Context,
+	dogTypeID int64,
+) (bool, error) {
+	return deleteWhere(tx, c, &model.DogTypeMst{}, "dog_type_id = ?", dogTypeID, "dog_type_mstの削除に失敗しました。")
+}
+
+// CountDogsByDogTypeID: 犬種が設定されているdog数の取得
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogTypeID
+//
+// return:
+//   - int64: dog数
+//   - error: error情報
+func (asr *adminScopeRepository) CountDogsByDogTypeID(
+	tx *gorm.DB,
+	c echo.Context,
+	dogTypeID int64,
+) (int64, error) {
+	return countWhere(tx, c, &model.DogDogType{}, "dog_type_id = ?", []any{dogTypeID}, "dogの犬種の取得に失敗しました。")
+}
+
+// CancelDogrunEvent: イベントの中止
+// 開催予定の場合のみ更新する
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunEventID
+//
+// return:
+//   - bool: 更新したか
+//   - error: error情報
+func (asr *adminScopeRepository) CancelDogrunEvent(
+	tx *gorm.DB,
+	c echo.Context,
+	eventID int64,
+) (bool, error) {
+	return updateWhere(tx, c, &model.DogrunEvent{}, "dogrun_event_id = ? AND status = '"+model.DOGRUN_EVENT_STATUS_SCHEDULED+"'", eventID, map[string]any{
+		"status": model.DOGRUN_EVENT_STATUS_CANCELLED,
+	}, "イベントの中止に失敗しました。")
+}
+
+// DeleteDogrunImage: ドッグラン画像の削除
+// ファイルは参照されなくなるため、孤立ファイルの削除で削除される
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunImageID
+//
+// return:
+//   - bool: 削除したか
+//   - error: error情報
+func (asr *adminScopeRepository) DeleteDogrunImage(
+	tx *gorm.DB,
+	c echo.Context,
+	imageID int64,
+) (bool, error) {
+	return deleteWhere(tx, c, &model.DogrunImage{}, "dogrun_image_id = ?", imageID, "ドッグラン画像の削除に失敗しました。")
+}
+
+// ClearDogProfileBio: dogのプロフィールの自己紹介の削除
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogID
+//
+// return:
+//   - bool: 更新したか
+//   - error: error情報
+func (asr *adminScopeRepository) ClearDogProfileBio(
+	tx *gorm.DB,
+	c echo.Context,
+	dogID int64,
+) (bool, error) {
+	return updateWhere(tx, c, &model.DogSocialProfile{}, "dog_id = ? AND bio IS NOT NULL", dogID, map[string]any{
+		"bio": nil,
+	}, "dogのプロフィールの更新に失敗しました。")
+}
+
+// ClearDogImage: dogの画像の削除
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogID
+//
+// return:
+//   - bool: 更新したか
+//   - error: error情報
+func (asr *adminScopeRepository) ClearDogImage(
+	tx *gorm.DB,
+	c echo.Context,
+	dogID int64,
+) (bool, error) {
+	return updateWhere(tx, c, &model.Dog{}, "dog_id = ? AND image IS NOT NULL", dogID, map[string]any{
+		"image": nil,
+	}, "dogの画像の削除に失敗しました。")
+}
+
+// ClearDogOwnerImage: dogownerの画像の削除
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogOwnerID
+//
+// return:
+//   - bool: 更新したか
+//   - error: error情報
+func (asr *adminScopeRepository) ClearDogOwnerImage(
+	tx *gorm.DB,
+	c echo.Context,
+	dogOwnerID int64,
+) (bool, error) {
+	return updateWhere(tx, c, &model.DogOwner{}, "dog_owner_id = ? AND image IS NOT NULL", dogOwnerID, map[string]any{
+		"image": nil,
+	}, "dogownerの画像の削除に失敗しました。")
+}
+
+// CreateSystemOperator: 運営者の登録
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - *model.SystemOperator: 運営者
+//
+// return:
+//   - error: error情報
+func (asr *adminScopeRepository) CreateSystemOperator(
+	tx *gorm.DB,
+	c echo.Context,
+	operator *model.SystemOperator,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := tx.Create(operator).Error; err != nil {
+		logger.Error("Failed to create SystemOperator: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"運営者の登録に失敗しました。",
+			wrErrors.NewAdminServerErrorEType(),
+		)
+	}
+	return nil
+}
+
+/*
+条件に一致するレコードの更新。更新したかを返す
+*/
+func updateWhere(tx *gorm.DB, c echo.Context, m any, query string, id int64, updates map[string]any, msg string) (bool, error) {
+	result := tx.Model(m).Where(query, id).Updates(updates)
+	if result.Error != nil {
+		log.GetLogger(c).Sugar().Error(result.Error)
+		return false, wrErrors.NewWRError(result.Error, msg, wrErrors.NewAdminServerErrorEType())
+	}
+	return result.RowsAffected > 0, nil
+}
+
+/*
+条件に一致するレコードの削除。削除したかを返す
+*/
+func deleteWhere(tx *gorm.DB, c echo.Context, m any, query string, id int64, msg string) (bool, error) {
+	result := tx.Where(query, id).Delete(m)
+	if result.Error != nil {
+		log.GetLogger(c).Sugar().Error(result.Error)
+		return false, wrErrors.NewWRError(result.Error, msg, wrErrors.NewAdminServerErrorEType())
+	}
+	return result.RowsAffected > 0, nil
+}
+
+/*
+条件に一致するレコード数の取得
+*/
+func countWhere(tx *gorm.DB, c echo.Context, m any, query string, args []any, msg string) (int64, error) {
+	var count int64
+	if err := tx.Model(m).Where(query, args...).Count(&count).Error; err != nil {
+		log.GetLogger(c).Sugar().Error(err)
+		return 0, wrErrors.NewWRError(err, msg, wrErrors.NewAdminServerErrorEType())
+	}
+	return count, nil
+}
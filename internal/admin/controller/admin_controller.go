@@ -0,0 +1,572 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/admin/core/dto"
+	adminHandler "github.com/wanrun-develop/wanrun/internal/admin/core/handler"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+)
+
+type IAdminController interface {
+	SearchDogOwners(c echo.Context) error
+	GetDogOwner(c echo.Context) error
+	SuspendDogOwner(c echo.Context) error
+	UnsuspendDogOwner(c echo.Context) error
+	SearchDogrunmgs(c echo.Context) error
+	GetDogrunmg(c echo.Context) error
+	SuspendDogrunmg(c echo.Context) error
+	UnsuspendDogrunmg(c echo.Context) error
+	SearchDogs(c echo.Context) error
+	GetDog(c echo.Context) error
+	SearchDogruns(c echo.Context) error
+	GetDogrun(c echo.Context) error
+	MergeDogrun(c echo.Context) error
+	SearchOrgs(c echo.Context) error
+	GetOrg(c echo.Context) error
+	SearchUploads(c echo.Context) error
+	GetUpload(c echo.Context) error
+	GetTagMsts(c echo.Context) error
+	CreateTagMst(c echo.Context) error
+	UpdateTagMst(c echo.Context) error
+	DeleteTagMst(c echo.Context) error
+	GetDogTypeMsts(c echo.Context) error
+	CreateDogTypeMst(c echo.Context) error
+	UpdateDogTypeMst(c echo.Context) error
+	DeleteDogTypeMst(c echo.Context) error
+	Moderate(c echo.Context) error
+	CreateOperator(c echo.Context) error
+}
+
+type adminController struct {
+	ah  adminHandler.IAdminHandler
+	aah adminHandler.IAdminAccountHandler
+	ach adminHandler.IAdminContentHandler
+}
+
+func NewAdminController(
+	ah adminHandler.IAdminHandler,
+	aah adminHandler.IAdminAccountHandler,
+	ach adminHandler.IAdminContentHandler,
+) IAdminController {
+	return &adminController{
+		ah:  ah,
+		aah: aah,
+		ach: ach,
+	}
+}
+
+// SearchDogOwners: dogownerの検索
+func (a *adminController) SearchDogOwners(c echo.Context) error {
+	searchReq := dto.AdminSearchReq{}
+
+	if err := bindAndValidate(c, &searchReq); err != nil {
+		return err
+	}
+
+	dogOwners, wrErr := a.ah.SearchDogOwners(c, searchReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, dogOwners)
+}
+
+// GetDogOwner: dogownerの詳細の取得
+func (a *adminController) GetDogOwner(c echo.Context) error {
+	dogOwnerID, err := parseIDParam(c, "dogOwnerId")
+
+	if err != nil {
+		return err
+	}
+
+	dogOwner, wrErr := a.ah.GetDogOwner(c, dogOwnerID)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, dogOwner)
+}
+
+// SuspendDogOwner: dogownerの利用停止
+func (a *adminController) SuspendDogOwner(c echo.Context) error {
+	dogOwnerID, err := parseIDParam(c, "dogOwnerId")
+
+	if err != nil {
+		return err
+	}
+
+	suspendReq := dto.AdminSuspendReq{}
+
+	if err := bindAndValidate(c, &suspendReq); err != nil {
+		return err
+	}
+
+	dogOwner, wrErr := a.aah.SuspendDogOwner(c, dogOwnerID, suspendReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, dogOwner)
+}
+
+// UnsuspendDogOwner: dogownerの利用停止の解除
+func (a *adminController) UnsuspendDogOwner(c echo.Context) error {
+	dogOwnerID, err := parseIDParam(c, "dogOwnerId")
+
+	if err != nil {
+		return err
+	}
+
+	dogOwner, wrErr := a.aah.UnsuspendDogOwner(c, dogOwnerID)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, dogOwner)
+}
+
+// SearchDogrunmgs: dogrunmgの検索
+func (a *adminController) SearchDogrunmgs(c echo.Context) error {
+	searchReq := dto.AdminSearchReq{}
+
+	if err := bindAndValidate(c, &searchReq); err != nil {
+		return err
+	}
+
+	dogrunmgs, wrErr := a.ah.SearchDogrunmgs(c, searchReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, dogrunmgs)
+}
+
+// GetDogrunmg: dogrunmgの詳細の取得
+func (a *adminController) GetDogrunmg(c echo.Context) error {
+	dmID, err := parseIDParam(c, "dogrunmgId")
+
+	if err != nil {
+		return err
+	}
+
+	dogrunmg, wrErr := a.ah.GetDogrunmg(c, dmID)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, dogrunmg)
+}
+
+// SuspendDogrunmg: dogrunmgの利用停止
+func (a *adminController) SuspendDogrunmg(c echo.Context) error {
+	dmID, err := parseIDParam(c, "dogrunmgId")
+
+	if err != nil {
+		return err
+	}
+
+	suspendReq := dto.AdminSuspendReq{}
+
+	if err := bindAndValidate(c, &suspendReq); err != nil {
+		return err
+	}
+
+	dogrunmg, wrErr := a.aah.SuspendDogrunmg(c, dmID, suspendReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, dogrunmg)
+}
+
+// UnsuspendDogrunmg: dogrunmgの利用停止の解除
+func (a *adminController) UnsuspendDogrunmg(c echo.Context) error {
+	dmID, err := parseIDParam(c, "dogrunmgId")
+
+	if err != nil {
+		return err
+	}
+
+	dogrunmg, wrErr := a.aah.UnsuspendDogrunmg(c, dmID)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, dogrunmg)
+}
+
+// SearchDogs: dogの検索
+func (a *adminController) SearchDogs(c echo.Context) error {
+	searchReq := dto.AdminSearchReq{}
+
+	if err := bindAndValidate(c, &searchReq); err != nil {
+		return err
+	}
+
+	dogs, wrErr := a.ah.SearchDogs(c, searchReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, dogs)
+}
+
+// GetDog: dogの詳細の取得
+func (a *adminController) GetDog(c echo.Context) error {
+	dogID, err := parseIDParam(c, "dogId")
+
+	if err != nil {
+		return err
+	}
+
+	dog, wrErr := a.ah.GetDog(c, dogID)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, dog)
+}
+
+// SearchDogruns: ドッグランの検索
+func (a *adminController) SearchDogruns(c echo.Context) error {
+	searchReq := dto.AdminSearchReq{}
+
+	if err := bindAndValidate(c, &searchReq); err != nil {
+		return err
+	}
+
+	dogruns, wrErr := a.ah.SearchDogruns(c, searchReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, dogruns)
+}
+
+// GetDogrun: ドッグランの詳細の取得
+func (a *adminController) GetDogrun(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "dogrunId")
+
+	if err != nil {
+		return err
+	}
+
+	dogrun, wrErr := a.ah.GetDogrun(c, dogrunID)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, dogrun)
+}
+
+// MergeDogrun: 重複したドッグランの統合
+func (a *adminController) MergeDogrun(c echo.Context) error {
+	sourceID, err := parseIDParam(c, "dogrunId")
+
+	if err != nil {
+		return err
+	}
+
+	mergeReq := dto.AdminDogrunMergeReq{}
+
+	if err := bindAndValidate(c, &mergeReq); err != nil {
+		return err
+	}
+
+	res, wrErr := a.ach.MergeDogrun(c, sourceID, mergeReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// SearchOrgs: organizationの検索
+func (a *adminController) SearchOrgs(c echo.Context) error {
+	searchReq := dto.AdminSearchReq{}
+
+	if err := bindAndValidate(c, &searchReq); err != nil {
+		return err
+	}
+
+	orgs, wrErr := a.ah.SearchOrgs(c, searchReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, orgs)
+}
+
+// GetOrg: organizationの詳細の取得
+func (a *adminController) GetOrg(c echo.Context) error {
+	orgID, err := parseIDParam(c, "orgId")
+
+	if err != nil {
+		return err
+	}
+
+	org, wrErr := a.ah.GetOrg(c, orgID)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, org)
+}
+
+// SearchUploads: アップロードされたファイルの検索
+func (a *adminController) SearchUploads(c echo.Context) error {
+	searchReq := dto.AdminUploadSearchReq{}
+
+	if err := bindAndValidate(c, &searchReq); err != nil {
+		return err
+	}
+
+	uploads, wrErr := a.ah.SearchUploads(c, searchReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, uploads)
+}
+
+// GetUpload: アップロードされたファイルの詳細の取得
+func (a *adminController) GetUpload(c echo.Context) error {
+	upload, wrErr := a.ah.GetUpload(c, c.Param("fileId"))
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, upload)
+}
+
+// GetTagMsts: tag_mstの一覧の取得
+func (a *adminController) GetTagMsts(c echo.Context) error {
+	tagMsts, wrErr := a.ah.GetTagMsts(c)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, tagMsts)
+}
+
+// CreateTagMst: tag_mstの登録
+func (a *adminController) CreateTagMst(c echo.Context) error {
+	tagMstReq := dto.AdminTagMstReq{}
+
+	if err := bindAndValidate(c, &tagMstReq); err != nil {
+		return err
+	}
+
+	tagMst, wrErr := a.ach.CreateTagMst(c, tagMstReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusCreated, tagMst)
+}
+
+// UpdateTagMst: tag_mstの更新
+func (a *adminController) UpdateTagMst(c echo.Context) error {
+	tagID, err := parseIDParam(c, "tagId")
+
+	if err != nil {
+		return err
+	}
+
+	tagMstReq := dto.AdminTagMstReq{}
+
+	if err := bindAndValidate(c, &tagMstReq); err != nil {
+		return err
+	}
+
+	tagMst, wrErr := a.ach.UpdateTagMst(c, tagID, tagMstReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, tagMst)
+}
+
+// DeleteTagMst: tag_mstの削除
+func (a *adminController) DeleteTagMst(c echo.Context) error {
+	tagID, err := parseIDParam(c, "tagId")
+
+	if err != nil {
+		return err
+	}
+
+	if wrErr := a.ach.DeleteTagMst(c, tagID); wrErr != nil {
+		return wrErr
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetDogTypeMsts: dog_type_mstの一覧の取得
+func (a *adminController) GetDogTypeMsts(c echo.Context) error {
+	dogTypeMsts, wrErr := a.ah.GetDogTypeMsts(c)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, dogTypeMsts)
+}
+
+// CreateDogTypeMst: dog_type_mstの登録
+func (a *adminController) CreateDogTypeMst(c echo.Context) error {
+	dogTypeMstReq := dto.AdminDogTypeMstReq{}
+
+	if err := bindAndValidate(c, &dogTypeMstReq); err != nil {
+		return err
+	}
+
+	dogTypeMst, wrErr := a.ach.CreateDogTypeMst(c, dogTypeMstReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusCreated, dogTypeMst)
+}
+
+// UpdateDogTypeMst: dog_type_mstの更新
+func (a *adminController) UpdateDogTypeMst(c echo.Context) error {
+	dogTypeID, err := parseIDParam(c, "dogTypeId")
+
+	if err != nil {
+		return err
+	}
+
+	dogTypeMstReq := dto.AdminDogTypeMstReq{}
+
+	if err := bindAndValidate(c, &dogTypeMstReq); err != nil {
+		return err
+	}
+
+	dogTypeMst, wrErr := a.ach.UpdateDogTypeMst(c, dogTypeID, dogTypeMstReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, dogTypeMst)
+}
+
+// DeleteDogTypeMst: dog_type_mstの削除
+func (a *adminController) DeleteDogTypeMst(c echo.Context) error {
+	dogTypeID, err := parseIDParam(c, "dogTypeId")
+
+	if err != nil {
+		return err
+	}
+
+	if wrErr := a.ach.DeleteDogTypeMst(c, dogTypeID); wrErr != nil {
+		return wrErr
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Moderate: ユーザー投稿の非表示・削除
+func (a *adminController) Moderate(c echo.Context) error {
+	moderationReq := dto.AdminModerationReq{}
+
+	if err := bindAndValidate(c, &moderationReq); err != nil {
+		return err
+	}
+
+	if wrErr := a.ach.Moderate(c, moderationReq); wrErr != nil {
+		return wrErr
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CreateOperator: 運営者の登録
+func (a *adminController) CreateOperator(c echo.Context) error {
+	operatorReq := dto.AdminOperatorReq{}
+
+	if err := bindAndValidate(c, &operatorReq); err != nil {
+		return err
+	}
+
+	operator, wrErr := a.aah.CreateOperator(c, operatorReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusCreated, operator)
+}
+
+/*
+リクエストのバインドとバリデーション
+*/
+func bindAndValidate(c echo.Context, req any) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := c.Bind(req); err != nil {
+		wrErr := errors.NewWRError(
+			err,
+			"入力項目に不正があります。",
+			errors.NewAdminClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		wrErr := errors.NewWRError(
+			err,
+			"必須の項目に不正があります。",
+			errors.NewAdminClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	return nil
+}
+
+/*
+パスパラメータのIDの変換
+*/
+func parseIDParam(c echo.Context, name string) (int64, error) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id <= 0 {
+		wrErr := errors.NewWRError(
+			err,
+			"パスパラメータのIDが不正です。",
+			errors.NewAdminClientErrorEType(),
+		)
+		log.GetLogger(c).Sugar().Error(wrErr)
+		return 0, wrErr
+	}
+	return id, nil
+}
@@ -0,0 +1,164 @@
+package dto
+
+import (
+	"time"
+)
+
+// モデレーションの対象
+const (
+	MODERATION_DOGRUN_EVENT   = "dogrunEvent"   // イベントの中止
+	MODERATION_DOGRUN_IMAGE   = "dogrunImage"   // ドッグラン画像の削除
+	MODERATION_DOG_PROFILE    = "dogProfile"    // dogの自己紹介の削除
+	MODERATION_DOG_IMAGE      = "dogImage"      // dogの画像の削除
+	MODERATION_DOGOWNER_IMAGE = "dogownerImage" // dogownerの画像の削除
+)
+
+type AdminSearchReq struct {
+	Keyword string `query:"keyword" validate:"max=256"`
+	Limit   int    `query:"limit" validate:"min=0,max=100"`
+	Offset  int    `query:"offset" validate:"min=0"`
+}
+
+type AdminUploadSearchReq struct {
+	OwnerType string `query:"ownerType" validate:"max=16"`
+	OwnerID   int64  `query:"ownerId" validate:"min=0"`
+	Limit     int    `query:"limit" validate:"min=0,max=100"`
+	Offset    int    `query:"offset" validate:"min=0"`
+}
+
+type AdminSuspendReq struct {
+	Reason string `json:"reason" validate:"required,max=512"`
+}
+
+type AdminDogrunMergeReq struct {
+	TargetDogrunID int64  `json:"targetDogrunId" validate:"required,gt=0"` // 統合先
+	Note           string `json:"note" validate:"max=512"`
+}
+
+type AdminTagMstReq struct {
+	TagName     string `json:"tagName" validate:"required,max=64"`
+	Description string `json:"description"`
+	Category    string `json:"category" validate:"required,oneof=facility ground size_zone rule other"`
+}
+
+type AdminDogTypeMstReq struct {
+	Name string `json:"name" validate:"required,max=64"`
+}
+
+type AdminModerationReq struct {
+	ContentType string `json:"contentType" validate:"required,oneof=dogrunEvent dogrunImage dogProfile dogImage dogownerImage"`
+	ContentID   int64  `json:"contentId" validate:"required,gt=0"`
+	Reason      string `json:"reason" validate:"required,max=512"`
+}
+
+type AdminOperatorReq struct {
+	Name     string `json:"name" validate:"required,max=128"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required"`
+}
+
+type AdminCredentialRes struct {
+	GrantType   string     `json:"grantType,omitempty"`
+	Email       string     `json:"email,omitempty"`
+	PhoneNumber string     `json:"phoneNumber,omitempty"`
+	LoginAt     *time.Time `json:"loginAt,omitempty"`
+}
+
+type AdminDogOwnerRes struct {
+	DogOwnerID       int64                `json:"dogOwnerId"`
+	Name             string               `json:"name"`
+	Image            string               `json:"image,omitempty"`
+	Sex              string               `json:"sex,omitempty"`
+	SuspendedAt      *time.Time           `json:"suspendedAt,omitempty"`
+	SuspensionReason string               `json:"suspensionReason,omitempty"`
+	CreateAt         time.Time            `json:"createAt"`
+	Credentials      []AdminCredentialRes `json:"credentials,omitempty"` // 詳細のみ
+	Dogs             []AdminDogRes        `json:"dogs,omitempty"`        // 詳細のみ
+}
+
+type AdminDogrunmgRes struct {
+	DogrunmgID       int64      `json:"dogrunmgId"`
+	Name             string     `json:"name"`
+	Email            string     `json:"email"`
+	OrganizationID   int64      `json:"organizationId"`
+	OrganizationName string     `json:"organizationName"`
+	IsAdmin          bool       `json:"isAdmin"`
+	IsActive         bool       `json:"isActive"`
+	SuspendedAt      *time.Time `json:"suspendedAt,omitempty"`
+	SuspensionReason string     `json:"suspensionReason,omitempty"`
+	LoginAt          *time.Time `json:"loginAt,omitempty"`
+	CreateAt         time.Time  `json:"createAt"`
+}
+
+type AdminDogRes struct {
+	DogID             int64     `json:"dogId"`
+	DogOwnerID        int64     `json:"dogOwnerId"`
+	DogOwnerName      string    `json:"dogOwnerName,omitempty"`
+	Name              string    `json:"name"`
+	Sex               string    `json:"sex,omitempty"`
+	Image             string    `json:"image,omitempty"`
+	MicrochipID       string    `json:"microchipId,omitempty"`
+	DogTypeIDs        []int64   `json:"dogTypeIds,omitempty"`        // 詳細のみ
+	CoOwnerIDs        []int64   `json:"coOwnerIds,omitempty"`        // 詳細のみ
+	Bio               string    `json:"bio,omitempty"`               // 詳細のみ
+	ProfileVisibility string    `json:"profileVisibility,omitempty"` // 詳細のみ
+	CreateAt          time.Time `json:"createAt"`
+}
+
+type AdminDogrunRes struct {
+	DogrunID           int64     `json:"dogrunId"`
+	PlaceID            string    `json:"placeId,omitempty"`
+	Name               string    `json:"name"`
+	Address            string    `json:"address,omitempty"`
+	DogrunManagerID    int64     `json:"dogrunManagerId,omitempty"`
+	IsManaged          bool      `json:"isManaged"`
+	MergedIntoDogrunID int64     `json:"mergedIntoDogrunId,omitempty"` // 統合済みの場合の統合先
+	CreateAt           time.Time `json:"createAt"`
+}
+
+type AdminOrgRes struct {
+	OrganizationID int64              `json:"organizationId"`
+	OrgName        string             `json:"organizationName"`
+	ContactEmail   string             `json:"contactEmail"`
+	PhoneNumber    string             `json:"phoneNumber"`
+	Address        string             `json:"address"`
+	ClosedAt       *time.Time         `json:"closedAt,omitempty"`
+	CreateAt       time.Time          `json:"createAt"`
+	Managers       []AdminDogrunmgRes `json:"managers,omitempty"` // 詳細のみ
+	Dogruns        []AdminDogrunRes   `json:"dogruns,omitempty"`  // 詳細のみ
+}
+
+type AdminUploadRes struct {
+	FileID      string     `json:"fileId"`
+	OwnerType   string     `json:"ownerType"`
+	OwnerID     int64      `json:"ownerId"`
+	ContentType string     `json:"contentType"`
+	FileSize    int64      `json:"fileSize"`
+	CreateAt    time.Time  `json:"createAt"`
+	URL         string     `json:"url,omitempty"`       // 詳細のみ
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"` // 詳細のみ
+}
+
+type AdminTagMstRes struct {
+	TagID       int64  `json:"tagId"`
+	TagName     string `json:"tagName"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+}
+
+type AdminDogTypeMstRes struct {
+	DogTypeID int64  `json:"dogTypeId"`
+	Name      string `json:"name"`
+}
+
+type AdminDogrunMergeRes struct {
+	SourceDogrunID int64            `json:"sourceDogrunId"`
+	TargetDogrunID int64            `json:"targetDogrunId"`
+	Moved          map[string]int64 `json:"moved"` // テーブルごとの付け替えた件数
+}
+
+type AdminOperatorRes struct {
+	SystemOperatorID int64  `json:"systemOperatorId"`
+	Name             string `json:"name"`
+	Email            string `json:"email"`
+}
@@ -0,0 +1,350 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	adminRepository "github.com/wanrun-develop/wanrun/internal/admin/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/admin/core/dto"
+	auditDTO "github.com/wanrun-develop/wanrun/internal/audit/core/dto"
+	auditFacade "github.com/wanrun-develop/wanrun/internal/audit/facade"
+	"github.com/wanrun-develop/wanrun/internal/auth/core"
+	authFacade "github.com/wanrun-develop/wanrun/internal/auth/core/facade"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/transaction"
+	"github.com/wanrun-develop/wanrun/internal/wrcontext"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	wrUtil "github.com/wanrun-develop/wanrun/pkg/util"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type IAdminAccountHandler interface {
+	SuspendDogOwner(c echo.Context, dogOwnerID int64, req dto.AdminSuspendReq) (dto.AdminDogOwnerRes, error)
+	UnsuspendDogOwner(c echo.Context, dogOwnerID int64) (dto.AdminDogOwnerRes, error)
+	SuspendDogrunmg(c echo.Context, dmID int64, req dto.AdminSuspendReq) (dto.AdminDogrunmgRes, error)
+	UnsuspendDogrunmg(c echo.Context, dmID int64) (dto.AdminDogrunmgRes, error)
+	CreateOperator(c echo.Context, req dto.AdminOperatorReq) (dto.AdminOperatorRes, error)
+}
+
+type adminAccountHandler struct {
+	ar  adminRepository.IAdminRepository
+	asr adminRepository.IAdminScopeRepository
+	tm  transaction.ITransactionManager
+	af  authFacade.IAuthFacade
+	auf auditFacade.IAuditFacade
+}
+
+func NewAdminAccountHandler(
+	ar adminRepository.IAdminRepository,
+	asr adminRepository.IAdminScopeRepository,
+	tm transaction.ITransactionManager,
+	af authFacade.IAuthFacade,
+	auf auditFacade.IAuditFacade,
+) IAdminAccountHandler {
+	return &adminAccountHandler{
+		ar:  ar,
+		asr: asr,
+		tm:  tm,
+		af:  af,
+		auf: auf,
+	}
+}
+
+// SuspendDogOwner: dogownerの利用停止
+// 発行済みのトークンは無効化し、利用停止中はログインできない。
+// 既に利用停止中の場合も、トークンの無効化のみ行う
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogOwnerID
+//   - dto.AdminSuspendReq: 利用停止の理由
+//
+// return:
+//   - dto.AdminDogOwnerRes: 利用停止後のdogowner
+//   - error: error情報
+func (aah *adminAccountHandler) SuspendDogOwner(c echo.Context, dogOwnerID int64, req dto.AdminSuspendReq) (dto.AdminDogOwnerRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if _, wrErr := aah.getDogOwner(c, dogOwnerID); wrErr != nil {
+		return dto.AdminDogOwnerRes{}, wrErr
+	}
+
+	ctx := c.Request().Context()
+
+	if err := aah.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		suspended, wrErr := aah.asr.SuspendDogOwner(tx, c, dogOwnerID, req.Reason, time.Now())
+
+		if wrErr != nil || !suspended {
+			return wrErr
+		}
+
+		return recordAdminAction(tx, c, aah.auf, model.ADMIN_ACTION_SUSPEND, model.ADMIN_TARGET_DOGOWNER, targetID(dogOwnerID), req.Reason, nil)
+	}); err != nil {
+		logger.Error("Transaction failed:", err)
+		return dto.AdminDogOwnerRes{}, err
+	}
+
+	// ログイン中のトークンの無効化
+	if wrErr := aah.af.RevokeDogowner(c, dogOwnerID); wrErr != nil {
+		return dto.AdminDogOwnerRes{}, wrErr
+	}
+
+	logger.Infof("Suspended DogOwner %d", dogOwnerID)
+
+	return aah.getDogOwner(c, dogOwnerID)
+}
+
+// UnsuspendDogOwner: dogownerの利用停止の解除
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogOwnerID
+//
+// return:
+//   - dto.AdminDogOwnerRes: 解除後のdogowner
+//   - error: error情報
+func (aah *adminAccountHandler) UnsuspendDogOwner(c echo.Context, dogOwnerID int64) (dto.AdminDogOwnerRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if _, wrErr := aah.getDogOwner(c, dogOwnerID); wrErr != nil {
+		return dto.AdminDogOwnerRes{}, wrErr
+	}
+
+	ctx := c.Request().Context()
+
+	if err := aah.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		unsuspended, wrErr := aah.asr.UnsuspendDogOwner(tx, c, dogOwnerID)
+
+		if wrErr != nil || !unsuspended {
+			return wrErr
+		}
+
+		return recordAdminAction(tx, c, aah.auf, model.ADMIN_ACTION_UNSUSPEND, model.ADMIN_TARGET_DOGOWNER, targetID(dogOwnerID), "", nil)
+	}); err != nil {
+		logger.Error("Transaction failed:", err)
+		return dto.AdminDogOwnerRes{}, err
+	}
+
+	return aah.getDogOwner(c, dogOwnerID)
+}
+
+// SuspendDogrunmg: dogrunmgの利用停止
+// 発行済みのトークンは無効化し、利用停止中はログインできない。
+// 既に利用停止中の場合も、トークンの無効化のみ行う
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogrunmgID
+//   - dto.AdminSuspendReq: 利用停止の理由
+//
+// return:
+//   - dto.AdminDogrunmgRes: 利用停止後のdogrunmg
+//   - error: error情報
+func (aah *adminAccountHandler) SuspendDogrunmg(c echo.Context, dmID int64, req dto.AdminSuspendReq) (dto.AdminDogrunmgRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if _, wrErr := aah.getDogrunmg(c, dmID); wrErr != nil {
+		return dto.AdminDogrunmgRes{}, wrErr
+	}
+
+	ctx := c.Request().Context()
+
+	if err := aah.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		suspended, wrErr := aah.asr.SuspendDogrunmg(tx, c, dmID, req.Reason, time.Now())
+
+		if wrErr != nil || !suspended {
+			return wrErr
+		}
+
+		return recordAdminAction(tx, c, aah.auf, model.ADMIN_ACTION_SUSPEND, model.ADMIN_TARGET_DOGRUNMG, targetID(dmID), req.Reason, nil)
+	}); err != nil {
+		logger.Error("Transaction failed:", err)
+		return dto.AdminDogrunmgRes{}, err
+	}
+
+	// ログイン中のトークンの無効化
+	if wrErr := aah.af.RevokeDogrunmg(c, dmID); wrErr != nil {
+		return dto.AdminDogrunmgRes{}, wrErr
+	}
+
+	logger.Infof("Suspended Dogrunmg %d", dmID)
+
+	return aah.getDogrunmg(c, dmID)
+}
+
+// UnsuspendDogrunmg: dogrunmgの利用停止の解除
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogrunmgID
+//
+// return:
+//   - dto.AdminDogrunmgRes: 解除後のdogrunmg
+//   - error: error情報
+func (aah *adminAccountHandler) UnsuspendDogrunmg(c echo.Context, dmID int64) (dto.AdminDogrunmgRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if _, wrErr := aah.getDogrunmg(c, dmID); wrErr != nil {
+		return dto.AdminDogrunmgRes{}, wrErr
+	}
+
+	ctx := c.Request().Context()
+
+	if err := aah.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		unsuspended, wrErr := aah.asr.UnsuspendDogrunmg(tx, c, dmID)
+
+		if wrErr != nil || !unsuspended {
+			return wrErr
+		}
+
+		return recordAdminAction(tx, c, aah.auf, model.ADMIN_ACTION_UNSUSPEND, model.ADMIN_TARGET_DOGRUNMG, targetID(dmID), "", nil)
+	}); err != nil {
+		logger.Error("Transaction failed:", err)
+		return dto.AdminDogrunmgRes{}, err
+	}
+
+	return aah.getDogrunmg(c, dmID)
+}
+
+// CreateOperator: 運営者の登録
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.AdminOperatorReq: 運営者の情報
+//
+// return:
+//   - dto.AdminOperatorRes: 登録した運営者
+//   - error: error情報
+func (aah *adminAccountHandler) CreateOperator(c echo.Context, req dto.AdminOperatorReq) (dto.AdminOperatorRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	count, wrErr := aah.ar.CountSystemOperatorsByEmail(c, req.Email)
+
+	if wrErr != nil {
+		return dto.AdminOperatorRes{}, wrErr
+	}
+
+	if count > 0 {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"既に登録されているメールアドレスです。",
+			wrErrors.NewAdminClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return dto.AdminOperatorRes{}, wrErr
+	}
+
+	// パスワードのハッシュ化
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+
+	if err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"パスワードに不正な文字列が入っています。",
+			wrErrors.NewAdminClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return dto.AdminOperatorRes{}, wrErr
+	}
+
+	operator := model.SystemOperator{
+		Name:     wrUtil.NewSqlNullString(req.Name),
+		Email:    wrUtil.NewSqlNullString(req.Email),
+		Password: wrUtil.NewSqlNullString(string(hash)),
+		IsActive: wrUtil.NewSqlNullBool(true),
+	}
+	ctx := c.Request().Context()
+
+	if err := aah.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		if wrErr := aah.asr.CreateSystemOperator(tx, c, &operator); wrErr != nil {
+			return wrErr
+		}
+
+		return recordAdminAction(tx, c, aah.auf, model.ADMIN_ACTION_CREATE_OPERATOR, model.ADMIN_TARGET_SYSTEM_OPERATOR, targetID(operator.SystemOperatorID.Int64), "", map[string]string{
+			"email": req.Email,
+		})
+	}); err != nil {
+		logger.Error("Transaction failed:", err)
+		return dto.AdminOperatorRes{}, err
+	}
+
+	return dto.AdminOperatorRes{
+		SystemOperatorID: operator.SystemOperatorID.Int64,
+		Name:             operator.Name.String,
+		Email:            operator.Email.String,
+	}, nil
+}
+
+/*
+dogownerの取得。存在しない場合はエラー
+*/
+func (aah *adminAccountHandler) getDogOwner(c echo.Context, dogOwnerID int64) (dto.AdminDogOwnerRes, error) {
+	dogOwner, wrErr := aah.ar.FindDogOwnerByID(c, dogOwnerID)
+
+	if wrErr != nil {
+		return dto.AdminDogOwnerRes{}, wrErr
+	}
+
+	if dogOwner.IsEmpty() {
+		return dto.AdminDogOwnerRes{}, notFoundError(c, "dogownerが存在しません。")
+	}
+
+	return toAdminDogOwnerRes(dogOwner), nil
+}
+
+/*
+dogrunmgの取得。存在しない場合はエラー
+*/
+func (aah *adminAccountHandler) getDogrunmg(c echo.Context, dmID int64) (dto.AdminDogrunmgRes, error) {
+	credential, wrErr := aah.ar.FindDogrunmgCredential(c, dmID)
+
+	if wrErr != nil {
+		return dto.AdminDogrunmgRes{}, wrErr
+	}
+
+	if !credential.CredentialID.Valid {
+		return dto.AdminDogrunmgRes{}, notFoundError(c, "dogrunmgが存在しません。")
+	}
+
+	return toAdminDogrunmgRes(credential), nil
+}
+
+/*
+ログイン中の運営者の操作を監査ログに記録。操作理由と詳細は変更後の値として記録する
+*/
+func recordAdminAction(
+	tx *gorm.DB,
+	c echo.Context,
+	auf auditFacade.IAuditFacade,
+	action string,
+	targetType string,
+	targetID string,
+	note string,
+	detail any,
+) error {
+	operatorID, wrErr := wrcontext.GetLoginUserID(c)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	after := map[string]any{}
+	if note != "" {
+		after["note"] = note
+	}
+	if detail != nil {
+		after["detail"] = detail
+	}
+
+	return auf.Record(tx, c, auditDTO.AuditEntry{
+		ActorID:    operatorID,
+		ActorRole:  core.SYSTEM,
+		Action:     action,
+		Result:     model.AUDIT_RESULT_SUCCESS,
+		TargetType: targetType,
+		TargetID:   targetID,
+		After:      after,
+	})
+}
@@ -0,0 +1,488 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	adminRepository "github.com/wanrun-develop/wanrun/internal/admin/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/admin/core/dto"
+	auditFacade "github.com/wanrun-develop/wanrun/internal/audit/facade"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/transaction"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	wrUtil "github.com/wanrun-develop/wanrun/pkg/util"
+	"gorm.io/gorm"
+)
+
+type IAdminContentHandler interface {
+	MergeDogrun(c echo.Context, sourceID int64, req dto.AdminDogrunMergeReq) (dto.AdminDogrunMergeRes, error)
+	CreateTagMst(c echo.Context, req dto.AdminTagMstReq) (dto.AdminTagMstRes, error)
+	UpdateTagMst(c echo.Context, tagID int64, req dto.AdminTagMstReq) (dto.AdminTagMstRes, error)
+	DeleteTagMst(c echo.Context, tagID int64) error
+	CreateDogTypeMst(c echo.Context, req dto.AdminDogTypeMstReq) (dto.AdminDogTypeMstRes, error)
+	UpdateDogTypeMst(c echo.Context, dogTypeID int64, req dto.AdminDogTypeMstReq) (dto.AdminDogTypeMstRes, error)
+	DeleteDogTypeMst(c echo.Context, dogTypeID int64) error
+	Moderate(c echo.Context, req dto.AdminModerationReq) error
+}
+
+type adminContentHandler struct {
+	ar  adminRepository.IAdminRepository
+	asr adminRepository.IAdminScopeRepository
+	tm  transaction.ITransactionManager
+	auf auditFacade.IAuditFacade
+}
+
+func NewAdminContentHandler(
+	ar adminRepository.IAdminRepository,
+	asr adminRepository.IAdminScopeRepository,
+	tm transaction.ITransactionManager,
+	auf auditFacade.IAuditFacade,
+) IAdminContentHandler {
+	return &adminContentHandler{
+		ar:  ar,
+		asr: asr,
+		tm:  tm,
+		auf: auf,
+	}
+}
+
+// MergeDogrun: 重複したドッグランの統合
+// 統合元のブックマーク、タグ、画像、チェックイン、イベントを統合先に付け替える。
+// 統合元は検索対象外となり、place_idでの取得は統合先を返す。
+// 管理されているドッグランと、管理申請の審査中のドッグランは統合元にできない
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: 統合元のdogrunID
+//   - dto.AdminDogrunMergeReq: 統合先のdogrunID
+//
+// return:
+//   - dto.AdminDogrunMergeRes: 統合結果
+//   - error: error情報
+func (ach *adminContentHandler) MergeDogrun(c echo.Context, sourceID int64, req dto.AdminDogrunMergeReq) (dto.AdminDogrunMergeRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if sourceID == req.TargetDogrunID {
+		return dto.AdminDogrunMergeRes{}, clientError(c, "同じドッグランには統合できません。")
+	}
+
+	moved := map[string]int64{}
+	ctx := c.Request().Context()
+
+	if err := ach.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		// 同じドッグランの統合・管理申請と競合しないようロックして取得
+		dogruns, wrErr := ach.asr.FindDogrunsForUpdate(tx, c, []int64{sourceID, req.TargetDogrunID})
+
+		if wrErr != nil {
+			return wrErr
+		}
+
+		if len(dogruns) != 2 {
+			return notFoundError(c, "ドッグランが存在しません。")
+		}
+
+		for _, dogrun := range dogruns {
+			if dogrun.IsMerged() {
+				return clientError(c, "既に統合されたドッグランです。")
+			}
+			if dogrun.DogrunID.Int64 == sourceID && dogrun.IsManaged.Bool {
+				return clientError(c, "管理されているドッグランは統合元にできません。")
+			}
+		}
+
+		pendingCount, wrErr := ach.asr.CountPendingClaims(tx, c, sourceID)
+
+		if wrErr != nil {
+			return wrErr
+		}
+
+		if pendingCount > 0 {
+			return clientError(c, "管理申請の審査中のドッグランは統合元にできません。")
+		}
+
+		moved, wrErr = ach.asr.MergeDogrun(tx, c, sourceID, req.TargetDogrunID)
+
+		if wrErr != nil {
+			return wrErr
+		}
+
+		return recordAdminAction(tx, c, ach.auf, model.ADMIN_ACTION_MERGE_DOGRUN, model.ADMIN_TARGET_DOGRUN, targetID(sourceID), req.Note, map[string]any{
+			"targetDogrunId": req.TargetDogrunID,
+			"moved":          moved,
+		})
+	}); err != nil {
+		logger.Error("Transaction failed:", err)
+		return dto.AdminDogrunMergeRes{}, err
+	}
+
+	logger.Infof("Merged Dogrun %d -> %d", sourceID, req.TargetDogrunID)
+
+	return dto.AdminDogrunMergeRes{
+		SourceDogrunID: sourceID,
+		TargetDogrunID: req.TargetDogrunID,
+		Moved:          moved,
+	}, nil
+}
+
+// CreateTagMst: tag_mstの登録
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.AdminTagMstReq: マスターの内容
+//
+// return:
+//   - dto.AdminTagMstRes: 登録したマスター
+//   - error: error情報
+func (ach *adminContentHandler) CreateTagMst(c echo.Context, req dto.AdminTagMstReq) (dto.AdminTagMstRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	tagMst := model.TagMst{
+		TagName:     wrUtil.NewSqlNullString(req.TagName),
+		Description: wrUtil.NewSqlNullString(req.Description),
+		Category:    wrUtil.NewSqlNullString(req.Category),
+	}
+	ctx := c.Request().Context()
+
+	if err := ach.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		if wrErr := ach.asr.CreateTagMst(tx, c, &tagMst); wrErr != nil {
+			return wrErr
+		}
+
+		return recordAdminAction(tx, c, ach.auf, model.ADMIN_ACTION_CREATE_MST, model.ADMIN_TARGET_TAG_MST, targetID(tagMst.TagID.Int64), "", req)
+	}); err != nil {
+		logger.Error("Transaction failed:", err)
+		return dto.AdminTagMstRes{}, err
+	}
+
+	return toAdminTagMstRes(tagMst), nil
+}
+
+// UpdateTagMst: tag_mstの更新
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: tagID
+//   - dto.AdminTagMstReq: マスターの内容
+//
+// return:
+//   - dto.AdminTagMstRes: 更新後のマスター
+//   - error: error情報
+func (ach *adminContentHandler) UpdateTagMst(c echo.Context, tagID int64, req dto.AdminTagMstReq) (dto.AdminTagMstRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	ctx := c.Request().Context()
+
+	if err := ach.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		updated, wrErr := ach.asr.UpdateTagMst(tx, c, tagID, map[string]any{
+			"tag_name":    req.TagName,
+			"description": wrUtil.NewSqlNullString(req.Description),
+			"category":    req.Category,
+		})
+
+		if wrErr != nil {
+			return wrErr
+		}
+
+		if !updated {
+			return notFoundError(c, "タグが存在しません。")
+		}
+
+		return recordAdminAction(tx, c, ach.auf, model.ADMIN_ACTION_UPDATE_MST, model.ADMIN_TARGET_TAG_MST, targetID(tagID), "", req)
+	}); err != nil {
+		logger.Error("Transaction failed:", err)
+		return dto.AdminTagMstRes{}, err
+	}
+
+	return dto.AdminTagMstRes{
+		TagID:       tagID,
+		TagName:     req.TagName,
+		Description: req.Description,
+		Category:    req.Category,
+	}, nil
+}
+
+// DeleteTagMst: tag_mstの削除
+// ドッグランに設定されているタグは削除できない
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: tagID
+//
+// return:
+//   - error: error情報
+func (ach *adminContentHandler) DeleteTagMst(c echo.Context, tagID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	ctx := c.Request().Context()
+
+	if err := ach.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		count, wrErr := ach.asr.CountDogrunTagsByTagID(tx, c, tagID)
+
+		if wrErr != nil {
+			return wrErr
+		}
+
+		if count > 0 {
+			return clientError(c, "ドッグランに設定されているタグは削除できません。")
+		}
+
+		deleted, wrErr := ach.asr.DeleteTagMst(tx, c, tagID)
+
+		if wrErr != nil {
+			return wrErr
+		}
+
+		if !deleted {
+			return notFoundError(c, "タグが存在しません。")
+		}
+
+		return recordAdminAction(tx, c, ach.auf, model.ADMIN_ACTION_DELETE_MST, model.ADMIN_TARGET_TAG_MST, targetID(tagID), "", nil)
+	}); err != nil {
+		logger.Error("Transaction failed:", err)
+		return err
+	}
+
+	return nil
+}
+
+// CreateDogTypeMst: dog_type_mstの登録
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.AdminDogTypeMstReq: マスターの内容
+//
+// return:
+//   - dto.AdminDogTypeMstRes: 登録したマスター
+//   - error: error情報
+func (ach *adminContentHandler) CreateDogTypeMst(c echo.Context, req dto.AdminDogTypeMstReq) (dto.AdminDogTypeMstRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogTypeMst := model.DogTypeMst{
+		Name: req.Name,
+	}
+	ctx := c.Request().Context()
+
+	if err := ach.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		if wrErr := ach.asr.CreateDogTypeMst(tx, c, &dogTypeMst); wrErr != nil {
+			return wrErr
+		}
+
+		return recordAdminAction(tx, c, ach.auf, model.ADMIN_ACTION_CREATE_MST, model.ADMIN_TARGET_DOG_TYPE_MST, targetID(int64(dogTypeMst.DogTypeID)), "", req)
+	}); err != nil {
+		logger.Error("Transaction failed:", err)
+		return dto.AdminDogTypeMstRes{}, err
+	}
+
+	return toAdminDogTypeMstRes(dogTypeMst), nil
+}
+
+// UpdateDogTypeMst: dog_type_mstの更新
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogTypeID
+//   - dto.AdminDogTypeMstReq: マスターの内容
+//
+// return:
+//   - dto.AdminDogTypeMstRes: 更新後のマスター
+//   - error: error情報
+func (ach *adminContentHandler) UpdateDogTypeMst(c echo.Context, dogTypeID int64, req dto.AdminDogTypeMstReq) (dto.AdminDogTypeMstRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	ctx := c.Request().Context()
+
+	if err := ach.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		updated, wrErr := ach.asr.UpdateDogTypeMst(tx, c, dogTypeID, req.Name)
+
+		if wrErr != nil {
+			return wrErr
+		}
+
+		if !updated {
+			return notFoundError(c, "犬種が存在しません。")
+		}
+
+		return recordAdminAction(tx, c, ach.auf, model.ADMIN_ACTION_UPDATE_MST, model.ADMIN_TARGET_DOG_TYPE_MST, targetID(dogTypeID), "", req)
+	}); err != nil {
+		logger.Error("Transaction failed:", err)
+		return dto.AdminDogTypeMstRes{}, err
+	}
+
+	return dto.AdminDogTypeMstRes{
+		DogTypeID: dogTypeID,
+		Name:      req.Name,
+	}, nil
+}
+
+// DeleteDogTypeMst: dog_type_mstの削除
+// dogに設定されている犬種は削除できない
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogTypeID
+//
+// return:
+//   - error: error情報
+func (ach *adminContentHandler) DeleteDogTypeMst(c echo.Context, dogTypeID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	ctx := c.Request().Context()
+
+	if err := ach.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		count, wrErr := ach.asr.CountDogsByDogTypeID(tx, c, dogTypeID)
+
+		if wrErr != nil {
+			return wrErr
+		}
+
+		if count > 0 {
+			return clientError(c, "dogに設定されている犬種は削除できません。")
+		}
+
+		deleted, wrErr := ach.asr.DeleteDogTypeMst(tx, c, dogTypeID)
+
+		if wrErr != nil {
+			return wrErr
+		}
+
+		if !deleted {
+			return notFoundError(c, "犬種が存在しません。")
+		}
+
+		return recordAdminAction(tx, c, ach.auf, model.ADMIN_ACTION_DELETE_MST, model.ADMIN_TARGET_DOG_TYPE_MST, targetID(dogTypeID), "", nil)
+	}); err != nil {
+		logger.Error("Transaction failed:", err)
+		return err
+	}
+
+	return nil
+}
+
+// Moderate: ユーザー投稿の非表示・削除
+// イベントは中止、ドッグラン画像は削除、dogの自己紹介・画像とdogownerの画像は削除する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.AdminModerationReq: 対象と理由
+//
+// return:
+//   - error: error情報
+func (ach *adminContentHandler) Moderate(c echo.Context, req dto.AdminModerationReq) error {
+	logger := log.GetLogger(c).Sugar()
+
+	// 対象の内容を操作記録に残す
+	detail, targetType, wrErr := ach.findModerationTarget(c, req)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	ctx := c.Request().Context()
+
+	if err := ach.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		var moderated bool
+		var wrErr error
+
+		switch req.ContentType {
+		case dto.MODERATION_DOGRUN_EVENT:
+			moderated, wrErr = ach.asr.CancelDogrunEvent(tx, c, req.ContentID)
+		case dto.MODERATION_DOGRUN_IMAGE:
+			moderated, wrErr = ach.asr.DeleteDogrunImage(tx, c, req.ContentID)
+		case dto.MODERATION_DOG_PROFILE:
+			moderated, wrErr = ach.asr.ClearDogProfileBio(tx, c, req.ContentID)
+		case dto.MODERATION_DOG_IMAGE:
+			moderated, wrErr = ach.asr.ClearDogImage(tx, c, req.ContentID)
+		case dto.MODERATION_DOGOWNER_IMAGE:
+			moderated, wrErr = ach.asr.ClearDogOwnerImage(tx, c, req.ContentID)
+		}
+
+		if wrErr != nil {
+			return wrErr
+		}
+
+		if !moderated {
+			return clientError(c, "対象の投稿は既に非表示です。")
+		}
+
+		return recordAdminAction(tx, c, ach.auf, model.ADMIN_ACTION_MODERATE, targetType, targetID(req.ContentID), req.Reason, detail)
+	}); err != nil {
+		logger.Error("Transaction failed:", err)
+		return err
+	}
+
+	logger.Infof("Moderated %s %d", req.ContentType, req.ContentID)
+
+	return nil
+}
+
+/*
+モデレーションの対象の取得。操作記録に残す内容と対象の種別を返す
+*/
+func (ach *adminContentHandler) findModerationTarget(c echo.Context, req dto.AdminModerationReq) (map[string]any, string, error) {
+	switch req.ContentType {
+	case dto.MODERATION_DOGRUN_EVENT:
+		event, wrErr := ach.ar.FindDogrunEventByID(c, req.ContentID)
+		if wrErr != nil {
+			return nil, "", wrErr
+		}
+		if event.IsEmpty() {
+			return nil, "", notFoundError(c, "イベントが存在しません。")
+		}
+		return map[string]any{
+			"dogrunId": event.DogrunID.Int64,
+			"title":    event.Title.String,
+		}, model.ADMIN_TARGET_DOGRUN_EVENT, nil
+
+	case dto.MODERATION_DOGRUN_IMAGE:
+		image, wrErr := ach.ar.FindDogrunImageByID(c, req.ContentID)
+		if wrErr != nil {
+			return nil, "", wrErr
+		}
+		if image.IsEmpty() {
+			return nil, "", notFoundError(c, "ドッグラン画像が存在しません。")
+		}
+		return map[string]any{
+			"dogrunId": image.DogrunID.Int64,
+			"image":    image.Image.String,
+		}, model.ADMIN_TARGET_DOGRUN_IMAGE, nil
+
+	case dto.MODERATION_DOG_PROFILE, dto.MODERATION_DOG_IMAGE:
+		dog, wrErr := ach.ar.FindDogByID(c, req.ContentID)
+		if wrErr != nil {
+			return nil, "", wrErr
+		}
+		if dog.IsEmpty() {
+			return nil, "", notFoundError(c, "dogが存在しません。")
+		}
+		if req.ContentType == dto.MODERATION_DOG_PROFILE {
+			return map[string]any{
+				"bio": dog.DogSocialProfile.Bio.String,
+			}, model.ADMIN_TARGET_DOG_PROFILE, nil
+		}
+		return map[string]any{
+			"image": dog.Image.String,
+		}, model.ADMIN_TARGET_DOG_IMAGE, nil
+
+	case dto.MODERATION_DOGOWNER_IMAGE:
+		dogOwner, wrErr := ach.ar.FindDogOwnerByID(c, req.ContentID)
+		if wrErr != nil {
+			return nil, "", wrErr
+		}
+		if dogOwner.IsEmpty() {
+			return nil, "", notFoundError(c, "dogownerが存在しません。")
+		}
+		return map[string]any{
+			"image": dogOwner.Image.String,
+		}, model.ADMIN_TARGET_DOGOWNER_IMAGE, nil
+	}
+
+	return nil, "", clientError(c, "モデレーションの対象が不正です。")
+}
+
+/*
+リクエスト内容が不正な場合のエラー
+*/
+func clientError(c echo.Context, msg string) error {
+	wrErr := wrErrors.NewWRError(nil, msg, wrErrors.NewAdminClientErrorEType())
+	log.GetLogger(c).Sugar().Error(wrErr)
+	return wrErr
+}
@@ -0,0 +1,565 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	adminRepository "github.com/wanrun-develop/wanrun/internal/admin/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/admin/core/dto"
+	cmsFacade "github.com/wanrun-develop/wanrun/internal/cms/facade"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+)
+
+const (
+	DEFAULT_SEARCH_LIMIT = 20 // 検索の取得件数の指定がない場合の件数
+)
+
+type IAdminHandler interface {
+	SearchDogOwners(c echo.Context, req dto.AdminSearchReq) ([]dto.AdminDogOwnerRes, error)
+	GetDogOwner(c echo.Context, dogOwnerID int64) (dto.AdminDogOwnerRes, error)
+	SearchDogrunmgs(c echo.Context, req dto.AdminSearchReq) ([]dto.AdminDogrunmgRes, error)
+	GetDogrunmg(c echo.Context, dmID int64) (dto.AdminDogrunmgRes, error)
+	SearchDogs(c echo.Context, req dto.AdminSearchReq) ([]dto.AdminDogRes, error)
+	GetDog(c echo.Context, dogID int64) (dto.AdminDogRes, error)
+	SearchDogruns(c echo.Context, req dto.AdminSearchReq) ([]dto.AdminDogrunRes, error)
+	GetDogrun(c echo.Context, dogrunID int64) (dto.AdminDogrunRes, error)
+	SearchOrgs(c echo.Context, req dto.AdminSearchReq) ([]dto.AdminOrgRes, error)
+	GetOrg(c echo.Context, orgID int64) (dto.AdminOrgRes, error)
+	SearchUploads(c echo.Context, req dto.AdminUploadSearchReq) ([]dto.AdminUploadRes, error)
+	GetUpload(c echo.Context, fileID string) (dto.AdminUploadRes, error)
+	GetTagMsts(c echo.Context) ([]dto.AdminTagMstRes, error)
+	GetDogTypeMsts(c echo.Context) ([]dto.AdminDogTypeMstRes, error)
+}
+
+type adminHandler struct {
+	ar adminRepository.IAdminRepository
+	cf cmsFacade.ICmsFacade
+}
+
+func NewAdminHandler(
+	ar adminRepository.IAdminRepository,
+	cf cmsFacade.ICmsFacade,
+) IAdminHandler {
+	return &adminHandler{
+		ar: ar,
+		cf: cf,
+	}
+}
+
+// SearchDogOwners: dogownerの検索
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.AdminSearchReq: 検索条件
+//
+// return:
+//   - []dto.AdminDogOwnerRes: 登録の新しい順のdogowner
+//   - error: error情報
+func (ah *adminHandler) SearchDogOwners(c echo.Context, req dto.AdminSearchReq) ([]dto.AdminDogOwnerRes, error) {
+	dogOwners, wrErr := ah.ar.SearchDogOwners(c, req.Keyword, searchLimit(req.Limit), req.Offset)
+
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	res := []dto.AdminDogOwnerRes{}
+	for _, dogOwner := range dogOwners {
+		res = append(res, toAdminDogOwnerRes(dogOwner))
+	}
+	return res, nil
+}
+
+// GetDogOwner: dogownerの詳細の取得
+// ログイン方法ごとのクレデンシャルと、飼い主のdogを含む
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogOwnerID
+//
+// return:
+//   - dto.AdminDogOwnerRes: dogowner
+//   - error: error情報
+func (ah *adminHandler) GetDogOwner(c echo.Context, dogOwnerID int64) (dto.AdminDogOwnerRes, error) {
+	dogOwner, wrErr := ah.ar.FindDogOwnerByID(c, dogOwnerID)
+
+	if wrErr != nil {
+		return dto.AdminDogOwnerRes{}, wrErr
+	}
+
+	if dogOwner.IsEmpty() {
+		return dto.AdminDogOwnerRes{}, notFoundError(c, "dogownerが存在しません。")
+	}
+
+	credentials, wrErr := ah.ar.FindDogOwnerCredentials(c, dogOwnerID)
+
+	if wrErr != nil {
+		return dto.AdminDogOwnerRes{}, wrErr
+	}
+
+	dogs, wrErr := ah.ar.FindDogsByDogOwnerID(c, dogOwnerID)
+
+	if wrErr != nil {
+		return dto.AdminDogOwnerRes{}, wrErr
+	}
+
+	res := toAdminDogOwnerRes(dogOwner)
+	for _, credential := range credentials {
+		res.Credentials = append(res.Credentials, dto.AdminCredentialRes{
+			GrantType:   credential.GrantType.String,
+			Email:       credential.Email.String,
+			PhoneNumber: credential.PhoneNumber.String,
+			LoginAt:     nullTimePtr(credential.LoginAt.Valid, credential.LoginAt.Time),
+		})
+	}
+	for _, dog := range dogs {
+		res.Dogs = append(res.Dogs, toAdminDogRes(dog))
+	}
+	return res, nil
+}
+
+// SearchDogrunmgs: dogrunmgの検索
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.AdminSearchReq: 検索条件
+//
+// return:
+//   - []dto.AdminDogrunmgRes: 登録の新しい順のdogrunmg
+//   - error: error情報
+func (ah *adminHandler) SearchDogrunmgs(c echo.Context, req dto.AdminSearchReq) ([]dto.AdminDogrunmgRes, error) {
+	credentials, wrErr := ah.ar.SearchDogrunmgs(c, req.Keyword, searchLimit(req.Limit), req.Offset)
+
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	res := []dto.AdminDogrunmgRes{}
+	for _, credential := range credentials {
+		res = append(res, toAdminDogrunmgRes(credential))
+	}
+	return res, nil
+}
+
+// GetDogrunmg: dogrunmgの詳細の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogrunmgID
+//
+// return:
+//   - dto.AdminDogrunmgRes: dogrunmg
+//   - error: error情報
+func (ah *adminHandler) GetDogrunmg(c echo.Context, dmID int64) (dto.AdminDogrunmgRes, error) {
+	credential, wrErr := ah.ar.FindDogrunmgCredential(c, dmID)
+
+	if wrErr != nil {
+		return dto.AdminDogrunmgRes{}, wrErr
+	}
+
+	if !credential.CredentialID.Valid {
+		return dto.AdminDogrunmgRes{}, notFoundError(c, "dogrunmgが存在しません。")
+	}
+
+	return toAdminDogrunmgRes(credential), nil
+}
+
+// SearchDogs: dogの検索
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.AdminSearchReq: 検索条件
+//
+// return:
+//   - []dto.AdminDogRes: 登録の新しい順のdog
+//   - error: error情報
+func (ah *adminHandler) SearchDogs(c echo.Context, req dto.AdminSearchReq) ([]dto.AdminDogRes, error) {
+	dogs, wrErr := ah.ar.SearchDogs(c, req.Keyword, searchLimit(req.Limit), req.Offset)
+
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	res := []dto.AdminDogRes{}
+	for _, dog := range dogs {
+		res = append(res, toAdminDogRes(dog))
+	}
+	return res, nil
+}
+
+// GetDog: dogの詳細の取得
+// 犬種、共同飼い主、プロフィールを含む
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogID
+//
+// return:
+//   - dto.AdminDogRes: dog
+//   - error: error情報
+func (ah *adminHandler) GetDog(c echo.Context, dogID int64) (dto.AdminDogRes, error) {
+	dog, wrErr := ah.ar.FindDogByID(c, dogID)
+
+	if wrErr != nil {
+		return dto.AdminDogRes{}, wrErr
+	}
+
+	if dog.IsEmpty() {
+		return dto.AdminDogRes{}, notFoundError(c, "dogが存在しません。")
+	}
+
+	res := toAdminDogRes(dog)
+	for _, dogType := range dog.DogDogTypes {
+		res.DogTypeIDs = append(res.DogTypeIDs, dogType.DogTypeID.Int64)
+	}
+	for _, coOwner := range dog.DogCoOwners {
+		res.CoOwnerIDs = append(res.CoOwnerIDs, coOwner.DogOwnerID.Int64)
+	}
+	res.Bio = dog.DogSocialProfile.Bio.String
+	res.ProfileVisibility = dog.DogSocialProfile.ProfileVisibilityOrDefault()
+	return res, nil
+}
+
+// SearchDogruns: ドッグランの検索
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.AdminSearchReq: 検索条件
+//
+// return:
+//   - []dto.AdminDogrunRes: 登録の新しい順のドッグラン(統合済みを含む)
+//   - error: error情報
+func (ah *adminHandler) SearchDogruns(c echo.Context, req dto.AdminSearchReq) ([]dto.AdminDogrunRes, error) {
+	dogruns, wrErr := ah.ar.SearchDogruns(c, req.Keyword, searchLimit(req.Limit), req.Offset)
+
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	res := []dto.AdminDogrunRes{}
+	for _, dogrun := range dogruns {
+		res = append(res, toAdminDogrunRes(dogrun))
+	}
+	return res, nil
+}
+
+// GetDogrun: ドッグランの詳細の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogrunID
+//
+// return:
+//   - dto.AdminDogrunRes: ドッグラン
+//   - error: error情報
+func (ah *adminHandler) GetDogrun(c echo.Context, dogrunID int64) (dto.AdminDogrunRes, error) {
+	dogrun, wrErr := ah.ar.FindDogrunByID(c, dogrunID)
+
+	if wrErr != nil {
+		return dto.AdminDogrunRes{}, wrErr
+	}
+
+	if dogrun.IsEmpty() {
+		return dto.AdminDogrunRes{}, notFoundError(c, "ドッグランが存在しません。")
+	}
+
+	return toAdminDogrunRes(dogrun), nil
+}
+
+// SearchOrgs: organizationの検索
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.AdminSearchReq: 検索条件
+//
+// return:
+//   - []dto.AdminOrgRes: 登録の新しい順のorganization(退会済みを含む)
+//   - error: error情報
+func (ah *adminHandler) SearchOrgs(c echo.Context, req dto.AdminSearchReq) ([]dto.AdminOrgRes, error) {
+	orgs, wrErr := ah.ar.SearchOrgs(c, req.Keyword, searchLimit(req.Limit), req.Offset)
+
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	res := []dto.AdminOrgRes{}
+	for _, org := range orgs {
+		res = append(res, toAdminOrgRes(org))
+	}
+	return res, nil
+}
+
+// GetOrg: organizationの詳細の取得
+// 所属するdogrunmgと管理しているドッグランを含む
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: organizationID
+//
+// return:
+//   - dto.AdminOrgRes: organization
+//   - error: error情報
+func (ah *adminHandler) GetOrg(c echo.Context, orgID int64) (dto.AdminOrgRes, error) {
+	org, wrErr := ah.ar.FindOrgByID(c, orgID)
+
+	if wrErr != nil {
+		return dto.AdminOrgRes{}, wrErr
+	}
+
+	if org.IsEmpty() {
+		return dto.AdminOrgRes{}, notFoundError(c, "organizationが存在しません。")
+	}
+
+	credentials, wrErr := ah.ar.FindDogrunmgCredentialsByOrgID(c, orgID)
+
+	if wrErr != nil {
+		return dto.AdminOrgRes{}, wrErr
+	}
+
+	dogruns, wrErr := ah.ar.FindDogrunsByOrgID(c, orgID)
+
+	if wrErr != nil {
+		return dto.AdminOrgRes{}, wrErr
+	}
+
+	res := toAdminOrgRes(org)
+	for _, credential := range credentials {
+		res.Managers = append(res.Managers, toAdminDogrunmgRes(credential))
+	}
+	for _, dogrun := range dogruns {
+		res.Dogruns = append(res.Dogruns, toAdminDogrunRes(dogrun))
+	}
+	return res, nil
+}
+
+// SearchUploads: アップロードされたファイルの検索
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.AdminUploadSearchReq: 検索条件
+//
+// return:
+//   - []dto.AdminUploadRes: アップロードの新しい順のファイル
+//   - error: error情報
+func (ah *adminHandler) SearchUploads(c echo.Context, req dto.AdminUploadSearchReq) ([]dto.AdminUploadRes, error) {
+	files, wrErr := ah.ar.SearchUploads(c, req.OwnerType, req.OwnerID, searchLimit(req.Limit), req.Offset)
+
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	res := []dto.AdminUploadRes{}
+	for _, file := range files {
+		res = append(res, toAdminUploadRes(file))
+	}
+	return res, nil
+}
+
+// GetUpload: アップロードされたファイルの詳細の取得
+// 内容の確認用に署名付きURLを発行する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: fileID
+//
+// return:
+//   - dto.AdminUploadRes: ファイル
+//   - error: error情報
+func (ah *adminHandler) GetUpload(c echo.Context, fileID string) (dto.AdminUploadRes, error) {
+	file, wrErr := ah.ar.FindUploadByFileID(c, fileID)
+
+	if wrErr != nil {
+		return dto.AdminUploadRes{}, wrErr
+	}
+
+	if !file.S3FileInfoID.Valid {
+		return dto.AdminUploadRes{}, notFoundError(c, "ファイルが存在しません。")
+	}
+
+	fileURL, wrErr := ah.cf.PresignFileURL(c, fileID)
+
+	if wrErr != nil {
+		return dto.AdminUploadRes{}, wrErr
+	}
+
+	res := toAdminUploadRes(file)
+	res.URL = fileURL.URL
+	res.ExpiresAt = &fileURL.ExpiresAt
+	return res, nil
+}
+
+// GetTagMsts: tag_mstの一覧の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//
+// return:
+//   - []dto.AdminTagMstRes: ID順のマスター
+//   - error: error情報
+func (ah *adminHandler) GetTagMsts(c echo.Context) ([]dto.AdminTagMstRes, error) {
+	tagMsts, wrErr := ah.ar.FindTagMsts(c)
+
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	res := []dto.AdminTagMstRes{}
+	for _, tagMst := range tagMsts {
+		res = append(res, toAdminTagMstRes(tagMst))
+	}
+	return res, nil
+}
+
+// GetDogTypeMsts: dog_type_mstの一覧の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//
+// return:
+//   - []dto.AdminDogTypeMstRes: ID順のマスター
+//   - error: error情報
+func (ah *adminHandler) GetDogTypeMsts(c echo.Context) ([]dto.AdminDogTypeMstRes, error) {
+	dogTypeMsts, wrErr := ah.ar.FindDogTypeMsts(c)
+
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	res := []dto.AdminDogTypeMstRes{}
+	for _, dogTypeMst := range dogTypeMsts {
+		res = append(res, toAdminDogTypeMstRes(dogTypeMst))
+	}
+	return res, nil
+}
+
+/*
+検索の取得件数。指定がない場合はデフォルトの件数
+*/
+func searchLimit(limit int) int {
+	if limit <= 0 {
+		return DEFAULT_SEARCH_LIMIT
+	}
+	return limit
+}
+
+/*
+対象が存在しない場合のエラー
+*/
+func notFoundError(c echo.Context, msg string) error {
+	wrErr := wrErrors.NewWRError(nil, msg, wrErrors.NewAdminClientErrorEType())
+	log.GetLogger(c).Sugar().Error(wrErr)
+	return wrErr
+}
+
+/*
+NULL許容の日時をポインタに変換
+*/
+func nullTimePtr(valid bool, t time.Time) *time.Time {
+	if !valid {
+		return nil
+	}
+	return &t
+}
+
+func toAdminDogOwnerRes(dogOwner model.DogOwner) dto.AdminDogOwnerRes {
+	return dto.AdminDogOwnerRes{
+		DogOwnerID:       dogOwner.DogOwnerID.Int64,
+		Name:             dogOwner.Name.String,
+		Image:            dogOwner.Image.String,
+		Sex:              dogOwner.Sex.String,
+		SuspendedAt:      nullTimePtr(dogOwner.SuspendedAt.Valid, dogOwner.SuspendedAt.Time),
+		SuspensionReason: dogOwner.SuspensionReason.String,
+		CreateAt:         dogOwner.CreateAt.Time,
+	}
+}
+
+func toAdminDogrunmgRes(credential model.DogrunmgCredential) dto.AdminDogrunmgRes {
+	dogrunmg := credential.AuthDogrunmg.Dogrunmg
+	return dto.AdminDogrunmgRes{
+		DogrunmgID:       dogrunmg.DogrunmgID.Int64,
+		Name:             dogrunmg.Name.String,
+		Email:            credential.Email.String,
+		OrganizationID:   dogrunmg.OrganizationID.Int64,
+		OrganizationName: dogrunmg.Organization.Name.String,
+		IsAdmin:          credential.AuthDogrunmg.IsAdmin.Bool,
+		IsActive:         dogrunmg.IsActiveManager(),
+		SuspendedAt:      nullTimePtr(dogrunmg.SuspendedAt.Valid, dogrunmg.SuspendedAt.Time),
+		SuspensionReason: dogrunmg.SuspensionReason.String,
+		LoginAt:          nullTimePtr(credential.LoginAt.Valid, credential.LoginAt.Time),
+		CreateAt:         dogrunmg.CreateAt.Time,
+	}
+}
+
+func toAdminDogRes(dog model.Dog) dto.AdminDogRes {
+	return dto.AdminDogRes{
+		DogID:        dog.DogID.Int64,
+		DogOwnerID:   dog.DogOwnerID.Int64,
+		DogOwnerName: dog.DogOwner.Name.String,
+		Name:         dog.Name.String,
+		Sex:          dog.Sex.String,
+		Image:        dog.Image.String,
+		MicrochipID:  dog.MicrochipID.String,
+		CreateAt:     dog.CreateAt.Time,
+	}
+}
+
+func toAdminDogrunRes(dogrun model.Dogrun) dto.AdminDogrunRes {
+	return dto.AdminDogrunRes{
+		DogrunID:           dogrun.DogrunID.Int64,
+		PlaceID:            dogrun.PlaceId.String,
+		Name:               dogrun.Name.String,
+		Address:            dogrun.Address.String,
+		DogrunManagerID:    dogrun.DogrunManagerID.Int64,
+		IsManaged:          dogrun.IsManaged.Bool,
+		MergedIntoDogrunID: dogrun.MergedIntoID.Int64,
+		CreateAt:           dogrun.CreateAt.Time,
+	}
+}
+
+func toAdminOrgRes(org model.Organization) dto.AdminOrgRes {
+	return dto.AdminOrgRes{
+		OrganizationID: org.OrganizationID.Int64,
+		OrgName:        org.Name.String,
+		ContactEmail:   org.ContactEmail.String,
+		PhoneNumber:    org.PhoneNumber.String,
+		Address:        org.Address.String,
+		ClosedAt:       nullTimePtr(org.ClosedAt.Valid, org.ClosedAt.Time),
+		CreateAt:       org.CreateAt.Time,
+	}
+}
+
+func toAdminUploadRes(file model.S3FileInfo) dto.AdminUploadRes {
+	return dto.AdminUploadRes{
+		FileID:      file.FileID.String,
+		OwnerType:   file.OwnerType.String,
+		OwnerID:     file.OwnerID.Int64,
+		ContentType: file.ContentType.String,
+		FileSize:    file.FileSize.Int64,
+		CreateAt:    file.CreateAt.Time,
+	}
+}
+
+func toAdminTagMstRes(tagMst model.TagMst) dto.AdminTagMstRes {
+	return dto.AdminTagMstRes{
+		TagID:       tagMst.TagID.Int64,
+		TagName:     tagMst.TagName.String,
+		Description: tagMst.Description.String,
+		Category:    tagMst.Category.String,
+	}
+}
+
+func toAdminDogTypeMstRes(dogTypeMst model.DogTypeMst) dto.AdminDogTypeMstRes {
+	return dto.AdminDogTypeMstRes{
+		DogTypeID: int64(dogTypeMst.DogTypeID),
+		Name:      dogTypeMst.Name,
+	}
+}
+
+/*
+操作対象のIDを記録用の文字列に変換
+*/
+func targetID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/audit/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+)
+
+type IAuditRepository interface {
+	FindAuditLogs(echo.Context, dto.AuditLogSearchReq, int, int) ([]model.AuditLog, error)
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) IAuditRepository {
+	return &auditRepository{db}
+}
+
+// FindAuditLogs: 監査ログの検索
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - dto.AuditLogSearchReq:	検索条件。未指定の項目は条件にしない
+//   - int:	取得件数
+//   - int:	取得開始位置
+//
+// return:
+//   - []model.AuditLog:	記録の新しい順の監査ログ
+//   - error:	エラー
+func (r *auditRepository) FindAuditLogs(c echo.Context, req dto.AuditLogSearchReq, limit int, offset int) ([]model.AuditLog, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	query := r.db.Model(&model.AuditLog{})
+	if req.ActorID != 0 {
+		query = query.Where("actor_id = ?", req.ActorID)
+	}
+	if req.ActorRole != nil {
+		query = query.Where("actor_role = ?", *req.ActorRole)
+	}
+	if req.Action != "" {
+		query = query.Where("action = ?", req.Action)
+	}
+	if req.Result != "" {
+		query = query.Where("result = ?", req.Result)
+	}
+	if req.TargetType != "" {
+		query = query.Where("target_type = ?", req.TargetType)
+	}
+	if req.TargetID != "" {
+		query = query.Where("target_id = ?", req.TargetID)
+	}
+	if req.From != nil {
+		query = query.Where("reg_at >= ?", *req.From)
+	}
+	if req.To != nil {
+		query = query.Where("reg_at < ?", *req.To)
+	}
+
+	auditLogs := []model.AuditLog{}
+	if err := query.Order("audit_log_id DESC").Limit(limit).Offset(offset).Find(&auditLogs).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "監査ログの取得に失敗しました。", errors.NewAuditServerErrorEType())
+	}
+	return auditLogs, nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+)
+
+type IAuditScopeRepository interface {
+	CreateAuditLog(tx *gorm.DB, c echo.Context, auditLog *model.AuditLog) error
+}
+
+type auditScopeRepository struct {
+}
+
+func NewAuditScopeRepository() IAuditScopeRepository {
+	return &auditScopeRepository{}
+}
+
+// CreateAuditLog: 監査ログの登録
+// 監査ログは追記のみで、更新・削除はDBのトリガーで拒否される
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - *model.AuditLog: 監査ログ
+//
+// return:
+//   - error: error情報
+func (asr *auditScopeRepository) CreateAuditLog(
+	tx *gorm.DB,
+	c echo.Context,
+	auditLog *model.AuditLog,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := tx.Create(auditLog).Error; err != nil {
+		logger.Error("Failed to create AuditLog: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"監査ログの登録に失敗しました。",
+			wrErrors.NewAuditServerErrorEType(),
+		)
+	}
+	return nil
+}
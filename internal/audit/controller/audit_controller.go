@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/audit/core/dto"
+	auditHandler "github.com/wanrun-develop/wanrun/internal/audit/core/handler"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+)
+
+type IAuditController interface {
+	GetAuditLogs(c echo.Context) error
+}
+
+type auditController struct {
+	ah auditHandler.IAuditHandler
+}
+
+func NewAuditController(ah auditHandler.IAuditHandler) IAuditController {
+	return &auditController{ah}
+}
+
+// GetAuditLogs: 監査ログの検索
+func (a *auditController) GetAuditLogs(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	searchReq := dto.AuditLogSearchReq{}
+
+	if err := c.Bind(&searchReq); err != nil {
+		wrErr := errors.NewWRError(
+			err,
+			"入力項目に不正があります。",
+			errors.NewAuditClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	if err := validator.New().Struct(searchReq); err != nil {
+		wrErr := errors.NewWRError(
+			err,
+			"必須の項目に不正があります。",
+			errors.NewAuditClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	auditLogs, wrErr := a.ah.GetAuditLogs(c, searchReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, auditLogs)
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry: 監査ログとして記録する内容
+type AuditEntry struct {
+	ActorID    int64  // 操作者のID。0の場合は操作者なし(未ログイン)
+	ActorRole  int    // 操作者のロール。ActorIDが0の場合は記録しない
+	Action     string // model.AUDIT_ACTION_*
+	Result     string // model.AUDIT_RESULT_*
+	TargetType string // model.AUDIT_TARGET_*
+	TargetID   string
+	Before     any // 変更前の値。nilの場合は記録しない
+	After      any // 変更後の値。nilの場合は記録しない
+}
+
+type AuditLogSearchReq struct {
+	ActorID    int64      `query:"actorId" validate:"min=0"`
+	ActorRole  *int       `query:"actorRole" validate:"omitempty,min=0"`
+	Action     string     `query:"action" validate:"max=32"`
+	Result     string     `query:"result" validate:"omitempty,oneof=success failure"`
+	TargetType string     `query:"targetType" validate:"max=32"`
+	TargetID   string     `query:"targetId" validate:"max=256"`
+	From       *time.Time `query:"from"`
+	To         *time.Time `query:"to"`
+	Limit      int        `query:"limit" validate:"min=0,max=100"`
+	Offset     int        `query:"offset" validate:"min=0"`
+}
+
+type AuditLogRes struct {
+	AuditLogID int64           `json:"auditLogId"`
+	ActorID    *int64          `json:"actorId"`
+	ActorRole  *int64          `json:"actorRole"`
+	Action     string          `json:"action"`
+	Result     string          `json:"result"`
+	TargetType string          `json:"targetType"`
+	TargetID   string          `json:"targetId,omitempty"`
+	RequestID  string          `json:"requestId,omitempty"`
+	IPAddress  string          `json:"ipAddress,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	CreateAt   time.Time       `json:"createAt"`
+}
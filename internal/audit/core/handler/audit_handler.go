@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"encoding/json"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/audit/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/audit/core/dto"
+)
+
+const DEFAULT_SEARCH_LIMIT = 20
+
+type IAuditHandler interface {
+	GetAuditLogs(c echo.Context, req dto.AuditLogSearchReq) ([]dto.AuditLogRes, error)
+}
+
+type auditHandler struct {
+	ar repository.IAuditRepository
+}
+
+func NewAuditHandler(ar repository.IAuditRepository) IAuditHandler {
+	return &auditHandler{ar}
+}
+
+// GetAuditLogs: 監査ログの検索
+//
+// args:
+//   - echo.Context: Echoのコンテキスト
+//   - dto.AuditLogSearchReq: 検索条件
+//
+// return:
+//   - []dto.AuditLogRes: 記録の新しい順の監査ログ
+//   - error: error情報
+func (ah *auditHandler) GetAuditLogs(c echo.Context, req dto.AuditLogSearchReq) ([]dto.AuditLogRes, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DEFAULT_SEARCH_LIMIT
+	}
+
+	auditLogs, wrErr := ah.ar.FindAuditLogs(c, req, limit, req.Offset)
+
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	res := []dto.AuditLogRes{}
+	for _, auditLog := range auditLogs {
+		logRes := dto.AuditLogRes{
+			AuditLogID: auditLog.AuditLogID.Int64,
+			Action:     auditLog.Action.String,
+			Result:     auditLog.Result.String,
+			TargetType: auditLog.TargetType.String,
+			TargetID:   auditLog.TargetID.String,
+			RequestID:  auditLog.RequestID.String,
+			IPAddress:  auditLog.IPAddress.String,
+			CreateAt:   auditLog.CreateAt.Time,
+		}
+		if auditLog.ActorID.Valid {
+			logRes.ActorID = &auditLog.ActorID.Int64
+		}
+		if auditLog.ActorRole.Valid {
+			logRes.ActorRole = &auditLog.ActorRole.Int64
+		}
+		if auditLog.BeforeData.Valid {
+			logRes.Before = json.RawMessage(auditLog.BeforeData.String)
+		}
+		if auditLog.AfterData.Valid {
+			logRes.After = json.RawMessage(auditLog.AfterData.String)
+		}
+		res = append(res, logRes)
+	}
+	return res, nil
+}
@@ -0,0 +1,203 @@
+package facade
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/audit/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/audit/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/transaction"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+	"gorm.io/gorm"
+)
+
+// 監査ログに値を残さない項目名(小文字で部分一致)
+var redactedKeys = []string{"password", "token", "jwt", "secret"}
+
+const REDACTED_VALUE = "***"
+
+type IAuditFacade interface {
+	Record(tx *gorm.DB, c echo.Context, entry dto.AuditEntry) error
+	RecordInTransaction(c echo.Context, entry dto.AuditEntry) error
+}
+
+type auditFacade struct {
+	asr repository.IAuditScopeRepository
+	tm  transaction.ITransactionManager
+}
+
+func NewAuditFacade(asr repository.IAuditScopeRepository, tm transaction.ITransactionManager) IAuditFacade {
+	return &auditFacade{asr, tm}
+}
+
+// Record: 呼び出し元のトランザクション内での監査ログの記録
+// 呼び出し元がロールバックした場合は監査ログも残らない
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: Echoのコンテキスト。リクエストIDと接続元IPの取得に使用
+//   - dto.AuditEntry: 記録する内容
+//
+// return:
+//   - error: error情報
+func (af *auditFacade) Record(tx *gorm.DB, c echo.Context, entry dto.AuditEntry) error {
+	auditLog, wrErr := newAuditLog(c, entry)
+	if wrErr != nil {
+		return wrErr
+	}
+	return af.asr.CreateAuditLog(tx, c, auditLog)
+}
+
+// RecordInTransaction: 監査ログの記録
+// contextにトランザクションがある場合はそれに参加し、ない場合は単独のトランザクションで記録する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストIDと接続元IPの取得に使用
+//   - dto.AuditEntry: 記録する内容
+//
+// return:
+//   - error: error情報
+func (af *auditFacade) RecordInTransaction(c echo.Context, entry dto.AuditEntry) error {
+	ctx := c.Request().Context()
+
+	if tx := af.tm.GetTx(ctx); tx != nil {
+		return af.Record(tx, c, entry)
+	}
+	return af.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		return af.Record(tx, c, entry)
+	})
+}
+
+/*
+記録内容から監査ログのモデルを作成
+*/
+func newAuditLog(c echo.Context, entry dto.AuditEntry) (*model.AuditLog, error) {
+	before, after, wrErr := diffValues(c, entry.Before, entry.After)
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	auditLog := &model.AuditLog{
+		ActorID:    util.NewSqlNullInt64(entry.ActorID),
+		Action:     util.NewSqlNullString(entry.Action),
+		Result:     util.NewSqlNullString(entry.Result),
+		TargetType: util.NewSqlNullString(entry.TargetType),
+		TargetID:   util.NewSqlNullString(entry.TargetID),
+		RequestID:  util.NewSqlNullString(c.Response().Header().Get(echo.HeaderXRequestID)),
+		IPAddress:  util.NewSqlNullString(c.RealIP()),
+		BeforeData: util.NewSqlNullString(before),
+		AfterData:  util.NewSqlNullString(after),
+	}
+	// 操作者なし(未ログイン)の場合はロールも記録しない
+	// 操作者がいる場合はSYSTEM(0)のロールも記録するため、ゼロ値でも有効とする
+	if entry.ActorID != 0 {
+		auditLog.ActorRole = sql.NullInt64{Int64: int64(entry.ActorRole), Valid: true}
+	}
+	return auditLog, nil
+}
+
+/*
+変更前後の値を比較し、変更のあった項目のみをJSONにする。秘匿情報はマスクする
+片方のみの場合はその値の全項目を対象とする
+*/
+func diffValues(c echo.Context, before any, after any) (string, string, error) {
+	beforeMap, wrErr := toMap(c, before)
+	if wrErr != nil {
+		return "", "", wrErr
+	}
+	afterMap, wrErr := toMap(c, after)
+	if wrErr != nil {
+		return "", "", wrErr
+	}
+
+	if beforeMap != nil && afterMap != nil {
+		for key, value := range beforeMap {
+			if afterValue, ok := afterMap[key]; ok && reflect.DeepEqual(value, afterValue) {
+				delete(beforeMap, key)
+				delete(afterMap, key)
+			}
+		}
+	}
+
+	beforeJSON, wrErr := toJSON(c, beforeMap)
+	if wrErr != nil {
+		return "", "", wrErr
+	}
+	afterJSON, wrErr := toJSON(c, afterMap)
+	if wrErr != nil {
+		return "", "", wrErr
+	}
+	return beforeJSON, afterJSON, nil
+}
+
+/*
+値をJSONのオブジェクトとしてmapに変換。nilの場合はnil
+*/
+func toMap(c echo.Context, value any) (map[string]any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, marshalError(c, err)
+	}
+	m := map[string]any{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, marshalError(c, err)
+	}
+	redact(m)
+	return m, nil
+}
+
+/*
+mapをJSONの文字列に変換。nilの場合は空文字
+*/
+func toJSON(c echo.Context, m map[string]any) (string, error) {
+	if m == nil {
+		return "", nil
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return "", marshalError(c, err)
+	}
+	return string(b), nil
+}
+
+/*
+秘匿情報の項目をマスク。入れ子のオブジェクトも対象とする
+*/
+func redact(m map[string]any) {
+	for key, value := range m {
+		if isRedactedKey(key) {
+			m[key] = REDACTED_VALUE
+			continue
+		}
+		if nested, ok := value.(map[string]any); ok {
+			redact(nested)
+		}
+	}
+}
+
+func isRedactedKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, redactedKey := range redactedKeys {
+		if strings.Contains(lower, redactedKey) {
+			return true
+		}
+	}
+	return false
+}
+
+func marshalError(c echo.Context, err error) error {
+	wrErr := wrErrors.NewWRError(err, "監査ログの値の変換に失敗しました。", wrErrors.NewAuditServerErrorEType())
+	log.GetLogger(c).Sugar().Error(wrErr)
+	return wrErr
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/wanrun-develop/wanrun/internal/auth/core/dto"
@@ -27,6 +28,10 @@ type IAuthRepository interface {
 	GetDogrunmgByCredentials(c echo.Context, email string) ([]model.DogrunmgCredential, error)
 	UpdateDogrunmgJwtID(c echo.Context, dmID int64, ji string) error
 	DeleteDogrunmgJwtID(c echo.Context, dmID int64) error
+	GetSystemOperatorByEmail(c echo.Context, email string) (model.SystemOperator, error)
+	GetSystemOperatorJwtID(c echo.Context, soID int64) (string, error)
+	UpdateSystemOperatorJwtID(c echo.Context, soID int64, ji string) error
+	DeleteSystemOperatorJwtID(c echo.Context, soID int64) error
 }
 
 type authRepository struct {
@@ -647,3 +652,148 @@ func (ar *authRepository) UpdateDogrunmgJwtID(
 
 	return nil
 }
+
+// GetSystemOperatorByEmail: Emailを元にシステムユーザー(運営者)の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: 運営者のemail
+//
+// return:
+//   - model.SystemOperator: 取得した運営者の情報。存在しない場合は空
+//   - error: error情報
+func (ar *authRepository) GetSystemOperatorByEmail(c echo.Context, email string) (model.SystemOperator, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	var result model.SystemOperator
+
+	// Emailに基づくレコードを検索
+	if err := ar.db.Model(&model.SystemOperator{}).
+		Where("email = ?", email).
+		Find(&result).Error; err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"DBからのデータ取得に失敗しました。",
+			wrErrors.NewAuthServerErrorEType(),
+		)
+
+		logger.Errorf("DB search failure: %v", wrErr)
+
+		return model.SystemOperator{}, wrErr
+	}
+
+	return result, nil
+}
+
+// GetSystemOperatorJwtID: システムユーザー(運営者)のjwtIDの取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: 取得したいsystemOperatorID
+//
+// return:
+//   - string: 対象のjwt_id。無効化された運営者は空
+//   - error: error情報
+func (ar *authRepository) GetSystemOperatorJwtID(c echo.Context, soID int64) (string, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	var result model.SystemOperator
+
+	// 対象の運営者のjwt_idの取得
+	err := ar.db.Model(&model.SystemOperator{}).
+		Where("system_operator_id = ?", soID).
+		First(&result).
+		Error
+
+	if err != nil {
+		// 空だった時
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			wrErr := wrErrors.NewWRError(
+				err,
+				"認証情報がありません",
+				wrErrors.NewAuthClientErrorEType())
+
+			logger.Errorf("Not found jwt id error: %v", wrErr)
+
+			return "", wrErr
+		}
+
+		// その他のエラー処理
+		wrErr := wrErrors.NewWRError(
+			err,
+			"DBからのデータ取得に失敗しました。",
+			wrErrors.NewAuthServerErrorEType())
+
+		logger.Errorf("Failed to get JWT ID: %v", wrErr)
+
+		return "", wrErr
+	}
+
+	// 無効化された運営者のトークンは全て無効
+	if !result.IsActiveOperator() {
+		return "", nil
+	}
+
+	return result.JwtID.String, nil
+}
+
+// UpdateSystemOperatorJwtID: 対象のシステムユーザー(運営者)のjwt_idとログイン時間の更新
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: systemOperatorのPK
+//   - string: 更新用のjwt_id
+//
+// return:
+//   - error: error情報
+func (ar *authRepository) UpdateSystemOperatorJwtID(c echo.Context, soID int64, ji string) error {
+	logger := log.GetLogger(c).Sugar()
+
+	// 対象の運営者のjwt_idの更新
+	if err := ar.db.Model(&model.SystemOperator{}).
+		Where("system_operator_id = ?", soID).
+		Updates(map[string]any{
+			"jwt_id":   ji,
+			"login_at": time.Now(),
+		}).Error; err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"DBへの更新が失敗しました。",
+			wrErrors.NewAuthServerErrorEType())
+
+		logger.Errorf("Failed to update JWT ID: %v", wrErr)
+
+		return wrErr
+	}
+
+	return nil
+}
+
+// DeleteSystemOperatorJwtID: 対象のシステムユーザー(運営者)のjwt_idの削除
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: systemOperatorのID
+//
+// return:
+//   - error: error情報
+func (ar *authRepository) DeleteSystemOperatorJwtID(c echo.Context, soID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	// 対象の運営者のjwt_idの更新
+	if err := ar.db.Model(&model.SystemOperator{}).
+		Where("system_operator_id = ?", soID).
+		Update("jwt_id", nil).Error; err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"DBへの同期が失敗しました。",
+			wrErrors.NewAuthServerErrorEType(),
+		)
+
+		logger.Errorf("Failed to delete system operator JWT ID: %v", wrErr)
+
+		return wrErr
+	}
+
+	return nil
+}
@@ -20,6 +20,8 @@ type IAuthController interface {
 	LogInDogrunmg(echo.Context) error
 	RevokeDogowner(echo.Context) error
 	RevokeDogrunmg(echo.Context) error
+	LogInSystemOperator(echo.Context) error
+	RevokeSystemOperator(echo.Context) error
 	// GoogleOAuth(echo.Context) error
 	IssueGeneralUserToken(echo.Context) error
 }
@@ -237,6 +239,69 @@ func (ac *authController) RevokeDogrunmg(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]any{})
 }
 
+// LogInSystemOperator: システムユーザー(運営者)のログイン
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用されます。
+//
+// return:
+//   - error: error情報
+func (ac *authController) LogInSystemOperator(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	asoReq := dto.AuthSystemOperatorReq{}
+
+	if err := c.Bind(&asoReq); err != nil {
+		wrErr := errors.NewWRError(err, "入力項目に不正があります。", errors.NewAuthClientErrorEType())
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	//リクエストボディのバリデーション
+	if err := validator.New().Struct(&asoReq); err != nil {
+		err = errors.NewWRError(
+			err,
+			"必須の項目に不正があります。",
+			errors.NewAuthClientErrorEType(),
+		)
+		logger.Error(err)
+		return err
+	}
+
+	// 運営者のLogIn
+	token, wrErr := ac.ah.LogInSystemOperator(c, asoReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"accessToken": token,
+	})
+}
+
+// RevokeSystemOperator: システムユーザー(運営者)のrevoke機能
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用されます。
+//
+// return:
+//   - error: error情報
+func (ac *authController) RevokeSystemOperator(c echo.Context) error {
+	// claimsから運営者のID取得
+	operatorID, wrErr := wrcontext.GetLoginUserID(c)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	if wrErr := ac.ah.RevokeSystemOperator(c, operatorID); wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{})
+}
+
 // /*
 // OAuthのクエリパラメータのバリデーション
 // */
@@ -0,0 +1,6 @@
+package dto
+
+type AuthSystemOperatorReq struct {
+	Password string `json:"password" validate:"required"`
+	Email    string `json:"email" validate:"required"`
+}
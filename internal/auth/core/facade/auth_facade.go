@@ -2,24 +2,33 @@ package facade
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
+	auditDTO "github.com/wanrun-develop/wanrun/internal/audit/core/dto"
+	auditFacade "github.com/wanrun-develop/wanrun/internal/audit/facade"
 	"github.com/wanrun-develop/wanrun/internal/auth/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/auth/core/handler"
+	model "github.com/wanrun-develop/wanrun/internal/models"
 	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
 	"github.com/wanrun-develop/wanrun/pkg/log"
 )
 
 type IAuthFacade interface {
 	OrgEmailValidate(c echo.Context, email string) error
+	RevokeDogowner(c echo.Context, doID int64) error
+	RevokeDogrunmg(c echo.Context, dmID int64) error
 }
 
 type authFacade struct {
-	ar repository.IAuthRepository
+	ar  repository.IAuthRepository
+	auf auditFacade.IAuditFacade
 }
 
-func NewAuthFacade(ar repository.IAuthRepository) IAuthFacade {
+func NewAuthFacade(ar repository.IAuthRepository, auf auditFacade.IAuditFacade) IAuthFacade {
 	return &authFacade{
-		ar: ar,
+		ar:  ar,
+		auf: auf,
 	}
 }
 
@@ -56,3 +65,48 @@ func (af *authFacade) OrgEmailValidate(c echo.Context, email string) error {
 
 	return nil
 }
+
+// RevokeDogowner: dogownerの発行済みトークンの無効化
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: 対象のdogownerID
+//
+// return:
+//   - error: error情報
+func (af *authFacade) RevokeDogowner(c echo.Context, doID int64) error {
+	if wrErr := af.ar.DeleteDogownerJwtID(c, doID); wrErr != nil {
+		return wrErr
+	}
+	return af.recordRevoke(c, model.AUDIT_TARGET_DOGOWNER, doID)
+}
+
+// RevokeDogrunmg: dogrunmgの発行済みトークンの無効化
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: 対象のdogrunmgID
+//
+// return:
+//   - error: error情報
+func (af *authFacade) RevokeDogrunmg(c echo.Context, dmID int64) error {
+	if wrErr := af.ar.DeleteDogrunmgJwtID(c, dmID); wrErr != nil {
+		return wrErr
+	}
+	return af.recordRevoke(c, model.AUDIT_TARGET_DOGRUNMG, dmID)
+}
+
+/*
+トークン無効化の監査ログの記録。操作者はログイン中のユーザー(運営者など)
+*/
+func (af *authFacade) recordRevoke(c echo.Context, targetType string, targetID int64) error {
+	actorID, actorRole := handler.LoginActor(c)
+	return af.auf.RecordInTransaction(c, auditDTO.AuditEntry{
+		ActorID:    actorID,
+		ActorRole:  actorRole,
+		Action:     model.AUDIT_ACTION_REVOKE,
+		Result:     model.AUDIT_RESULT_SUCCESS,
+		TargetType: targetType,
+		TargetID:   strconv.FormatInt(targetID, 10),
+	})
+}
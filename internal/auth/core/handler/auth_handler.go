@@ -7,9 +7,12 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 	"github.com/wanrun-develop/wanrun/configs"
+	auditDTO "github.com/wanrun-develop/wanrun/internal/audit/core/dto"
+	auditFacade "github.com/wanrun-develop/wanrun/internal/audit/facade"
 	"github.com/wanrun-develop/wanrun/internal/auth/adapters/repository"
 	"github.com/wanrun-develop/wanrun/internal/auth/core"
 	authDTO "github.com/wanrun-develop/wanrun/internal/auth/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
 	"github.com/wanrun-develop/wanrun/pkg/errors"
 	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
 	"github.com/wanrun-develop/wanrun/pkg/log"
@@ -22,20 +25,23 @@ type IAuthHandler interface {
 	RevokeDogowner(c echo.Context, dogownerID int64) error
 	LogInDogrunmg(c echo.Context, ador authDTO.AuthDogrunmgReq) (string, error)
 	RevokeDogrunmg(c echo.Context, dmID int64) error
+	LogInSystemOperator(c echo.Context, asoReq authDTO.AuthSystemOperatorReq) (string, error)
+	RevokeSystemOperator(c echo.Context, soID int64) error
 	// GoogleOAuth(c echo.Context, authorizationCode string, grantType types.GrantType) (dto.ResDogOwnerDto, error)
 	IssueGeneralUserToke(c echo.Context) (string, error)
 }
 
 type authHandler struct {
-	ar repository.IAuthRepository
+	ar  repository.IAuthRepository
+	auf auditFacade.IAuditFacade
 	// ag google.IOAuthGoogle
 }
 
 //	func NewAuthHandler(ar repository.IAuthRepository, g google.IOAuthGoogle) IAuthHandler {
 //		return &authHandler{ar, g}
 //	}
-func NewAuthHandler(ar repository.IAuthRepository) IAuthHandler {
-	return &authHandler{ar}
+func NewAuthHandler(ar repository.IAuthRepository, auf auditFacade.IAuditFacade) IAuthHandler {
+	return &authHandler{ar, auf}
 }
 
 // JWTのClaims
@@ -105,6 +111,7 @@ func (ah *authHandler) LogInDogowner(c echo.Context, adoReq authDTO.AuthDogOwner
 			wrErrors.NewAuthClientErrorEType(),
 		)
 		logger.Errorf("Dogowner not found: %v", wrErr)
+		ah.recordLoginFailure(c, model.AUDIT_TARGET_DOGOWNER, loginIdentifier(adoReq), "not_found")
 		return "", wrErr
 	}
 
@@ -127,10 +134,23 @@ func (ah *authHandler) LogInDogowner(c echo.Context, adoReq authDTO.AuthDogOwner
 			wrErrors.NewAuthServerErrorEType())
 
 		logger.Errorf("Password compare failure: %v", wrErr)
+		ah.recordLoginFailure(c, model.AUDIT_TARGET_DOGOWNER, loginIdentifier(adoReq), "invalid_password")
 
 		return "", wrErr
 	}
 
+	// 利用停止中のdogownerはログイン不可
+	if results[0].AuthDogOwner.DogOwner.IsSuspended() {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"利用停止中のユーザーです",
+			wrErrors.NewAuthClientErrorEType(),
+		)
+		logger.Errorf("Suspended dogowner: %v", wrErr)
+		ah.recordLoginFailure(c, model.AUDIT_TARGET_DOGOWNER, loginIdentifier(adoReq), "suspended")
+		return "", wrErr
+	}
+
 	// 更新用のJWT IDの生成
 	jwtID, wrErr := GenerateJwtID(c)
 
@@ -143,6 +163,11 @@ func (ah *authHandler) LogInDogowner(c echo.Context, adoReq authDTO.AuthDogOwner
 		return "", wrErr
 	}
 
+	// ログインの監査ログ
+	if wrErr := ah.recordLogin(c, results[0].AuthDogOwner.DogOwnerID.Int64, core.DOGOWNER_ROLE, model.AUDIT_TARGET_DOGOWNER); wrErr != nil {
+		return "", wrErr
+	}
+
 	// 作成したDogownerの情報をdto詰め替え
 	dogownerDetail := authDTO.UserAuthInfoDTO{
 		UserID: results[0].AuthDogOwner.DogOwnerID.Int64,
@@ -176,7 +201,7 @@ func (ah *authHandler) RevokeDogowner(c echo.Context, doID int64) error {
 		return wrErr
 	}
 
-	return nil
+	return ah.recordRevoke(c, model.AUDIT_TARGET_DOGOWNER, doID)
 }
 
 // LogInDogrunmg: dogrunmgの存在チェックバリデーションとJWTの更新, 署名済みjwtを返す
@@ -208,6 +233,7 @@ func (ah *authHandler) LogInDogrunmg(c echo.Context, admReq authDTO.AuthDogrunmg
 			wrErrors.NewAuthClientErrorEType(),
 		)
 		logger.Errorf("Dogrunmg not found: %v", wrErr)
+		ah.recordLoginFailure(c, model.AUDIT_TARGET_DOGRUNMG, admReq.Email, "not_found")
 		return "", wrErr
 	}
 
@@ -230,7 +256,32 @@ func (ah *authHandler) LogInDogrunmg(c echo.Context, admReq authDTO.AuthDogrunmg
 			wrErrors.NewAuthServerErrorEType())
 
 		logger.Errorf("Password compare failure: %v", wrErr)
+		ah.recordLoginFailure(c, model.AUDIT_TARGET_DOGRUNMG, admReq.Email, "invalid_password")
+
+		return "", wrErr
+	}
+
+	// 無効化されたdogrunmgはログイン不可
+	if !results[0].AuthDogrunmg.Dogrunmg.IsActiveManager() {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"無効化されたユーザーです",
+			wrErrors.NewAuthClientErrorEType(),
+		)
+		logger.Errorf("Inactive dogrunmg: %v", wrErr)
+		ah.recordLoginFailure(c, model.AUDIT_TARGET_DOGRUNMG, admReq.Email, "inactive")
+		return "", wrErr
+	}
 
+	// 利用停止中のdogrunmgはログイン不可
+	if results[0].AuthDogrunmg.Dogrunmg.IsSuspended() {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"利用停止中のユーザーです",
+			wrErrors.NewAuthClientErrorEType(),
+		)
+		logger.Errorf("Suspended dogrunmg: %v", wrErr)
+		ah.recordLoginFailure(c, model.AUDIT_TARGET_DOGRUNMG, admReq.Email, "suspended")
 		return "", wrErr
 	}
 
@@ -254,6 +305,11 @@ func (ah *authHandler) LogInDogrunmg(c echo.Context, admReq authDTO.AuthDogrunmg
 		roleID = core.DOGRUNMG_ROLE
 	}
 
+	// ログインの監査ログ
+	if wrErr := ah.recordLogin(c, results[0].AuthDogrunmg.DogrunmgID.Int64, roleID, model.AUDIT_TARGET_DOGRUNMG); wrErr != nil {
+		return "", wrErr
+	}
+
 	// 取得したDogrunmgの情報をdto詰め替え
 	dogrunmgDetail := authDTO.UserAuthInfoDTO{
 		UserID: results[0].AuthDogrunmg.DogrunmgID.Int64,
@@ -287,7 +343,187 @@ func (ah *authHandler) RevokeDogrunmg(c echo.Context, dmID int64) error {
 		return wrErr
 	}
 
-	return nil
+	return ah.recordRevoke(c, model.AUDIT_TARGET_DOGRUNMG, dmID)
+}
+
+// LogInSystemOperator: システムユーザー(運営者)の存在チェックバリデーションとJWTの更新, 署名済みjwtを返す
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.AuthSystemOperatorReq: 運営者のリクエスト情報
+//
+// return:
+//   - string: 検証済みのjwt
+//   - error: error情報
+func (ah *authHandler) LogInSystemOperator(c echo.Context, asoReq authDTO.AuthSystemOperatorReq) (string, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	// Email情報を元に運営者の取得
+	operator, wrErr := ah.ar.GetSystemOperatorByEmail(c, asoReq.Email)
+
+	if wrErr != nil {
+		return "", wrErr
+	}
+
+	// 対象の運営者がいない場合
+	if operator.IsEmpty() {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"対象のユーザーが存在しません",
+			wrErrors.NewAuthClientErrorEType(),
+		)
+		logger.Errorf("System operator not found: %v", wrErr)
+		ah.recordLoginFailure(c, model.AUDIT_TARGET_SYSTEM_OPERATOR, asoReq.Email, "not_found")
+		return "", wrErr
+	}
+
+	// パスワードの確認
+	if err := bcrypt.CompareHashAndPassword([]byte(operator.Password.String), []byte(asoReq.Password)); err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"パスワードが間違っています",
+			wrErrors.NewAuthClientErrorEType())
+
+		logger.Errorf("Password compare failure: %v", wrErr)
+		ah.recordLoginFailure(c, model.AUDIT_TARGET_SYSTEM_OPERATOR, asoReq.Email, "invalid_password")
+
+		return "", wrErr
+	}
+
+	// 無効化された運営者はログイン不可
+	if !operator.IsActiveOperator() {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"無効化されたユーザーです",
+			wrErrors.NewAuthClientErrorEType(),
+		)
+		logger.Errorf("Inactive system operator: %v", wrErr)
+		ah.recordLoginFailure(c, model.AUDIT_TARGET_SYSTEM_OPERATOR, asoReq.Email, "inactive")
+		return "", wrErr
+	}
+
+	// 更新用のJWT IDの生成
+	jwtID, wrErr := GenerateJwtID(c)
+
+	if wrErr != nil {
+		return "", wrErr
+	}
+
+	// 取得した運営者のjwt_idの更新
+	if wrErr = ah.ar.UpdateSystemOperatorJwtID(c, operator.SystemOperatorID.Int64, jwtID); wrErr != nil {
+		return "", wrErr
+	}
+
+	// ログインの監査ログ
+	if wrErr = ah.recordLogin(c, operator.SystemOperatorID.Int64, core.SYSTEM, model.AUDIT_TARGET_SYSTEM_OPERATOR); wrErr != nil {
+		return "", wrErr
+	}
+
+	// 運営者の情報をdto詰め替え
+	operatorDetail := authDTO.UserAuthInfoDTO{
+		UserID: operator.SystemOperatorID.Int64,
+		JwtID:  jwtID,
+		RoleID: core.SYSTEM,
+	}
+
+	logger.Infof("systemOperatorDetail: %v", operatorDetail)
+
+	// 署名済みのjwt token取得
+	return GetSignedJwt(c, operatorDetail)
+}
+
+// RevokeSystemOperator: システムユーザー(運営者)のRevoke機能
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: 運営者のID
+//
+// return:
+//   - error: error情報
+func (ah *authHandler) RevokeSystemOperator(c echo.Context, soID int64) error {
+	// 対象の運営者のIDからJWT IDの削除
+	if wrErr := ah.ar.DeleteSystemOperatorJwtID(c, soID); wrErr != nil {
+		return wrErr
+	}
+
+	return ah.recordRevoke(c, model.AUDIT_TARGET_SYSTEM_OPERATOR, soID)
+}
+
+// LoginActor: 監査ログ用の操作者の取得
+// wrcontextはこのパッケージに依存しているため、contextのclaimsを直接参照する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト
+//
+// return:
+//   - int64: 操作者のID。未ログインの場合は0
+//   - int: 操作者のロール
+func LoginActor(c echo.Context) (int64, int) {
+	claims, ok := c.Get(core.CONTEXT_KEY).(*AccountClaims)
+	if !ok || claims == nil {
+		return 0, 0
+	}
+	userID, err := strconv.ParseInt(claims.UserID, 10, 64)
+	if err != nil {
+		return 0, 0
+	}
+	return userID, claims.Role
+}
+
+/*
+ログイン成功の監査ログの記録
+*/
+func (ah *authHandler) recordLogin(c echo.Context, userID int64, role int, targetType string) error {
+	return ah.auf.RecordInTransaction(c, auditDTO.AuditEntry{
+		ActorID:    userID,
+		ActorRole:  role,
+		Action:     model.AUDIT_ACTION_LOGIN,
+		Result:     model.AUDIT_RESULT_SUCCESS,
+		TargetType: targetType,
+		TargetID:   strconv.FormatInt(userID, 10),
+	})
+}
+
+/*
+ログイン失敗の監査ログの記録
+ログイン失敗のエラーを優先するため、記録に失敗してもログ出力のみとする
+*/
+func (ah *authHandler) recordLoginFailure(c echo.Context, targetType string, identifier string, reason string) {
+	wrErr := ah.auf.RecordInTransaction(c, auditDTO.AuditEntry{
+		Action:     model.AUDIT_ACTION_LOGIN,
+		Result:     model.AUDIT_RESULT_FAILURE,
+		TargetType: targetType,
+		TargetID:   identifier,
+		After:      map[string]string{"reason": reason},
+	})
+	if wrErr != nil {
+		log.GetLogger(c).Sugar().Errorf("Failed to record login failure: %v", wrErr)
+	}
+}
+
+/*
+トークン無効化の監査ログの記録
+*/
+func (ah *authHandler) recordRevoke(c echo.Context, targetType string, targetID int64) error {
+	actorID, actorRole := LoginActor(c)
+	return ah.auf.RecordInTransaction(c, auditDTO.AuditEntry{
+		ActorID:    actorID,
+		ActorRole:  actorRole,
+		Action:     model.AUDIT_ACTION_REVOKE,
+		Result:     model.AUDIT_RESULT_SUCCESS,
+		TargetType: targetType,
+		TargetID:   strconv.FormatInt(targetID, 10),
+	})
+}
+
+/*
+ログインに使われた識別子(メールアドレスか電話番号)
+*/
+func loginIdentifier(adoReq authDTO.AuthDogOwnerReq) string {
+	if adoReq.Email != "" {
+		return adoReq.Email
+	}
+	return adoReq.PhoneNumber
 }
 
 /*
@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -35,10 +36,16 @@ var skipPaths = []string{
 	"/auth/dogrunmg/token",
 	"/dogowner/signUp",
 	"/org/contract",
+	"/org/invitation/accept",
 	"/health",
 	"/auth/general/token",
 }
 
+// 認証をスキップするパスのプレフィックス
+var skipPathPrefixes = []string{
+	"/cms/storage/", // ローカルストレージの署名付きURL(署名で検証する)
+}
+
 // NewJwtValidationMiddleware: JWT検証用のミドルウェア設定を生成
 //
 // args:
@@ -57,7 +64,9 @@ func (aj *authJwt) NewJwtValidationMiddleware() echo.MiddlewareFunc {
 			ContextKey:  core.CONTEXT_KEY,   // カスタムキーを設定
 			Skipper: func(c echo.Context) bool { // スキップするパスを指定
 				path := c.Request().URL.Path
-				return slices.Contains(skipPaths, path)
+				return slices.Contains(skipPaths, path) || slices.ContainsFunc(skipPathPrefixes, func(prefix string) bool {
+					return strings.HasPrefix(path, prefix)
+				})
 			},
 			SuccessHandler: func(c echo.Context) {
 				// contextからJWTのclaims取得と検証, jwtIDの一致確認
@@ -180,6 +189,10 @@ func (aj *authJwt) jwtIDValid(c echo.Context, ac *handler.AccountClaims) error {
 		case core.DOGRUNMG_ROLE, core.DOGRUNMG_ADMIN_ROLE:
 			// dogrunmgのjwtID取得
 			return aj.ar.GetDogrunmgJwtID(c, id)
+		// system
+		case core.SYSTEM:
+			// 運営者のjwtID取得
+			return aj.ar.GetSystemOperatorJwtID(c, id)
 		//general
 		case core.GENERAL:
 			//jetIDの定数返す
@@ -16,8 +16,10 @@ var ALL = []int{
 	core.GENERAL,
 }
 
-// システムロールのみ (ミドルウェアでシステムユーザーは全て許可済み)
-var SYSTEM = []int{}
+// システムロールのみ (運営者。ユーザー本人として振る舞う機能は許可しない)
+var SYSTEM = []int{
+	core.SYSTEM,
+}
 
 // ドッグラン参照
 var DOGRUN_REFER = []int{
@@ -49,6 +51,13 @@ var DOGRUN_SUPER_MANAGE = []int{
 	core.DOGRUNMG_ADMIN_ROLE,
 }
 
+// ドッグランのイベント管理 (dogownerのオフ会、マネージャーの公式イベント)
+var DOGRUN_EVENT_MANAGE = []int{
+	core.DOGOWNER_ROLE,
+	core.DOGRUNMG_ADMIN_ROLE,
+	core.DOGRUNMG_ROLE,
+}
+
 // RoleAuthorization: ロール認可
 // トークン認証後、コンテキストのclaim情報からRoleを取得し、認可を検証
 //
@@ -67,14 +76,6 @@ func RoleAuthorization(allowedRoles []int) echo.MiddlewareFunc {
 				return err
 			}
 
-			//システムユーザーはチェック対象外
-			for _, systemRole := range SYSTEM {
-				if userRole == systemRole {
-					err := next(c)
-					return err
-				}
-			}
-
 			//引数の認可対象であるかチェック
 			for _, allowedRole := range allowedRoles {
 				if userRole == allowedRole {
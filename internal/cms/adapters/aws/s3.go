@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/smithy-go"
 	"github.com/labstack/echo/v4"
 	"github.com/wanrun-develop/wanrun/configs"
+	"github.com/wanrun-develop/wanrun/internal/cms/adapters/storage"
 	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
 	"github.com/wanrun-develop/wanrun/pkg/log"
 )
@@ -18,17 +20,11 @@ const (
 	DEFAULT_REGION string = "ap-northeast-1"
 )
 
-type IS3Provider interface {
-	PutObject(c echo.Context, sok string, src io.Reader) error
-	DeleteObject(c echo.Context, sok string) error
-	GetObject(c echo.Context, sok string) error
-}
-
 type s3Provider struct {
 	svc *s3.Client
 }
 
-func NewS3Provider(cfg aws.Config) IS3Provider {
+func NewS3Provider(cfg aws.Config) storage.IObjectStorage {
 	return &s3Provider{
 		svc: s3.NewFromConfig(cfg),
 	}
@@ -40,6 +36,7 @@ func NewS3Provider(cfg aws.Config) IS3Provider {
 //   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
 //   - string: アップロードするファイルのS3オブジェクトキー（例: "uploads/coco.png"）
 //   - io.Reader: ファイルデータ
+//   - string: MIMEタイプ
 //
 // return:
 //   - error: error情報
@@ -47,6 +44,7 @@ func (cs3 *s3Provider) PutObject(
 	c echo.Context,
 	sok string,
 	src io.Reader,
+	contentType string,
 ) error {
 	logger := log.GetLogger(c).Sugar()
 
@@ -54,9 +52,10 @@ func (cs3 *s3Provider) PutObject(
 
 	// s3への登録情報
 	input := &s3.PutObjectInput{
-		Bucket: aws.String(configs.FetchConfigStr("aws.s3.bucket.name")),
-		Key:    aws.String(sok),
-		Body:   src,
+		Bucket:      aws.String(configs.FetchConfigStr("aws.s3.bucket.name")),
+		Key:         aws.String(sok),
+		Body:        src,
+		ContentType: aws.String(contentType),
 	}
 
 	logger.Infof("PutObject input: %+v", input)
@@ -140,8 +139,9 @@ func (cs3 *s3Provider) DeleteObject(c echo.Context, sok string) error {
 //   - string: アップロードするファイルのS3オブジェクトキー（例: "uploads/coco.png"）
 //
 // return:
+//   - io.ReadCloser: オブジェクトの内容。呼び出し元でCloseする
 //   - error: error情報
-func (cs3 *s3Provider) GetObject(c echo.Context, sok string) error {
+func (cs3 *s3Provider) GetObject(c echo.Context, sok string) (io.ReadCloser, error) {
 	logger := log.GetLogger(c).Sugar()
 
 	logger.Debugf("Bucket: %v, Key: %v", configs.FetchConfigStr("aws.s3.bucket.name"), sok)
@@ -174,12 +174,103 @@ func (cs3 *s3Provider) GetObject(c echo.Context, sok string) error {
 		)
 
 		logger.Errorf("S3 getObject failure: %v", wrErr)
-		return wrErr
+		return nil, wrErr
 	}
 
-	// logger.Info("Success s3 object delete!!!")
+	return getObjectOutput.Body, nil
+}
 
-	return nil
+// PresignGetObject: S3オブジェクト取得用の署名付きURLを発行する関数
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: 取得するファイルのS3オブジェクトキー（例: "uploads/coco.png"）
+//   - time.Duration: URLの有効期間
+//
+// return:
+//   - string: 署名付きURL
+//   - error: error情報
+func (cs3 *s3Provider) PresignGetObject(c echo.Context, sok string, expires time.Duration) (string, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	logger.Debugf("Bucket: %v, Key: %v", configs.FetchConfigStr("aws.s3.bucket.name"), sok)
+
+	presignClient := s3.NewPresignClient(cs3.svc, s3.WithPresignClientFromClientOptions(getS3Options()...))
+
+	// 署名付きURLの発行
+	req, err := presignClient.PresignGetObject(
+		context.Background(),
+		&s3.GetObjectInput{
+			Bucket: aws.String(configs.FetchConfigStr("aws.s3.bucket.name")),
+			Key:    aws.String(sok),
+		},
+		s3.WithPresignExpires(expires),
+	)
+
+	if err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"署名付きURLの発行に失敗しました。",
+			wrErrors.NewCmsServerErrorEType(),
+		)
+
+		logger.Errorf("S3 presign getObject failure: %v", wrErr)
+		return "", wrErr
+	}
+
+	return req.URL, nil
+}
+
+// PresignPutObject: S3オブジェクトアップロード用の署名付きURLを発行する関数
+// Content-TypeとContent-Lengthを署名に含めるため、クライアントは同じ値で送信する必要がある
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: アップロードするファイルのS3オブジェクトキー（例: "uploads/coco.png"）
+//   - string: MIMEタイプ
+//   - int64: ファイルサイズ
+//   - time.Duration: URLの有効期間
+//
+// return:
+//   - string: 署名付きURL
+//   - error: error情報
+func (cs3 *s3Provider) PresignPutObject(
+	c echo.Context,
+	sok string,
+	contentType string,
+	contentLength int64,
+	expires time.Duration,
+) (string, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	logger.Debugf("Bucket: %v, Key: %v", configs.FetchConfigStr("aws.s3.bucket.name"), sok)
+
+	presignClient := s3.NewPresignClient(cs3.svc, s3.WithPresignClientFromClientOptions(getS3Options()...))
+
+	// 署名付きURLの発行
+	req, err := presignClient.PresignPutObject(
+		context.Background(),
+		&s3.PutObjectInput{
+			Bucket:        aws.String(configs.FetchConfigStr("aws.s3.bucket.name")),
+			Key:           aws.String(sok),
+			ContentType:   aws.String(contentType),
+			ContentLength: aws.Int64(contentLength),
+		},
+		s3.WithPresignExpires(expires),
+	)
+
+	if err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"署名付きURLの発行に失敗しました。",
+			wrErrors.NewCmsServerErrorEType(),
+		)
+
+		logger.Errorf("S3 presign putObject failure: %v", wrErr)
+		return "", wrErr
+	}
+
+	return req.URL, nil
 }
 
 // getS3Options: S3オプションの取得
@@ -202,15 +293,17 @@ func getS3Options() []func(*s3.Options) {
 	return nil
 }
 
-// checkS3ObjectNotExists: S3にオブジェクトがないことを確認する関数(削除済みかの確認)
+// HeadObject: S3のオブジェクトのメタ情報を取得する関数
 //
 // args:
 //   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
-//   - string: アップロードするファイルのS3オブジェクトキー（例: "uploads/coco.png"）
+//   - string: 確認するファイルのS3オブジェクトキー（例: "uploads/coco.png"）
 //
 // return:
+//   - storage.ObjectInfo: オブジェクト情報
+//   - bool: オブジェクトが存在するか
 //   - error: error情報
-func (cs3 *s3Provider) checkS3ObjectNotExists(c echo.Context, sok string) error {
+func (cs3 *s3Provider) HeadObject(c echo.Context, sok string) (storage.ObjectInfo, bool, error) {
 	logger := log.GetLogger(c).Sugar()
 
 	logger.Debugf("Bucket: %v, Key: %v", configs.FetchConfigStr("aws.s3.bucket.name"), sok)
@@ -233,27 +326,76 @@ func (cs3 *s3Provider) checkS3ObjectNotExists(c echo.Context, sok string) error
 		optFns...,
 	)
 
-	logger.Infof("GetObject output: %+v", headObjectOutput)
+	logger.Infof("HeadObject output: %+v", headObjectOutput)
 
 	if err != nil {
 		var apiErr smithy.APIError
-		// 対象のオブジェクトがないので正常に削除されている
+		// 対象のオブジェクトがない
 		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NotFound" {
-			logger.Infof("S3 object does not exist: %v. It has already been deleted or does not require deletion.", sok)
-		} else {
-			// 通常のエラー
+			logger.Infof("S3 object does not exist: %v", sok)
+			return storage.ObjectInfo{}, false, nil
+		}
+
+		// 通常のエラー
+		wrErr := wrErrors.NewWRError(
+			err,
+			"画像、ファイルの取得に失敗しました。",
+			wrErrors.NewCmsServerErrorEType(),
+		)
+
+		logger.Errorf("S3 HeadObject failure: %v", wrErr)
+		return storage.ObjectInfo{}, false, wrErr
+	}
+
+	return storage.ObjectInfo{
+		Key:          sok,
+		Size:         aws.ToInt64(headObjectOutput.ContentLength),
+		ContentType:  aws.ToString(headObjectOutput.ContentType),
+		LastModified: aws.ToTime(headObjectOutput.LastModified),
+	}, true, nil
+}
+
+// ListObjects: S3のプレフィックス配下のオブジェクト一覧を取得する関数
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: プレフィックス（例: "cms/wanrun/"）
+//
+// return:
+//   - []storage.ObjectInfo: オブジェクト情報
+//   - error: error情報
+func (cs3 *s3Provider) ListObjects(c echo.Context, prefix string) ([]storage.ObjectInfo, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	logger.Debugf("Bucket: %v, Prefix: %v", configs.FetchConfigStr("aws.s3.bucket.name"), prefix)
+
+	paginator := s3.NewListObjectsV2Paginator(cs3.svc, &s3.ListObjectsV2Input{
+		Bucket: aws.String(configs.FetchConfigStr("aws.s3.bucket.name")),
+		Prefix: aws.String(prefix),
+	})
+
+	objects := []storage.ObjectInfo{}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background(), getS3Options()...)
+		if err != nil {
 			wrErr := wrErrors.NewWRError(
 				err,
-				"画像、ファイルの取得に失敗しました。",
+				"画像、ファイルの一覧取得に失敗しました。",
 				wrErrors.NewCmsServerErrorEType(),
 			)
 
-			logger.Errorf("S3 HeadObject failure: %v", wrErr)
-			return wrErr
+			logger.Errorf("S3 listObjects failure: %v", wrErr)
+			return nil, wrErr
+		}
+
+		for _, object := range page.Contents {
+			objects = append(objects, storage.ObjectInfo{
+				Key:          aws.ToString(object.Key),
+				Size:         aws.ToInt64(object.Size),
+				LastModified: aws.ToTime(object.LastModified),
+			})
 		}
-	} else {
-		logger.Warnf("S3 object exists: %v", sok)
 	}
 
-	return nil
+	return objects, nil
 }
@@ -0,0 +1,342 @@
+package local
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/cms/adapters/storage"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+)
+
+const (
+	SIGNED_URL_PATH = "/cms/storage/" // 署名付きURLでの配信パス
+)
+
+// LocalStorage: ローカルファイルシステムへの保存(開発・テスト用)
+// 署名付きURLは、ServeSignedObjectで配信する
+type LocalStorage struct {
+	rootDir string
+	baseURL string
+	secret  []byte
+}
+
+func NewLocalStorage(rootDir, baseURL, secret string) *LocalStorage {
+	return &LocalStorage{
+		rootDir: rootDir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		secret:  []byte(secret),
+	}
+}
+
+var _ storage.IObjectStorage = (*LocalStorage)(nil)
+
+// PutObject: ファイルの保存
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: オブジェクトキー
+//   - io.Reader: ファイルデータ
+//   - string: MIMEタイプ(拡張子から判定するため未使用)
+//
+// return:
+//   - error: error情報
+func (ls *LocalStorage) PutObject(c echo.Context, key string, src io.Reader, contentType string) error {
+	logger := log.GetLogger(c).Sugar()
+
+	filePath, wrErr := ls.resolvePath(c, key)
+	if wrErr != nil {
+		return wrErr
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		wrErr := wrErrors.NewWRError(err, "画像のアップロードに失敗しました。", wrErrors.NewCmsServerErrorEType())
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		wrErr := wrErrors.NewWRError(err, "画像のアップロードに失敗しました。", wrErrors.NewCmsServerErrorEType())
+		logger.Error(wrErr)
+		return wrErr
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, src); err != nil {
+		wrErr := wrErrors.NewWRError(err, "画像のアップロードに失敗しました。", wrErrors.NewCmsServerErrorEType())
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	return nil
+}
+
+// GetObject: ファイルの取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: オブジェクトキー
+//
+// return:
+//   - io.ReadCloser: ファイルの内容。呼び出し元でCloseする
+//   - error: error情報
+func (ls *LocalStorage) GetObject(c echo.Context, key string) (io.ReadCloser, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	filePath, wrErr := ls.resolvePath(c, key)
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		wrErr := wrErrors.NewWRError(err, "画像、ファイルの取得に失敗しました。", wrErrors.NewCmsServerErrorEType())
+		logger.Error(wrErr)
+		return nil, wrErr
+	}
+
+	return file, nil
+}
+
+// DeleteObject: ファイルの削除。存在しない場合は削除済みとして扱う
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: オブジェクトキー
+//
+// return:
+//   - error: error情報
+func (ls *LocalStorage) DeleteObject(c echo.Context, key string) error {
+	logger := log.GetLogger(c).Sugar()
+
+	filePath, wrErr := ls.resolvePath(c, key)
+	if wrErr != nil {
+		return wrErr
+	}
+
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		wrErr := wrErrors.NewWRError(err, "画像、ファイルの削除に失敗しました。", wrErrors.NewCmsServerErrorEType())
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	return nil
+}
+
+// HeadObject: ファイルのメタ情報の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: オブジェクトキー
+//
+// return:
+//   - storage.ObjectInfo: オブジェクト情報
+//   - bool: ファイルが存在するか
+//   - error: error情報
+func (ls *LocalStorage) HeadObject(c echo.Context, key string) (storage.ObjectInfo, bool, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	filePath, wrErr := ls.resolvePath(c, key)
+	if wrErr != nil {
+		return storage.ObjectInfo{}, false, wrErr
+	}
+
+	info, err := os.Stat(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return storage.ObjectInfo{}, false, nil
+	}
+	if err != nil {
+		wrErr := wrErrors.NewWRError(err, "画像、ファイルの取得に失敗しました。", wrErrors.NewCmsServerErrorEType())
+		logger.Error(wrErr)
+		return storage.ObjectInfo{}, false, wrErr
+	}
+
+	return ls.toObjectInfo(key, info), true, nil
+}
+
+// ListObjects: プレフィックス配下のファイル一覧の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: プレフィックス（例: "cms/wanrun/"）
+//
+// return:
+//   - []storage.ObjectInfo: オブジェクト情報
+//   - error: error情報
+func (ls *LocalStorage) ListObjects(c echo.Context, prefix string) ([]storage.ObjectInfo, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	objects := []storage.ObjectInfo{}
+	err := filepath.WalkDir(ls.rootDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(ls.rootDir, filePath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, ls.toObjectInfo(key, info))
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		wrErr := wrErrors.NewWRError(err, "画像、ファイルの一覧取得に失敗しました。", wrErrors.NewCmsServerErrorEType())
+		logger.Error(wrErr)
+		return nil, wrErr
+	}
+
+	return objects, nil
+}
+
+// PresignGetObject: ファイル取得用の署名付きURLの発行
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: オブジェクトキー
+//   - time.Duration: URLの有効期間
+//
+// return:
+//   - string: 署名付きURL
+//   - error: error情報
+func (ls *LocalStorage) PresignGetObject(c echo.Context, key string, expires time.Duration) (string, error) {
+	return ls.presign(http.MethodGet, key, "", 0, expires), nil
+}
+
+// PresignPutObject: ファイルアップロード用の署名付きURLの発行
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: オブジェクトキー
+//   - string: MIMEタイプ
+//   - int64: ファイルサイズ
+//   - time.Duration: URLの有効期間
+//
+// return:
+//   - string: 署名付きURL
+//   - error: error情報
+func (ls *LocalStorage) PresignPutObject(c echo.Context, key string, contentType string, contentLength int64, expires time.Duration) (string, error) {
+	return ls.presign(http.MethodPut, key, contentType, contentLength, expires), nil
+}
+
+// ServeSignedObject: 署名付きURLでのファイルの取得・アップロード
+// JWT認証の対象外のため、署名と有効期限で検証する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//
+// return:
+//   - error: error情報
+func (ls *LocalStorage) ServeSignedObject(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	key := c.Param("*")
+	method := c.Request().Method
+
+	var contentType string
+	var contentLength int64
+	if method == http.MethodPut {
+		contentType = c.Request().Header.Get(echo.HeaderContentType)
+		contentLength = c.Request().ContentLength
+	}
+
+	expiresAt, err := strconv.ParseInt(c.QueryParam("expires"), 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		wrErr := wrErrors.NewWRError(err, "署名付きURLの有効期限が切れています。", wrErrors.NewCmsClientErrorEType())
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	expected := ls.sign(method, key, contentType, contentLength, expiresAt)
+	if !hmac.Equal([]byte(expected), []byte(c.QueryParam("signature"))) {
+		wrErr := wrErrors.NewWRError(nil, "署名付きURLが不正です。", wrErrors.NewCmsClientErrorEType())
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	if method == http.MethodPut {
+		if wrErr := ls.PutObject(c, key, c.Request().Body, contentType); wrErr != nil {
+			return wrErr
+		}
+		return c.NoContent(http.StatusOK)
+	}
+
+	filePath, wrErr := ls.resolvePath(c, key)
+	if wrErr != nil {
+		return wrErr
+	}
+	return c.File(filePath)
+}
+
+/*
+署名付きURLの生成
+*/
+func (ls *LocalStorage) presign(method, key, contentType string, contentLength int64, expires time.Duration) string {
+	expiresAt := time.Now().Add(expires).Unix()
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt, 10))
+	query.Set("signature", ls.sign(method, key, contentType, contentLength, expiresAt))
+
+	return fmt.Sprintf("%s%s%s?%s", ls.baseURL, SIGNED_URL_PATH, key, query.Encode())
+}
+
+/*
+HMAC-SHA256での署名
+*/
+func (ls *LocalStorage) sign(method, key, contentType string, contentLength int64, expiresAt int64) string {
+	mac := hmac.New(sha256.New, ls.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%d\n%d", method, key, contentType, contentLength, expiresAt)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+/*
+オブジェクトキーからファイルパスを解決する
+ルートディレクトリ外へのアクセスは許可しない
+*/
+func (ls *LocalStorage) resolvePath(c echo.Context, key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned == "/" || cleaned != "/"+key {
+		wrErr := wrErrors.NewWRError(nil, fmt.Sprintf("オブジェクトキーが不正です: %s", key), wrErrors.NewCmsClientErrorEType())
+		log.GetLogger(c).Sugar().Error(wrErr)
+		return "", wrErr
+	}
+	return filepath.Join(ls.rootDir, filepath.FromSlash(cleaned)), nil
+}
+
+/*
+ファイル情報をオブジェクト情報に変換
+*/
+func (ls *LocalStorage) toObjectInfo(key string, info fs.FileInfo) storage.ObjectInfo {
+	return storage.ObjectInfo{
+		Key:          key,
+		Size:         info.Size(),
+		ContentType:  mime.TypeByExtension(path.Ext(key)),
+		LastModified: info.ModTime(),
+	}
+}
@@ -12,6 +12,11 @@ type ICmsRepository interface {
 	CreateS3FileInfo(c echo.Context, s3FileInfo model.S3FileInfo) error
 	GetS3FileInfoByFileID(c echo.Context, fileID string) ([]model.S3FileInfo, error)
 	DeleteS3FileInfo(c echo.Context, s3FileInfo model.S3FileInfo) error
+	CountVisibleFileReferences(c echo.Context, fileID string, dogOwnerID int64, dogrunmgID int64) (int64, error)
+	FindAllS3FileInfo(c echo.Context) ([]model.S3FileInfo, error)
+	FindReferencedFileIDs(c echo.Context) ([]string, error)
+	SumFileSizeByOwner(c echo.Context, ownerType string, ownerID int64) (int64, error)
+	FindDogrunmgOrganizationID(c echo.Context, dogrunmgID int64) (int64, error)
 }
 
 type cmsRepository struct {
@@ -61,6 +66,7 @@ func (cr *cmsRepository) GetS3FileInfoByFileID(c echo.Context, fileID string) ([
 
 	s3Files := []model.S3FileInfo{}
 	if err := cr.db.Model(&model.S3FileInfo{}).
+		Preload("Variants").
 		Where("file_id = ?", fileID).
 		Find(&s3Files).Error; err != nil {
 		wrErr := wrErrors.NewWRError(
@@ -78,7 +84,7 @@ func (cr *cmsRepository) GetS3FileInfoByFileID(c echo.Context, fileID string) ([
 	return s3Files, nil
 }
 
-// DeleteS3FileInfo: S3FileInfoの削除
+// DeleteS3FileInfo: S3FileInfoとサムネイル情報の削除
 //
 // args:
 //   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
@@ -89,10 +95,16 @@ func (cr *cmsRepository) GetS3FileInfoByFileID(c echo.Context, fileID string) ([
 func (cr *cmsRepository) DeleteS3FileInfo(c echo.Context, s3Info model.S3FileInfo) error {
 	logger := log.GetLogger(c).Sugar()
 
-	if err := cr.db.Model(&model.S3FileInfo{}).
-		Where("file_id = ? AND s3_object_key = ?", s3Info.FileID, s3Info.S3ObjectKey).
-		Delete(&model.S3FileInfo{}).
-		Error; err != nil {
+	if err := cr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("s3_file_info_id = ?", s3Info.S3FileInfoID).
+			Delete(&model.S3FileVariant{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.S3FileInfo{}).
+			Where("file_id = ? AND s3_object_key = ?", s3Info.FileID, s3Info.S3ObjectKey).
+			Delete(&model.S3FileInfo{}).
+			Error
+	}); err != nil {
 		wrErr := wrErrors.NewWRError(
 			err,
 			"DBへの削除処理が失敗しました。",
@@ -107,3 +119,236 @@ func (cr *cmsRepository) DeleteS3FileInfo(c echo.Context, s3Info model.S3FileInf
 
 	return nil
 }
+
+// CountVisibleFileReferences: ログインユーザーが閲覧可能な参照元から参照されている数の取得
+// ドッグランのギャラリー画像は公開。dogの画像はプロフィールの公開範囲、飼い主・共同飼い主、友達のdogの飼い主か、
+// 会員申請先のドッグランのマネージャーかで判定する。dogOwnerの画像は会員申請先のドッグランのマネージャーのみ
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: fileID
+//   - int64: ログインユーザーのdogOwnerID。dogOwner以外は0
+//   - int64: ログインユーザーのdogrunManagerID。マネージャー以外は0
+//
+// return:
+//   - int64: 参照数
+//   - error: error情報
+func (cr *cmsRepository) CountVisibleFileReferences(c echo.Context, fileID string, dogOwnerID int64, dogrunmgID int64) (int64, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	var refCount int64
+	if err := cr.db.Raw(`
+		SELECT COUNT(*) FROM (
+			SELECT 1 FROM dogrun_images WHERE image = @fileID
+			UNION ALL
+			SELECT 1 FROM dogs d
+			LEFT JOIN dog_social_profiles p ON p.dog_id = d.dog_id
+			WHERE d.image = @fileID
+			AND (
+				p.profile_visibility = @public
+				OR (@dogOwnerID <> 0 AND (
+					d.dog_owner_id = @dogOwnerID
+					OR EXISTS (SELECT 1 FROM dog_co_owners co WHERE co.dog_id = d.dog_id AND co.dog_owner_id = @dogOwnerID)
+				))
+				OR (@dogOwnerID <> 0 AND p.profile_visibility = @friends AND EXISTS (
+					SELECT 1 FROM dog_friendships f
+					JOIN dogs fd ON fd.dog_id IN (f.requester_dog_id, f.addressee_dog_id) AND fd.dog_id <> d.dog_id
+					WHERE f.status = @accepted
+					AND d.dog_id IN (f.requester_dog_id, f.addressee_dog_id)
+					AND (
+						fd.dog_owner_id = @dogOwnerID
+						OR EXISTS (SELECT 1 FROM dog_co_owners fco WHERE fco.dog_id = fd.dog_id AND fco.dog_owner_id = @dogOwnerID)
+					)
+				))
+				OR (@dogrunmgID <> 0 AND EXISTS (
+					SELECT 1 FROM dogrun_membership_dogs md
+					JOIN dogrun_memberships m ON m.dogrun_membership_id = md.dogrun_membership_id
+					JOIN dogruns r ON r.dogrun_id = m.dogrun_id
+					WHERE md.dog_id = d.dog_id
+					AND m.status IN @membershipStatuses
+					AND r.dogrun_manager_id = @dogrunmgID
+				))
+			)
+			UNION ALL
+			SELECT 1 FROM dog_owners o
+			WHERE o.image = @fileID
+			AND @dogrunmgID <> 0
+			AND EXISTS (
+				SELECT 1 FROM dogrun_memberships m
+				JOIN dogruns r ON r.dogrun_id = m.dogrun_id
+				WHERE m.dog_owner_id = o.dog_owner_id
+				AND m.status IN @membershipStatuses
+				AND r.dogrun_manager_id = @dogrunmgID
+			)
+		) refs`,
+		map[string]interface{}{
+			"fileID":     fileID,
+			"dogOwnerID": dogOwnerID,
+			"dogrunmgID": dogrunmgID,
+			"public":     model.DOG_VISIBILITY_PUBLIC,
+			"friends":    model.DOG_VISIBILITY_FRIENDS,
+			"accepted":   model.DOG_FRIENDSHIP_STATUS_ACCEPTED,
+			"membershipStatuses": []string{
+				model.DOGRUN_MEMBERSHIP_STATUS_PENDING,
+				model.DOGRUN_MEMBERSHIP_STATUS_APPROVED,
+			},
+		}).
+		Scan(&refCount).Error; err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"DBからのデータ取得に失敗しました。",
+			wrErrors.NewCmsServerErrorEType(),
+		)
+		logger.Errorf("DB search failure: %v", wrErr)
+		return 0, wrErr
+	}
+
+	return refCount, nil
+}
+
+// FindAllS3FileInfo: 全S3FileInfoとサムネイル情報の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//
+// return:
+//   - []model.S3FileInfo: S3ファイル情報
+//   - error: error情報
+func (cr *cmsRepository) FindAllS3FileInfo(c echo.Context) ([]model.S3FileInfo, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	s3Files := []model.S3FileInfo{}
+	if err := cr.db.Model(&model.S3FileInfo{}).
+		Preload("Variants").
+		Find(&s3Files).Error; err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"DBからのデータ取得に失敗しました。",
+			wrErrors.NewCmsServerErrorEType(),
+		)
+		logger.Errorf("DB search failure: %v", wrErr)
+		return []model.S3FileInfo{}, wrErr
+	}
+
+	return s3Files, nil
+}
+
+// FindReferencedFileIDs: プロフィール(dog, dogOwner, dogrunManager)の画像、ドッグランのギャラリー画像、会員登録の添付書類、管理申請の証拠書類として参照されているfileIDの取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//
+// return:
+//   - []string: fileID
+//   - error: error情報
+func (cr *cmsRepository) FindReferencedFileIDs(c echo.Context) ([]string, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	fileIDs := []string{}
+	if err := cr.db.Raw(`
+		SELECT image FROM dogs WHERE image IS NOT NULL AND image <> ''
+		UNION
+		SELECT image FROM dog_owners WHERE image IS NOT NULL AND image <> ''
+		UNION
+		SELECT image FROM dogrun_managers WHERE image IS NOT NULL AND image <> ''
+		UNION
+		SELECT image FROM dogrun_images
+		UNION
+		SELECT file_id FROM dogrun_membership_documents
+		UNION
+		SELECT file_id FROM dogrun_claim_documents`).
+		Scan(&fileIDs).Error; err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"DBからのデータ取得に失敗しました。",
+			wrErrors.NewCmsServerErrorEType(),
+		)
+		logger.Errorf("DB search failure: %v", wrErr)
+		return []string{}, wrErr
+	}
+
+	return fileIDs, nil
+}
+
+// SumFileSizeByOwner: 所有者ごとの使用容量(元ファイルとサムネイルの合計)の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: 所有者の種別
+//   - int64: 所有者のID
+//
+// return:
+//   - int64: 使用容量(byte)
+//   - error: error情報
+func (cr *cmsRepository) SumFileSizeByOwner(c echo.Context, ownerType string, ownerID int64) (int64, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	var fileSize, variantSize int64
+	if err := cr.db.Model(&model.S3FileInfo{}).
+		Select("COALESCE(SUM(file_size), 0)").
+		Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).
+		Scan(&fileSize).Error; err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"DBからのデータ取得に失敗しました。",
+			wrErrors.NewCmsServerErrorEType(),
+		)
+		logger.Errorf("DB search failure: %v", wrErr)
+		return 0, wrErr
+	}
+
+	if err := cr.db.Model(&model.S3FileVariant{}).
+		Select("COALESCE(SUM(s3_file_variants.file_size), 0)").
+		Joins("JOIN s3_file_info ON s3_file_info.s3_file_info_id = s3_file_variants.s3_file_info_id").
+		Where("s3_file_info.owner_type = ? AND s3_file_info.owner_id = ?", ownerType, ownerID).
+		Scan(&variantSize).Error; err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"DBからのデータ取得に失敗しました。",
+			wrErrors.NewCmsServerErrorEType(),
+		)
+		logger.Errorf("DB search failure: %v", wrErr)
+		return 0, wrErr
+	}
+
+	return fileSize + variantSize, nil
+}
+
+// FindDogrunmgOrganizationID: dogrunmgの所属するorganizationIDの取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogrunmgID
+//
+// return:
+//   - int64: organizationID
+//   - error: error情報
+func (cr *cmsRepository) FindDogrunmgOrganizationID(c echo.Context, dogrunmgID int64) (int64, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunmg := model.Dogrunmg{}
+	if err := cr.db.Model(&model.Dogrunmg{}).
+		Select("organization_id").
+		Where("dogrun_manager_id = ?", dogrunmgID).
+		Find(&dogrunmg).Error; err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"DBからのデータ取得に失敗しました。",
+			wrErrors.NewCmsServerErrorEType(),
+		)
+		logger.Errorf("DB search failure: %v", wrErr)
+		return 0, wrErr
+	}
+
+	if !dogrunmg.OrganizationID.Valid {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"所属する組織が存在しません。",
+			wrErrors.NewCmsClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return 0, wrErr
+	}
+
+	return dogrunmg.OrganizationID.Int64, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+/*
+SQLの生成のみ行うDB。DBへの接続は行わず、生成したSQLを記録する
+Raw().Scan()はDryRunで結果を返せないため、呼び出し元はエラーを無視してSQLのみ確認する
+*/
+func newDryRunDB(t *testing.T) (*gorm.DB, *[]string) {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+
+	queries := []string{}
+	if err := db.Callback().Row().After("gorm:row").Register("test:capture", func(tx *gorm.DB) {
+		queries = append(queries, tx.Statement.SQL.String())
+	}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	return db, &queries
+}
+
+func TestFindReferencedFileIDs(t *testing.T) {
+	log.SetLogger(zap.NewNop())
+
+	db, queries := newDryRunDB(t)
+	cr := NewCmsRepository(db)
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder())
+
+	_, _ = cr.FindReferencedFileIDs(c)
+	if len(*queries) != 1 {
+		t.Fatalf("queries = %q, want 1 query", *queries)
+	}
+	query := strings.Join(strings.Fields((*queries)[0]), " ")
+
+	// s3_file_infoのfileIDを保持している全てのカラム
+	tests := []struct {
+		table  string
+		column string
+	}{
+		{table: "dogs", column: "image"},
+		{table: "dog_owners", column: "image"},
+		{table: "dogrun_managers", column: "image"},
+		{table: "dogrun_images", column: "image"},
+		{table: "dogrun_membership_documents", column: "file_id"},
+		{table: "dogrun_claim_documents", column: "file_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			pattern := regexp.MustCompile(`SELECT ` + tt.column + ` FROM ` + tt.table + `\b`)
+			if !pattern.MatchString(query) {
+				t.Errorf("query = %q, want to select %s.%s", query, tt.table, tt.column)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"io"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	STORAGE_TYPE_S3    = "s3"    // S3(ローカルはminio)
+	STORAGE_TYPE_LOCAL = "local" // ローカルファイルシステム(開発・テスト用)
+)
+
+// オブジェクト情報
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+type IObjectStorage interface {
+	PutObject(c echo.Context, key string, src io.Reader, contentType string) error
+	GetObject(c echo.Context, key string) (io.ReadCloser, error)
+	DeleteObject(c echo.Context, key string) error
+	HeadObject(c echo.Context, key string) (ObjectInfo, bool, error)
+	ListObjects(c echo.Context, prefix string) ([]ObjectInfo, error)
+	PresignGetObject(c echo.Context, key string, expires time.Duration) (string, error)
+	PresignPutObject(c echo.Context, key string, contentType string, contentLength int64, expires time.Duration) (string, error)
+}
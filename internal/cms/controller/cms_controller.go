@@ -5,10 +5,10 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/wanrun-develop/wanrun/internal/cms/core/dto"
 	"github.com/wanrun-develop/wanrun/internal/cms/core/handler"
-	"github.com/wanrun-develop/wanrun/internal/wrcontext"
 	"github.com/wanrun-develop/wanrun/pkg/errors"
 	"github.com/wanrun-develop/wanrun/pkg/log"
 )
@@ -16,6 +16,10 @@ import (
 type ICmsController interface {
 	UploadFile(c echo.Context) error
 	DeleteFile(c echo.Context) error
+	GetFileURL(c echo.Context) error
+	IssueUploadURL(c echo.Context) error
+	RunOrphanGC(c echo.Context) error
+	GetQuota(c echo.Context) error
 }
 
 type cmsController struct {
@@ -29,13 +33,6 @@ func NewCmsController(ch handler.ICmsHandler) ICmsController {
 func (cc *cmsController) UploadFile(c echo.Context) error {
 	logger := log.GetLogger(c).Sugar()
 
-	// dogOwnerIDの取得
-	dogOwnerID, wrErr := wrcontext.GetLoginUserID(c)
-
-	if wrErr != nil {
-		return wrErr
-	}
-
 	// フォームからファイルの取得
 	file, err := c.FormFile("file") // "file"はフロントエンドのフォームデータのキー
 
@@ -73,10 +70,10 @@ func (cc *cmsController) UploadFile(c echo.Context) error {
 	baseName := strings.TrimSuffix(fileName, filepath.Ext(fileName))
 
 	fuq := dto.FileUploadReq{
-		FileName:   baseName,
-		Extension:  ext,
-		Src:        src,
-		DogOwnerID: dogOwnerID,
+		FileName:  baseName,
+		Extension: ext,
+		Src:       src,
+		OwnerType: c.FormValue("ownerType"), // 未指定はログインユーザー
 	}
 
 	// FileUploadのハンドラー
@@ -117,3 +114,139 @@ func (cc *cmsController) DeleteFile(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// GetFileURL: ファイル取得用の署名付きURLの発行
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//
+// return:
+//   - error: error情報
+func (cc *cmsController) GetFileURL(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	fuReq := dto.FileURLReq{}
+	if err := c.Bind(&fuReq); err != nil {
+		wrErr := errors.NewWRError(
+			err,
+			"入力項目に不正があります。",
+			errors.NewCmsClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	if err := validator.New().Struct(fuReq); err != nil {
+		wrErr := errors.NewWRError(
+			err,
+			"入力項目に不正があります。",
+			errors.NewCmsClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	fuRes, wrErr := cc.ch.HandleFileURL(c, fuReq)
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, fuRes)
+}
+
+// IssueUploadURL: S3へ直接アップロードするための署名付きURLの発行
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//
+// return:
+//   - error: error情報
+func (cc *cmsController) IssueUploadURL(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	uuReq := dto.UploadURLReq{}
+	if err := c.Bind(&uuReq); err != nil {
+		wrErr := errors.NewWRError(
+			err,
+			"入力項目に不正があります。",
+			errors.NewCmsClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	if err := validator.New().Struct(uuReq); err != nil {
+		wrErr := errors.NewWRError(
+			err,
+			"入力項目に不正があります。",
+			errors.NewCmsClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	uuRes, wrErr := cc.ch.HandleUploadURL(c, uuReq)
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, uuRes)
+}
+
+// RunOrphanGC: 孤立したファイルの検出と削除
+// dryRun=falseを指定した場合のみ削除する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//
+// return:
+//   - error: error情報
+func (cc *cmsController) RunOrphanGC(c echo.Context) error {
+	dryRun := c.QueryParam("dryRun") != "false"
+
+	gcRes, wrErr := cc.ch.HandleOrphanGC(c, dryRun)
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, gcRes)
+}
+
+// GetQuota: 使用容量と上限の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//
+// return:
+//   - error: error情報
+func (cc *cmsController) GetQuota(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	qReq := dto.QuotaReq{}
+	if err := c.Bind(&qReq); err != nil {
+		wrErr := errors.NewWRError(
+			err,
+			"入力項目に不正があります。",
+			errors.NewCmsClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	if err := validator.New().Struct(qReq); err != nil {
+		wrErr := errors.NewWRError(
+			err,
+			"入力項目に不正があります。",
+			errors.NewCmsClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	qRes, wrErr := cc.ch.HandleQuota(c, qReq)
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, qRes)
+}
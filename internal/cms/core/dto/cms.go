@@ -1,18 +1,96 @@
 package dto
 
-import "mime/multipart"
+import (
+	"mime/multipart"
+	"time"
+)
+
+const (
+	OWNER_TYPE_DOGOWNER = "dogowner"
+	OWNER_TYPE_DOGRUNMG = "dogrunmg"
+	OWNER_TYPE_ORG      = "org"
+)
 
 type FileUploadReq struct {
-	FileName   string         // ファイル名
-	Extension  string         // ファイルの拡張子 (例: ".png", ".txt")
-	Src        multipart.File // ファイルの内容
-	DogOwnerID int64
+	FileName  string         // ファイル名
+	Extension string         // ファイルの拡張子 (例: ".png", ".txt")
+	Src       multipart.File // ファイルの内容
+	OwnerType string         // 所有者の種別(未指定はログインユーザー)
 }
 
 type FileUploadRes struct {
-	FileID string `json:"fileId"`
+	FileID      string           `json:"fileId"`
+	OwnerType   string           `json:"ownerType"`
+	ContentType string           `json:"contentType"`
+	Variants    []FileVariantRes `json:"variants"`
+}
+
+type FileVariantRes struct {
+	Variant string `json:"variant"` // small, medium, large
+	Format  string `json:"format"`  // jpeg
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
 }
 
 type FileDeleteReq struct {
 	FileID string `json:"fileId" validate:"required"`
 }
+
+type FileURLReq struct {
+	FileID  string `param:"fileId" validate:"required"`
+	Variant string `query:"variant" validate:"omitempty,oneof=small medium large"` // サムネイル指定(未指定は元画像)
+	Format  string `query:"format" validate:"omitempty,oneof=jpeg"`                // サムネイルの形式(未指定はjpeg)
+}
+
+type FileURLRes struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type UploadURLReq struct {
+	FileName    string `json:"fileName" validate:"required,max=128"`
+	ContentType string `json:"contentType" validate:"required,max=64"`
+	FileSize    int64  `json:"fileSize" validate:"required,gt=0"`
+	OwnerType   string `json:"ownerType" validate:"omitempty,oneof=dogowner dogrunmg org"` // 所有者の種別(未指定はログインユーザー)
+}
+
+type UploadURLRes struct {
+	FileID    string            `json:"fileId"`
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"` // アップロード時に必須のヘッダー
+	ExpiresAt time.Time         `json:"expiresAt"`
+}
+
+const (
+	ORPHAN_REASON_UNREGISTERED   = "unregistered"   // s3_file_infoにも各ドメインにも登録されていないオブジェクト
+	ORPHAN_REASON_UNREFERENCED   = "unreferenced"   // s3_file_infoに登録済みだが、どこからも参照されていない画像
+	ORPHAN_REASON_MISSING_OBJECT = "missing_object" // s3_file_infoに登録済みだが、オブジェクトが存在しない
+)
+
+type QuotaReq struct {
+	OwnerType string `query:"ownerType" validate:"omitempty,oneof=dogowner dogrunmg org"` // 所有者の種別(未指定はログインユーザー)
+}
+
+type QuotaRes struct {
+	OwnerType  string `json:"ownerType"`
+	UsedBytes  int64  `json:"usedBytes"`
+	QuotaBytes int64  `json:"quotaBytes"`
+}
+
+type OrphanGCRes struct {
+	DryRun         bool            `json:"dryRun"`
+	GraceBefore    time.Time       `json:"graceBefore"` // この日時より前のものを対象とする
+	ScannedObjects int             `json:"scannedObjects"`
+	DeletedCount   int             `json:"deletedCount"`
+	Orphans        []OrphanFileRes `json:"orphans"`
+}
+
+type OrphanFileRes struct {
+	S3ObjectKey string    `json:"s3ObjectKey"`
+	FileID      string    `json:"fileId,omitempty"`
+	FileSize    int64     `json:"fileSize"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	Reason      string    `json:"reason"`
+	Deleted     bool      `json:"deleted"`
+}
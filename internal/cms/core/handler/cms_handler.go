@@ -1,13 +1,20 @@
 package handler
 
 import (
+	"bytes"
 	"fmt"
-	"io"
-	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
-	"github.com/wanrun-develop/wanrun/internal/cms/adapters/aws"
+	"github.com/wanrun-develop/wanrun/configs"
+	auditDTO "github.com/wanrun-develop/wanrun/internal/audit/core/dto"
+	auditFacade "github.com/wanrun-develop/wanrun/internal/audit/facade"
+	authHandler "github.com/wanrun-develop/wanrun/internal/auth/core/handler"
 	"github.com/wanrun-develop/wanrun/internal/cms/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/cms/adapters/storage"
 	"github.com/wanrun-develop/wanrun/internal/cms/core/dto"
 	model "github.com/wanrun-develop/wanrun/internal/models"
 	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
@@ -19,23 +26,48 @@ import (
 const (
 	S3_ROOT_FOLDER    = "cms"
 	S3_SERVICE_FOLDER = "wanrun"
+	S3_VARIANT_FOLDER = "variants"
 )
 
 type ICmsHandler interface {
 	HandleFileUpload(c echo.Context, fuq dto.FileUploadReq) (dto.FileUploadRes, error)
 	HandleFileDelete(c echo.Context, fdReq dto.FileDeleteReq) error
+	HandleFileURL(c echo.Context, fuReq dto.FileURLReq) (dto.FileURLRes, error)
+	HandleUploadURL(c echo.Context, uuReq dto.UploadURLReq) (dto.UploadURLRes, error)
+	HandleOrphanGC(c echo.Context, dryRun bool) (dto.OrphanGCRes, error)
+	HandleQuota(c echo.Context, qReq dto.QuotaReq) (dto.QuotaRes, error)
+	UploadOwnedFile(c echo.Context, ownerType string, ownerID int64, fuq dto.FileUploadReq) (dto.FileUploadRes, error)
+	DeleteFileByID(c echo.Context, fileID string, reason string) error
 }
 
+// 署名付きURLで直接アップロードを許可するMIMEタイプと拡張子
+// 画像はEXIF除去のため、必ずHandleFileUploadを経由させる
+var allowedDirectUploadMimeTypes = map[string]string{
+	"video/mp4":       "mp4",
+	"video/quicktime": "mov",
+	"video/webm":      "webm",
+	"application/pdf": "pdf",
+}
+
+// 削除の監査ログに記録する削除理由
+const (
+	DELETE_REASON_OWNER    = "owner"    // 所有者による削除
+	DELETE_REASON_REFERRER = "referrer" // 参照元(ドッグランのギャラリー画像など)の削除
+)
+
 type cmsHandler struct {
-	cs3 aws.IS3Provider
+	st  storage.IObjectStorage
 	cr  repository.ICmsRepository
+	auf auditFacade.IAuditFacade
 }
 
-func NewCmsHandler(cs3 aws.IS3Provider, cr repository.ICmsRepository) ICmsHandler {
-	return &cmsHandler{cs3, cr}
+func NewCmsHandler(st storage.IObjectStorage, cr repository.ICmsRepository, auf auditFacade.IAuditFacade) ICmsHandler {
+	return &cmsHandler{st, cr, auf}
 }
 
-// HandleFileUpload: S3へアップロードとDB登録
+// HandleFileUpload: 画像の加工、S3へアップロードとDB登録
+// 許可された画像のみを受け付け、EXIFを除去した元画像とサムネイルをアップロードする
+// 所有者の容量上限を超える場合はアップロードしない
 //
 // args:
 //   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
@@ -44,6 +76,46 @@ func NewCmsHandler(cs3 aws.IS3Provider, cr repository.ICmsRepository) ICmsHandle
 // return:
 //   - error: error情報
 func (ch *cmsHandler) HandleFileUpload(c echo.Context, fuq dto.FileUploadReq) (dto.FileUploadRes, error) {
+	// 所有者の決定
+	owner, wrErr := ch.resolveOwner(c, fuq.OwnerType)
+	if wrErr != nil {
+		return dto.FileUploadRes{}, wrErr
+	}
+
+	return ch.UploadOwnedFile(c, owner.ownerType, owner.ownerID, fuq)
+}
+
+// UploadOwnedFile: 所有者を指定した画像の加工、S3へアップロードとDB登録
+// 他サービスから、ログインユーザー以外(参照元のドメイン)の所有者でアップロードする場合にも使用する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: 所有者の種別
+//   - int64: 所有者のID
+//   - dto.FileUploadReq: アップロードするファイル情報
+//
+// return:
+//   - dto.FileUploadRes: 登録したファイル情報
+//   - error: error情報
+func (ch *cmsHandler) UploadOwnedFile(c echo.Context, ownerType string, ownerID int64, fuq dto.FileUploadReq) (dto.FileUploadRes, error) {
+	logger := log.GetLogger(c).Sugar()
+	owner := fileOwner{ownerType, ownerID}
+
+	// 画像の判定と加工
+	processed, wrErr := ProcessImage(c, fuq.Src, true)
+	if wrErr != nil {
+		return dto.FileUploadRes{}, wrErr
+	}
+
+	// 容量上限の確認
+	totalBytes := int64(len(processed.Data))
+	for _, variant := range processed.Variants {
+		totalBytes += int64(len(variant.Data))
+	}
+	if wrErr := ch.checkQuota(c, owner, totalBytes); wrErr != nil {
+		return dto.FileUploadRes{}, wrErr
+	}
+
 	// fileIDの生成
 	fileID, wrErr := generateFileID(c)
 
@@ -51,40 +123,83 @@ func (ch *cmsHandler) HandleFileUpload(c echo.Context, fuq dto.FileUploadReq) (d
 		return dto.FileUploadRes{}, wrErr
 	}
 
+	// 拡張子は実際の形式に合わせる
+	fuq.Extension = processed.Extension()
+
 	// s3オブジェクトキーの生成
 	s3ObjectKey := generateS3ObjectKey(fileID, fuq)
 
-	// s3へのアップロード
-	if wrErr := ch.cs3.PutObject(c, s3ObjectKey, fuq.Src); wrErr != nil {
-		return dto.FileUploadRes{}, wrErr
+	// アップロード済みのオブジェクト(失敗時の削除用)
+	uploadedKeys := []string{}
+	cleanup := func() {
+		for _, key := range uploadedKeys {
+			if wrErr := ch.st.DeleteObject(c, key); wrErr != nil {
+				logger.Warnf("アップロード済みのS3オブジェクトの削除に失敗: %v", key)
+			}
+		}
 	}
 
-	// fileのサイズ取得
-	fileSize, wrErr := getFileSize(c, fuq.Src)
-	if wrErr != nil {
+	// s3へのアップロード
+	if wrErr := ch.st.PutObject(c, s3ObjectKey, bytes.NewReader(processed.Data), processed.ContentType()); wrErr != nil {
 		return dto.FileUploadRes{}, wrErr
 	}
+	uploadedKeys = append(uploadedKeys, s3ObjectKey)
+
+	// サムネイルのアップロード
+	variants := []model.S3FileVariant{}
+	variantsRes := []dto.FileVariantRes{}
+	for _, variant := range processed.Variants {
+		variantKey := generateS3VariantObjectKey(fileID, variant)
+		if wrErr := ch.st.PutObject(c, variantKey, bytes.NewReader(variant.Data), variant.ContentType()); wrErr != nil {
+			cleanup()
+			return dto.FileUploadRes{}, wrErr
+		}
+		uploadedKeys = append(uploadedKeys, variantKey)
+
+		variants = append(variants, model.S3FileVariant{
+			Variant:     wrUtil.NewSqlNullString(variant.Name),
+			Format:      wrUtil.NewSqlNullString(variant.Format),
+			Width:       wrUtil.NewSqlNullInt64(int64(variant.Width)),
+			Height:      wrUtil.NewSqlNullInt64(int64(variant.Height)),
+			FileSize:    wrUtil.NewSqlNullInt64(int64(len(variant.Data))),
+			S3ObjectKey: wrUtil.NewSqlNullString(variantKey),
+		})
+		variantsRes = append(variantsRes, dto.FileVariantRes{
+			Variant: variant.Name,
+			Format:  variant.Format,
+			Width:   variant.Width,
+			Height:  variant.Height,
+		})
+	}
 
 	s3FI := model.S3FileInfo{
 		FileID:      wrUtil.NewSqlNullString(fileID),
-		FileSize:    wrUtil.NewSqlNullInt64(fileSize),
+		FileSize:    wrUtil.NewSqlNullInt64(int64(len(processed.Data))),
 		S3ObjectKey: wrUtil.NewSqlNullString(s3ObjectKey),
-		DogOwnerID:  wrUtil.NewSqlNullInt64(fuq.DogOwnerID),
+		ContentType: wrUtil.NewSqlNullString(processed.ContentType()),
+		OwnerType:   wrUtil.NewSqlNullString(owner.ownerType),
+		OwnerID:     wrUtil.NewSqlNullInt64(owner.ownerID),
+		Variants:    variants,
 	}
 
 	// S3FileInfoの登録
 	if wrErr := ch.cr.CreateS3FileInfo(c, s3FI); wrErr != nil {
+		cleanup()
 		return dto.FileUploadRes{}, wrErr
 	}
 
 	fuRes := dto.FileUploadRes{
-		FileID: s3FI.FileID.String,
+		FileID:      s3FI.FileID.String,
+		OwnerType:   s3FI.OwnerType.String,
+		ContentType: s3FI.ContentType.String,
+		Variants:    variantsRes,
 	}
 
 	return fuRes, nil
 }
 
 // HandleFileDelete: S3へのファイル削除と対象のDBレコード削除
+// ファイルの所有者のみ削除可能
 //
 // args:
 //   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
@@ -125,19 +240,276 @@ func (ch *cmsHandler) HandleFileDelete(c echo.Context, fdReq dto.FileDeleteReq)
 		return wrErr
 	}
 
+	// 所有者の確認
+	isOwner, wrErr := ch.isFileOwner(c, s3Files[0], true)
+	if wrErr != nil {
+		return wrErr
+	}
+	if !isOwner {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"対象のファイルを削除する権限がありません",
+			wrErrors.NewCmsClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	return ch.deleteS3File(c, s3Files[0], DELETE_REASON_OWNER)
+}
+
+// DeleteFileByID: fileIDを指定したファイルの削除
+// 参照元の削除にあわせて他サービスから削除する場合に使用する。権限のチェックは呼び出し元で行う
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: fileID
+//   - string: 監査ログに記録する削除理由
+//
+// return:
+//   - error: error情報
+func (ch *cmsHandler) DeleteFileByID(c echo.Context, fileID string, reason string) error {
+	logger := log.GetLogger(c).Sugar()
+
+	s3Files, wrErr := ch.cr.GetS3FileInfoByFileID(c, fileID)
+	if wrErr != nil {
+		return wrErr
+	}
+	if len(s3Files) != 1 {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"対象のS3File情報が存在しません",
+			wrErrors.NewCmsClientErrorEType(),
+		)
+		logger.Errorf("s3File not found: %v", wrErr)
+		return wrErr
+	}
+
+	return ch.deleteS3File(c, s3Files[0], reason)
+}
+
+// deleteS3File: オブジェクト、サムネイルとS3FileInfoの削除と監査ログの記録
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - model.S3FileInfo: 削除対象のS3ファイル情報
+//   - string: 監査ログに記録する削除理由
+//
+// return:
+//   - error: error情報
+func (ch *cmsHandler) deleteS3File(c echo.Context, s3File model.S3FileInfo, reason string) error {
+	logger := log.GetLogger(c).Sugar()
+
+	// サムネイルの削除
+	for _, variant := range s3File.Variants {
+		if wrErr := ch.st.DeleteObject(c, variant.S3ObjectKey.String); wrErr != nil {
+			return wrErr
+		}
+	}
+
 	// 対象のオブジェクトの削除
-	if wrErr := ch.cs3.DeleteObject(c, s3Files[0].S3ObjectKey.String); wrErr != nil {
+	if wrErr := ch.st.DeleteObject(c, s3File.S3ObjectKey.String); wrErr != nil {
 		return wrErr
 	}
 
 	logger.Info("Success s3 object delete!!!")
 
 	// 対象のS3file情報をDBから削除
-	if wrErr := ch.cr.DeleteS3FileInfo(c, s3Files[0]); wrErr != nil {
+	if wrErr := ch.cr.DeleteS3FileInfo(c, s3File); wrErr != nil {
 		return wrErr
 	}
 
-	return nil
+	// 削除の監査ログ
+	actorID, actorRole := authHandler.LoginActor(c)
+	return ch.auf.RecordInTransaction(c, auditDTO.AuditEntry{
+		ActorID:    actorID,
+		ActorRole:  actorRole,
+		Action:     model.AUDIT_ACTION_DELETE_FILE,
+		Result:     model.AUDIT_RESULT_SUCCESS,
+		TargetType: model.AUDIT_TARGET_FILE,
+		TargetID:   s3File.FileID.String,
+		Before: map[string]any{
+			"ownerType":   s3File.OwnerType.String,
+			"ownerId":     s3File.OwnerID.Int64,
+			"s3ObjectKey": s3File.S3ObjectKey.String,
+			"fileSize":    s3File.FileSize.Int64,
+			"contentType": s3File.ContentType.String,
+		},
+		After: map[string]string{"reason": reason},
+	})
+}
+
+// HandleFileURL: ファイル取得用の署名付きURLの発行
+// ファイルの所有者か、ログインユーザーが閲覧可能な参照元(ギャラリー画像、公開範囲内のdogの画像など)から参照されている場合のみ発行する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.FileURLReq: フロントからのリクエスト情報
+//
+// return:
+//   - dto.FileURLRes: 署名付きURL
+//   - error: error情報
+func (ch *cmsHandler) HandleFileURL(c echo.Context, fuReq dto.FileURLReq) (dto.FileURLRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	s3Files, wrErr := ch.cr.GetS3FileInfoByFileID(c, fuReq.FileID)
+	if wrErr != nil {
+		return dto.FileURLRes{}, wrErr
+	}
+	if len(s3Files) != 1 {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"対象のS3File情報が存在しません",
+			wrErrors.NewCmsClientErrorEType(),
+		)
+		logger.Errorf("s3File not found: %v", wrErr)
+		return dto.FileURLRes{}, wrErr
+	}
+	s3File := s3Files[0]
+
+	// 閲覧可能かの確認
+	isOwner, wrErr := ch.isFileOwner(c, s3File, false)
+	if wrErr != nil {
+		return dto.FileURLRes{}, wrErr
+	}
+	if !isOwner {
+		refCount, wrErr := ch.countVisibleFileReferences(c, fuReq.FileID)
+		if wrErr != nil {
+			return dto.FileURLRes{}, wrErr
+		}
+		if refCount == 0 {
+			wrErr := wrErrors.NewWRError(
+				nil,
+				"対象のファイルを閲覧する権限がありません",
+				wrErrors.NewCmsClientErrorEType(),
+			)
+			logger.Error(wrErr)
+			return dto.FileURLRes{}, wrErr
+		}
+	}
+
+	// 対象のオブジェクトキーの選択
+	s3ObjectKey := s3File.S3ObjectKey.String
+	if fuReq.Variant != "" {
+		format := fuReq.Format
+		if format == "" {
+			format = IMAGE_FORMAT_JPEG
+		}
+		s3ObjectKey = ""
+		for _, variant := range s3File.Variants {
+			if variant.Variant.String == fuReq.Variant && variant.Format.String == format {
+				s3ObjectKey = variant.S3ObjectKey.String
+				break
+			}
+		}
+		if s3ObjectKey == "" {
+			wrErr := wrErrors.NewWRError(
+				nil,
+				"指定されたサムネイルが存在しません",
+				wrErrors.NewCmsClientErrorEType(),
+			)
+			logger.Error(wrErr)
+			return dto.FileURLRes{}, wrErr
+		}
+	}
+
+	expires := time.Duration(configs.FetchConfigInt("cms.presign.expires")) * time.Second
+	url, wrErr := ch.st.PresignGetObject(c, s3ObjectKey, expires)
+	if wrErr != nil {
+		return dto.FileURLRes{}, wrErr
+	}
+
+	return dto.FileURLRes{
+		URL:       url,
+		ExpiresAt: time.Now().Add(expires),
+	}, nil
+}
+
+// HandleUploadURL: S3へ直接アップロードするための署名付きURLの発行とDB登録
+// BodyLimitを超える大きなファイル向け
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.UploadURLReq: フロントからのリクエスト情報
+//
+// return:
+//   - dto.UploadURLRes: 署名付きURLとアップロード時の必須ヘッダー
+//   - error: error情報
+func (ch *cmsHandler) HandleUploadURL(c echo.Context, uuReq dto.UploadURLReq) (dto.UploadURLRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	ext, ok := allowedDirectUploadMimeTypes[uuReq.ContentType]
+	if !ok {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			fmt.Sprintf("直接アップロードが許可されていないファイル形式です: %s", uuReq.ContentType),
+			wrErrors.NewCmsClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return dto.UploadURLRes{}, wrErr
+	}
+
+	maxBytes := int64(configs.FetchConfigInt("cms.presign.max.bytes"))
+	if uuReq.FileSize > maxBytes {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			fmt.Sprintf("ファイルサイズは%dbyteまでです", maxBytes),
+			wrErrors.NewCmsClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return dto.UploadURLRes{}, wrErr
+	}
+
+	// 所有者の決定と容量上限の確認
+	owner, wrErr := ch.resolveOwner(c, uuReq.OwnerType)
+	if wrErr != nil {
+		return dto.UploadURLRes{}, wrErr
+	}
+	if wrErr := ch.checkQuota(c, owner, uuReq.FileSize); wrErr != nil {
+		return dto.UploadURLRes{}, wrErr
+	}
+
+	// fileIDの生成
+	fileID, wrErr := generateFileID(c)
+	if wrErr != nil {
+		return dto.UploadURLRes{}, wrErr
+	}
+
+	// s3オブジェクトキーの生成
+	s3ObjectKey := generateS3ObjectKey(fileID, dto.FileUploadReq{
+		FileName:  strings.TrimSuffix(uuReq.FileName, filepath.Ext(uuReq.FileName)),
+		Extension: ext,
+	})
+
+	expires := time.Duration(configs.FetchConfigInt("cms.presign.expires")) * time.Second
+	url, wrErr := ch.st.PresignPutObject(c, s3ObjectKey, uuReq.ContentType, uuReq.FileSize, expires)
+	if wrErr != nil {
+		return dto.UploadURLRes{}, wrErr
+	}
+
+	s3FI := model.S3FileInfo{
+		FileID:      wrUtil.NewSqlNullString(fileID),
+		FileSize:    wrUtil.NewSqlNullInt64(uuReq.FileSize),
+		S3ObjectKey: wrUtil.NewSqlNullString(s3ObjectKey),
+		ContentType: wrUtil.NewSqlNullString(uuReq.ContentType),
+		OwnerType:   wrUtil.NewSqlNullString(owner.ownerType),
+		OwnerID:     wrUtil.NewSqlNullInt64(owner.ownerID),
+	}
+
+	// S3FileInfoの登録
+	if wrErr := ch.cr.CreateS3FileInfo(c, s3FI); wrErr != nil {
+		return dto.UploadURLRes{}, wrErr
+	}
+
+	return dto.UploadURLRes{
+		FileID: fileID,
+		URL:    url,
+		Method: http.MethodPut,
+		Headers: map[string]string{
+			echo.HeaderContentType: uuReq.ContentType,
+		},
+		ExpiresAt: time.Now().Add(expires),
+	}, nil
 }
 
 // generateS3ObjectKey: S3ObjectKeyの生成
@@ -158,6 +530,25 @@ func generateS3ObjectKey(fileID string, fuq dto.FileUploadReq) string {
 	)
 }
 
+// generateS3VariantObjectKey: サムネイルのS3ObjectKeyの生成
+//
+// args:
+//   - string: 生成したfileID
+//   - ProcessedImage: 加工済みのサムネイル
+//
+// return:
+//   - string: s3ObjectKey
+func generateS3VariantObjectKey(fileID string, variant ProcessedImage) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s.%s",
+		S3_ROOT_FOLDER,
+		S3_SERVICE_FOLDER,
+		fileID,
+		S3_VARIANT_FOLDER,
+		variant.Name,
+		variant.Extension(),
+	)
+}
+
 // generateFileID: FileIDの生成。引数の数だけランダムの文字列を生成
 //
 // args:
@@ -183,53 +574,3 @@ func generateFileID(c echo.Context) (string, error) {
 	// UUIDを生成
 	return util.UUIDGenerator(handleError)
 }
-
-// getFileSize: fileサイズの取得
-//
-// args:
-//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
-//   - multipart.File: フロントで取得したファイルデータ
-//
-// return:
-//   - int64: fileサイズ
-//   - error: error情報
-func getFileSize(c echo.Context, file multipart.File) (int64, error) {
-	logger := log.GetLogger(c).Sugar()
-	// 現在の位置を保存
-	currentPos, err := file.Seek(0, io.SeekCurrent)
-	if err != nil {
-		wrErr := wrErrors.NewWRError(
-			err,
-			"Fileの位置サイズの取得に失敗しました",
-			wrErrors.NewCmsServerErrorEType(),
-		)
-		logger.Error(wrErr)
-		return 0, wrErr
-	}
-
-	// ファイルのサイズを取得
-	size, err := file.Seek(0, io.SeekEnd)
-	if err != nil {
-		wrErr := wrErrors.NewWRError(
-			err,
-			"Fileの取得に失敗しました",
-			wrErrors.NewCmsServerErrorEType(),
-		)
-		logger.Error(wrErr)
-		return 0, wrErr
-	}
-
-	// 元の位置に戻す
-	_, err = file.Seek(currentPos, io.SeekStart)
-	if err != nil {
-		wrErr := wrErrors.NewWRError(
-			err,
-			"Fileの位置を戻すのに失敗しました",
-			wrErrors.NewCmsServerErrorEType(),
-		)
-		logger.Error(wrErr)
-		return 0, wrErr
-	}
-
-	return size, nil
-}
@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/configs"
+	"github.com/wanrun-develop/wanrun/internal/auth/core"
+	"github.com/wanrun-develop/wanrun/internal/cms/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/wrcontext"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+)
+
+// ファイルの所有者
+type fileOwner struct {
+	ownerType string
+	ownerID   int64
+}
+
+// HandleQuota: 所有者ごとの使用容量と上限の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.QuotaReq: フロントからのリクエスト情報
+//
+// return:
+//   - dto.QuotaRes: 使用容量と上限
+//   - error: error情報
+func (ch *cmsHandler) HandleQuota(c echo.Context, qReq dto.QuotaReq) (dto.QuotaRes, error) {
+	owner, wrErr := ch.resolveOwner(c, qReq.OwnerType)
+	if wrErr != nil {
+		return dto.QuotaRes{}, wrErr
+	}
+
+	usedBytes, wrErr := ch.cr.SumFileSizeByOwner(c, owner.ownerType, owner.ownerID)
+	if wrErr != nil {
+		return dto.QuotaRes{}, wrErr
+	}
+
+	return dto.QuotaRes{
+		OwnerType:  owner.ownerType,
+		UsedBytes:  usedBytes,
+		QuotaBytes: fetchQuotaBytes(owner.ownerType),
+	}, nil
+}
+
+// resolveOwner: ログインユーザーのロールから、ファイルの所有者を決定する
+// orgとしての所有はdogrunmgの管理者のみ許可する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: 指定された所有者の種別(未指定はログインユーザー)
+//
+// return:
+//   - fileOwner: ファイルの所有者
+//   - error: error情報
+func (ch *cmsHandler) resolveOwner(c echo.Context, ownerType string) (fileOwner, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	role, wrErr := wrcontext.GetLoginUserRole(c)
+	if wrErr != nil {
+		return fileOwner{}, wrErr
+	}
+	userID, wrErr := wrcontext.GetLoginUserID(c)
+	if wrErr != nil {
+		return fileOwner{}, wrErr
+	}
+
+	switch {
+	case role == core.DOGOWNER_ROLE && (ownerType == "" || ownerType == dto.OWNER_TYPE_DOGOWNER):
+		return fileOwner{dto.OWNER_TYPE_DOGOWNER, userID}, nil
+	case isDogrunmgRole(role) && (ownerType == "" || ownerType == dto.OWNER_TYPE_DOGRUNMG):
+		return fileOwner{dto.OWNER_TYPE_DOGRUNMG, userID}, nil
+	case role == core.DOGRUNMG_ADMIN_ROLE && ownerType == dto.OWNER_TYPE_ORG:
+		orgID, wrErr := ch.cr.FindDogrunmgOrganizationID(c, userID)
+		if wrErr != nil {
+			return fileOwner{}, wrErr
+		}
+		return fileOwner{dto.OWNER_TYPE_ORG, orgID}, nil
+	}
+
+	wrErr = wrErrors.NewWRError(
+		nil,
+		"指定された所有者でファイルを扱う権限がありません",
+		wrErrors.NewCmsClientErrorEType(),
+	)
+	logger.Error(wrErr)
+	return fileOwner{}, wrErr
+}
+
+// isFileOwner: ログインユーザーがファイルの所有者か
+// orgのファイルは所属するdogrunmgが所有者となる。管理(削除)はdogrunmgの管理者のみ
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - model.S3FileInfo: 対象のS3ファイル情報
+//   - bool: 管理(削除)目的か
+//
+// return:
+//   - bool: 所有者か
+//   - error: error情報
+func (ch *cmsHandler) isFileOwner(c echo.Context, s3File model.S3FileInfo, forManage bool) (bool, error) {
+	role, wrErr := wrcontext.GetLoginUserRole(c)
+	if wrErr != nil {
+		return false, wrErr
+	}
+	if role == core.SYSTEM {
+		return true, nil
+	}
+	userID, wrErr := wrcontext.GetLoginUserID(c)
+	if wrErr != nil {
+		return false, wrErr
+	}
+
+	ownerID := s3File.OwnerID.Int64
+	switch s3File.OwnerType.String {
+	case dto.OWNER_TYPE_DOGOWNER:
+		return role == core.DOGOWNER_ROLE && ownerID == userID, nil
+	case dto.OWNER_TYPE_DOGRUNMG:
+		return isDogrunmgRole(role) && ownerID == userID, nil
+	case dto.OWNER_TYPE_ORG:
+		if role != core.DOGRUNMG_ADMIN_ROLE && (forManage || role != core.DOGRUNMG_ROLE) {
+			return false, nil
+		}
+		orgID, wrErr := ch.cr.FindDogrunmgOrganizationID(c, userID)
+		if wrErr != nil {
+			return false, wrErr
+		}
+		return ownerID == orgID, nil
+	}
+
+	return false, nil
+}
+
+// countVisibleFileReferences: ログインユーザーのロールで閲覧可能な参照元から参照されている数
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: fileID
+//
+// return:
+//   - int64: 参照数
+//   - error: error情報
+func (ch *cmsHandler) countVisibleFileReferences(c echo.Context, fileID string) (int64, error) {
+	role, wrErr := wrcontext.GetLoginUserRole(c)
+	if wrErr != nil {
+		return 0, wrErr
+	}
+	userID, wrErr := wrcontext.GetLoginUserID(c)
+	if wrErr != nil {
+		return 0, wrErr
+	}
+
+	var dogOwnerID, dogrunmgID int64
+	if role == core.DOGOWNER_ROLE {
+		dogOwnerID = userID
+	} else if isDogrunmgRole(role) {
+		dogrunmgID = userID
+	}
+	return ch.cr.CountVisibleFileReferences(c, fileID, dogOwnerID, dogrunmgID)
+}
+
+// checkQuota: 所有者の使用容量が上限を超えないかの確認
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - fileOwner: ファイルの所有者
+//   - int64: 追加するファイルサイズ
+//
+// return:
+//   - error: error情報
+func (ch *cmsHandler) checkQuota(c echo.Context, owner fileOwner, addBytes int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	usedBytes, wrErr := ch.cr.SumFileSizeByOwner(c, owner.ownerType, owner.ownerID)
+	if wrErr != nil {
+		return wrErr
+	}
+
+	quotaBytes := fetchQuotaBytes(owner.ownerType)
+	if usedBytes+addBytes > quotaBytes {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			fmt.Sprintf("ストレージの容量上限(%dbyte)を超えています", quotaBytes),
+			wrErrors.NewCmsClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	return nil
+}
+
+/*
+所有者の種別ごとの容量上限
+*/
+func fetchQuotaBytes(ownerType string) int64 {
+	return int64(configs.FetchConfigInt(fmt.Sprintf("cms.quota.%s.bytes", ownerType)))
+}
+
+/*
+dogrunmgのロールか
+*/
+func isDogrunmgRole(role int) bool {
+	return role == core.DOGRUNMG_ROLE || role == core.DOGRUNMG_ADMIN_ROLE
+}
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rwcarlsen/goexif/exif"
+	"github.com/wanrun-develop/wanrun/configs"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // webpのデコード
+)
+
+const (
+	IMAGE_FORMAT_JPEG = "jpeg"
+	IMAGE_FORMAT_PNG  = "png"
+	IMAGE_FORMAT_WEBP = "webp"
+
+	JPEG_QUALITY = 85 // 再エンコード時のjpeg品質
+)
+
+// 許可する画像形式(MIMEタイプ -> 形式)
+var allowedImageMimeTypes = map[string]string{
+	"image/jpeg": IMAGE_FORMAT_JPEG,
+	"image/png":  IMAGE_FORMAT_PNG,
+	"image/webp": IMAGE_FORMAT_WEBP,
+}
+
+// 形式ごとのMIMEタイプと拡張子
+var imageFormatMimeTypes = map[string]string{
+	IMAGE_FORMAT_JPEG: "image/jpeg",
+	IMAGE_FORMAT_PNG:  "image/png",
+}
+
+var imageFormatExtensions = map[string]string{
+	IMAGE_FORMAT_JPEG: "jpg",
+	IMAGE_FORMAT_PNG:  "png",
+}
+
+// 元画像の保存形式(入力形式 -> 保存形式)
+// webpは可逆でしか再エンコードできずサイズが膨らむため、jpegで保存する
+var originalImageFormats = map[string]string{
+	IMAGE_FORMAT_JPEG: IMAGE_FORMAT_JPEG,
+	IMAGE_FORMAT_PNG:  IMAGE_FORMAT_PNG,
+	IMAGE_FORMAT_WEBP: IMAGE_FORMAT_JPEG,
+}
+
+// サムネイルの生成定義
+type imageVariantSpec struct {
+	Name   string // バリエーション名
+	MaxPx  int    // 長辺の最大px
+	Format string // 出力形式
+}
+
+var imageVariantSpecs = []imageVariantSpec{
+	{Name: "small", MaxPx: 160, Format: IMAGE_FORMAT_JPEG},
+	{Name: "medium", MaxPx: 480, Format: IMAGE_FORMAT_JPEG},
+	{Name: "large", MaxPx: 1080, Format: IMAGE_FORMAT_JPEG},
+}
+
+// 加工済みの画像
+type ProcessedImage struct {
+	Format   string
+	Data     []byte
+	Width    int
+	Height   int
+	Variants []ProcessedImage // サムネイル(元画像のみ保持)
+	Name     string           // バリエーション名(サムネイルのみ保持)
+}
+
+/*
+形式のMIMEタイプ
+*/
+func (pi ProcessedImage) ContentType() string {
+	return imageFormatMimeTypes[pi.Format]
+}
+
+/*
+形式の拡張子
+*/
+func (pi ProcessedImage) Extension() string {
+	return imageFormatExtensions[pi.Format]
+}
+
+// ProcessImage: アップロード画像の加工
+// 実際のMIMEタイプを判定して許可された画像のみ受け付け、
+// 再エンコードによりEXIF(GPS情報含む)を除去する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - io.Reader: アップロードされたファイルデータ
+//   - bool: サムネイルを生成するか
+//
+// return:
+//   - ProcessedImage: 加工済みの画像
+//   - error: error情報
+func ProcessImage(c echo.Context, src io.Reader, withVariants bool) (ProcessedImage, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	// サイズ上限を超えて読み込まない
+	maxBytes := int64(configs.FetchConfigInt("cms.upload.max.bytes"))
+	data, err := io.ReadAll(io.LimitReader(src, maxBytes+1))
+	if err != nil {
+		wrErr := wrErrors.NewWRError(err, "ファイルの読み込みに失敗しました", wrErrors.NewCmsServerErrorEType())
+		logger.Error(wrErr)
+		return ProcessedImage{}, wrErr
+	}
+	if int64(len(data)) > maxBytes {
+		wrErr := wrErrors.NewWRError(nil, fmt.Sprintf("ファイルサイズは%dbyteまでです", maxBytes), wrErrors.NewCmsClientErrorEType())
+		logger.Error(wrErr)
+		return ProcessedImage{}, wrErr
+	}
+
+	// 実際のMIMEタイプの判定
+	mimeType := http.DetectContentType(data)
+	format, ok := allowedImageMimeTypes[mimeType]
+	if !ok {
+		wrErr := wrErrors.NewWRError(nil, fmt.Sprintf("許可されていないファイル形式です: %s", mimeType), wrErrors.NewCmsClientErrorEType())
+		logger.Error(wrErr)
+		return ProcessedImage{}, wrErr
+	}
+
+	// 展開後のメモリ使用量を抑えるため、デコード前に画素数を確認する
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		wrErr := wrErrors.NewWRError(err, "画像の読み込みに失敗しました", wrErrors.NewCmsClientErrorEType())
+		logger.Error(wrErr)
+		return ProcessedImage{}, wrErr
+	}
+	maxPixels := int64(configs.FetchConfigInt("cms.image.max.pixels"))
+	if config.Width <= 0 || config.Height <= 0 || int64(config.Width)*int64(config.Height) > maxPixels {
+		wrErr := wrErrors.NewWRError(nil, fmt.Sprintf("画像の画素数は%dpxまでです", maxPixels), wrErrors.NewCmsClientErrorEType())
+		logger.Error(wrErr)
+		return ProcessedImage{}, wrErr
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		wrErr := wrErrors.NewWRError(err, "画像の読み込みに失敗しました", wrErrors.NewCmsClientErrorEType())
+		logger.Error(wrErr)
+		return ProcessedImage{}, wrErr
+	}
+
+	// EXIFの向き情報を画素に反映する(再エンコードでEXIFは除去される)
+	if format == IMAGE_FORMAT_JPEG {
+		img = applyExifOrientation(c, data, img)
+	}
+
+	// 元画像の再エンコード
+	processed, wrErr := encodeImage(c, img, originalImageFormats[format])
+	if wrErr != nil {
+		return ProcessedImage{}, wrErr
+	}
+
+	if !withVariants {
+		return processed, nil
+	}
+
+	// サムネイルの生成
+	for _, spec := range imageVariantSpecs {
+		variant, wrErr := encodeImage(c, resizeImage(img, spec.MaxPx), spec.Format)
+		if wrErr != nil {
+			return ProcessedImage{}, wrErr
+		}
+		variant.Name = spec.Name
+		processed.Variants = append(processed.Variants, variant)
+	}
+
+	return processed, nil
+}
+
+/*
+画像を指定形式でエンコード
+*/
+func encodeImage(c echo.Context, img image.Image, format string) (ProcessedImage, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case IMAGE_FORMAT_JPEG:
+		err = jpeg.Encode(&buf, flattenImage(img), &jpeg.Options{Quality: JPEG_QUALITY})
+	case IMAGE_FORMAT_PNG:
+		err = png.Encode(&buf, img)
+	default:
+		err = fmt.Errorf("unsupported format: %s", format)
+	}
+	if err != nil {
+		wrErr := wrErrors.NewWRError(err, "画像の変換に失敗しました", wrErrors.NewCmsServerErrorEType())
+		logger.Error(wrErr)
+		return ProcessedImage{}, wrErr
+	}
+
+	bounds := img.Bounds()
+	return ProcessedImage{
+		Format: format,
+		Data:   buf.Bytes(),
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+	}, nil
+}
+
+/*
+長辺がmaxPxに収まるように縮小する(拡大はしない)
+*/
+func resizeImage(img image.Image, maxPx int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxPx && height <= maxPx {
+		return img
+	}
+
+	if width >= height {
+		height = max(1, height*maxPx/width)
+		width = maxPx
+	} else {
+		width = max(1, width*maxPx/height)
+		height = maxPx
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+	return dst
+}
+
+/*
+jpeg用に透過部分を白背景で塗りつぶす
+*/
+func flattenImage(img image.Image) image.Image {
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Over)
+	return dst
+}
+
+/*
+EXIFのOrientationに従って画像を回転・反転する
+EXIFがない、または読み込めない場合はそのまま返す
+*/
+func applyExifOrientation(c echo.Context, data []byte, img image.Image) image.Image {
+	logger := log.GetLogger(c).Sugar()
+
+	x, err := exif.Decode(bytes.NewReader(data))
+	if err != nil {
+		return img
+	}
+
+	if _, _, err := x.LatLong(); err == nil {
+		logger.Info("EXIFの位置情報を除去します")
+	}
+
+	tag, err := x.Get(exif.Orientation)
+	if err != nil {
+		return img
+	}
+	orientation, err := tag.Int(0)
+	if err != nil || orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		for x := 0; x < dstW; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // 左右反転
+				sx, sy = w-1-x, y
+			case 3: // 180度回転
+				sx, sy = w-1-x, h-1-y
+			case 4: // 上下反転
+				sx, sy = x, h-1-y
+			case 5: // 左上-右下の対角線で反転
+				sx, sy = y, x
+			case 6: // 時計回りに90度回転
+				sx, sy = y, h-1-x
+			case 7: // 右上-左下の対角線で反転
+				sx, sy = w-1-y, h-1-x
+			case 8: // 反時計回りに90度回転
+				sx, sy = w-1-y, x
+			}
+			dst.Set(x, y, img.At(bounds.Min.X+sx, bounds.Min.Y+sy))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/configs"
+	"github.com/wanrun-develop/wanrun/internal/cms/adapters/storage"
+	"github.com/wanrun-develop/wanrun/internal/cms/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+)
+
+// HandleOrphanGC: 孤立したファイルの検出と削除
+// バケットのオブジェクトとs3_file_info、各ドメインからの参照を突き合わせ、猶予期間を過ぎた孤立ファイルを削除する
+// dry-runの場合は検出結果の返却のみ行う
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - bool: dry-runかどうか
+//
+// return:
+//   - dto.OrphanGCRes: 検出結果
+//   - error: error情報
+func (ch *cmsHandler) HandleOrphanGC(c echo.Context, dryRun bool) (dto.OrphanGCRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	graceBefore := time.Now().Add(-time.Duration(configs.FetchConfigInt("cms.gc.grace.hours")) * time.Hour)
+
+	// バケットのオブジェクト一覧
+	objects, wrErr := ch.st.ListObjects(c, fmt.Sprintf("%s/%s/", S3_ROOT_FOLDER, S3_SERVICE_FOLDER))
+	if wrErr != nil {
+		return dto.OrphanGCRes{}, wrErr
+	}
+	objectMap := make(map[string]storage.ObjectInfo, len(objects))
+	for _, object := range objects {
+		objectMap[object.Key] = object
+	}
+
+	// DBに登録済みのファイル情報と参照
+	s3Files, wrErr := ch.cr.FindAllS3FileInfo(c)
+	if wrErr != nil {
+		return dto.OrphanGCRes{}, wrErr
+	}
+	referencedFileIDs, wrErr := ch.cr.FindReferencedFileIDs(c)
+	if wrErr != nil {
+		return dto.OrphanGCRes{}, wrErr
+	}
+
+	referencedFileIDSet := toSet(referencedFileIDs)
+	knownKeySet := make(map[string]struct{}, len(s3Files))
+	for _, s3File := range s3Files {
+		knownKeySet[s3File.S3ObjectKey.String] = struct{}{}
+		for _, variant := range s3File.Variants {
+			knownKeySet[variant.S3ObjectKey.String] = struct{}{}
+		}
+	}
+
+	gcRes := dto.OrphanGCRes{
+		DryRun:         dryRun,
+		GraceBefore:    graceBefore,
+		ScannedObjects: len(objects),
+		Orphans:        []dto.OrphanFileRes{},
+	}
+
+	// どこにも登録されていないオブジェクト
+	for _, object := range objects {
+		if _, ok := knownKeySet[object.Key]; ok || !object.LastModified.Before(graceBefore) {
+			continue
+		}
+		orphan := dto.OrphanFileRes{
+			S3ObjectKey: object.Key,
+			FileSize:    object.Size,
+			UpdatedAt:   object.LastModified,
+			Reason:      dto.ORPHAN_REASON_UNREGISTERED,
+		}
+		if !dryRun {
+			if wrErr := ch.st.DeleteObject(c, object.Key); wrErr != nil {
+				logger.Warnf("孤立したオブジェクトの削除に失敗: %v", object.Key)
+			} else {
+				orphan.Deleted = true
+			}
+		}
+		gcRes.Orphans = append(gcRes.Orphans, orphan)
+	}
+
+	// 登録済みだが、参照されていない画像 or オブジェクトが存在しないもの
+	for _, s3File := range s3Files {
+		if !s3File.CreateAt.Valid || !s3File.CreateAt.Time.Before(graceBefore) {
+			continue
+		}
+
+		reason := ""
+		object, exists := objectMap[s3File.S3ObjectKey.String]
+		if !exists {
+			reason = dto.ORPHAN_REASON_MISSING_OBJECT
+		} else if _, ok := referencedFileIDSet[s3File.FileID.String]; !ok && isReferableFile(s3File) {
+			reason = dto.ORPHAN_REASON_UNREFERENCED
+		}
+		if reason == "" {
+			continue
+		}
+
+		orphan := dto.OrphanFileRes{
+			S3ObjectKey: s3File.S3ObjectKey.String,
+			FileID:      s3File.FileID.String,
+			FileSize:    s3File.FileSize.Int64,
+			UpdatedAt:   s3File.CreateAt.Time,
+			Reason:      reason,
+		}
+		if exists {
+			orphan.UpdatedAt = object.LastModified
+		}
+		if !dryRun {
+			if wrErr := ch.deleteS3File(c, s3File, reason); wrErr != nil {
+				logger.Warnf("孤立したファイルの削除に失敗: %v", s3File.FileID.String)
+			} else {
+				orphan.Deleted = true
+			}
+		}
+		gcRes.Orphans = append(gcRes.Orphans, orphan)
+	}
+
+	for _, orphan := range gcRes.Orphans {
+		if orphan.Deleted {
+			gcRes.DeletedCount++
+		}
+	}
+
+	logger.Infof("Orphan GC finished. dryRun: %v, scanned: %d, orphans: %d, deleted: %d",
+		dryRun, gcRes.ScannedObjects, len(gcRes.Orphans), gcRes.DeletedCount)
+
+	return gcRes, nil
+}
+
+/*
+各ドメインから参照される種類のファイルか
+参照元のカラムがあるのは画像(dog、プロフィール、ギャラリー)と、会員登録・管理申請の添付書類(画像、PDF)のみのため、
+参照元を持たない動画は対象外
+組織の所有ファイルは組織の共有ストレージとして参照元を持たずに保持するため、未参照での削除の対象外
+*/
+func isReferableFile(s3File model.S3FileInfo) bool {
+	if s3File.OwnerType.String == dto.OWNER_TYPE_ORG {
+		return false
+	}
+	return !s3File.ContentType.Valid ||
+		strings.HasPrefix(s3File.ContentType.String, "image/") ||
+		s3File.ContentType.String == "application/pdf"
+}
+
+/*
+スライスをセットに変換
+*/
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/cms/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/cms/adapters/storage"
+	"github.com/wanrun-develop/wanrun/internal/cms/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+	"go.uber.org/zap"
+)
+
+/*
+GCで使用するメソッドのみ差し替えたリポジトリ
+*/
+type gcTestRepository struct {
+	repository.ICmsRepository
+	s3Files           []model.S3FileInfo
+	referencedFileIDs []string
+}
+
+func (r *gcTestRepository) FindAllS3FileInfo(c echo.Context) ([]model.S3FileInfo, error) {
+	return r.s3Files, nil
+}
+
+func (r *gcTestRepository) FindReferencedFileIDs(c echo.Context) ([]string, error) {
+	return r.referencedFileIDs, nil
+}
+
+/*
+GCで使用するメソッドのみ差し替えたストレージ
+*/
+type gcTestStorage struct {
+	storage.IObjectStorage
+	objects []storage.ObjectInfo
+}
+
+func (s *gcTestStorage) ListObjects(c echo.Context, prefix string) ([]storage.ObjectInfo, error) {
+	return s.objects, nil
+}
+
+func newGCTestS3File(fileID string, ownerType string, contentType string, createAt time.Time, variantKeys ...string) model.S3FileInfo {
+	s3File := model.S3FileInfo{
+		FileID:      sql.NullString{String: fileID, Valid: true},
+		S3ObjectKey: sql.NullString{String: "cms/wanrun/" + fileID, Valid: true},
+		ContentType: sql.NullString{String: contentType, Valid: contentType != ""},
+		OwnerType:   sql.NullString{String: ownerType, Valid: true},
+		CreateAt:    util.CustomTime{NullTime: sql.NullTime{Time: createAt, Valid: true}},
+	}
+	for _, key := range variantKeys {
+		s3File.Variants = append(s3File.Variants, model.S3FileVariant{S3ObjectKey: sql.NullString{String: key, Valid: true}})
+	}
+	return s3File
+}
+
+func TestHandleOrphanGC(t *testing.T) {
+	log.SetLogger(zap.NewNop())
+
+	old := time.Now().Add(-7 * 24 * time.Hour)
+	recent := time.Now()
+
+	tests := []struct {
+		name              string
+		objects           []storage.ObjectInfo
+		s3Files           []model.S3FileInfo
+		referencedFileIDs []string
+		want              map[string]string // オブジェクトキー -> 検出理由
+	}{
+		{
+			name: "参照されている画像とサムネイルは対象外",
+			objects: []storage.ObjectInfo{
+				{Key: "cms/wanrun/f1", LastModified: old},
+				{Key: "cms/wanrun/f1_small.jpeg", LastModified: old},
+			},
+			s3Files:           []model.S3FileInfo{newGCTestS3File("f1", dto.OWNER_TYPE_DOGOWNER, "image/jpeg", old, "cms/wanrun/f1_small.jpeg")},
+			referencedFileIDs: []string{"f1"},
+			want:              map[string]string{},
+		},
+		{
+			name: "どこにも登録されていないオブジェクト",
+			objects: []storage.ObjectInfo{
+				{Key: "cms/wanrun/unknown", LastModified: old},
+				{Key: "cms/wanrun/uploading", LastModified: recent},
+			},
+			want: map[string]string{"cms/wanrun/unknown": dto.ORPHAN_REASON_UNREGISTERED},
+		},
+		{
+			name:    "参照されていない画像",
+			objects: []storage.ObjectInfo{{Key: "cms/wanrun/f1", LastModified: old}},
+			s3Files: []model.S3FileInfo{newGCTestS3File("f1", dto.OWNER_TYPE_DOGRUNMG, "image/png", old)},
+			want:    map[string]string{"cms/wanrun/f1": dto.ORPHAN_REASON_UNREFERENCED},
+		},
+		{
+			name:    "猶予期間内の参照されていない画像は対象外",
+			objects: []storage.ObjectInfo{{Key: "cms/wanrun/f1", LastModified: recent}},
+			s3Files: []model.S3FileInfo{newGCTestS3File("f1", dto.OWNER_TYPE_DOGOWNER, "image/png", recent)},
+			want:    map[string]string{},
+		},
+		{
+			name:    "組織の所有ファイルは参照がなくても対象外",
+			objects: []storage.ObjectInfo{{Key: "cms/wanrun/f1", LastModified: old}},
+			s3Files: []model.S3FileInfo{newGCTestS3File("f1", dto.OWNER_TYPE_ORG, "image/png", old)},
+			want:    map[string]string{},
+		},
+		{
+			name:    "参照されていない添付書類",
+			objects: []storage.ObjectInfo{{Key: "cms/wanrun/f1", LastModified: old}},
+			s3Files: []model.S3FileInfo{newGCTestS3File("f1", dto.OWNER_TYPE_DOGOWNER, "application/pdf", old)},
+			want:    map[string]string{"cms/wanrun/f1": dto.ORPHAN_REASON_UNREFERENCED},
+		},
+		{
+			name:              "会員登録から参照されている添付書類",
+			objects:           []storage.ObjectInfo{{Key: "cms/wanrun/f1", LastModified: old}},
+			s3Files:           []model.S3FileInfo{newGCTestS3File("f1", dto.OWNER_TYPE_DOGOWNER, "application/pdf", old)},
+			referencedFileIDs: []string{"f1"},
+			want:              map[string]string{},
+		},
+		{
+			name:    "動画は参照がなくても対象外",
+			objects: []storage.ObjectInfo{{Key: "cms/wanrun/f1", LastModified: old}},
+			s3Files: []model.S3FileInfo{newGCTestS3File("f1", dto.OWNER_TYPE_DOGOWNER, "video/mp4", old)},
+			want:    map[string]string{},
+		},
+		{
+			name:              "オブジェクトが存在しない登録",
+			s3Files:           []model.S3FileInfo{newGCTestS3File("f1", dto.OWNER_TYPE_ORG, "application/pdf", old)},
+			referencedFileIDs: []string{"f1"},
+			want:              map[string]string{"cms/wanrun/f1": dto.ORPHAN_REASON_MISSING_OBJECT},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := &cmsHandler{
+				st: &gcTestStorage{objects: tt.objects},
+				cr: &gcTestRepository{s3Files: tt.s3Files, referencedFileIDs: tt.referencedFileIDs},
+			}
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder())
+
+			gcRes, err := ch.HandleOrphanGC(c, true)
+			if err != nil {
+				t.Fatalf("HandleOrphanGC() error = %v", err)
+			}
+
+			got := map[string]string{}
+			for _, orphan := range gcRes.Orphans {
+				got[orphan.S3ObjectKey] = orphan.Reason
+				if orphan.Deleted {
+					t.Errorf("orphan %q deleted in dry-run", orphan.S3ObjectKey)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("orphans = %v, want %v", got, tt.want)
+			}
+			for key, reason := range tt.want {
+				if got[key] != reason {
+					t.Errorf("orphan %q reason = %q, want %q", key, got[key], reason)
+				}
+			}
+			if gcRes.ScannedObjects != len(tt.objects) || gcRes.DeletedCount != 0 {
+				t.Errorf("scanned = %d, deleted = %d, want %d, 0", gcRes.ScannedObjects, gcRes.DeletedCount, len(tt.objects))
+			}
+		})
+	}
+}
+
+func TestIsReferableFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		ownerType   string
+		contentType string
+		want        bool
+	}{
+		{name: "dogownerの画像", ownerType: dto.OWNER_TYPE_DOGOWNER, contentType: "image/jpeg", want: true},
+		{name: "dogrunmgの画像", ownerType: dto.OWNER_TYPE_DOGRUNMG, contentType: "image/webp", want: true},
+		{name: "種別不明", ownerType: dto.OWNER_TYPE_DOGOWNER, contentType: "", want: true},
+		{name: "動画", ownerType: dto.OWNER_TYPE_DOGOWNER, contentType: "video/mp4", want: false},
+		{name: "添付書類のPDF", ownerType: dto.OWNER_TYPE_DOGRUNMG, contentType: "application/pdf", want: true},
+		{name: "組織の画像", ownerType: dto.OWNER_TYPE_ORG, contentType: "image/png", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s3File := newGCTestS3File("f1", tt.ownerType, tt.contentType, time.Now())
+			if got := isReferableFile(s3File); got != tt.want {
+				t.Errorf("isReferableFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToSet(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   []string
+	}{
+		{name: "空", values: nil, want: []string{}},
+		{name: "重複なし", values: []string{"a", "b"}, want: []string{"a", "b"}},
+		{name: "重複あり", values: []string{"a", "b", "a"}, want: []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := toSet(tt.values)
+			got := []string{}
+			for value := range set {
+				got = append(got, value)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("toSet(%v) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
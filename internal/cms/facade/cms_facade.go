@@ -0,0 +1,135 @@
+package facade
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/configs"
+	"github.com/wanrun-develop/wanrun/internal/cms/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/cms/adapters/storage"
+	"github.com/wanrun-develop/wanrun/internal/cms/core/dto"
+	"github.com/wanrun-develop/wanrun/internal/cms/core/handler"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+)
+
+type ICmsFacade interface {
+	UploadFile(c echo.Context, ownerType string, ownerID int64, fuq dto.FileUploadReq) (string, error)
+	DeleteFile(c echo.Context, fileID string) error
+	CheckFilesOwnedBy(c echo.Context, ownerType string, ownerID int64, fileIDs []string) error
+	PresignFileURL(c echo.Context, fileID string) (dto.FileURLRes, error)
+}
+
+type cmsFacade struct {
+	st storage.IObjectStorage
+	cr repository.ICmsRepository
+	ch handler.ICmsHandler
+}
+
+func NewCmsFacade(st storage.IObjectStorage, cr repository.ICmsRepository, ch handler.ICmsHandler) ICmsFacade {
+	return &cmsFacade{st, cr, ch}
+}
+
+// UploadFile: 他サービスからのファイルのアップロード
+// 画像の判定とEXIFの除去、サムネイルの生成を行い、s3_file_infoに登録する。呼び出し元ではfileIDを保持する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: 所有者の種別
+//   - int64: 所有者のID
+//   - dto.FileUploadReq: アップロードするファイル情報
+//
+// return:
+//   - string: fileID
+//   - error: error情報
+func (cf *cmsFacade) UploadFile(c echo.Context, ownerType string, ownerID int64, fuq dto.FileUploadReq) (string, error) {
+	fuRes, wrErr := cf.ch.UploadOwnedFile(c, ownerType, ownerID, fuq)
+	if wrErr != nil {
+		return "", wrErr
+	}
+	return fuRes.FileID, nil
+}
+
+// DeleteFile: 他サービスからのファイルの削除
+// 参照元の削除にあわせて、オブジェクト、サムネイルとs3_file_infoを削除する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: fileID
+//
+// return:
+//   - error: error情報
+func (cf *cmsFacade) DeleteFile(c echo.Context, fileID string) error {
+	return cf.ch.DeleteFileByID(c, fileID, handler.DELETE_REASON_REFERRER)
+}
+
+// CheckFilesOwnedBy: 指定のファイルが全て指定の所有者のものかのチェック
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: 所有者の種別
+//   - int64: 所有者のID
+//   - []string: fileID
+//
+// return:
+//   - error: 存在しない、または所有者が異なるファイルがある場合はエラー
+func (cf *cmsFacade) CheckFilesOwnedBy(c echo.Context, ownerType string, ownerID int64, fileIDs []string) error {
+	logger := log.GetLogger(c).Sugar()
+
+	for _, fileID := range fileIDs {
+		s3Files, wrErr := cf.cr.GetS3FileInfoByFileID(c, fileID)
+		if wrErr != nil {
+			return wrErr
+		}
+		if len(s3Files) != 1 || s3Files[0].OwnerType.String != ownerType || s3Files[0].OwnerID.Int64 != ownerID {
+			wrErr := wrErrors.NewWRError(
+				nil,
+				fmt.Sprintf("指定されたファイル:%sが存在しないか、所有者ではありません", fileID),
+				wrErrors.NewCmsClientErrorEType(),
+			)
+			logger.Error(wrErr)
+			return wrErr
+		}
+	}
+	return nil
+}
+
+// PresignFileURL: 他サービスからのファイル取得用の署名付きURLの発行
+// 閲覧権限のチェックは呼び出し元で行う
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: fileID
+//
+// return:
+//   - dto.FileURLRes: 署名付きURL
+//   - error: error情報
+func (cf *cmsFacade) PresignFileURL(c echo.Context, fileID string) (dto.FileURLRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	s3Files, wrErr := cf.cr.GetS3FileInfoByFileID(c, fileID)
+	if wrErr != nil {
+		return dto.FileURLRes{}, wrErr
+	}
+	if len(s3Files) != 1 {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"対象のS3File情報が存在しません",
+			wrErrors.NewCmsClientErrorEType(),
+		)
+		logger.Errorf("s3File not found: %v", wrErr)
+		return dto.FileURLRes{}, wrErr
+	}
+
+	expires := time.Duration(configs.FetchConfigInt("cms.presign.expires")) * time.Second
+	url, wrErr := cf.st.PresignGetObject(c, s3Files[0].S3ObjectKey.String, expires)
+	if wrErr != nil {
+		return dto.FileURLRes{}, wrErr
+	}
+
+	return dto.FileURLRes{
+		URL:       url,
+		ExpiresAt: time.Now().Add(expires),
+	}, nil
+}
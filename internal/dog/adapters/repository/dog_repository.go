@@ -1,21 +1,49 @@
 package repository
 
 import (
+	"strings"
+	"time"
+
 	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/dog/core/dto"
 	model "github.com/wanrun-develop/wanrun/internal/models"
 	"github.com/wanrun-develop/wanrun/pkg/errors"
 	"github.com/wanrun-develop/wanrun/pkg/log"
+	"github.com/wanrun-develop/wanrun/pkg/util"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	DOG_SEARCH_MAX_RESULTS = 100 // dog検索の最大取得件数
 )
 
 type IDogRepository interface {
 	GetAllDogs(echo.Context) ([]model.Dog, error)
 	GetDogByID(echo.Context, int64) (model.Dog, error)
 	GetDogByDogOwnerID(echo.Context, int64) ([]model.Dog, error)
+	FindDogsByIDs(echo.Context, []int64) ([]model.Dog, error)
+	FindDogByMicrochipID(echo.Context, string) (model.Dog, error)
+	SearchDogs(echo.Context, dto.DogSearchReq) ([]model.Dog, error)
 	GetDogTypeMst(echo.Context) ([]model.DogTypeMst, error)
+	GetTemperamentMst(echo.Context) ([]model.TemperamentMst, error)
 	CreateDog(echo.Context, model.Dog) (model.Dog, error)
 	UpdateDog(echo.Context, model.Dog) (model.Dog, error)
-	DeleteDog(echo.Context, int64) error
+	DeleteDog(*gorm.DB, echo.Context, int64) error
+	DeleteDogCoOwner(echo.Context, int64, int64) error
+	FindOwnershipInvitationByID(echo.Context, int64) (model.DogOwnershipInvitation, error)
+	FindPendingOwnershipInvitations(echo.Context, int64) ([]model.DogOwnershipInvitation, error)
+	ExistsPendingOwnershipInvitation(echo.Context, int64, int64, string) (bool, error)
+	CreateOwnershipInvitation(echo.Context, *model.DogOwnershipInvitation) error
+	RespondOwnershipInvitation(echo.Context, model.DogOwnershipInvitation) error
+	AcceptOwnershipInvitation(echo.Context, model.DogOwnershipInvitation) error
+	FindDogSocialProfile(echo.Context, int64) (model.DogSocialProfile, error)
+	SaveDogSocialProfile(echo.Context, *model.DogSocialProfile) error
+	FindDogFriendshipByID(echo.Context, int64) (model.DogFriendship, error)
+	FindDogFriendshipBetween(echo.Context, int64, int64) (model.DogFriendship, error)
+	FindDogFriendships(echo.Context, []int64, string) ([]model.DogFriendship, error)
+	SaveDogFriendship(echo.Context, *model.DogFriendship) error
+	DeleteDogFriendship(echo.Context, int64) error
 }
 
 type dogRepository struct {
@@ -30,7 +58,7 @@ func (dr *dogRepository) GetAllDogs(c echo.Context) ([]model.Dog, error) {
 	logger := log.GetLogger(c).Sugar()
 
 	dogs := []model.Dog{}
-	if err := dr.db.Find(&dogs).Error; err != nil {
+	if err := preloadDogRelations(dr.db).Find(&dogs).Error; err != nil {
 		logger.Error(err)
 		err = errors.NewWRError(err, "dogのselectで失敗しました。", errors.NewDogServerErrorEType())
 		return []model.Dog{}, err
@@ -38,7 +66,7 @@ func (dr *dogRepository) GetAllDogs(c echo.Context) ([]model.Dog, error) {
 	return dogs, nil
 }
 
-// GetDogByID: DBへDogIDでdogsのセレクト。dogTypeもロードする
+// GetDogByID: DBへDogIDでdogsのセレクト。dogTypeと性格タグもロードする
 //
 // args:
 //   - int64:	dogId
@@ -50,7 +78,7 @@ func (dr *dogRepository) GetDogByID(c echo.Context, dogID int64) (model.Dog, err
 	logger := log.GetLogger(c).Sugar()
 
 	dog := model.Dog{}
-	if err := dr.db.Where("dog_id=?", dogID).Find(&dog).Error; err != nil {
+	if err := preloadDogRelations(dr.db).Where("dog_id=?", dogID).Find(&dog).Error; err != nil {
 		logger.Error(err)
 		err = errors.NewWRError(err, "dogのselectで失敗しました。", errors.NewDogServerErrorEType())
 		return model.Dog{}, err
@@ -58,7 +86,7 @@ func (dr *dogRepository) GetDogByID(c echo.Context, dogID int64) (model.Dog, err
 	return dog, nil
 }
 
-// GetDogByID: DBへDogOwnerIDでdogsのセレクト。dogTypeもロードする
+// GetDogByID: DBへDogOwnerIDでdogsのセレクト。dogTypeと性格タグもロードする
 //
 // args:
 //   - int:	dogId
@@ -70,7 +98,8 @@ func (dr *dogRepository) GetDogByDogOwnerID(c echo.Context, dogOwnerID int64) ([
 	logger := log.GetLogger(c).Sugar()
 
 	dogs := []model.Dog{}
-	if err := dr.db.Where("dog_owner_id=?", dogOwnerID).Find(&dogs).Error; err != nil {
+	coOwnedDogIDs := dr.db.Model(&model.DogCoOwner{}).Select("dog_id").Where("dog_owner_id = ?", dogOwnerID)
+	if err := preloadDogRelations(dr.db).Where("dog_owner_id=?", dogOwnerID).Or("dog_id IN (?)", coOwnedDogIDs).Order("dog_id").Find(&dogs).Error; err != nil {
 		logger.Error(err)
 		err = errors.NewWRError(err, "dogのselectで失敗しました。", errors.NewDogServerErrorEType())
 		return []model.Dog{}, err
@@ -78,6 +107,127 @@ func (dr *dogRepository) GetDogByDogOwnerID(c echo.Context, dogOwnerID int64) ([
 	return dogs, nil
 }
 
+// FindDogsByIDs: DBへDogIDsでdogsのセレクト。dogTypeと性格タグもロードする
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - []int64:	dogIDs
+//
+// return:
+//   - []model.Dog:	dogデータ
+//   - error:	エラー
+func (dr *dogRepository) FindDogsByIDs(c echo.Context, dogIDs []int64) ([]model.Dog, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogs := []model.Dog{}
+	if err := preloadDogRelations(dr.db).Where("dog_id IN ?", dogIDs).Find(&dogs).Error; err != nil {
+		logger.Error(err)
+		err = errors.NewWRError(err, "dogのselectで失敗しました。", errors.NewDogServerErrorEType())
+		return []model.Dog{}, err
+	}
+	return dogs, nil
+}
+
+// FindDogByMicrochipID: DBへマイクロチップ番号でdogsのセレクト
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - string:	マイクロチップ番号
+//
+// return:
+//   - model.Dog:	dogデータ
+//   - error:	エラー
+func (dr *dogRepository) FindDogByMicrochipID(c echo.Context, microchipID string) (model.Dog, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dog := model.Dog{}
+	if err := dr.db.Where("microchip_id = ?", microchipID).Find(&dog).Error; err != nil {
+		logger.Error(err)
+		err = errors.NewWRError(err, "dogのselectで失敗しました。", errors.NewDogServerErrorEType())
+		return model.Dog{}, err
+	}
+	return dog, nil
+}
+
+// SearchDogs: 条件に一致するdogsのセレクト。dogTypeと性格タグもロードする
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - dto.DogSearchReq:	検索条件
+//
+// return:
+//   - []model.Dog:	dogデータ
+//   - error:	エラー
+func (dr *dogRepository) SearchDogs(c echo.Context, condition dto.DogSearchReq) ([]model.Dog, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	query := preloadDogRelations(dr.db).Model(&model.Dog{})
+
+	// 今日ドッグランにチェックインしているdog
+	if condition.DogrunID != 0 {
+		startOfDay := time.Now().Truncate(24 * time.Hour)
+		endOfDay := startOfDay.Add(24 * time.Hour)
+		query = query.Where("dog_id IN (?)", dr.db.Model(&model.DogrunCheckin{}).
+			Select("dog_id").
+			Where("dogrun_id = ?", condition.DogrunID).
+			Where("checkin_at >= ? AND checkin_at < ?", startOfDay, endOfDay))
+	}
+	// いずれかの犬種を含む
+	if len(condition.DogTypeIDs) > 0 {
+		query = query.Where("dog_id IN (?)", dr.db.Model(&model.DogDogType{}).
+			Select("dog_id").
+			Where("dog_type_id IN ?", condition.DogTypeIDs))
+	}
+	// すべての性格タグを持つ
+	if len(condition.TemperamentIDs) > 0 {
+		query = query.Where("dog_id IN (?)", dr.db.Model(&model.DogTemperament{}).
+			Select("dog_id").
+			Where("temperament_id IN ?", condition.TemperamentIDs).
+			Group("dog_id").
+			Having("COUNT(DISTINCT temperament_id) = ?", len(condition.TemperamentIDs)))
+	}
+	// いずれかのサイズ区分
+	if len(condition.SizeClasses) > 0 {
+		sizeConditions := []string{}
+		for _, sizeClass := range condition.SizeClasses {
+			switch sizeClass {
+			case model.DOG_SIZE_SMALL:
+				sizeConditions = append(sizeConditions, "weight < @medium")
+			case model.DOG_SIZE_MEDIUM:
+				sizeConditions = append(sizeConditions, "(weight >= @medium AND weight < @large)")
+			case model.DOG_SIZE_LARGE:
+				sizeConditions = append(sizeConditions, "weight >= @large")
+			}
+		}
+		query = query.Where(strings.Join(sizeConditions, " OR "), map[string]interface{}{
+			"medium": model.DOG_SIZE_MEDIUM_MIN_WEIGHT,
+			"large":  model.DOG_SIZE_LARGE_MIN_WEIGHT,
+		})
+	}
+	if condition.Sex != "" {
+		query = query.Where("sex = ?", condition.Sex)
+	}
+	if condition.Neutered != nil {
+		query = query.Where("neutered = ?", *condition.Neutered)
+	}
+	// 月齢は誕生日の範囲に変換
+	now := time.Now()
+	if condition.MinAgeMonths != nil {
+		query = query.Where("birth_date <= ?", now.AddDate(0, -*condition.MinAgeMonths, 0))
+	}
+	if condition.MaxAgeMonths != nil {
+		query = query.Where("birth_date > ?", now.AddDate(0, -(*condition.MaxAgeMonths+1), 0))
+	}
+
+	dogs := []model.Dog{}
+	if err := query.Order("dog_id").Limit(DOG_SEARCH_MAX_RESULTS).Find(&dogs).Error; err != nil {
+		logger.Error(err)
+		err = errors.NewWRError(err, "dogの検索で失敗しました。", errors.NewDogServerErrorEType())
+		return []model.Dog{}, err
+	}
+	return dogs, nil
+}
+
 // GetDogTypeMst: dog_type_mstからマスターデータの全権select
 //
 // args:
@@ -98,7 +248,27 @@ func (dr *dogRepository) GetDogTypeMst(c echo.Context) ([]model.DogTypeMst, erro
 	return dogTypeMst, nil
 }
 
-// CreateDog: DBへdogのinsert
+// GetTemperamentMst: temperament_mstからマスターデータの全権select
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - []model.TemperamentMst:	マスターテーブルデータ
+//   - error:	エラー
+func (dr *dogRepository) GetTemperamentMst(c echo.Context) ([]model.TemperamentMst, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	temperamentMst := []model.TemperamentMst{}
+	if err := dr.db.Order("temperament_id").Find(&temperamentMst).Error; err != nil {
+		logger.Error(err)
+		err = errors.NewWRError(err, "temperament_mstのselectで失敗しました。", errors.NewDogServerErrorEType())
+		return []model.TemperamentMst{}, err
+	}
+	return temperamentMst, nil
+}
+
+// CreateDog: DBへdogのinsert。犬種と性格タグも登録する
 //
 // args:
 //   - model.Dog:	登録するdog
@@ -117,7 +287,7 @@ func (dr *dogRepository) CreateDog(c echo.Context, dog model.Dog) (model.Dog, er
 	return dog, nil
 }
 
-// UpdateDog: dogのupdate
+// UpdateDog: dogのupdate。犬種と性格タグは洗い替える
 //
 // args:
 //   - model.Dog:	更新するdog
@@ -128,7 +298,31 @@ func (dr *dogRepository) CreateDog(c echo.Context, dog model.Dog) (model.Dog, er
 func (dr *dogRepository) UpdateDog(c echo.Context, dog model.Dog) (model.Dog, error) {
 	logger := log.GetLogger(c).Sugar()
 
-	if err := dr.db.Save(&dog).Error; err != nil {
+	if err := dr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(&dog).Error; err != nil {
+			return err
+		}
+		if err := deleteDogRelations(tx, dog.DogID.Int64); err != nil {
+			return err
+		}
+		for i := range dog.DogDogTypes {
+			dog.DogDogTypes[i].DogID = dog.DogID
+		}
+		for i := range dog.DogTemperaments {
+			dog.DogTemperaments[i].DogID = dog.DogID
+		}
+		if len(dog.DogDogTypes) > 0 {
+			if err := tx.Create(&dog.DogDogTypes).Error; err != nil {
+				return err
+			}
+		}
+		if len(dog.DogTemperaments) > 0 {
+			if err := tx.Create(&dog.DogTemperaments).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
 		logger.Error(err)
 		err = errors.NewWRError(err, "dogのupdateで失敗しました。", errors.NewDogServerErrorEType())
 		return model.Dog{}, err
@@ -136,14 +330,23 @@ func (dr *dogRepository) UpdateDog(c echo.Context, dog model.Dog) (model.Dog, er
 	return dog, nil
 }
 
-func (dr *dogRepository) DeleteDog(c echo.Context, dogID int64) error {
+// DeleteDog: dogと関連データの削除
+// 呼び出し元のトランザクション内で削除する。ドッグラン関連(予約、会員登録、イベント)からの除外は呼び出し元で行う
+//
+// args:
+//   - *gorm.DB:	トランザクションを張っているtx情報
+//   - echo.Context:	コンテキスト
+//   - int64:	dogID
+//
+// return:
+//   - error:	エラー
+func (dr *dogRepository) DeleteDog(tx *gorm.DB, c echo.Context, dogID int64) error {
 	logger := log.GetLogger(c).Sugar()
 
-	result := dr.db.Where("dog_id=?", dogID).Delete(&model.Dog{})
-
-	if result.Error != nil {
-		logger.Error(result.Error)
-		err := errors.NewWRError(result.Error, "dogのdelete処理で失敗しました。", errors.NewDogServerErrorEType())
+	result, err := deleteDogWithRelations(tx, dogID)
+	if err != nil {
+		logger.Error(err)
+		err := errors.NewWRError(err, "dogのdelete処理で失敗しました。", errors.NewDogServerErrorEType())
 		return err
 	}
 	if result.RowsAffected < 1 {
@@ -153,3 +356,432 @@ func (dr *dogRepository) DeleteDog(c echo.Context, dogID int64) error {
 	}
 	return nil
 }
+
+/*
+dogと関連データの削除
+*/
+func deleteDogWithRelations(tx *gorm.DB, dogID int64) (*gorm.DB, error) {
+	if err := deleteDogRelations(tx, dogID); err != nil {
+		return nil, err
+	}
+	if err := tx.Where("dog_id = ?", dogID).Delete(&model.DogCoOwner{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("dog_id = ?", dogID).Delete(&model.DogOwnershipInvitation{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("dog_id = ?", dogID).Delete(&model.DogSocialProfile{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("requester_dog_id = ? OR addressee_dog_id = ?", dogID, dogID).Delete(&model.DogFriendship{}).Error; err != nil {
+		return nil, err
+	}
+	result := tx.Where("dog_id=?", dogID).Delete(&model.Dog{})
+	return result, result.Error
+}
+
+// DeleteDogCoOwner: 共同飼い主の解除
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogID
+//   - int64:	解除するdogOwnerID
+//
+// return:
+//   - error:	エラー
+func (dr *dogRepository) DeleteDogCoOwner(c echo.Context, dogID int64, dogOwnerID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	result := dr.db.Where("dog_id = ? AND dog_owner_id = ?", dogID, dogOwnerID).Delete(&model.DogCoOwner{})
+	if result.Error != nil {
+		logger.Error(result.Error)
+		return errors.NewWRError(result.Error, "共同飼い主の解除に失敗しました。", errors.NewDogServerErrorEType())
+	}
+	if result.RowsAffected < 1 {
+		err := errors.NewWRError(nil, "指定されたdog ownerは共同飼い主ではありません。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// FindOwnershipInvitationByID: 共同飼い主の招待・譲渡の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogOwnershipInvitationID
+//
+// return:
+//   - model.DogOwnershipInvitation:	招待。存在しない場合は空
+//   - error:	エラー
+func (dr *dogRepository) FindOwnershipInvitationByID(c echo.Context, invitationID int64) (model.DogOwnershipInvitation, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	invitation := model.DogOwnershipInvitation{}
+	if err := preloadOwnershipInvitationRelations(dr.db).
+		Where("dog_ownership_invitation_id = ?", invitationID).
+		Find(&invitation).Error; err != nil {
+		logger.Error(err)
+		return model.DogOwnershipInvitation{}, errors.NewWRError(err, "招待の取得に失敗しました。", errors.NewDogServerErrorEType())
+	}
+	return invitation, nil
+}
+
+// FindPendingOwnershipInvitations: dogOwnerが送受信した承認待ちの招待・譲渡の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogOwnerID
+//
+// return:
+//   - []model.DogOwnershipInvitation:	承認待ちかつ期限内の招待
+//   - error:	エラー
+func (dr *dogRepository) FindPendingOwnershipInvitations(c echo.Context, dogOwnerID int64) ([]model.DogOwnershipInvitation, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	invitations := []model.DogOwnershipInvitation{}
+	if err := preloadOwnershipInvitationRelations(dr.db).
+		Where("inviter_id = ? OR invitee_id = ?", dogOwnerID, dogOwnerID).
+		Where("status = ? AND expires_at > ?", model.OWNERSHIP_INVITATION_STATUS_PENDING, time.Now()).
+		Order("dog_ownership_invitation_id").
+		Find(&invitations).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "招待一覧の取得に失敗しました。", errors.NewDogServerErrorEType())
+	}
+	return invitations, nil
+}
+
+// ExistsPendingOwnershipInvitation: 同じdog、招待先、種別の承認待ちの招待があるか
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogID
+//   - int64:	招待先のdogOwnerID
+//   - string:	招待の種別
+//
+// return:
+//   - bool:	承認待ちの招待があるか
+//   - error:	エラー
+func (dr *dogRepository) ExistsPendingOwnershipInvitation(c echo.Context, dogID int64, inviteeID int64, invitationType string) (bool, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	var count int64
+	if err := dr.db.Model(&model.DogOwnershipInvitation{}).
+		Where("dog_id = ? AND invitee_id = ? AND invitation_type = ?", dogID, inviteeID, invitationType).
+		Where("status = ? AND expires_at > ?", model.OWNERSHIP_INVITATION_STATUS_PENDING, time.Now()).
+		Count(&count).Error; err != nil {
+		logger.Error(err)
+		return false, errors.NewWRError(err, "招待の取得に失敗しました。", errors.NewDogServerErrorEType())
+	}
+	return count > 0, nil
+}
+
+// CreateOwnershipInvitation: 共同飼い主の招待・譲渡の登録
+// 期限切れで承認待ちのまま残っている同じ招待は取消にする
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - *model.DogOwnershipInvitation:	招待
+//
+// return:
+//   - error:	エラー
+func (dr *dogRepository) CreateOwnershipInvitation(c echo.Context, invitation *model.DogOwnershipInvitation) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := dr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.DogOwnershipInvitation{}).
+			Where("dog_id = ? AND invitee_id = ? AND invitation_type = ?", invitation.DogID, invitation.InviteeID, invitation.InvitationType).
+			Where("status = ? AND expires_at <= ?", model.OWNERSHIP_INVITATION_STATUS_PENDING, time.Now()).
+			Update("status", model.OWNERSHIP_INVITATION_STATUS_CANCELED).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(invitation).Error
+	}); err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "招待の登録に失敗しました。", errors.NewDogServerErrorEType())
+	}
+	return nil
+}
+
+// RespondOwnershipInvitation: 招待の辞退・取消。ステータスと回答日時を更新する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.DogOwnershipInvitation:	更新する招待
+//
+// return:
+//   - error:	エラー
+func (dr *dogRepository) RespondOwnershipInvitation(c echo.Context, invitation model.DogOwnershipInvitation) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := dr.db.Model(&model.DogOwnershipInvitation{}).
+		Where("dog_ownership_invitation_id = ?", invitation.DogOwnershipInvitationID).
+		Updates(map[string]any{
+			"status":       invitation.Status,
+			"responded_at": invitation.RespondedAt,
+		}).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "招待の更新に失敗しました。", errors.NewDogServerErrorEType())
+	}
+	return nil
+}
+
+// AcceptOwnershipInvitation: 招待の承認
+// 共同飼い主の招待は共同飼い主に追加し、譲渡は主たる飼い主を変更する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.DogOwnershipInvitation:	承認する招待
+//
+// return:
+//   - error:	エラー
+func (dr *dogRepository) AcceptOwnershipInvitation(c echo.Context, invitation model.DogOwnershipInvitation) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogID := invitation.DogID.Int64
+	inviteeID := invitation.InviteeID.Int64
+	now := time.Now()
+
+	if err := dr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.DogOwnershipInvitation{}).
+			Where("dog_ownership_invitation_id = ?", invitation.DogOwnershipInvitationID).
+			Updates(map[string]any{
+				"status":       model.OWNERSHIP_INVITATION_STATUS_ACCEPTED,
+				"responded_at": now,
+			}).Error; err != nil {
+			return err
+		}
+
+		switch invitation.InvitationType.String {
+		case model.OWNERSHIP_INVITATION_TYPE_CO_OWNER:
+			return createDogCoOwner(tx, dogID, inviteeID)
+
+		case model.OWNERSHIP_INVITATION_TYPE_TRANSFER:
+			if err := tx.Model(&model.Dog{}).Where("dog_id = ?", dogID).Update("dog_owner_id", inviteeID).Error; err != nil {
+				return err
+			}
+			// 新しい飼い主は共同飼い主から外す
+			if err := tx.Where("dog_id = ? AND dog_owner_id = ?", dogID, inviteeID).Delete(&model.DogCoOwner{}).Error; err != nil {
+				return err
+			}
+			if invitation.KeepAsCoOwner.Bool {
+				if err := createDogCoOwner(tx, dogID, invitation.InviterID.Int64); err != nil {
+					return err
+				}
+			}
+			// 元の飼い主が出した承認待ちの譲渡は無効になるため取消にする
+			return tx.Model(&model.DogOwnershipInvitation{}).
+				Where("dog_id = ? AND invitation_type = ? AND status = ?", dogID, model.OWNERSHIP_INVITATION_TYPE_TRANSFER, model.OWNERSHIP_INVITATION_STATUS_PENDING).
+				Updates(map[string]any{
+					"status":       model.OWNERSHIP_INVITATION_STATUS_CANCELED,
+					"responded_at": now,
+				}).Error
+		}
+		return nil
+	}); err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "招待の承認に失敗しました。", errors.NewDogServerErrorEType())
+	}
+	return nil
+}
+
+// FindDogSocialProfile: dogの公開プロフィール設定の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogID
+//
+// return:
+//   - model.DogSocialProfile:	公開設定。未登録の場合は空
+//   - error:	エラー
+func (dr *dogRepository) FindDogSocialProfile(c echo.Context, dogID int64) (model.DogSocialProfile, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	socialProfile := model.DogSocialProfile{}
+	if err := dr.db.Where("dog_id = ?", dogID).Find(&socialProfile).Error; err != nil {
+		logger.Error(err)
+		return model.DogSocialProfile{}, errors.NewWRError(err, "公開プロフィールの取得に失敗しました。", errors.NewDogServerErrorEType())
+	}
+	return socialProfile, nil
+}
+
+// SaveDogSocialProfile: dogの公開プロフィール設定の登録・更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - *model.DogSocialProfile:	公開設定
+//
+// return:
+//   - error:	エラー
+func (dr *dogRepository) SaveDogSocialProfile(c echo.Context, socialProfile *model.DogSocialProfile) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := dr.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "dog_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"bio", "profile_visibility", "checkin_visibility", "allow_friend_requests", "upd_at"}),
+	}).Create(socialProfile).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "公開プロフィールの保存に失敗しました。", errors.NewDogServerErrorEType())
+	}
+	return nil
+}
+
+// FindDogFriendshipByID: 友達申請の取得。両方のdogと飼い主、公開設定もロードする
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogFriendshipID
+//
+// return:
+//   - model.DogFriendship:	友達申請。存在しない場合は空
+//   - error:	エラー
+func (dr *dogRepository) FindDogFriendshipByID(c echo.Context, friendshipID int64) (model.DogFriendship, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	friendship := model.DogFriendship{}
+	if err := preloadDogFriendshipRelations(dr.db).
+		Where("dog_friendship_id = ?", friendshipID).
+		Find(&friendship).Error; err != nil {
+		logger.Error(err)
+		return model.DogFriendship{}, errors.NewWRError(err, "友達申請の取得に失敗しました。", errors.NewDogServerErrorEType())
+	}
+	return friendship, nil
+}
+
+// FindDogFriendshipBetween: 2匹のdogの友達申請の取得(申請の向きは問わない)
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogID
+//   - int64:	相手のdogID
+//
+// return:
+//   - model.DogFriendship:	友達申請。存在しない場合は空
+//   - error:	エラー
+func (dr *dogRepository) FindDogFriendshipBetween(c echo.Context, dogID int64, otherDogID int64) (model.DogFriendship, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	friendship := model.DogFriendship{}
+	if err := dr.db.
+		Where("(requester_dog_id = ? AND addressee_dog_id = ?) OR (requester_dog_id = ? AND addressee_dog_id = ?)", dogID, otherDogID, otherDogID, dogID).
+		Find(&friendship).Error; err != nil {
+		logger.Error(err)
+		return model.DogFriendship{}, errors.NewWRError(err, "友達申請の取得に失敗しました。", errors.NewDogServerErrorEType())
+	}
+	return friendship, nil
+}
+
+// FindDogFriendships: dogの友達申請の一覧(申請の向きは問わない)
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - []int64:	dogIDs
+//   - string:	ステータス
+//
+// return:
+//   - []model.DogFriendship:	友達申請
+//   - error:	エラー
+func (dr *dogRepository) FindDogFriendships(c echo.Context, dogIDs []int64, status string) ([]model.DogFriendship, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	friendships := []model.DogFriendship{}
+	if len(dogIDs) == 0 {
+		return friendships, nil
+	}
+	if err := preloadDogFriendshipRelations(dr.db).
+		Where("requester_dog_id IN ? OR addressee_dog_id IN ?", dogIDs, dogIDs).
+		Where("status = ?", status).
+		Order("dog_friendship_id").
+		Find(&friendships).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "友達一覧の取得に失敗しました。", errors.NewDogServerErrorEType())
+	}
+	return friendships, nil
+}
+
+// SaveDogFriendship: 友達申請の登録・更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - *model.DogFriendship:	友達申請
+//
+// return:
+//   - error:	エラー
+func (dr *dogRepository) SaveDogFriendship(c echo.Context, friendship *model.DogFriendship) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := dr.db.Omit(clause.Associations).Save(friendship).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "友達申請の保存に失敗しました。", errors.NewDogServerErrorEType())
+	}
+	return nil
+}
+
+// DeleteDogFriendship: 友達申請の削除(申請の取消、友達の解除)
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogFriendshipID
+//
+// return:
+//   - error:	エラー
+func (dr *dogRepository) DeleteDogFriendship(c echo.Context, friendshipID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := dr.db.Where("dog_friendship_id = ?", friendshipID).Delete(&model.DogFriendship{}).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "友達申請の削除に失敗しました。", errors.NewDogServerErrorEType())
+	}
+	return nil
+}
+
+/*
+犬種、性格タグと共同飼い主のプリロード
+*/
+func preloadDogRelations(db *gorm.DB) *gorm.DB {
+	return db.Preload("DogDogTypes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("dog_dog_type_id")
+	}).Preload("DogTemperaments", func(db *gorm.DB) *gorm.DB {
+		return db.Order("dog_temperament_id")
+	}).Preload("DogCoOwners", func(db *gorm.DB) *gorm.DB {
+		return db.Order("dog_co_owner_id")
+	}).Preload("DogCoOwners.DogOwner")
+}
+
+/*
+友達申請の両方のdogと、その共同飼い主、公開設定のプリロード
+*/
+func preloadDogFriendshipRelations(db *gorm.DB) *gorm.DB {
+	return db.Preload("RequesterDog.DogCoOwners").Preload("RequesterDog.DogSocialProfile").
+		Preload("AddresseeDog.DogCoOwners").Preload("AddresseeDog.DogSocialProfile")
+}
+
+/*
+招待のdog、招待元、招待先のプリロード
+*/
+func preloadOwnershipInvitationRelations(db *gorm.DB) *gorm.DB {
+	return db.Preload("Dog").Preload("Inviter").Preload("Invitee")
+}
+
+/*
+共同飼い主の登録。登録済みの場合は何もしない
+*/
+func createDogCoOwner(tx *gorm.DB, dogID int64, dogOwnerID int64) error {
+	dogCoOwner := model.DogCoOwner{
+		DogID:      util.NewSqlNullInt64(dogID),
+		DogOwnerID: util.NewSqlNullInt64(dogOwnerID),
+	}
+	return tx.Omit(clause.Associations).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "dog_id"}, {Name: "dog_owner_id"}}, DoNothing: true}).
+		Create(&dogCoOwner).Error
+}
+
+/*
+犬種と性格タグの削除
+*/
+func deleteDogRelations(tx *gorm.DB, dogID int64) error {
+	if err := tx.Where("dog_id = ?", dogID).Delete(&model.DogDogType{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("dog_id = ?", dogID).Delete(&model.DogTemperament{}).Error
+}
@@ -18,17 +18,38 @@ type IDogController interface {
 	GetDogByID(c echo.Context) error
 	GetDogByDogOwnerID(c echo.Context) error
 	GetDogTypeMst(c echo.Context) error
+	GetTemperamentMst(c echo.Context) error
+	SearchDogs(c echo.Context) error
 	CreateDog(c echo.Context) error
 	UpdateDog(c echo.Context) error
 	DeleteDog(c echo.Context) error
+	GetDogOwners(c echo.Context) error
+	InviteCoOwner(c echo.Context) error
+	RequestTransfer(c echo.Context) error
+	RemoveCoOwner(c echo.Context) error
+	GetOwnershipInvitations(c echo.Context) error
+	AcceptOwnershipInvitation(c echo.Context) error
+	DeclineOwnershipInvitation(c echo.Context) error
+	CancelOwnershipInvitation(c echo.Context) error
+	GetDogProfile(c echo.Context) error
+	GetDogProfileSettings(c echo.Context) error
+	SaveDogProfileSettings(c echo.Context) error
+	GetFriends(c echo.Context) error
+	GetFriendRequests(c echo.Context) error
+	RequestFriend(c echo.Context) error
+	AcceptFriendRequest(c echo.Context) error
+	DeclineFriendRequest(c echo.Context) error
+	DeleteFriendship(c echo.Context) error
 }
 
 type dogController struct {
-	h handler.IDogHandler
+	h  handler.IDogHandler
+	oh handler.IDogOwnershipHandler
+	sh handler.IDogSocialHandler
 }
 
-func NewDogController(h handler.IDogHandler) IDogController {
-	return &dogController{h}
+func NewDogController(h handler.IDogHandler, oh handler.IDogOwnershipHandler, sh handler.IDogSocialHandler) IDogController {
+	return &dogController{h, oh, sh}
 }
 
 func (dc *dogController) GetAllDogs(c echo.Context) error {
@@ -113,6 +134,59 @@ func (dc *dogController) GetDogTypeMst(c echo.Context) error {
 	return c.JSON(http.StatusOK, mstRes)
 }
 
+// GetTemperamentMst: 性格タグのマスターデータの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) GetTemperamentMst(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+	logger.Info("TemperamentMst情報の取得開始")
+
+	mstRes, err := dc.h.GetTemperamentMst(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, mstRes)
+}
+
+// SearchDogs: 犬種、サイズ、月齢、性格タグなどの条件で犬を検索
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) SearchDogs(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	//リクエストボディをバインド
+	var searchReq dto.DogSearchReq
+	if err := c.Bind(&searchReq); err != nil {
+		err = errors.NewWRError(err, errors.M_REQUEST_BODY_IS_INVALID, errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	validate := validator.New()
+	// カスタムバリデーションルールの登録
+	_ = validate.RegisterValidation("sex", common.VSex)
+	//リクエストボディのバリデーション
+	if err := validate.Struct(searchReq); err != nil {
+		err = errors.NewWRError(err, errors.M_REQUEST_BODY_VALIDATION_FAILED, errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	resDogs, err := dc.h.SearchDogs(c, searchReq)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resDogs)
+}
+
 // CreateDog: 犬の登録
 // dogIdが指定されていないこと。各フィールドのバリデーション
 // args:
@@ -206,3 +280,399 @@ func (dc *dogController) DeleteDog(c echo.Context) error {
 	}
 	return c.NoContent(http.StatusNoContent)
 }
+
+// GetDogOwners: dogの飼い主と共同飼い主の一覧
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) GetDogOwners(c echo.Context) error {
+	dogID, err := parseIDParam(c, "dogID")
+	if err != nil {
+		return err
+	}
+
+	resOwners, err := dc.oh.GetDogOwners(c, dogID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resOwners)
+}
+
+// InviteCoOwner: 共同飼い主の招待
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) InviteCoOwner(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogID, err := parseIDParam(c, "dogID")
+	if err != nil {
+		return err
+	}
+
+	var inviteReq dto.DogCoOwnerInviteReq
+	if err := c.Bind(&inviteReq); err != nil {
+		err = errors.NewWRError(err, errors.M_REQUEST_BODY_IS_INVALID, errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := validator.New().Struct(inviteReq); err != nil {
+		err = errors.NewWRError(err, errors.M_REQUEST_BODY_VALIDATION_FAILED, errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	resInvitation, err := dc.oh.InviteCoOwner(c, dogID, inviteReq)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resInvitation)
+}
+
+// RequestTransfer: 飼い主の譲渡の申請
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) RequestTransfer(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogID, err := parseIDParam(c, "dogID")
+	if err != nil {
+		return err
+	}
+
+	var transferReq dto.DogTransferReq
+	if err := c.Bind(&transferReq); err != nil {
+		err = errors.NewWRError(err, errors.M_REQUEST_BODY_IS_INVALID, errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := validator.New().Struct(transferReq); err != nil {
+		err = errors.NewWRError(err, errors.M_REQUEST_BODY_VALIDATION_FAILED, errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	resInvitation, err := dc.oh.RequestTransfer(c, dogID, transferReq)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resInvitation)
+}
+
+// RemoveCoOwner: 共同飼い主の解除
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) RemoveCoOwner(c echo.Context) error {
+	dogID, err := parseIDParam(c, "dogID")
+	if err != nil {
+		return err
+	}
+	dogOwnerID, err := parseIDParam(c, "dogOwnerID")
+	if err != nil {
+		return err
+	}
+
+	if err := dc.oh.RemoveCoOwner(c, dogID, dogOwnerID); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetOwnershipInvitations: 送受信した承認待ちの招待・譲渡の一覧
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) GetOwnershipInvitations(c echo.Context) error {
+	resInvitations, err := dc.oh.GetInvitations(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resInvitations)
+}
+
+// AcceptOwnershipInvitation: 招待・譲渡の承認
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) AcceptOwnershipInvitation(c echo.Context) error {
+	invitationID, err := parseIDParam(c, "invitationID")
+	if err != nil {
+		return err
+	}
+
+	if err := dc.oh.AcceptInvitation(c, invitationID); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeclineOwnershipInvitation: 招待・譲渡の辞退
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) DeclineOwnershipInvitation(c echo.Context) error {
+	invitationID, err := parseIDParam(c, "invitationID")
+	if err != nil {
+		return err
+	}
+
+	if err := dc.oh.DeclineInvitation(c, invitationID); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CancelOwnershipInvitation: 招待・譲渡の取消
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) CancelOwnershipInvitation(c echo.Context) error {
+	invitationID, err := parseIDParam(c, "invitationID")
+	if err != nil {
+		return err
+	}
+
+	if err := dc.oh.CancelInvitation(c, invitationID); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetDogProfile: dogの公開プロフィールの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) GetDogProfile(c echo.Context) error {
+	dogID, err := parseIDParam(c, "dogID")
+	if err != nil {
+		return err
+	}
+
+	resProfile, err := dc.sh.GetDogProfile(c, dogID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resProfile)
+}
+
+// GetDogProfileSettings: 公開プロフィール設定の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) GetDogProfileSettings(c echo.Context) error {
+	dogID, err := parseIDParam(c, "dogID")
+	if err != nil {
+		return err
+	}
+
+	resSettings, err := dc.sh.GetDogProfileSettings(c, dogID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resSettings)
+}
+
+// SaveDogProfileSettings: 公開プロフィール設定の保存
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) SaveDogProfileSettings(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogID, err := parseIDParam(c, "dogID")
+	if err != nil {
+		return err
+	}
+
+	var settingsReq dto.DogProfileSettingsReq
+	if err := c.Bind(&settingsReq); err != nil {
+		err = errors.NewWRError(err, errors.M_REQUEST_BODY_IS_INVALID, errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := validator.New().Struct(settingsReq); err != nil {
+		err = errors.NewWRError(err, errors.M_REQUEST_BODY_VALIDATION_FAILED, errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	resSettings, err := dc.sh.SaveDogProfileSettings(c, dogID, settingsReq)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resSettings)
+}
+
+// GetFriends: dogの友達一覧
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) GetFriends(c echo.Context) error {
+	dogID, err := parseIDParam(c, "dogID")
+	if err != nil {
+		return err
+	}
+
+	resFriends, err := dc.sh.GetFriends(c, dogID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resFriends)
+}
+
+// GetFriendRequests: 送受信した承認待ちの友達申請の一覧
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) GetFriendRequests(c echo.Context) error {
+	resRequests, err := dc.sh.GetFriendRequests(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resRequests)
+}
+
+// RequestFriend: 友達申請
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) RequestFriend(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogID, err := parseIDParam(c, "dogID")
+	if err != nil {
+		return err
+	}
+
+	var friendReq dto.DogFriendReq
+	if err := c.Bind(&friendReq); err != nil {
+		err = errors.NewWRError(err, errors.M_REQUEST_BODY_IS_INVALID, errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := validator.New().Struct(friendReq); err != nil {
+		err = errors.NewWRError(err, errors.M_REQUEST_BODY_VALIDATION_FAILED, errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	resRequest, err := dc.sh.RequestFriend(c, dogID, friendReq)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resRequest)
+}
+
+// AcceptFriendRequest: 友達申請の承認
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) AcceptFriendRequest(c echo.Context) error {
+	friendshipID, err := parseIDParam(c, "friendshipID")
+	if err != nil {
+		return err
+	}
+
+	if err := dc.sh.AcceptFriendRequest(c, friendshipID); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeclineFriendRequest: 友達申請の辞退
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) DeclineFriendRequest(c echo.Context) error {
+	friendshipID, err := parseIDParam(c, "friendshipID")
+	if err != nil {
+		return err
+	}
+
+	if err := dc.sh.DeclineFriendRequest(c, friendshipID); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteFriendship: 友達申請の取消、友達の解除
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogController) DeleteFriendship(c echo.Context) error {
+	friendshipID, err := parseIDParam(c, "friendshipID")
+	if err != nil {
+		return err
+	}
+
+	if err := dc.sh.DeleteFriendship(c, friendshipID); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+/*
+パスパラメータのIDの変換
+*/
+func parseIDParam(c echo.Context, name string) (int64, error) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id <= 0 {
+		err = errors.NewWRError(err, errors.M_REQUEST_PARAM_MUST_BE_NATURAL, errors.NewDogClientErrorEType())
+		log.GetLogger(c).Sugar().Error(err)
+		return 0, err
+	}
+	return id, nil
+}
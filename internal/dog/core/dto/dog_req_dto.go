@@ -2,11 +2,51 @@ package dto
 
 // dogのsave用
 type DogSaveReq struct {
-	DogID      int64  `json:"dogId" validate:"primaryKey"`
-	DogOwnerID int64  `json:"dogOwnerId" validate:"required"`
-	Name       string `json:"name" validate:"required"`
-	DogTypeID  int64  `json:"dogTypeId" validate:"required"`
-	Weight     int64  `json:"weight" validate:"required"`
-	Sex        string `json:"sex" validate:"required,sex"`
-	Image      string `json:"image"`
+	DogID          int64   `json:"dogId" validate:"primaryKey"`
+	DogOwnerID     int64   `json:"dogOwnerId" validate:"required"`
+	Name           string  `json:"name" validate:"required"`
+	DogTypeIDs     []int64 `json:"dogTypeIds" validate:"required,min=1,max=3,unique"` // ミックス犬は複数指定
+	Weight         int64   `json:"weight" validate:"required"`
+	Sex            string  `json:"sex" validate:"required,sex"`
+	Image          string  `json:"image"`
+	BirthDate      string  `json:"birthDate" validate:"omitempty,datetime=2006-01-02"`
+	Neutered       *bool   `json:"neutered"`
+	MicrochipID    string  `json:"microchipId" validate:"omitempty,numeric,len=15"`
+	TemperamentIDs []int64 `json:"temperamentIds" validate:"max=10,unique"`
+}
+
+// dogの検索用
+type DogSearchReq struct {
+	DogrunID       int64    `json:"dogrunId"` // 指定時は今日そのドッグランにチェックインしているdogのみ
+	DogTypeIDs     []int64  `json:"dogTypeIds" validate:"max=50,unique"`
+	SizeClasses    []string `json:"sizeClasses" validate:"max=3,unique,dive,oneof=small medium large"`
+	Sex            string   `json:"sex" validate:"omitempty,sex"`
+	Neutered       *bool    `json:"neutered"`
+	TemperamentIDs []int64  `json:"temperamentIds" validate:"max=10,unique"` // すべてを持つdogのみ
+	MinAgeMonths   *int     `json:"minAgeMonths" validate:"omitempty,gte=0"`
+	MaxAgeMonths   *int     `json:"maxAgeMonths" validate:"omitempty,gte=0"`
+}
+
+// 共同飼い主の招待用
+type DogCoOwnerInviteReq struct {
+	Email string `json:"email" validate:"required,email"` // 招待するdog ownerのログイン用email
+}
+
+// 飼い主の譲渡用
+type DogTransferReq struct {
+	Email         string `json:"email" validate:"required,email"` // 譲渡先のdog ownerのログイン用email
+	KeepAsCoOwner bool   `json:"keepAsCoOwner"`                   // 譲渡後も共同飼い主として残るか
+}
+
+// 公開プロフィール設定の保存用
+type DogProfileSettingsReq struct {
+	Bio                 string `json:"bio" validate:"max=500"`
+	ProfileVisibility   string `json:"profileVisibility" validate:"required,oneof=public friends private"`
+	CheckinVisibility   string `json:"checkinVisibility" validate:"required,oneof=friends private"`
+	AllowFriendRequests bool   `json:"allowFriendRequests"`
+}
+
+// 友達申請用
+type DogFriendReq struct {
+	TargetDogID int64 `json:"targetDogId" validate:"required,gt=0"`
 }
@@ -6,25 +6,37 @@ import (
 
 // dog詳細レスポンス
 type DogDetailsRes struct {
-	DogID      int64         `json:"dogId"`
-	DogOwnerID int64         `json:"dogOwnerId"`
-	Name       string        `json:"name"`
-	Weight     int64         `json:"weight"`
-	Sex        string        `json:"sex"`
-	Image      string        `json:"image"`
-	DogTypeId  []int64       `json:"dogTypeId"`
-	CreateAt   common.WRTime `json:"createAt"`
-	UpdateAt   common.WRTime `json:"updateAt"`
+	DogID         int64         `json:"dogId"`
+	DogOwnerID    int64         `json:"dogOwnerId"`
+	Name          string        `json:"name"`
+	Weight        int64         `json:"weight"`
+	SizeClass     string        `json:"sizeClass,omitempty"`
+	Sex           string        `json:"sex"`
+	Image         string        `json:"image"`
+	DogTypeId     []int64       `json:"dogTypeId"`
+	BirthDate     string        `json:"birthDate,omitempty"`
+	AgeMonths     *int          `json:"ageMonths,omitempty"`
+	Neutered      *bool         `json:"neutered,omitempty"`
+	MicrochipID   string        `json:"microchipId,omitempty"`
+	TemperamentId []int64       `json:"temperamentId"`
+	CoOwnerIds    []int64       `json:"coOwnerIds"`
+	CreateAt      common.WRTime `json:"createAt"`
+	UpdateAt      common.WRTime `json:"updateAt"`
 }
 
 // dog一覧用レスポンス
 type DogListRes struct {
-	DogID     int64   `json:"dogId"`
-	Name      string  `json:"name"`
-	Weight    int64   `json:"weight"`
-	Sex       string  `json:"sex"`
-	Image     string  `json:"image"`
-	DogTypeId []int64 `json:"dogTypeId"`
+	DogID         int64   `json:"dogId"`
+	Name          string  `json:"name"`
+	Weight        int64   `json:"weight"`
+	SizeClass     string  `json:"sizeClass,omitempty"`
+	Sex           string  `json:"sex"`
+	Image         string  `json:"image"`
+	DogTypeId     []int64 `json:"dogTypeId"`
+	AgeMonths     *int    `json:"ageMonths,omitempty"`
+	Neutered      *bool   `json:"neutered,omitempty"`
+	TemperamentId []int64 `json:"temperamentId"`
+	Permission    string  `json:"permission,omitempty"` // owner: 主たる飼い主, co_owner: 共同飼い主
 }
 
 // dogType用レスポンス
@@ -32,3 +44,84 @@ type DogTypeMstRes struct {
 	DogTypeID int    `json:"dogTypeId"`
 	Name      string `json:"name"`
 }
+
+// 性格タグ用レスポンス
+type TemperamentMstRes struct {
+	TemperamentID int    `json:"temperamentId"`
+	Name          string `json:"name"`
+	Description   string `json:"description,omitempty"`
+}
+
+// 飼い主、共同飼い主のレスポンス
+type DogCoOwnerRes struct {
+	DogOwnerID int64  `json:"dogOwnerId"`
+	Name       string `json:"name"`
+	Image      string `json:"image"`
+	Permission string `json:"permission"` // owner: 主たる飼い主, co_owner: 共同飼い主
+}
+
+// 共同飼い主の招待・譲渡のレスポンス
+type DogOwnershipInvitationRes struct {
+	InvitationID   int64         `json:"invitationId"`
+	DogID          int64         `json:"dogId"`
+	DogName        string        `json:"dogName"`
+	InviterID      int64         `json:"inviterId"`
+	InviterName    string        `json:"inviterName"`
+	InviteeID      int64         `json:"inviteeId"`
+	InviteeName    string        `json:"inviteeName"`
+	InvitationType string        `json:"invitationType"` // co_owner: 共同飼い主の招待, transfer: 譲渡
+	KeepAsCoOwner  bool          `json:"keepAsCoOwner"`
+	Status         string        `json:"status"`
+	ExpiresAt      common.WRTime `json:"expiresAt"`
+	CreateAt       common.WRTime `json:"createAt"`
+}
+
+// 公開プロフィールのレスポンス
+type DogProfileRes struct {
+	DogID             int64   `json:"dogId"`
+	Name              string  `json:"name"`
+	Image             string  `json:"image"`
+	Sex               string  `json:"sex"`
+	SizeClass         string  `json:"sizeClass,omitempty"`
+	DogTypeId         []int64 `json:"dogTypeId"`
+	AgeMonths         *int    `json:"ageMonths,omitempty"`
+	TemperamentId     []int64 `json:"temperamentId"`
+	Bio               string  `json:"bio,omitempty"`
+	ProfileVisibility string  `json:"profileVisibility"`
+	FriendCount       int     `json:"friendCount"`
+	IsFriend          bool    `json:"isFriend"` // ログインユーザーのdogと友達か
+	IsMine            bool    `json:"isMine"`   // ログインユーザーが飼い主、共同飼い主か
+}
+
+// 公開プロフィール設定のレスポンス
+type DogProfileSettingsRes struct {
+	DogID               int64  `json:"dogId"`
+	Bio                 string `json:"bio"`
+	ProfileVisibility   string `json:"profileVisibility"`
+	CheckinVisibility   string `json:"checkinVisibility"`
+	AllowFriendRequests bool   `json:"allowFriendRequests"`
+}
+
+// dogの概要
+type DogSummaryRes struct {
+	DogID int64  `json:"dogId"`
+	Name  string `json:"name"`
+	Image string `json:"image"`
+}
+
+// 友達のレスポンス
+type DogFriendRes struct {
+	FriendshipID int64         `json:"friendshipId"`
+	DogID        int64         `json:"dogId"` // ログインユーザーのdog
+	Friend       DogSummaryRes `json:"friend"`
+	Since        common.WRTime `json:"since"`
+}
+
+// 友達申請のレスポンス
+type DogFriendRequestRes struct {
+	FriendshipID int64         `json:"friendshipId"`
+	Direction    string        `json:"direction"` // received: 受信, sent: 送信
+	RequesterDog DogSummaryRes `json:"requesterDog"`
+	AddresseeDog DogSummaryRes `json:"addresseeDog"`
+	CreateAt     common.WRTime `json:"createAt"`
+}
@@ -1,14 +1,25 @@
 package handler
 
 import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/labstack/echo/v4"
+	auditDTO "github.com/wanrun-develop/wanrun/internal/audit/core/dto"
+	auditFacade "github.com/wanrun-develop/wanrun/internal/audit/facade"
+	authHandler "github.com/wanrun-develop/wanrun/internal/auth/core/handler"
 	"github.com/wanrun-develop/wanrun/internal/dog/adapters/repository"
 	"github.com/wanrun-develop/wanrun/internal/dog/core/dto"
 	dwRepository "github.com/wanrun-develop/wanrun/internal/dogowner/adapters/repository"
+	dogrunRepository "github.com/wanrun-develop/wanrun/internal/dogrun/adapters/repository"
 	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/transaction"
 	"github.com/wanrun-develop/wanrun/pkg/errors"
 	"github.com/wanrun-develop/wanrun/pkg/log"
 	"github.com/wanrun-develop/wanrun/pkg/util"
+	"gorm.io/gorm"
 )
 
 type IDogHandler interface {
@@ -16,18 +27,33 @@ type IDogHandler interface {
 	GetDogByID(echo.Context, int64) (dto.DogDetailsRes, error)
 	GetDogByDogOwnerID(echo.Context, int64) ([]dto.DogListRes, error)
 	GetDogTypeMst(c echo.Context) ([]dto.DogTypeMstRes, error)
+	GetTemperamentMst(c echo.Context) ([]dto.TemperamentMstRes, error)
+	SearchDogs(echo.Context, dto.DogSearchReq) ([]dto.DogListRes, error)
 	CreateDog(echo.Context, dto.DogSaveReq) (int64, error)
 	UpdateDog(echo.Context, dto.DogSaveReq) (int64, error)
 	DeleteDog(echo.Context, int64) error
 }
 
 type dogHandler struct {
-	r   repository.IDogRepository
-	dwr dwRepository.IDogOwnerRepository
+	r    repository.IDogRepository
+	dwr  dwRepository.IDogOwnerRepository
+	drsr dogrunRepository.IDogrunReservationScopeRepository
+	dmsr dogrunRepository.IDogrunMembershipScopeRepository
+	desr dogrunRepository.IDogrunEventScopeRepository
+	tm   transaction.ITransactionManager
+	auf  auditFacade.IAuditFacade
 }
 
-func NewDogHandler(r repository.IDogRepository, dwr dwRepository.IDogOwnerRepository) IDogHandler {
-	return &dogHandler{r, dwr}
+func NewDogHandler(
+	r repository.IDogRepository,
+	dwr dwRepository.IDogOwnerRepository,
+	drsr dogrunRepository.IDogrunReservationScopeRepository,
+	dmsr dogrunRepository.IDogrunMembershipScopeRepository,
+	desr dogrunRepository.IDogrunEventScopeRepository,
+	tm transaction.ITransactionManager,
+	auf auditFacade.IAuditFacade,
+) IDogHandler {
+	return &dogHandler{r, dwr, drsr, dmsr, desr, tm, auf}
 }
 
 func (h *dogHandler) GetAllDogs(c echo.Context) ([]dto.DogListRes, error) {
@@ -44,15 +70,7 @@ func (h *dogHandler) GetAllDogs(c echo.Context) ([]dto.DogListRes, error) {
 	resDogs := []dto.DogListRes{}
 
 	for _, d := range dogs {
-		dr := dto.DogListRes{
-			DogID:     d.DogID.Int64,
-			Name:      d.Name.String,
-			Weight:    d.Weight.Int64,
-			Sex:       d.Sex.String,
-			Image:     d.Image.String,
-			DogTypeId: []int64{d.DogTypeID.Int64},
-		}
-		resDogs = append(resDogs, dr)
+		resDogs = append(resDogs, convertDogListRes(d))
 	}
 	return resDogs, nil
 }
@@ -75,20 +93,30 @@ func (h *dogHandler) GetDogByID(c echo.Context, dogID int64) (dto.DogDetailsRes,
 	}
 
 	resDog := dto.DogDetailsRes{
-		DogID:      d.DogID.Int64,
-		DogOwnerID: d.DogOwnerID.Int64,
-		Name:       d.Name.String,
-		Weight:     d.Weight.Int64,
-		Sex:        d.Sex.String,
-		Image:      d.Image.String,
-		DogTypeId:  []int64{d.DogTypeID.Int64},
-		CreateAt:   util.ConvertToWRTime(d.CreateAt),
-		UpdateAt:   util.ConvertToWRTime(d.UpdateAt),
+		DogID:         d.DogID.Int64,
+		DogOwnerID:    d.DogOwnerID.Int64,
+		Name:          d.Name.String,
+		Weight:        d.Weight.Int64,
+		SizeClass:     d.SizeClass(),
+		Sex:           d.Sex.String,
+		Image:         d.Image.String,
+		DogTypeId:     d.DogTypeIDs(),
+		AgeMonths:     ageMonthsPtr(d),
+		Neutered:      neuteredPtr(d),
+		MicrochipID:   d.MicrochipID.String,
+		TemperamentId: d.TemperamentIDs(),
+		CoOwnerIds:    d.CoOwnerIDs(),
+		CreateAt:      util.ConvertToWRTime(d.CreateAt),
+		UpdateAt:      util.ConvertToWRTime(d.UpdateAt),
+	}
+	if d.BirthDate.Valid {
+		resDog.BirthDate = d.BirthDate.Time.Format(time.DateOnly)
 	}
 	return resDog, nil
 }
 
 // GetDogByDogOwnerID: dogの詳細を検索して返す
+// 共同飼い主になっているdogも含む
 //
 // args:
 //   - echo.Context:	コンテキスト
@@ -125,15 +153,9 @@ func (h *dogHandler) GetDogByDogOwnerID(c echo.Context, dogOwnerID int64) ([]dto
 	resDogs := []dto.DogListRes{}
 
 	for _, d := range dogs {
-		dr := dto.DogListRes{
-			DogID:     d.DogID.Int64,
-			Name:      d.Name.String,
-			Weight:    d.Weight.Int64,
-			Sex:       d.Sex.String,
-			Image:     d.Image.String,
-			DogTypeId: []int64{d.DogTypeID.Int64},
-		}
-		resDogs = append(resDogs, dr)
+		resDog := convertDogListRes(d)
+		resDog.Permission = d.PermissionOf(dogOwner.DogOwnerID.Int64)
+		resDogs = append(resDogs, resDog)
 	}
 
 	return resDogs, nil
@@ -165,6 +187,63 @@ func (h *dogHandler) GetDogTypeMst(c echo.Context) ([]dto.DogTypeMstRes, error)
 	return mstRes, nil
 }
 
+// GetTemperamentMst: 性格タグのマスター情報の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - []dto.TemperamentMstRes:	マスター情報
+//   - error:	エラー
+func (h *dogHandler) GetTemperamentMst(c echo.Context) ([]dto.TemperamentMstRes, error) {
+	temperamentMst, err := h.r.GetTemperamentMst(c)
+	if err != nil {
+		return []dto.TemperamentMstRes{}, err
+	}
+	mstRes := []dto.TemperamentMstRes{}
+
+	for _, m := range temperamentMst {
+		mst := dto.TemperamentMstRes{
+			TemperamentID: m.TemperamentID,
+			Name:          m.Name,
+			Description:   m.Description.String,
+		}
+		mstRes = append(mstRes, mst)
+	}
+
+	return mstRes, nil
+}
+
+// SearchDogs: 犬種、サイズ、月齢、性格タグなどの条件でdogを検索
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - dto.DogSearchReq:	検索条件
+//
+// return:
+//   - []dto.DogListRes:	dogの一覧レスポンス
+//   - error:	エラー
+func (h *dogHandler) SearchDogs(c echo.Context, searchReq dto.DogSearchReq) ([]dto.DogListRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if searchReq.MinAgeMonths != nil && searchReq.MaxAgeMonths != nil && *searchReq.MinAgeMonths > *searchReq.MaxAgeMonths {
+		err := errors.NewWRError(nil, "月齢の範囲指定が不正です。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return []dto.DogListRes{}, err
+	}
+
+	dogs, err := h.r.SearchDogs(c, searchReq)
+	if err != nil {
+		return []dto.DogListRes{}, err
+	}
+
+	resDogs := []dto.DogListRes{}
+	for _, d := range dogs {
+		resDogs = append(resDogs, convertDogListRes(d))
+	}
+	return resDogs, nil
+}
+
 // CreateDog: 犬の登録
 //
 //	dogownerの存在チェック
@@ -187,13 +266,16 @@ func (h *dogHandler) CreateDog(c echo.Context, saveReq dto.DogSaveReq) (int64, e
 		return 0, err
 	}
 
+	//犬種、性格タグ、マイクロチップのチェック
+	if err := h.validateDogProfile(c, saveReq); err != nil {
+		return 0, err
+	}
+
 	dog := model.Dog{
 		DogOwnerID: util.NewSqlNullInt64(dogOwnerID),
-		Name:       util.NewSqlNullString(saveReq.Name),
-		DogTypeID:  util.NewSqlNullInt64(saveReq.DogTypeID),
-		Weight:     util.NewSqlNullInt64(saveReq.Weight),
-		Sex:        util.NewSqlNullString(saveReq.Sex),
-		Image:      util.NewSqlNullString(saveReq.Image),
+	}
+	if err := setDogSaveReq(c, &dog, saveReq); err != nil {
+		return 0, err
 	}
 
 	dog, err := h.r.CreateDog(c, dog)
@@ -230,21 +312,27 @@ func (h *dogHandler) UpdateDog(c echo.Context, saveReq dto.DogSaveReq) (int64, e
 		return 0, err
 	}
 
-	//dogownerが変わっていれば存在チェック
+	//飼い主、共同飼い主のみ更新可能
+	if err = checkDogPermission(c, dog, false); err != nil {
+		return 0, err
+	}
+
+	//飼い主の変更は譲渡で行う
 	if saveReq.DogOwnerID != dog.DogOwnerID.Int64 {
-		dogOwnerID := saveReq.DogOwnerID
-		if err = h.isExistsDogOwner(c, dogOwnerID); err != nil {
-			return 0, err
-		}
+		err = errors.NewWRError(nil, "飼い主の変更は譲渡の申請から行ってください。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return 0, err
+	}
+
+	//犬種、性格タグ、マイクロチップのチェック
+	if err = h.validateDogProfile(c, saveReq); err != nil {
+		return 0, err
 	}
 
 	//更新値をつめる
-	dog.DogOwnerID = util.NewSqlNullInt64(saveReq.DogOwnerID)
-	dog.Name = util.NewSqlNullString(saveReq.Name)
-	dog.DogTypeID = util.NewSqlNullInt64(saveReq.DogTypeID)
-	dog.Weight = util.NewSqlNullInt64(saveReq.Weight)
-	dog.Sex = util.NewSqlNullString(saveReq.Sex)
-	dog.Image = util.NewSqlNullString(saveReq.Image)
+	if err = setDogSaveReq(c, &dog, saveReq); err != nil {
+		return 0, err
+	}
 	//更新
 	dog, err = h.r.UpdateDog(c, dog)
 	if err != nil {
@@ -257,13 +345,47 @@ func (h *dogHandler) UpdateDog(c echo.Context, saveReq dto.DogSaveReq) (int64, e
 }
 
 func (h *dogHandler) DeleteDog(c echo.Context, dogID int64) error {
-	if _, err := h.isExistsDog(c, dogID); err != nil {
+	dog, err := h.isExistsDog(c, dogID)
+	if err != nil {
 		return err
 	}
-	if err := h.r.DeleteDog(c, dogID); err != nil {
+	//主たる飼い主のみ削除可能
+	if err := checkDogPermission(c, dog, true); err != nil {
 		return err
 	}
-	return nil
+
+	// ドッグラン関連からの除外、削除、監査ログの記録を1トランザクションで行う
+	actorID, actorRole := authHandler.LoginActor(c)
+	return h.tm.DoInTransaction(c, c.Request().Context(), func(tx *gorm.DB) error {
+		if err := h.desr.DetachDog(tx, c, dogID); err != nil {
+			return err
+		}
+		if err := h.drsr.DetachDog(tx, c, dogID); err != nil {
+			return err
+		}
+		if err := h.dmsr.DetachDog(tx, c, dogID); err != nil {
+			return err
+		}
+		if err := h.r.DeleteDog(tx, c, dogID); err != nil {
+			return err
+		}
+		return h.auf.Record(tx, c, auditDTO.AuditEntry{
+			ActorID:    actorID,
+			ActorRole:  actorRole,
+			Action:     model.AUDIT_ACTION_DELETE_DOG,
+			Result:     model.AUDIT_RESULT_SUCCESS,
+			TargetType: model.AUDIT_TARGET_DOG,
+			TargetID:   strconv.FormatInt(dogID, 10),
+			Before: map[string]any{
+				"dogOwnerId": dog.DogOwnerID.Int64,
+				"name":       dog.Name.String,
+				"weight":     dog.Weight.Int64,
+				"sex":        dog.Sex.String,
+				"image":      dog.Image.String,
+				"birthDate":  dog.BirthDate.Time,
+			},
+		})
+	})
 }
 
 // isExistsDog: dogの存在チェック
@@ -315,3 +437,142 @@ func (h *dogHandler) isExistsDogOwner(c echo.Context, dogOwnerID int64) error {
 	}
 	return nil
 }
+
+// validateDogProfile: 犬種、性格タグの存在チェックとマイクロチップ番号の重複チェック
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - dto.DogSaveReq:	リクエスト内容
+//
+// return:
+//   - error:	エラー
+func (h *dogHandler) validateDogProfile(c echo.Context, saveReq dto.DogSaveReq) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogTypeMst, err := h.r.GetDogTypeMst(c)
+	if err != nil {
+		return err
+	}
+	dogTypeMap := make(map[int64]struct{})
+	for _, m := range dogTypeMst {
+		dogTypeMap[int64(m.DogTypeID)] = struct{}{}
+	}
+	for _, dogTypeID := range saveReq.DogTypeIDs {
+		if _, exists := dogTypeMap[dogTypeID]; !exists {
+			err := errors.NewWRError(nil, fmt.Sprintf("指定された犬種ID:%dは存在しません。", dogTypeID), errors.NewDogClientErrorEType())
+			logger.Error(err)
+			return err
+		}
+	}
+
+	temperamentMst, err := h.r.GetTemperamentMst(c)
+	if err != nil {
+		return err
+	}
+	temperamentMap := make(map[int64]struct{})
+	for _, m := range temperamentMst {
+		temperamentMap[int64(m.TemperamentID)] = struct{}{}
+	}
+	for _, temperamentID := range saveReq.TemperamentIDs {
+		if _, exists := temperamentMap[temperamentID]; !exists {
+			err := errors.NewWRError(nil, fmt.Sprintf("指定された性格タグID:%dは存在しません。", temperamentID), errors.NewDogClientErrorEType())
+			logger.Error(err)
+			return err
+		}
+	}
+
+	if saveReq.MicrochipID != "" {
+		dog, err := h.r.FindDogByMicrochipID(c, saveReq.MicrochipID)
+		if err != nil {
+			return err
+		}
+		if !dog.IsEmpty() && dog.DogID.Int64 != saveReq.DogID {
+			err := errors.NewWRError(nil, "指定されたマイクロチップ番号はすでに登録されています。", errors.NewDogClientErrorEType())
+			logger.Error(err)
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+リクエスト内容をdogにつめる
+*/
+func setDogSaveReq(c echo.Context, dog *model.Dog, saveReq dto.DogSaveReq) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dog.Name = util.NewSqlNullString(saveReq.Name)
+	dog.Weight = util.NewSqlNullInt64(saveReq.Weight)
+	dog.Sex = util.NewSqlNullString(saveReq.Sex)
+	dog.Image = util.NewSqlNullString(saveReq.Image)
+	dog.MicrochipID = util.NewSqlNullString(saveReq.MicrochipID)
+
+	dog.BirthDate = sql.NullTime{}
+	if saveReq.BirthDate != "" {
+		birthDate, err := time.ParseInLocation(time.DateOnly, saveReq.BirthDate, time.Local)
+		if err != nil || birthDate.After(time.Now()) {
+			err := errors.NewWRError(err, "誕生日が不正です。", errors.NewDogClientErrorEType())
+			logger.Error(err)
+			return err
+		}
+		dog.BirthDate = sql.NullTime{Time: birthDate, Valid: true}
+	}
+
+	dog.Neutered = sql.NullBool{}
+	if saveReq.Neutered != nil {
+		dog.Neutered = sql.NullBool{Bool: *saveReq.Neutered, Valid: true}
+	}
+
+	dog.DogDogTypes = []model.DogDogType{}
+	for _, dogTypeID := range saveReq.DogTypeIDs {
+		dog.DogDogTypes = append(dog.DogDogTypes, model.DogDogType{
+			DogTypeID: util.NewSqlNullInt64(dogTypeID),
+		})
+	}
+	dog.DogTemperaments = []model.DogTemperament{}
+	for _, temperamentID := range saveReq.TemperamentIDs {
+		dog.DogTemperaments = append(dog.DogTemperaments, model.DogTemperament{
+			TemperamentID: util.NewSqlNullInt64(temperamentID),
+		})
+	}
+	return nil
+}
+
+/*
+dog一覧レスポンスへの変換
+*/
+func convertDogListRes(d model.Dog) dto.DogListRes {
+	return dto.DogListRes{
+		DogID:         d.DogID.Int64,
+		Name:          d.Name.String,
+		Weight:        d.Weight.Int64,
+		SizeClass:     d.SizeClass(),
+		Sex:           d.Sex.String,
+		Image:         d.Image.String,
+		DogTypeId:     d.DogTypeIDs(),
+		AgeMonths:     ageMonthsPtr(d),
+		Neutered:      neuteredPtr(d),
+		TemperamentId: d.TemperamentIDs(),
+	}
+}
+
+/*
+月齢。誕生日未登録の場合はnil
+*/
+func ageMonthsPtr(d model.Dog) *int {
+	ageMonths, ok := d.AgeMonths(time.Now())
+	if !ok {
+		return nil
+	}
+	return &ageMonths
+}
+
+/*
+去勢・避妊済みか。未登録の場合はnil
+*/
+func neuteredPtr(d model.Dog) *bool {
+	if !d.Neutered.Valid {
+		return nil
+	}
+	return &d.Neutered.Bool
+}
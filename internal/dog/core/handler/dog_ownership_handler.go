@@ -0,0 +1,493 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/auth/core"
+	"github.com/wanrun-develop/wanrun/internal/dog/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/dog/core/dto"
+	dwRepository "github.com/wanrun-develop/wanrun/internal/dogowner/adapters/repository"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/wrcontext"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+)
+
+const (
+	OWNERSHIP_INVITATION_EXPIRE_DAYS = 7 //共同飼い主の招待・譲渡の承認期限(日)
+	DOG_CO_OWNER_MAX_COUNT           = 5 //dogごとの共同飼い主の上限数
+)
+
+type IDogOwnershipHandler interface {
+	GetDogOwners(echo.Context, int64) ([]dto.DogCoOwnerRes, error)
+	InviteCoOwner(echo.Context, int64, dto.DogCoOwnerInviteReq) (dto.DogOwnershipInvitationRes, error)
+	RequestTransfer(echo.Context, int64, dto.DogTransferReq) (dto.DogOwnershipInvitationRes, error)
+	RemoveCoOwner(echo.Context, int64, int64) error
+	GetInvitations(echo.Context) ([]dto.DogOwnershipInvitationRes, error)
+	AcceptInvitation(echo.Context, int64) error
+	DeclineInvitation(echo.Context, int64) error
+	CancelInvitation(echo.Context, int64) error
+}
+
+type dogOwnershipHandler struct {
+	r   repository.IDogRepository
+	dwr dwRepository.IDogOwnerRepository
+}
+
+func NewDogOwnershipHandler(r repository.IDogRepository, dwr dwRepository.IDogOwnerRepository) IDogOwnershipHandler {
+	return &dogOwnershipHandler{r, dwr}
+}
+
+// GetDogOwners: dogの飼い主と共同飼い主の一覧
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogID
+//
+// return:
+//   - []dto.DogCoOwnerRes:	飼い主(先頭)と共同飼い主
+//   - error:	エラー
+func (h *dogOwnershipHandler) GetDogOwners(c echo.Context, dogID int64) ([]dto.DogCoOwnerRes, error) {
+	dog, err := findManageableDog(c, h.r, dogID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := h.dwr.GetDogOwnerById(dog.DogOwnerID.Int64)
+	if err != nil {
+		err = errors.NewWRError(err, "dogOwner検索で失敗しました。", errors.NewDogServerErrorEType())
+		log.GetLogger(c).Sugar().Error(err)
+		return nil, err
+	}
+
+	resOwners := []dto.DogCoOwnerRes{convertDogCoOwnerRes(owner, model.DOG_PERMISSION_OWNER)}
+	for _, dogCoOwner := range dog.DogCoOwners {
+		resOwners = append(resOwners, convertDogCoOwnerRes(dogCoOwner.DogOwner, model.DOG_PERMISSION_CO_OWNER))
+	}
+	return resOwners, nil
+}
+
+// InviteCoOwner: 共同飼い主の招待
+// 主たる飼い主のみ招待可能。招待されたdog ownerの承認で共同飼い主になる
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogID
+//   - dto.DogCoOwnerInviteReq:	リクエスト内容
+//
+// return:
+//   - dto.DogOwnershipInvitationRes:	登録した招待
+//   - error:	エラー
+func (h *dogOwnershipHandler) InviteCoOwner(c echo.Context, dogID int64, req dto.DogCoOwnerInviteReq) (dto.DogOwnershipInvitationRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dog, err := findManageableDog(c, h.r, dogID, true)
+	if err != nil {
+		return dto.DogOwnershipInvitationRes{}, err
+	}
+	if len(dog.DogCoOwners) >= DOG_CO_OWNER_MAX_COUNT {
+		err := errors.NewWRError(nil, fmt.Sprintf("共同飼い主は%d人までです。", DOG_CO_OWNER_MAX_COUNT), errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return dto.DogOwnershipInvitationRes{}, err
+	}
+
+	invitee, err := h.findInvitee(c, dog, req.Email)
+	if err != nil {
+		return dto.DogOwnershipInvitationRes{}, err
+	}
+	if dog.PermissionOf(invitee.DogOwnerID.Int64) == model.DOG_PERMISSION_CO_OWNER {
+		err := errors.NewWRError(nil, "指定されたdog ownerはすでに共同飼い主です。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return dto.DogOwnershipInvitationRes{}, err
+	}
+
+	return h.createInvitation(c, dog, invitee, model.OWNERSHIP_INVITATION_TYPE_CO_OWNER, false)
+}
+
+// RequestTransfer: 主たる飼い主の譲渡の申請
+// 主たる飼い主のみ申請可能。譲渡先のdog ownerの承認で主たる飼い主が変わる
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogID
+//   - dto.DogTransferReq:	リクエスト内容
+//
+// return:
+//   - dto.DogOwnershipInvitationRes:	登録した譲渡の申請
+//   - error:	エラー
+func (h *dogOwnershipHandler) RequestTransfer(c echo.Context, dogID int64, req dto.DogTransferReq) (dto.DogOwnershipInvitationRes, error) {
+	dog, err := findManageableDog(c, h.r, dogID, true)
+	if err != nil {
+		return dto.DogOwnershipInvitationRes{}, err
+	}
+
+	invitee, err := h.findInvitee(c, dog, req.Email)
+	if err != nil {
+		return dto.DogOwnershipInvitationRes{}, err
+	}
+
+	return h.createInvitation(c, dog, invitee, model.OWNERSHIP_INVITATION_TYPE_TRANSFER, req.KeepAsCoOwner)
+}
+
+// RemoveCoOwner: 共同飼い主の解除
+// 主たる飼い主は全員を、共同飼い主は自分のみ解除可能
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogID
+//   - int64:	解除するdogOwnerID
+//
+// return:
+//   - error:	エラー
+func (h *dogOwnershipHandler) RemoveCoOwner(c echo.Context, dogID int64, dogOwnerID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dog, err := findManageableDog(c, h.r, dogID, false)
+	if err != nil {
+		return err
+	}
+
+	permission, err := loginDogPermission(c, dog)
+	if err != nil {
+		return err
+	}
+	if permission == model.DOG_PERMISSION_CO_OWNER {
+		userID, err := wrcontext.GetLoginUserID(c)
+		if err != nil {
+			return err
+		}
+		if userID != dogOwnerID {
+			err := errors.NewWRError(nil, "共同飼い主は自分以外を解除できません。", errors.NewDogClientErrorEType())
+			logger.Error(err)
+			return err
+		}
+	}
+
+	return h.r.DeleteDogCoOwner(c, dogID, dogOwnerID)
+}
+
+// GetInvitations: ログインユーザーが送受信した承認待ちの招待・譲渡の一覧
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - []dto.DogOwnershipInvitationRes:	招待の一覧
+//   - error:	エラー
+func (h *dogOwnershipHandler) GetInvitations(c echo.Context) ([]dto.DogOwnershipInvitationRes, error) {
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := h.r.FindPendingOwnershipInvitations(c, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resInvitations := []dto.DogOwnershipInvitationRes{}
+	for _, invitation := range invitations {
+		resInvitations = append(resInvitations, convertDogOwnershipInvitationRes(invitation))
+	}
+	return resInvitations, nil
+}
+
+// AcceptInvitation: 招待・譲渡の承認
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	招待ID
+//
+// return:
+//   - error:	エラー
+func (h *dogOwnershipHandler) AcceptInvitation(c echo.Context, invitationID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	invitation, err := h.findPendingInvitation(c, invitationID, false)
+	if err != nil {
+		return err
+	}
+
+	// 申請後に飼い主が変わっていれば、招待元の権限がないため承認できない
+	if invitation.Dog.DogOwnerID.Int64 != invitation.InviterID.Int64 {
+		err := errors.NewWRError(nil, "招待したdog ownerはすでに飼い主ではありません。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	return h.r.AcceptOwnershipInvitation(c, invitation)
+}
+
+// DeclineInvitation: 招待・譲渡の辞退
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	招待ID
+//
+// return:
+//   - error:	エラー
+func (h *dogOwnershipHandler) DeclineInvitation(c echo.Context, invitationID int64) error {
+	invitation, err := h.findPendingInvitation(c, invitationID, false)
+	if err != nil {
+		return err
+	}
+
+	invitation.Status = util.NewSqlNullString(model.OWNERSHIP_INVITATION_STATUS_DECLINED)
+	invitation.RespondedAt = util.NewSqlNullTime(time.Now())
+	return h.r.RespondOwnershipInvitation(c, invitation)
+}
+
+// CancelInvitation: 招待・譲渡の取消
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	招待ID
+//
+// return:
+//   - error:	エラー
+func (h *dogOwnershipHandler) CancelInvitation(c echo.Context, invitationID int64) error {
+	invitation, err := h.findPendingInvitation(c, invitationID, true)
+	if err != nil {
+		return err
+	}
+
+	invitation.Status = util.NewSqlNullString(model.OWNERSHIP_INVITATION_STATUS_CANCELED)
+	invitation.RespondedAt = util.NewSqlNullTime(time.Now())
+	return h.r.RespondOwnershipInvitation(c, invitation)
+}
+
+// findInvitee: 招待先のdog ownerの取得
+// 存在しない場合と、自分自身の場合はエラー
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.Dog:	対象のdog
+//   - string:	招待先のemail
+//
+// return:
+//   - model.DogOwner:	招待先のdog owner
+//   - error:	エラー
+func (h *dogOwnershipHandler) findInvitee(c echo.Context, dog model.Dog, email string) (model.DogOwner, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	invitee, err := h.dwr.GetDogOwnerByEmail(email)
+	if err != nil {
+		err = errors.NewWRError(err, "dogOwner検索で失敗しました。", errors.NewDogServerErrorEType())
+		logger.Error(err)
+		return model.DogOwner{}, err
+	}
+	if invitee.IsEmpty() {
+		err := errors.NewWRError(nil, "指定されたemailのdog ownerは存在しません。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return model.DogOwner{}, err
+	}
+	if dog.PermissionOf(invitee.DogOwnerID.Int64) == model.DOG_PERMISSION_OWNER {
+		err := errors.NewWRError(nil, "自分自身は招待できません。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return model.DogOwner{}, err
+	}
+	return invitee, nil
+}
+
+// createInvitation: 招待・譲渡の登録
+// 同じ招待先、種別の承認待ちがある場合はエラー
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.Dog:	対象のdog
+//   - model.DogOwner:	招待先のdog owner
+//   - string:	招待の種別
+//   - bool:	譲渡後に共同飼い主として残るか
+//
+// return:
+//   - dto.DogOwnershipInvitationRes:	登録した招待
+//   - error:	エラー
+func (h *dogOwnershipHandler) createInvitation(c echo.Context, dog model.Dog, invitee model.DogOwner, invitationType string, keepAsCoOwner bool) (dto.DogOwnershipInvitationRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	exists, err := h.r.ExistsPendingOwnershipInvitation(c, dog.DogID.Int64, invitee.DogOwnerID.Int64, invitationType)
+	if err != nil {
+		return dto.DogOwnershipInvitationRes{}, err
+	}
+	if exists {
+		err := errors.NewWRError(nil, "承認待ちの招待がすでにあります。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return dto.DogOwnershipInvitationRes{}, err
+	}
+
+	invitation := model.DogOwnershipInvitation{
+		DogID:          dog.DogID,
+		InviterID:      dog.DogOwnerID,
+		InviteeID:      invitee.DogOwnerID,
+		InvitationType: util.NewSqlNullString(invitationType),
+		KeepAsCoOwner:  util.NewSqlNullBool(keepAsCoOwner),
+		Status:         util.NewSqlNullString(model.OWNERSHIP_INVITATION_STATUS_PENDING),
+		ExpiresAt:      util.NewSqlNullTime(time.Now().AddDate(0, 0, OWNERSHIP_INVITATION_EXPIRE_DAYS)),
+	}
+	if err := h.r.CreateOwnershipInvitation(c, &invitation); err != nil {
+		return dto.DogOwnershipInvitationRes{}, err
+	}
+
+	inviter, err := h.dwr.GetDogOwnerById(dog.DogOwnerID.Int64)
+	if err != nil {
+		err = errors.NewWRError(err, "dogOwner検索で失敗しました。", errors.NewDogServerErrorEType())
+		logger.Error(err)
+		return dto.DogOwnershipInvitationRes{}, err
+	}
+	invitation.Dog = dog
+	invitation.Inviter = inviter
+	invitation.Invitee = invitee
+	return convertDogOwnershipInvitationRes(invitation), nil
+}
+
+// findPendingInvitation: 承認待ちかつ期限内の招待の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	招待ID
+//   - bool:	招待元として操作するか(falseの場合は招待先として操作する)
+//
+// return:
+//   - model.DogOwnershipInvitation:	招待
+//   - error:	エラー
+func (h *dogOwnershipHandler) findPendingInvitation(c echo.Context, invitationID int64, asInviter bool) (model.DogOwnershipInvitation, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return model.DogOwnershipInvitation{}, err
+	}
+
+	invitation, err := h.r.FindOwnershipInvitationByID(c, invitationID)
+	if err != nil {
+		return model.DogOwnershipInvitation{}, err
+	}
+
+	operatorID := invitation.InviteeID.Int64
+	if asInviter {
+		operatorID = invitation.InviterID.Int64
+	}
+	if invitation.IsEmpty() || operatorID != userID {
+		err := errors.NewWRError(nil, "指定された招待は存在しません。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return model.DogOwnershipInvitation{}, err
+	}
+	if !invitation.IsPending(time.Now()) {
+		err := errors.NewWRError(nil, "承認待ちの招待ではないか、期限が切れています。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return model.DogOwnershipInvitation{}, err
+	}
+	return invitation, nil
+}
+
+// findManageableDog: ログインユーザーが管理できるdogの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - repository.IDogRepository:	dogリポジトリ
+//   - int64:	dogID
+//   - bool:	主たる飼い主のみ許可するか
+//
+// return:
+//   - model.Dog:	dog
+//   - error:	エラー
+func findManageableDog(c echo.Context, r repository.IDogRepository, dogID int64, ownerOnly bool) (model.Dog, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dog, err := r.GetDogByID(c, dogID)
+	if err != nil {
+		return model.Dog{}, err
+	}
+	if dog.IsEmpty() {
+		err := errors.NewWRError(nil, "指定されたdogは存在しません。", errors.NewDogClientErrorEType())
+		logger.Error("不正なdog idの指定", err)
+		return model.Dog{}, err
+	}
+	if err := checkDogPermission(c, dog, ownerOnly); err != nil {
+		return model.Dog{}, err
+	}
+	return dog, nil
+}
+
+// checkDogPermission: ログインユーザーがdogを操作できるかのチェック
+// systemユーザーは主たる飼い主として扱う
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.Dog:	対象のdog
+//   - bool:	主たる飼い主のみ許可するか
+//
+// return:
+//   - error:	エラー
+func checkDogPermission(c echo.Context, dog model.Dog, ownerOnly bool) error {
+	logger := log.GetLogger(c).Sugar()
+
+	permission, err := loginDogPermission(c, dog)
+	if err != nil {
+		return err
+	}
+	switch {
+	case permission == "":
+		err := errors.NewWRError(nil, fmt.Sprintf("指定されたドッグID:%dはあなたのペットではありません", dog.DogID.Int64), errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	case ownerOnly && permission != model.DOG_PERMISSION_OWNER:
+		err := errors.NewWRError(nil, "主たる飼い主のみ操作できます。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+/*
+ログインユーザーのdogに対する権限
+*/
+func loginDogPermission(c echo.Context, dog model.Dog) (string, error) {
+	role, err := wrcontext.GetLoginUserRole(c)
+	if err != nil {
+		return "", err
+	}
+	if role == core.SYSTEM {
+		return model.DOG_PERMISSION_OWNER, nil
+	}
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return "", err
+	}
+	return dog.PermissionOf(userID), nil
+}
+
+/*
+飼い主レスポンスへの変換
+*/
+func convertDogCoOwnerRes(dogOwner model.DogOwner, permission string) dto.DogCoOwnerRes {
+	return dto.DogCoOwnerRes{
+		DogOwnerID: dogOwner.DogOwnerID.Int64,
+		Name:       dogOwner.Name.String,
+		Image:      dogOwner.Image.String,
+		Permission: permission,
+	}
+}
+
+/*
+招待レスポンスへの変換
+*/
+func convertDogOwnershipInvitationRes(invitation model.DogOwnershipInvitation) dto.DogOwnershipInvitationRes {
+	return dto.DogOwnershipInvitationRes{
+		InvitationID:   invitation.DogOwnershipInvitationID.Int64,
+		DogID:          invitation.DogID.Int64,
+		DogName:        invitation.Dog.Name.String,
+		InviterID:      invitation.InviterID.Int64,
+		InviterName:    invitation.Inviter.Name.String,
+		InviteeID:      invitation.InviteeID.Int64,
+		InviteeName:    invitation.Invitee.Name.String,
+		InvitationType: invitation.InvitationType.String,
+		KeepAsCoOwner:  invitation.KeepAsCoOwner.Bool,
+		Status:         invitation.Status.String,
+		ExpiresAt:      util.ConvertToWRTime(invitation.ExpiresAt),
+		CreateAt:       util.ConvertToWRTime(invitation.CreateAt),
+	}
+}
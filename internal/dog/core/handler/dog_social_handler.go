@@ -0,0 +1,517 @@
+package handler
+
+import (
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/auth/core"
+	"github.com/wanrun-develop/wanrun/internal/dog/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/dog/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/wrcontext"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+)
+
+// 友達申請の向き
+const (
+	FRIEND_REQUEST_DIRECTION_RECEIVED = "received" // 受信
+	FRIEND_REQUEST_DIRECTION_SENT     = "sent"     // 送信
+)
+
+type IDogSocialHandler interface {
+	GetDogProfile(echo.Context, int64) (dto.DogProfileRes, error)
+	GetDogProfileSettings(echo.Context, int64) (dto.DogProfileSettingsRes, error)
+	SaveDogProfileSettings(echo.Context, int64, dto.DogProfileSettingsReq) (dto.DogProfileSettingsRes, error)
+	GetFriends(echo.Context, int64) ([]dto.DogFriendRes, error)
+	GetFriendRequests(echo.Context) ([]dto.DogFriendRequestRes, error)
+	RequestFriend(echo.Context, int64, dto.DogFriendReq) (dto.DogFriendRequestRes, error)
+	AcceptFriendRequest(echo.Context, int64) error
+	DeclineFriendRequest(echo.Context, int64) error
+	DeleteFriendship(echo.Context, int64) error
+	GetCheckinVisibleFriends(echo.Context, int64) ([]dto.DogFriendRes, error)
+}
+
+type dogSocialHandler struct {
+	r repository.IDogRepository
+}
+
+func NewDogSocialHandler(r repository.IDogRepository) IDogSocialHandler {
+	return &dogSocialHandler{r}
+}
+
+// GetDogProfile: dogの公開プロフィールの取得
+// 公開範囲が友達のみの場合は友達のdogの飼い主、非公開の場合は飼い主、共同飼い主のみ参照可能
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogID
+//
+// return:
+//   - dto.DogProfileRes:	公開プロフィール
+//   - error:	エラー
+func (h *dogSocialHandler) GetDogProfile(c echo.Context, dogID int64) (dto.DogProfileRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dog, err := h.r.GetDogByID(c, dogID)
+	if err != nil {
+		return dto.DogProfileRes{}, err
+	}
+	if dog.IsEmpty() {
+		err := errors.NewWRError(nil, "指定されたdogは存在しません。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return dto.DogProfileRes{}, err
+	}
+
+	socialProfile, err := h.r.FindDogSocialProfile(c, dogID)
+	if err != nil {
+		return dto.DogProfileRes{}, err
+	}
+	friendships, err := h.r.FindDogFriendships(c, []int64{dogID}, model.DOG_FRIENDSHIP_STATUS_ACCEPTED)
+	if err != nil {
+		return dto.DogProfileRes{}, err
+	}
+
+	// ログインユーザーとの関係
+	permission, err := loginDogPermission(c, dog)
+	if err != nil {
+		return dto.DogProfileRes{}, err
+	}
+	myDogIDs, err := h.loginManagedDogIDs(c)
+	if err != nil {
+		return dto.DogProfileRes{}, err
+	}
+	isMine := permission != ""
+	isFriend := slices.ContainsFunc(friendships, func(friendship model.DogFriendship) bool {
+		return slices.Contains(myDogIDs, friendship.OtherDog(dogID).DogID.Int64)
+	})
+
+	visibility := socialProfile.ProfileVisibilityOrDefault()
+	visible := visibility == model.DOG_VISIBILITY_PUBLIC ||
+		(visibility == model.DOG_VISIBILITY_FRIENDS && isFriend) ||
+		isMine
+	if !visible {
+		err := errors.NewWRError(nil, "このdogのプロフィールは公開されていません。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return dto.DogProfileRes{}, err
+	}
+
+	return dto.DogProfileRes{
+		DogID:             dog.DogID.Int64,
+		Name:              dog.Name.String,
+		Image:             dog.Image.String,
+		Sex:               dog.Sex.String,
+		SizeClass:         dog.SizeClass(),
+		DogTypeId:         dog.DogTypeIDs(),
+		AgeMonths:         ageMonthsPtr(dog),
+		TemperamentId:     dog.TemperamentIDs(),
+		Bio:               socialProfile.Bio.String,
+		ProfileVisibility: visibility,
+		FriendCount:       len(friendships),
+		IsFriend:          isFriend,
+		IsMine:            isMine,
+	}, nil
+}
+
+// GetDogProfileSettings: 公開プロフィール設定の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogID
+//
+// return:
+//   - dto.DogProfileSettingsRes:	公開設定。未登録の場合は初期値
+//   - error:	エラー
+func (h *dogSocialHandler) GetDogProfileSettings(c echo.Context, dogID int64) (dto.DogProfileSettingsRes, error) {
+	if _, err := findManageableDog(c, h.r, dogID, false); err != nil {
+		return dto.DogProfileSettingsRes{}, err
+	}
+
+	socialProfile, err := h.r.FindDogSocialProfile(c, dogID)
+	if err != nil {
+		return dto.DogProfileSettingsRes{}, err
+	}
+	if socialProfile.IsEmpty() {
+		socialProfile.AllowFriendRequests = util.NewSqlNullBool(true)
+	}
+	return convertDogProfileSettingsRes(dogID, socialProfile), nil
+}
+
+// SaveDogProfileSettings: 公開プロフィール設定の保存
+// 飼い主、共同飼い主のみ保存可能
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogID
+//   - dto.DogProfileSettingsReq:	公開設定
+//
+// return:
+//   - dto.DogProfileSettingsRes:	保存した公開設定
+//   - error:	エラー
+func (h *dogSocialHandler) SaveDogProfileSettings(c echo.Context, dogID int64, req dto.DogProfileSettingsReq) (dto.DogProfileSettingsRes, error) {
+	if _, err := findManageableDog(c, h.r, dogID, false); err != nil {
+		return dto.DogProfileSettingsRes{}, err
+	}
+
+	socialProfile := model.DogSocialProfile{
+		DogID:               util.NewSqlNullInt64(dogID),
+		Bio:                 util.NewSqlNullString(req.Bio),
+		ProfileVisibility:   util.NewSqlNullString(req.ProfileVisibility),
+		CheckinVisibility:   util.NewSqlNullString(req.CheckinVisibility),
+		AllowFriendRequests: util.NewSqlNullBool(req.AllowFriendRequests),
+	}
+	if err := h.r.SaveDogSocialProfile(c, &socialProfile); err != nil {
+		return dto.DogProfileSettingsRes{}, err
+	}
+	return convertDogProfileSettingsRes(dogID, socialProfile), nil
+}
+
+// GetFriends: dogの友達一覧
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogID
+//
+// return:
+//   - []dto.DogFriendRes:	友達一覧
+//   - error:	エラー
+func (h *dogSocialHandler) GetFriends(c echo.Context, dogID int64) ([]dto.DogFriendRes, error) {
+	if _, err := findManageableDog(c, h.r, dogID, false); err != nil {
+		return nil, err
+	}
+
+	friendships, err := h.r.FindDogFriendships(c, []int64{dogID}, model.DOG_FRIENDSHIP_STATUS_ACCEPTED)
+	if err != nil {
+		return nil, err
+	}
+
+	resFriends := []dto.DogFriendRes{}
+	for _, friendship := range friendships {
+		resFriends = append(resFriends, convertDogFriendRes(friendship, dogID))
+	}
+	return resFriends, nil
+}
+
+// GetFriendRequests: ログインユーザーのdogが送受信した承認待ちの友達申請の一覧
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - []dto.DogFriendRequestRes:	友達申請の一覧
+//   - error:	エラー
+func (h *dogSocialHandler) GetFriendRequests(c echo.Context) ([]dto.DogFriendRequestRes, error) {
+	myDogIDs, err := h.loginManagedDogIDs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	friendships, err := h.r.FindDogFriendships(c, myDogIDs, model.DOG_FRIENDSHIP_STATUS_PENDING)
+	if err != nil {
+		return nil, err
+	}
+
+	resRequests := []dto.DogFriendRequestRes{}
+	for _, friendship := range friendships {
+		resRequests = append(resRequests, convertDogFriendRequestRes(friendship, myDogIDs))
+	}
+	return resRequests, nil
+}
+
+// RequestFriend: 友達申請
+// 申請先は公開プロフィールで、友達申請を受け付けているdogのみ
+// 辞退された申請は再申請できる
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	申請するdogID
+//   - dto.DogFriendReq:	申請先
+//
+// return:
+//   - dto.DogFriendRequestRes:	登録した友達申請
+//   - error:	エラー
+func (h *dogSocialHandler) RequestFriend(c echo.Context, dogID int64, req dto.DogFriendReq) (dto.DogFriendRequestRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dog, err := findManageableDog(c, h.r, dogID, false)
+	if err != nil {
+		return dto.DogFriendRequestRes{}, err
+	}
+	if req.TargetDogID == dogID {
+		err := errors.NewWRError(nil, "自分自身には友達申請できません。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return dto.DogFriendRequestRes{}, err
+	}
+
+	targetDog, err := h.r.GetDogByID(c, req.TargetDogID)
+	if err != nil {
+		return dto.DogFriendRequestRes{}, err
+	}
+	if targetDog.IsEmpty() {
+		err := errors.NewWRError(nil, "指定されたdogは存在しません。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return dto.DogFriendRequestRes{}, err
+	}
+	targetProfile, err := h.r.FindDogSocialProfile(c, req.TargetDogID)
+	if err != nil {
+		return dto.DogFriendRequestRes{}, err
+	}
+	if !targetProfile.AcceptsFriendRequests() {
+		err := errors.NewWRError(nil, "指定されたdogは友達申請を受け付けていません。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return dto.DogFriendRequestRes{}, err
+	}
+
+	friendship, err := h.r.FindDogFriendshipBetween(c, dogID, req.TargetDogID)
+	if err != nil {
+		return dto.DogFriendRequestRes{}, err
+	}
+	switch friendship.Status.String {
+	case model.DOG_FRIENDSHIP_STATUS_ACCEPTED:
+		err := errors.NewWRError(nil, "すでに友達です。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return dto.DogFriendRequestRes{}, err
+	case model.DOG_FRIENDSHIP_STATUS_PENDING:
+		err := errors.NewWRError(nil, "承認待ちの友達申請がすでにあります。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return dto.DogFriendRequestRes{}, err
+	}
+
+	// 辞退済みの場合は、同じレコードを申請し直す
+	friendship.RequesterDogID = util.NewSqlNullInt64(dogID)
+	friendship.AddresseeDogID = util.NewSqlNullInt64(req.TargetDogID)
+	friendship.Status = util.NewSqlNullString(model.DOG_FRIENDSHIP_STATUS_PENDING)
+	friendship.RespondedAt = sql.NullTime{}
+	if err := h.r.SaveDogFriendship(c, &friendship); err != nil {
+		return dto.DogFriendRequestRes{}, err
+	}
+
+	friendship.RequesterDog = dog
+	friendship.AddresseeDog = targetDog
+	return convertDogFriendRequestRes(friendship, []int64{dogID}), nil
+}
+
+// AcceptFriendRequest: 友達申請の承認
+// 申請されたdogの飼い主、共同飼い主のみ承認可能
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogFriendshipID
+//
+// return:
+//   - error:	エラー
+func (h *dogSocialHandler) AcceptFriendRequest(c echo.Context, friendshipID int64) error {
+	return h.respondFriendRequest(c, friendshipID, model.DOG_FRIENDSHIP_STATUS_ACCEPTED)
+}
+
+// DeclineFriendRequest: 友達申請の辞退
+// 申請されたdogの飼い主、共同飼い主のみ辞退可能
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogFriendshipID
+//
+// return:
+//   - error:	エラー
+func (h *dogSocialHandler) DeclineFriendRequest(c echo.Context, friendshipID int64) error {
+	return h.respondFriendRequest(c, friendshipID, model.DOG_FRIENDSHIP_STATUS_DECLINED)
+}
+
+// DeleteFriendship: 友達申請の取消、友達の解除
+// どちらかのdogの飼い主、共同飼い主のみ削除可能
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogFriendshipID
+//
+// return:
+//   - error:	エラー
+func (h *dogSocialHandler) DeleteFriendship(c echo.Context, friendshipID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	friendship, err := h.r.FindDogFriendshipByID(c, friendshipID)
+	if err != nil {
+		return err
+	}
+	requesterPermission, err := loginDogPermission(c, friendship.RequesterDog)
+	if err != nil {
+		return err
+	}
+	addresseePermission, err := loginDogPermission(c, friendship.AddresseeDog)
+	if err != nil {
+		return err
+	}
+	if friendship.IsEmpty() || (requesterPermission == "" && addresseePermission == "") {
+		err := errors.NewWRError(nil, "指定された友達申請は存在しません。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	return h.r.DeleteDogFriendship(c, friendshipID)
+}
+
+// GetCheckinVisibleFriends: dogOwnerのdogの友達のうち、チェックインを友達に公開しているdogの一覧
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogOwnerID
+//
+// return:
+//   - []dto.DogFriendRes:	友達一覧
+//   - error:	エラー
+func (h *dogSocialHandler) GetCheckinVisibleFriends(c echo.Context, dogOwnerID int64) ([]dto.DogFriendRes, error) {
+	myDogs, err := h.r.GetDogByDogOwnerID(c, dogOwnerID)
+	if err != nil {
+		return nil, err
+	}
+	myDogIDs := []int64{}
+	for _, dog := range myDogs {
+		myDogIDs = append(myDogIDs, dog.DogID.Int64)
+	}
+
+	friendships, err := h.r.FindDogFriendships(c, myDogIDs, model.DOG_FRIENDSHIP_STATUS_ACCEPTED)
+	if err != nil {
+		return nil, err
+	}
+
+	resFriends := []dto.DogFriendRes{}
+	for _, friendship := range friendships {
+		myDogID := friendship.RequesterDogID.Int64
+		if !slices.Contains(myDogIDs, myDogID) {
+			myDogID = friendship.AddresseeDogID.Int64
+		}
+		friendDog := friendship.OtherDog(myDogID)
+		// 自分のdog同士、チェックイン非公開のdogは除く
+		if slices.Contains(myDogIDs, friendDog.DogID.Int64) ||
+			friendDog.DogSocialProfile.CheckinVisibilityOrDefault() == model.DOG_VISIBILITY_PRIVATE {
+			continue
+		}
+		resFriends = append(resFriends, convertDogFriendRes(friendship, myDogID))
+	}
+	return resFriends, nil
+}
+
+// respondFriendRequest: 友達申請の承認・辞退
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogFriendshipID
+//   - string:	更新後のステータス
+//
+// return:
+//   - error:	エラー
+func (h *dogSocialHandler) respondFriendRequest(c echo.Context, friendshipID int64, status string) error {
+	logger := log.GetLogger(c).Sugar()
+
+	friendship, err := h.r.FindDogFriendshipByID(c, friendshipID)
+	if err != nil {
+		return err
+	}
+	addresseePermission, err := loginDogPermission(c, friendship.AddresseeDog)
+	if err != nil {
+		return err
+	}
+	if friendship.IsEmpty() || addresseePermission == "" {
+		err := errors.NewWRError(nil, "指定された友達申請は存在しません。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if friendship.Status.String != model.DOG_FRIENDSHIP_STATUS_PENDING {
+		err := errors.NewWRError(nil, "承認待ちの友達申請ではありません。", errors.NewDogClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	friendship.Status = util.NewSqlNullString(status)
+	friendship.RespondedAt = util.NewSqlNullTime(time.Now())
+	return h.r.SaveDogFriendship(c, &friendship)
+}
+
+// loginManagedDogIDs: ログインユーザーが飼い主、共同飼い主のdogIDの一覧
+// dog owner以外の場合は空
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - []int64:	dogIDs
+//   - error:	エラー
+func (h *dogSocialHandler) loginManagedDogIDs(c echo.Context) ([]int64, error) {
+	role, err := wrcontext.GetLoginUserRole(c)
+	if err != nil {
+		return nil, err
+	}
+	if role != core.DOGOWNER_ROLE {
+		return []int64{}, nil
+	}
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	dogs, err := h.r.GetDogByDogOwnerID(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	dogIDs := []int64{}
+	for _, dog := range dogs {
+		dogIDs = append(dogIDs, dog.DogID.Int64)
+	}
+	return dogIDs, nil
+}
+
+/*
+公開設定レスポンスへの変換
+*/
+func convertDogProfileSettingsRes(dogID int64, socialProfile model.DogSocialProfile) dto.DogProfileSettingsRes {
+	return dto.DogProfileSettingsRes{
+		DogID:               dogID,
+		Bio:                 socialProfile.Bio.String,
+		ProfileVisibility:   socialProfile.ProfileVisibilityOrDefault(),
+		CheckinVisibility:   socialProfile.CheckinVisibilityOrDefault(),
+		AllowFriendRequests: socialProfile.AllowFriendRequests.Bool,
+	}
+}
+
+/*
+友達レスポンスへの変換
+*/
+func convertDogFriendRes(friendship model.DogFriendship, myDogID int64) dto.DogFriendRes {
+	return dto.DogFriendRes{
+		FriendshipID: friendship.DogFriendshipID.Int64,
+		DogID:        myDogID,
+		Friend:       convertDogSummaryRes(friendship.OtherDog(myDogID)),
+		Since:        util.ConvertToWRTime(friendship.RespondedAt),
+	}
+}
+
+/*
+友達申請レスポンスへの変換
+*/
+func convertDogFriendRequestRes(friendship model.DogFriendship, myDogIDs []int64) dto.DogFriendRequestRes {
+	direction := FRIEND_REQUEST_DIRECTION_SENT
+	if slices.Contains(myDogIDs, friendship.AddresseeDogID.Int64) {
+		direction = FRIEND_REQUEST_DIRECTION_RECEIVED
+	}
+	return dto.DogFriendRequestRes{
+		FriendshipID: friendship.DogFriendshipID.Int64,
+		Direction:    direction,
+		RequesterDog: convertDogSummaryRes(friendship.RequesterDog),
+		AddresseeDog: convertDogSummaryRes(friendship.AddresseeDog),
+		CreateAt:     util.ConvertToWRTime(friendship.CreateAt),
+	}
+}
+
+/*
+dog概要レスポンスへの変換
+*/
+func convertDogSummaryRes(dog model.Dog) dto.DogSummaryRes {
+	return dto.DogSummaryRes{
+		DogID: dog.DogID.Int64,
+		Name:  dog.Name.String,
+		Image: dog.Image.String,
+	}
+}
@@ -5,6 +5,9 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/wanrun-develop/wanrun/internal/dog/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/dog/core/dto"
+	"github.com/wanrun-develop/wanrun/internal/dog/core/handler"
+	model "github.com/wanrun-develop/wanrun/internal/models"
 	"github.com/wanrun-develop/wanrun/internal/wrcontext"
 	"github.com/wanrun-develop/wanrun/pkg/errors"
 	"github.com/wanrun-develop/wanrun/pkg/log"
@@ -12,18 +15,21 @@ import (
 
 type IDogFacade interface {
 	CheckDogownerValid(echo.Context, []int64) error
+	FindDogsByIDs(echo.Context, []int64) ([]model.Dog, error)
+	GetCheckinVisibleFriends(echo.Context, int64) ([]dto.DogFriendRes, error)
 }
 
 type dogFacade struct {
-	dr repository.IDogRepository
+	dr  repository.IDogRepository
+	dsh handler.IDogSocialHandler
 }
 
-func NewDogFacade(drr repository.IDogRepository) IDogFacade {
-	return &dogFacade{drr}
+func NewDogFacade(drr repository.IDogRepository, dsh handler.IDogSocialHandler) IDogFacade {
+	return &dogFacade{drr, dsh}
 }
 
 // CheckDogownerValid: dogのdogownerが正しいかチェック
-// ログインユーザーのdogであるかをチェック
+// ログインユーザーのdog(共同飼い主のdogを含む)であるかをチェック
 //
 // args:
 //   - echo.Context:	コンテキスト
@@ -60,3 +66,29 @@ func (f dogFacade) CheckDogownerValid(c echo.Context, paramDogIDs []int64) error
 
 	return nil
 }
+
+// FindDogsByIDs: dogのプロフィール(犬種、性格タグを含む)の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - []int64:	dogIDs
+//
+// return:
+//   - []model.Dog:	dogデータ
+//   - error:	エラー
+func (f dogFacade) FindDogsByIDs(c echo.Context, dogIDs []int64) ([]model.Dog, error) {
+	return f.dr.FindDogsByIDs(c, dogIDs)
+}
+
+// GetCheckinVisibleFriends: dogOwnerのdogの友達のうち、チェックインを友達に公開しているdogの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogOwnerID
+//
+// return:
+//   - []dto.DogFriendRes:	友達一覧
+//   - error:	エラー
+func (f dogFacade) GetCheckinVisibleFriends(c echo.Context, dogOwnerID int64) ([]dto.DogFriendRes, error) {
+	return f.dsh.GetCheckinVisibleFriends(c, dogOwnerID)
+}
@@ -7,6 +7,7 @@ import (
 
 type IDogOwnerRepository interface {
 	GetDogOwnerById(int64) (model.DogOwner, error)
+	GetDogOwnerByEmail(string) (model.DogOwner, error)
 }
 
 type dogOwnerRepository struct {
@@ -24,3 +25,19 @@ func (dr *dogOwnerRepository) GetDogOwnerById(dogOwnerId int64) (model.DogOwner,
 	}
 	return dogOwner, nil
 }
+
+/*
+ログイン用のemailで、dogOwnerの取得
+*/
+func (dr *dogOwnerRepository) GetDogOwnerByEmail(email string) (model.DogOwner, error) {
+	dogOwner := model.DogOwner{}
+	if err := dr.db.
+		Joins("JOIN auth_dog_owners ON auth_dog_owners.dog_owner_id = dog_owners.dog_owner_id").
+		Joins("JOIN dog_owner_credentials ON dog_owner_credentials.auth_dog_owner_id = auth_dog_owners.auth_dog_owner_id").
+		Where("dog_owner_credentials.email = ?", email).
+		Limit(1).
+		Find(&dogOwner).Error; err != nil {
+		return model.DogOwner{}, err
+	}
+	return dogOwner, nil
+}
@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"strconv"
+
 	"github.com/labstack/echo/v4"
+	auditDTO "github.com/wanrun-develop/wanrun/internal/audit/core/dto"
+	auditFacade "github.com/wanrun-develop/wanrun/internal/audit/facade"
 	authRepository "github.com/wanrun-develop/wanrun/internal/auth/adapters/repository"
 	"github.com/wanrun-develop/wanrun/internal/auth/core"
 	authDTO "github.com/wanrun-develop/wanrun/internal/auth/core/dto"
@@ -27,6 +31,7 @@ type dogOwnerHandler struct {
 	asr  authRepository.IAuthScopeRepository
 	dor  dogOwnerRepository.IDogOwnerRepository
 	ar   authRepository.IAuthRepository
+	auf  auditFacade.IAuditFacade
 }
 
 func NewDogOwnerHandler(
@@ -35,6 +40,7 @@ func NewDogOwnerHandler(
 	asr authRepository.IAuthScopeRepository,
 	dor dogOwnerRepository.IDogOwnerRepository,
 	ar authRepository.IAuthRepository,
+	auf auditFacade.IAuditFacade,
 ) IDogOwnerHandler {
 	return &dogOwnerHandler{
 		dosr: dosr,
@@ -42,6 +48,7 @@ func NewDogOwnerHandler(
 		asr:  asr,
 		dor:  dor,
 		ar:   ar,
+		auf:  auf,
 	}
 }
 
@@ -129,6 +136,24 @@ func (doh *dogOwnerHandler) DogOwnerSignUp(c echo.Context, doReq doDTO.DogOwnerR
 			return wrErr
 		}
 
+		// 登録の監査ログ
+		dogOwnerID := dogOwnerCredential.AuthDogOwner.DogOwnerID.Int64
+		if wrErr := doh.auf.Record(tx, c, auditDTO.AuditEntry{
+			ActorID:    dogOwnerID,
+			ActorRole:  core.DOGOWNER_ROLE,
+			Action:     model.AUDIT_ACTION_SIGNUP,
+			Result:     model.AUDIT_RESULT_SUCCESS,
+			TargetType: model.AUDIT_TARGET_DOGOWNER,
+			TargetID:   strconv.FormatInt(dogOwnerID, 10),
+			After: map[string]string{
+				"name":        doReq.DogOwnerName,
+				"email":       doReq.Email,
+				"phoneNumber": doReq.PhoneNumber,
+			},
+		}); wrErr != nil {
+			return wrErr
+		}
+
 		// 正常に完了
 		return nil
 
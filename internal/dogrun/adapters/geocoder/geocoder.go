@@ -0,0 +1,49 @@
+package geocoder
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	GEOCODER_TYPE_GOOGLE = "google" // Google Geocoding API
+	GEOCODER_TYPE_LOCAL  = "local"  // 郵便番号CSVを使用したローカルの代替(開発・テスト用)
+)
+
+// ジオコーディングの結果
+// 該当する住所・座標がない場合は空
+type GeocodeResult struct {
+	PostCode  string // 郵便番号(ハイフンあり 例:100-0005)
+	Address   string // 住所
+	Latitude  float64
+	Longitude float64
+}
+
+/*
+該当する結果がないか
+*/
+func (r GeocodeResult) IsEmpty() bool {
+	return r.PostCode == "" && r.Address == "" && !r.HasLocation()
+}
+
+/*
+座標があるか
+*/
+func (r GeocodeResult) HasLocation() bool {
+	return r.Latitude != 0 && r.Longitude != 0
+}
+
+type IGeocoder interface {
+	Name() string
+	Geocode(c echo.Context, address string) (GeocodeResult, error)
+	ReverseGeocode(c echo.Context, latitude, longitude float64) (GeocodeResult, error)
+}
+
+/*
+7桁の郵便番号をハイフンありの形式にする
+*/
+func FormatPostCode(postCode string) string {
+	if len(postCode) != 7 {
+		return postCode
+	}
+	return postCode[:3] + "-" + postCode[3:]
+}
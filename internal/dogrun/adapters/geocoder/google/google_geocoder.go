@@ -0,0 +1,162 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/geocoder"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+)
+
+const (
+	GEOCODE_URL = "https://maps.googleapis.com/maps/api/geocode/json"
+
+	STATUS_OK           = "OK"
+	STATUS_ZERO_RESULTS = "ZERO_RESULTS" // 該当なし
+
+	ADDRESS_TYPE_POSTAL_CODE = "postal_code"
+)
+
+// formatted_addressの先頭の国名と郵便番号 (例: 日本、〒100-0005 )
+var addressPrefix = regexp.MustCompile(`^(日本、)?(〒\d{3}-\d{4}\s*)?`)
+
+type geocodeResponse struct {
+	Status       string          `json:"status"`
+	ErrorMessage string          `json:"error_message"`
+	Results      []geocodeResult `json:"results"`
+}
+
+type geocodeResult struct {
+	FormattedAddress  string             `json:"formatted_address"`
+	AddressComponents []addressComponent `json:"address_components"`
+	Geometry          struct {
+		Location struct {
+			Lat float64 `json:"lat"`
+			Lng float64 `json:"lng"`
+		} `json:"location"`
+	} `json:"geometry"`
+}
+
+type addressComponent struct {
+	LongName string   `json:"long_name"`
+	Types    []string `json:"types"`
+}
+
+// GoogleGeocoder: Google Geocoding APIでのジオコーディング
+type GoogleGeocoder struct {
+	apiKey string
+	client *http.Client
+}
+
+func NewGoogleGeocoder(apiKey string) *GoogleGeocoder {
+	return &GoogleGeocoder{
+		apiKey: apiKey,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+var _ geocoder.IGeocoder = (*GoogleGeocoder)(nil)
+
+// Name: ジオコーダー名
+//
+// return:
+//   - string: ジオコーダー名
+func (gg *GoogleGeocoder) Name() string {
+	return geocoder.GEOCODER_TYPE_GOOGLE
+}
+
+// Geocode: 住所から座標と郵便番号を取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: 住所
+//
+// return:
+//   - geocoder.GeocodeResult: 結果。該当なしの場合は空
+//   - error: error情報
+func (gg *GoogleGeocoder) Geocode(c echo.Context, address string) (geocoder.GeocodeResult, error) {
+	return gg.request(c, url.Values{"address": {address}})
+}
+
+// ReverseGeocode: 座標から住所と郵便番号を取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - float64: 緯度
+//   - float64: 経度
+//
+// return:
+//   - geocoder.GeocodeResult: 結果。該当なしの場合は空
+//   - error: error情報
+func (gg *GoogleGeocoder) ReverseGeocode(c echo.Context, latitude, longitude float64) (geocoder.GeocodeResult, error) {
+	return gg.request(c, url.Values{"latlng": {fmt.Sprintf("%f,%f", latitude, longitude)}})
+}
+
+/*
+Geocoding APIの実行。先頭の結果のみ使用する
+*/
+func (gg *GoogleGeocoder) request(c echo.Context, params url.Values) (geocoder.GeocodeResult, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	params.Set("key", gg.apiKey)
+	params.Set("language", "ja")
+	params.Set("region", "jp")
+
+	resp, err := gg.client.Get(GEOCODE_URL + "?" + params.Encode())
+	if err != nil {
+		err = errors.NewWRError(err, "ジオコーディングのリクエストに失敗しました", errors.NewDogrunServerErrorEType())
+		logger.Error(err)
+		return geocoder.GeocodeResult{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		err = errors.NewWRError(err, "ジオコーディングのリクエストに失敗しました", errors.NewDogrunServerErrorEType())
+		logger.Errorw("geocoding api RESPONSE is error", "status_code", resp.StatusCode, "body", string(body), "error", err)
+		return geocoder.GeocodeResult{}, err
+	}
+
+	var geocodeRes geocodeResponse
+	if err := json.Unmarshal(body, &geocodeRes); err != nil {
+		err = errors.NewWRError(err, "ジオコーディングのレスポンスの変換に失敗しました", errors.NewDogrunServerErrorEType())
+		logger.Error(err)
+		return geocoder.GeocodeResult{}, err
+	}
+
+	switch geocodeRes.Status {
+	case STATUS_OK:
+	case STATUS_ZERO_RESULTS:
+		return geocoder.GeocodeResult{}, nil
+	default:
+		err := errors.NewWRError(nil, "ジオコーディングに失敗しました", errors.NewDogrunServerErrorEType())
+		logger.Errorw("geocoding api RESPONSE is error", "status", geocodeRes.Status, "error_message", geocodeRes.ErrorMessage)
+		return geocoder.GeocodeResult{}, err
+	}
+	if len(geocodeRes.Results) == 0 {
+		return geocoder.GeocodeResult{}, nil
+	}
+
+	first := geocodeRes.Results[0]
+	result := geocoder.GeocodeResult{
+		Address:   strings.TrimSpace(addressPrefix.ReplaceAllString(first.FormattedAddress, "")),
+		Latitude:  first.Geometry.Location.Lat,
+		Longitude: first.Geometry.Location.Lng,
+	}
+	for _, component := range first.AddressComponents {
+		if slices.Contains(component.Types, ADDRESS_TYPE_POSTAL_CODE) {
+			result.PostCode = geocoder.FormatPostCode(strings.ReplaceAll(component.LongName, "-", ""))
+			break
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,242 @@
+package local
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/geocoder"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+)
+
+// 郵便番号CSVの列(0始まり)
+// 日本郵便のutf_ken_all.csv(15列)の末尾に、緯度・経度の2列を追加したCSVを使用する(misc/postcode/README.md参照)
+// 日本郵便のCSVをそのまま使用した場合は郵便番号のみ返し、座標は返さない
+const (
+	COLUMN_POSTCODE   = 2
+	COLUMN_PREFECTURE = 6
+	COLUMN_CITY       = 7
+	COLUMN_TOWN       = 8
+	COLUMN_LATITUDE   = 15 // 追加列(16列目)
+	COLUMN_LONGITUDE  = 16 // 追加列(17列目)
+
+	TOWN_NOT_LISTED = "以下に掲載がない場合" // 町域の記載がない場合の町域名
+
+	REVERSE_GEOCODE_MAX_METERS = 3000 // 逆ジオコーディングで一致とみなす最大距離
+)
+
+/*
+郵便番号CSVの1行
+*/
+type postcodeEntry struct {
+	postCode   string
+	prefecture string
+	city       string
+	town       string
+	latitude   float64
+	longitude  float64
+}
+
+/*
+都道府県からの住所
+*/
+func (e postcodeEntry) address() string {
+	return e.prefecture + e.city + e.town
+}
+
+/*
+座標があるか
+*/
+func (e postcodeEntry) hasLocation() bool {
+	return e.latitude != 0 && e.longitude != 0
+}
+
+// PostcodeGeocoder: 郵便番号CSVを使用したローカルの代替(開発・テスト用)
+// 町域までの住所の前方一致で郵便番号を求める
+type PostcodeGeocoder struct {
+	entries      []postcodeEntry
+	byPrefecture map[string][]postcodeEntry
+}
+
+// NewPostcodeGeocoder: 郵便番号CSVを読み込んで生成する
+// CSVはUTF-8であること
+//
+// args:
+//   - string: 郵便番号CSVのパス
+//
+// return:
+//   - *PostcodeGeocoder: ジオコーダー
+//   - error: error情報
+func NewPostcodeGeocoder(csvPath string) (*PostcodeGeocoder, error) {
+	file, err := os.Open(csvPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	pg := &PostcodeGeocoder{byPrefecture: map[string][]postcodeEntry{}}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) <= COLUMN_TOWN {
+			continue
+		}
+		entry := postcodeEntry{
+			postCode:   geocoder.FormatPostCode(record[COLUMN_POSTCODE]),
+			prefecture: record[COLUMN_PREFECTURE],
+			city:       record[COLUMN_CITY],
+			town:       normalizeTown(record[COLUMN_TOWN]),
+		}
+		if len(record) > COLUMN_LONGITUDE {
+			entry.latitude, _ = strconv.ParseFloat(record[COLUMN_LATITUDE], 64)
+			entry.longitude, _ = strconv.ParseFloat(record[COLUMN_LONGITUDE], 64)
+		}
+		pg.entries = append(pg.entries, entry)
+		pg.byPrefecture[entry.prefecture] = append(pg.byPrefecture[entry.prefecture], entry)
+	}
+	return pg, nil
+}
+
+var _ geocoder.IGeocoder = (*PostcodeGeocoder)(nil)
+
+// HasLocations: 座標のある行があるか
+// 緯度・経度の列を追加していないCSVの場合はfalse
+//
+// return:
+//   - bool: 座標のある行があるか
+func (pg *PostcodeGeocoder) HasLocations() bool {
+	for _, entry := range pg.entries {
+		if entry.hasLocation() {
+			return true
+		}
+	}
+	return false
+}
+
+// Name: ジオコーダー名
+//
+// return:
+//   - string: ジオコーダー名
+func (pg *PostcodeGeocoder) Name() string {
+	return geocoder.GEOCODER_TYPE_LOCAL
+}
+
+// Geocode: 住所から郵便番号と座標を取得
+// 町域まで最も長く一致する行を使用する。座標はCSVにある場合のみ
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: 住所
+//
+// return:
+//   - geocoder.GeocodeResult: 結果。該当なしの場合は空
+//   - error: error情報
+func (pg *PostcodeGeocoder) Geocode(c echo.Context, address string) (geocoder.GeocodeResult, error) {
+	address = normalizeAddress(address)
+
+	candidates := pg.entries
+	for prefecture, entries := range pg.byPrefecture {
+		if strings.HasPrefix(address, prefecture) {
+			candidates = entries
+			break
+		}
+	}
+
+	var matched postcodeEntry
+	matchedLen := 0
+	for _, entry := range candidates {
+		// 都道府県が省略された住所も市区町村からの一致とする
+		for _, entryAddress := range []string{entry.address(), entry.city + entry.town} {
+			if len(entryAddress) > matchedLen && strings.HasPrefix(address, entryAddress) {
+				matched = entry
+				matchedLen = len(entryAddress)
+			}
+		}
+	}
+	if matchedLen == 0 {
+		return geocoder.GeocodeResult{}, nil
+	}
+	return toResult(matched), nil
+}
+
+// ReverseGeocode: 座標から最も近い行の住所と郵便番号を取得
+// 座標のあるCSVの場合のみ該当する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - float64: 緯度
+//   - float64: 経度
+//
+// return:
+//   - geocoder.GeocodeResult: 結果。該当なしの場合は空
+//   - error: error情報
+func (pg *PostcodeGeocoder) ReverseGeocode(c echo.Context, latitude, longitude float64) (geocoder.GeocodeResult, error) {
+	var nearest postcodeEntry
+	nearestDistance := math.MaxFloat64
+	for _, entry := range pg.entries {
+		if !entry.hasLocation() {
+			continue
+		}
+		if distance := util.CalcDistance(latitude, longitude, entry.latitude, entry.longitude); distance < nearestDistance {
+			nearest = entry
+			nearestDistance = distance
+		}
+	}
+	if nearestDistance > REVERSE_GEOCODE_MAX_METERS {
+		return geocoder.GeocodeResult{}, nil
+	}
+	// 座標は入力値を優先する
+	result := toResult(nearest)
+	result.Latitude = latitude
+	result.Longitude = longitude
+	return result, nil
+}
+
+/*
+CSVの行から結果を作成する
+*/
+func toResult(entry postcodeEntry) geocoder.GeocodeResult {
+	return geocoder.GeocodeResult{
+		PostCode:  entry.postCode,
+		Address:   entry.address(),
+		Latitude:  entry.latitude,
+		Longitude: entry.longitude,
+	}
+}
+
+/*
+町域名の補足(括弧書き)と、町域の記載がない場合の文言を除く
+*/
+func normalizeTown(town string) string {
+	if town == TOWN_NOT_LISTED {
+		return ""
+	}
+	if i := strings.IndexAny(town, "（("); i >= 0 {
+		return town[:i]
+	}
+	return town
+}
+
+/*
+住所の先頭の郵便番号と、空白を除く
+*/
+func normalizeAddress(address string) string {
+	address = strings.Join(strings.Fields(address), "")
+	address = strings.TrimPrefix(address, "日本、")
+	if strings.HasPrefix(address, "〒") {
+		address = strings.TrimLeft(strings.TrimPrefix(address, "〒"), "0123456789-")
+	}
+	return address
+}
@@ -50,6 +50,19 @@ var BASE_FILEDS = []string{
 	F_PHOTOS_B,
 }
 
+// 同期用(DBに保存する項目のみ)
+var SYNC_FIELDS = []string{
+	F_ID_IO,
+	F_ADDRESSCOMPONENTS_LO,
+	F_SHORTFORMATTEDADDRESS_LO,
+	F_LOCATION_LO,
+	F_DISPLAYNAME_B,
+	F_RATING_B,
+	F_USERRATINGCOUNT_A,
+	F_BUSINESSSTATUS_B,
+	F_REGULAROPENINGHOURS_A,
+}
+
 type IFieldMask interface {
 	getValue() string
 	getValueWPlaces() string
@@ -84,3 +97,25 @@ func (b BaseField) getValueWPlacesAndNextPageToken() string {
 	basePlacesFilesMask := b.getValueWPlaces()
 	return fmt.Sprintf("%s%s%s", basePlacesFilesMask, ",", F_NEXTPAGETOKEN_IO)
 }
+
+// 同期用
+type SyncField struct{}
+
+func (s SyncField) getValue() string {
+	return strings.Join(SYNC_FIELDS, ",")
+}
+
+/*
+search nearbyようにfieldに"palce."のプレフィックスを付与する
+*/
+func (s SyncField) getValueWPlaces() string {
+	fieldsWithPlace := make([]string, len(SYNC_FIELDS))
+	for i, field := range SYNC_FIELDS {
+		fieldsWithPlace[i] = "places." + field
+	}
+	return strings.Join(fieldsWithPlace, ",")
+}
+
+func (s SyncField) getValueWPlacesAndNextPageToken() string {
+	return fmt.Sprintf("%s%s%s", s.getValueWPlaces(), ",", F_NEXTPAGETOKEN_IO)
+}
@@ -1,6 +1,8 @@
 package googleplace
 
 import (
+	"strings"
+
 	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
 )
 
@@ -10,6 +12,8 @@ const (
 	RANKPREFERENCE_RELEVANCE  = "RELEVANCE"  //テキストクエリ結果を検索関連性に基づいて並べ替えます。
 )
 
+const SEARCH_TEXT_QUERY = "ドッグラン" // searchTextの検索文言。キーワードの指定がある場合は後ろに付ける
+
 // https://developers.google.com/maps/documentation/places/web-service/nearby-search
 type SearchNearbyPayLoad struct {
 	IncludedTypes       []string                  `json:"includedTypes" validate:"required"`               // 含む場所タイプ　https://developers.google.com/maps/documentation/places/web-service/place-types?hl=ja#table-a
@@ -87,8 +91,13 @@ func ConvertReqToSearchTextPayload(req dto.SearchAroundRectangleCondition) Searc
 		Rectangle: rectangle,
 	}
 
+	textQuery := SEARCH_TEXT_QUERY
+	if keyword := strings.TrimSpace(req.Keyword); keyword != "" {
+		textQuery += " " + keyword
+	}
+
 	return SearchTextPayLoad{
-		TextQuery:           "ドッグラン",
+		TextQuery:           textQuery,
 		PageSize:            20,
 		LocationRestriction: locationRestrictionRectangle,
 		RankPreference:      RANKPREFERENCE_RELEVANCE,
@@ -77,6 +77,13 @@ const (
 	ADDRESSCOMPONENT_TYPES_POSTAL_CODE string = "postal_code" //addressComponents.typeの郵便番号
 )
 
+// 営業状況(businessStatus)
+const (
+	BUSINESS_STATUS_OPERATIONAL        string = "OPERATIONAL"        //営業中
+	BUSINESS_STATUS_CLOSED_TEMPORARILY string = "CLOSED_TEMPORARILY" //休業中
+	BUSINESS_STATUS_CLOSED_PERMANENTLY string = "CLOSED_PERMANENTLY" //閉業
+)
+
 // 営業時間
 type OpeningHours struct {
 	OpenNow             bool                 `json:"openNow"`
@@ -0,0 +1,73 @@
+package fake
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/payment"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+)
+
+const (
+	DECLINED_TOKEN = "fake_declined" // 決済失敗として扱うトークン
+)
+
+// FakePaymentProvider: インメモリの疑似決済(開発・テスト用)
+// DECLINED_TOKEN以外のトークンは全て決済成功として扱う
+type FakePaymentProvider struct {
+	mu      sync.Mutex
+	seq     int64
+	charges map[string]payment.ChargeResult // IdempotencyKeyごとの決済結果
+}
+
+func NewFakePaymentProvider() *FakePaymentProvider {
+	return &FakePaymentProvider{
+		charges: map[string]payment.ChargeResult{},
+	}
+}
+
+var _ payment.IPaymentProvider = (*FakePaymentProvider)(nil)
+
+// Name: 決済プロバイダ名
+//
+// return:
+//   - string: 決済プロバイダ名
+func (fp *FakePaymentProvider) Name() string {
+	return payment.PROVIDER_TYPE_FAKE
+}
+
+// Charge: 疑似決済
+// 同じIdempotencyKeyの場合は前回の決済結果を返す
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - payment.ChargeRequest: 決済の依頼内容
+//
+// return:
+//   - payment.ChargeResult: 決済結果
+//   - error: error情報
+func (fp *FakePaymentProvider) Charge(c echo.Context, req payment.ChargeRequest) (payment.ChargeResult, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	if result, exist := fp.charges[req.IdempotencyKey]; exist {
+		return result, nil
+	}
+
+	fp.seq++
+	result := payment.ChargeResult{
+		ProviderPaymentID: fmt.Sprintf("fake_%d", fp.seq),
+		Status:            payment.CHARGE_STATUS_SUCCEEDED,
+	}
+	if req.PaymentMethodToken == DECLINED_TOKEN {
+		result.Status = payment.CHARGE_STATUS_FAILED
+		result.FailureReason = "card declined"
+	}
+	fp.charges[req.IdempotencyKey] = result
+	logger.Infof("疑似決済: %s, 金額: %d円, 結果: %s", result.ProviderPaymentID, req.Amount, result.Status)
+
+	return result, nil
+}
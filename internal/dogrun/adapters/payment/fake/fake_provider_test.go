@@ -0,0 +1,84 @@
+package fake
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/payment"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"go.uber.org/zap"
+)
+
+func TestFakePaymentProviderChargeIdempotency(t *testing.T) {
+	log.SetLogger(zap.NewNop())
+
+	type charge struct {
+		idempotencyKey string
+		token          string
+		wantStatus     string
+		wantSameAs     int // 同じ決済結果になるべき何回目の決済か。-1の場合は新しい決済
+	}
+
+	tests := []struct {
+		name    string
+		charges []charge
+	}{
+		{
+			name: "同じキーの再送は前回の結果を返す",
+			charges: []charge{
+				{idempotencyKey: "key-1", token: "tok", wantStatus: payment.CHARGE_STATUS_SUCCEEDED, wantSameAs: -1},
+				{idempotencyKey: "key-1", token: "tok", wantStatus: payment.CHARGE_STATUS_SUCCEEDED, wantSameAs: 0},
+			},
+		},
+		{
+			name: "異なるキーは別の決済",
+			charges: []charge{
+				{idempotencyKey: "key-1", token: "tok", wantStatus: payment.CHARGE_STATUS_SUCCEEDED, wantSameAs: -1},
+				{idempotencyKey: "key-2", token: "tok", wantStatus: payment.CHARGE_STATUS_SUCCEEDED, wantSameAs: -1},
+			},
+		},
+		{
+			name: "決済失敗の再送は別のトークンでも失敗のまま",
+			charges: []charge{
+				{idempotencyKey: "key-1", token: DECLINED_TOKEN, wantStatus: payment.CHARGE_STATUS_FAILED, wantSameAs: -1},
+				{idempotencyKey: "key-1", token: "tok", wantStatus: payment.CHARGE_STATUS_FAILED, wantSameAs: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp := NewFakePaymentProvider()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder())
+
+			results := []payment.ChargeResult{}
+			for i, ch := range tt.charges {
+				result, err := fp.Charge(c, payment.ChargeRequest{
+					Amount:             500,
+					PaymentMethodToken: ch.token,
+					IdempotencyKey:     ch.idempotencyKey,
+				})
+				if err != nil {
+					t.Fatalf("Charge() #%d error = %v", i, err)
+				}
+				if result.Status != ch.wantStatus {
+					t.Errorf("Charge() #%d status = %q, want %q", i, result.Status, ch.wantStatus)
+				}
+				if ch.wantSameAs >= 0 {
+					if result != results[ch.wantSameAs] {
+						t.Errorf("Charge() #%d = %+v, want %+v", i, result, results[ch.wantSameAs])
+					}
+				} else {
+					for j, prev := range results {
+						if result.ProviderPaymentID == prev.ProviderPaymentID {
+							t.Errorf("Charge() #%d providerPaymentID = %q, same as #%d", i, result.ProviderPaymentID, j)
+						}
+					}
+				}
+				results = append(results, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package payment
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	PROVIDER_TYPE_FAKE = "fake" // インメモリの疑似決済(開発・テスト用)
+)
+
+// 決済プロバイダでの決済結果
+const (
+	CHARGE_STATUS_SUCCEEDED = "succeeded" // 決済成功
+	CHARGE_STATUS_FAILED    = "failed"    // 決済失敗(カード拒否など)
+)
+
+// 決済の依頼内容
+type ChargeRequest struct {
+	Amount             int64  // 金額(円)
+	Description        string // 明細に表示する内容
+	PaymentMethodToken string // クライアントで取得した支払い方法のトークン
+	IdempotencyKey     string // 二重決済防止のキー
+}
+
+// 決済結果
+type ChargeResult struct {
+	ProviderPaymentID string
+	Status            string
+	FailureReason     string
+}
+
+type IPaymentProvider interface {
+	Name() string
+	Charge(c echo.Context, req ChargeRequest) (ChargeResult, error)
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+)
+
+type IDogrunClaimRepository interface {
+	FindClaimByID(echo.Context, int64) (model.DogrunClaim, error)
+	FindPendingClaim(echo.Context, int64, int64) (model.DogrunClaim, error)
+	FindClaimsByDogrunmgID(echo.Context, int64) ([]model.DogrunClaim, error)
+	FindClaims(echo.Context, string) ([]model.DogrunClaim, error)
+	CreateClaim(echo.Context, *model.DogrunClaim) error
+}
+
+type dogrunClaimRepository struct {
+	db *gorm.DB
+}
+
+func NewDogrunClaimRepository(db *gorm.DB) IDogrunClaimRepository {
+	return &dogrunClaimRepository{db}
+}
+
+// FindClaimByID: 管理申請の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunClaimID
+//
+// return:
+//   - model.DogrunClaim:	ドッグラン、申請者、証拠書類、履歴を含む管理申請。存在しない場合は空
+//   - error:	エラー
+func (r *dogrunClaimRepository) FindClaimByID(c echo.Context, claimID int64) (model.DogrunClaim, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	claim := model.DogrunClaim{}
+	if err := preloadClaimRelations(r.db).
+		Preload("DogrunClaimHistories", func(db *gorm.DB) *gorm.DB {
+			return db.Order("dogrun_claim_history_id")
+		}).
+		Where("dogrun_claim_id = ?", claimID).
+		Find(&claim).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunClaim{}, errors.NewWRError(err, "管理申請の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return claim, nil
+}
+
+// FindPendingClaim: マネージャーのドッグランへの審査中の管理申請の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - int64:	dogrunmgID
+//
+// return:
+//   - model.DogrunClaim:	管理申請。存在しない場合は空
+//   - error:	エラー
+func (r *dogrunClaimRepository) FindPendingClaim(c echo.Context, dogrunID int64, dmID int64) (model.DogrunClaim, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	claim := model.DogrunClaim{}
+	if err := r.db.
+		Where("dogrun_id = ? AND dogrun_manager_id = ? AND status = ?", dogrunID, dmID, model.DOGRUN_CLAIM_STATUS_PENDING).
+		Find(&claim).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunClaim{}, errors.NewWRError(err, "管理申請の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return claim, nil
+}
+
+// FindClaimsByDogrunmgID: マネージャーの管理申請一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunmgID
+//
+// return:
+//   - []model.DogrunClaim:	申請の新しい順の管理申請
+//   - error:	エラー
+func (r *dogrunClaimRepository) FindClaimsByDogrunmgID(c echo.Context, dmID int64) ([]model.DogrunClaim, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	claims := []model.DogrunClaim{}
+	if err := preloadClaimRelations(r.db).
+		Where("dogrun_manager_id = ?", dmID).
+		Order("reg_at DESC").
+		Find(&claims).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "管理申請一覧の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return claims, nil
+}
+
+// FindClaims: 審査向けの管理申請一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - string:	ステータス。空の場合は全て
+//
+// return:
+//   - []model.DogrunClaim:	申請の古い順の管理申請
+//   - error:	エラー
+func (r *dogrunClaimRepository) FindClaims(c echo.Context, status string) ([]model.DogrunClaim, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	query := preloadClaimRelations(r.db)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	claims := []model.DogrunClaim{}
+	if err := query.Order("reg_at ASC").Find(&claims).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "管理申請一覧の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return claims, nil
+}
+
+// CreateClaim: 管理申請の登録(証拠書類、履歴を含む)
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - *model.DogrunClaim:	管理申請
+//
+// return:
+//   - error:	エラー
+func (r *dogrunClaimRepository) CreateClaim(c echo.Context, claim *model.DogrunClaim) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.Omit("Dogrun", "Dogrunmg").Create(claim).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "管理申請の登録に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+/*
+管理申請のリレーション(ドッグラン、申請者のorganization、証拠書類)のpreload
+*/
+func preloadClaimRelations(db *gorm.DB) *gorm.DB {
+	return db.Preload("Dogrun").
+		Preload("Dogrunmg.Organization").
+		Preload("DogrunClaimDocuments")
+}
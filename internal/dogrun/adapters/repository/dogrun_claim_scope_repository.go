@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IDogrunClaimScopeRepository interface {
+	FindClaimForUpdate(tx *gorm.DB, c echo.Context, claimID int64) (model.DogrunClaim, error)
+	FindPendingClaimsByDogrunIDForUpdate(tx *gorm.DB, c echo.Context, dogrunID int64) ([]model.DogrunClaim, error)
+	UpdateClaimStatus(tx *gorm.DB, c echo.Context, claimID int64, updates map[string]any) error
+	CreateClaimHistory(tx *gorm.DB, c echo.Context, history *model.DogrunClaimHistory) error
+	AssignDogrunManager(tx *gorm.DB, c echo.Context, dogrunID int64, dmID int64) (bool, error)
+}
+
+type dogrunClaimScopeRepository struct {
+}
+
+func NewDogrunClaimScopeRepository() IDogrunClaimScopeRepository {
+	return &dogrunClaimScopeRepository{}
+}
+
+// FindClaimForUpdate: 管理申請を行ロックして取得
+// 同じ申請への審査、取り下げはトランザクション終了まで待機させる
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunClaimID
+//
+// return:
+//   - model.DogrunClaim: 管理申請。存在しない場合は空
+//   - error: error情報
+func (csr *dogrunClaimScopeRepository) FindClaimForUpdate(
+	tx *gorm.DB,
+	c echo.Context,
+	claimID int64,
+) (model.DogrunClaim, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	claim := model.DogrunClaim{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("dogrun_claim_id = ?", claimID).
+		Find(&claim).Error; err != nil {
+		logger.Error("Failed to lock DogrunClaim: ", err)
+		return model.DogrunClaim{}, wrErrors.NewWRError(
+			err,
+			"管理申請の取得に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return claim, nil
+}
+
+// FindPendingClaimsByDogrunIDForUpdate: ドッグランへの審査中の管理申請を行ロックして取得
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunID
+//
+// return:
+//   - []model.DogrunClaim: 審査中の管理申請
+//   - error: error情報
+func (csr *dogrunClaimScopeRepository) FindPendingClaimsByDogrunIDForUpdate(
+	tx *gorm.DB,
+	c echo.Context,
+	dogrunID int64,
+) ([]model.DogrunClaim, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	claims := []model.DogrunClaim{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("dogrun_id = ? AND status = ?", dogrunID, model.DOGRUN_CLAIM_STATUS_PENDING).
+		Order("dogrun_claim_id").
+		Find(&claims).Error; err != nil {
+		logger.Error("Failed to lock DogrunClaims: ", err)
+		return nil, wrErrors.NewWRError(
+			err,
+			"管理申請の取得に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return claims, nil
+}
+
+// UpdateClaimStatus: 管理申請のステータスの更新
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunClaimID
+//   - map[string]any: 更新内容
+//
+// return:
+//   - error: error情報
+func (csr *dogrunClaimScopeRepository) UpdateClaimStatus(
+	tx *gorm.DB,
+	c echo.Context,
+	claimID int64,
+	updates map[string]any,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := tx.Model(&model.DogrunClaim{}).
+		Where("dogrun_claim_id = ?", claimID).
+		Updates(updates).Error; err != nil {
+		logger.Error("Failed to update DogrunClaim: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"管理申請の更新に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return nil
+}
+
+// CreateClaimHistory: 管理申請の履歴の登録
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - *model.DogrunClaimHistory: 履歴
+//
+// return:
+//   - error: error情報
+func (csr *dogrunClaimScopeRepository) CreateClaimHistory(
+	tx *gorm.DB,
+	c echo.Context,
+	history *model.DogrunClaimHistory,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := tx.Create(history).Error; err != nil {
+		logger.Error("Failed to create DogrunClaimHistory: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"管理申請の履歴の登録に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return nil
+}
+
+// AssignDogrunManager: ドッグランの管理者の設定
+// 管理されていないドッグランの場合のみ更新する
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunID
+//   - int64: 管理者のdogrunmgID
+//
+// return:
+//   - bool: 更新したか(既に管理されている場合はfalse)
+//   - error: error情報
+func (csr *dogrunClaimScopeRepository) AssignDogrunManager(
+	tx *gorm.DB,
+	c echo.Context,
+	dogrunID int64,
+	dmID int64,
+) (bool, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	result := tx.Model(&model.Dogrun{}).
+		Where("dogrun_id = ? AND is_managed IS NOT TRUE", dogrunID).
+		Updates(map[string]any{
+			"dogrun_manager_id": dmID,
+			"is_managed":        true,
+			"upd_at":            time.Now(),
+		})
+	if result.Error != nil {
+		logger.Error("Failed to assign Dogrun manager: ", result.Error)
+		return false, wrErrors.NewWRError(
+			result.Error,
+			"ドッグランの管理者の設定に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return result.RowsAffected > 0, nil
+}
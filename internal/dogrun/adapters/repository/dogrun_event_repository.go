@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 定員超過
+var errDogrunEventCapacityExceeded = errors.New("dogrun event capacity exceeded")
+
+type IDogrunEventRepository interface {
+	FindDogrunEvents(echo.Context, int64, time.Time) ([]model.DogrunEvent, error)
+	FindDogrunEventByID(echo.Context, int64) (model.DogrunEvent, error)
+	FindOverlappingOfficialEvents(echo.Context, int64, time.Time, time.Time) ([]model.DogrunEvent, error)
+	CreateDogrunEvent(echo.Context, *model.DogrunEvent, *model.SpecialBusinessHour) error
+	CancelDogrunEvent(echo.Context, int64) error
+	CreateDogrunEventRsvps(echo.Context, int64, []model.DogrunEventRsvp) error
+	DeleteDogrunEventRsvps(echo.Context, int64, []int64) error
+}
+
+type dogrunEventRepository struct {
+	db *gorm.DB
+}
+
+func NewDogrunEventRepository(db *gorm.DB) IDogrunEventRepository {
+	return &dogrunEventRepository{db}
+}
+
+// FindDogrunEvents: ドッグランの開催予定のイベント一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - time.Time:	この日時より後に終了するイベントを対象とする
+//
+// return:
+//   - []model.DogrunEvent:	開始日時順のイベント
+//   - error:	エラー
+func (r *dogrunEventRepository) FindDogrunEvents(c echo.Context, dogrunID int64, from time.Time) ([]model.DogrunEvent, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	events := []model.DogrunEvent{}
+	if err := r.db.Preload("DogrunEventRsvps").
+		Where("dogrun_id = ? AND status = ? AND end_at > ?", dogrunID, model.DOGRUN_EVENT_STATUS_SCHEDULED, from).
+		Order("start_at ASC").
+		Order("dogrun_event_id ASC").
+		Find(&events).Error; err != nil {
+		logger.Error(err)
+		return nil, wrErrors.NewWRError(err, "イベント一覧の取得に失敗しました。", wrErrors.NewDogrunServerErrorEType())
+	}
+	return events, nil
+}
+
+// FindDogrunEventByID: イベントの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunEventID
+//
+// return:
+//   - model.DogrunEvent:	参加表明(dogを含む)を含むイベント。存在しない場合は空
+//   - error:	エラー
+func (r *dogrunEventRepository) FindDogrunEventByID(c echo.Context, dogrunEventID int64) (model.DogrunEvent, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	event := model.DogrunEvent{}
+	if err := r.db.Preload("DogrunEventRsvps", func(db *gorm.DB) *gorm.DB {
+		return db.Order("dogrun_event_rsvp_id ASC")
+	}).
+		Preload("DogrunEventRsvps.Dog").
+		Where("dogrun_event_id = ?", dogrunEventID).
+		Find(&event).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunEvent{}, wrErrors.NewWRError(err, "イベントの取得に失敗しました。", wrErrors.NewDogrunServerErrorEType())
+	}
+	return event, nil
+}
+
+// FindOverlappingOfficialEvents: 指定の時間帯と重なる開催予定の公式イベントの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - time.Time:	開始日時
+//   - time.Time:	終了日時
+//
+// return:
+//   - []model.DogrunEvent:	公式イベント
+//   - error:	エラー
+func (r *dogrunEventRepository) FindOverlappingOfficialEvents(c echo.Context, dogrunID int64, startAt time.Time, endAt time.Time) ([]model.DogrunEvent, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	events := []model.DogrunEvent{}
+	if err := r.db.
+		Where("dogrun_id = ? AND event_type = ? AND status = ?", dogrunID, model.DOGRUN_EVENT_TYPE_OFFICIAL, model.DOGRUN_EVENT_STATUS_SCHEDULED).
+		Where("start_at < ? AND end_at > ?", endAt, startAt).
+		Find(&events).Error; err != nil {
+		logger.Error(err)
+		return nil, wrErrors.NewWRError(err, "公式イベントの取得に失敗しました。", wrErrors.NewDogrunServerErrorEType())
+	}
+	return events, nil
+}
+
+// CreateDogrunEvent: イベントの登録
+// 特別営業時間の指定がある場合は、特別営業時間を登録・更新してイベントに紐付ける
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - *model.DogrunEvent:	イベント
+//   - *model.SpecialBusinessHour:	イベントに合わせた特別営業時間。不要な場合はnil
+//
+// return:
+//   - error:	エラー
+func (r *dogrunEventRepository) CreateDogrunEvent(c echo.Context, event *model.DogrunEvent, specialBusinessHour *model.SpecialBusinessHour) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if specialBusinessHour != nil {
+			if err := tx.Save(specialBusinessHour).Error; err != nil {
+				return err
+			}
+			event.SpecialBusinessHourID = specialBusinessHour.SpecialBusinessHourID
+		}
+		return tx.Create(event).Error
+	}); err != nil {
+		logger.Error(err)
+		return wrErrors.NewWRError(err, "イベントの登録に失敗しました。", wrErrors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// CancelDogrunEvent: イベントの中止
+// イベントに合わせて設定した特別営業時間はそのまま残す
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunEventID
+//
+// return:
+//   - error:	エラー
+func (r *dogrunEventRepository) CancelDogrunEvent(c echo.Context, dogrunEventID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.Model(&model.DogrunEvent{}).
+		Where("dogrun_event_id = ?", dogrunEventID).
+		Update("status", model.DOGRUN_EVENT_STATUS_CANCELLED).Error; err != nil {
+		logger.Error(err)
+		return wrErrors.NewWRError(err, "イベントの中止に失敗しました。", wrErrors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// CreateDogrunEventRsvps: イベントへの参加表明の登録
+// 定員のチェックはイベントをロックして行い、参加表明済みのdogは無視する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunEventID
+//   - []model.DogrunEventRsvp:	参加表明
+//
+// return:
+//   - error:	エラー
+func (r *dogrunEventRepository) CreateDogrunEventRsvps(c echo.Context, dogrunEventID int64, rsvps []model.DogrunEventRsvp) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogIDs := []int64{}
+	for _, rsvp := range rsvps {
+		dogIDs = append(dogIDs, rsvp.DogID.Int64)
+	}
+
+	var capacity int64
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		event := model.DogrunEvent{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("dogrun_event_id = ?", dogrunEventID).
+			Find(&event).Error; err != nil {
+			return err
+		}
+
+		if event.Capacity.Valid {
+			var rsvpCount, alreadyCount int64
+			if err := tx.Model(&model.DogrunEventRsvp{}).Where("dogrun_event_id = ?", dogrunEventID).Count(&rsvpCount).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&model.DogrunEventRsvp{}).Where("dogrun_event_id = ? AND dog_id IN ?", dogrunEventID, dogIDs).Count(&alreadyCount).Error; err != nil {
+				return err
+			}
+			if rsvpCount-alreadyCount+int64(len(rsvps)) > event.Capacity.Int64 {
+				capacity = event.Capacity.Int64
+				return errDogrunEventCapacityExceeded
+			}
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "dogrun_event_id"}, {Name: "dog_id"}},
+			DoNothing: true,
+		}).Create(&rsvps).Error
+	}); err != nil {
+		if errors.Is(err, errDogrunEventCapacityExceeded) {
+			wrErr := wrErrors.NewWRError(nil, fmt.Sprintf("イベントの定員(%d頭)を超えるため参加できません。", capacity), wrErrors.NewDogrunClientErrorEType())
+			logger.Error(wrErr)
+			return wrErr
+		}
+		logger.Error(err)
+		return wrErrors.NewWRError(err, "イベントへの参加表明に失敗しました。", wrErrors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// DeleteDogrunEventRsvps: イベントへの参加表明の取消
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunEventID
+//   - []int64:	取消するdogIDs
+//
+// return:
+//   - error:	エラー
+func (r *dogrunEventRepository) DeleteDogrunEventRsvps(c echo.Context, dogrunEventID int64, dogIDs []int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.
+		Where("dogrun_event_id = ? AND dog_id IN ?", dogrunEventID, dogIDs).
+		Delete(&model.DogrunEventRsvp{}).Error; err != nil {
+		logger.Error(err)
+		return wrErrors.NewWRError(err, "イベントへの参加表明の取消に失敗しました。", wrErrors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+)
+
+type IDogrunEventScopeRepository interface {
+	DetachDog(tx *gorm.DB, c echo.Context, dogID int64) error
+}
+
+type dogrunEventScopeRepository struct {
+}
+
+func NewDogrunEventScopeRepository() IDogrunEventScopeRepository {
+	return &dogrunEventScopeRepository{}
+}
+
+// DetachDog: イベントの参加表明からのdogの除外(dogの削除時)
+// 参加表明は参加数の集計対象のため、削除して枠を空ける
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogID
+//
+// return:
+//   - error: error情報
+func (esr *dogrunEventScopeRepository) DetachDog(
+	tx *gorm.DB,
+	c echo.Context,
+	dogID int64,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := tx.Where("dog_id = ?", dogID).Delete(&model.DogrunEventRsvp{}).Error; err != nil {
+		logger.Error("Failed to delete DogrunEventRsvps: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"イベントへの参加表明の削除に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return nil
+}
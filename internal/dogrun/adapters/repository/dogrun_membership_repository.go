@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+)
+
+type IDogrunMembershipRepository interface {
+	FindMembershipByID(echo.Context, int64) (model.DogrunMembership, error)
+	FindActiveMembership(echo.Context, int64, int64) (model.DogrunMembership, error)
+	FindApprovedMembershipsByDogIDs(echo.Context, int64, []int64) ([]model.DogrunMembership, error)
+	FindMembershipsByDogOwnerID(echo.Context, int64) ([]model.DogrunMembership, error)
+	FindMembershipsByDogrunID(echo.Context, int64, string) ([]model.DogrunMembership, error)
+	CreateMembership(echo.Context, *model.DogrunMembership) error
+	UpdateMembershipStatus(echo.Context, int64, []string, map[string]any) (bool, error)
+}
+
+type dogrunMembershipRepository struct {
+	db *gorm.DB
+}
+
+func NewDogrunMembershipRepository(db *gorm.DB) IDogrunMembershipRepository {
+	return &dogrunMembershipRepository{db}
+}
+
+// FindMembershipByID: 会員登録の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunMembershipID
+//
+// return:
+//   - model.DogrunMembership:	dogowner、dog、添付書類を含む会員登録。存在しない場合は空
+//   - error:	エラー
+func (r *dogrunMembershipRepository) FindMembershipByID(c echo.Context, membershipID int64) (model.DogrunMembership, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	membership := model.DogrunMembership{}
+	if err := preloadMembershipRelations(r.db).
+		Preload("DogOwner").
+		Where("dogrun_membership_id = ?", membershipID).
+		Find(&membership).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunMembership{}, errors.NewWRError(err, "会員登録の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return membership, nil
+}
+
+// FindActiveMembership: dogownerの審査中・承認済みの会員登録の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - int64:	dogOwnerID
+//
+// return:
+//   - model.DogrunMembership:	dog、添付書類を含む会員登録。存在しない場合は空
+//   - error:	エラー
+func (r *dogrunMembershipRepository) FindActiveMembership(c echo.Context, dogrunID int64, dogOwnerID int64) (model.DogrunMembership, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	membership := model.DogrunMembership{}
+	if err := preloadMembershipRelations(r.db).
+		Where("dogrun_id = ? AND dog_owner_id = ?", dogrunID, dogOwnerID).
+		Where("status IN ?", model.DOGRUN_MEMBERSHIP_ACTIVE_STATUSES).
+		Find(&membership).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunMembership{}, errors.NewWRError(err, "会員登録の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return membership, nil
+}
+
+// FindApprovedMembershipsByDogIDs: dogが含まれる承認済みの会員登録の取得
+// 共同飼い主が申請した会員登録も含めるため、申請したdogownerではなくdogで検索する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - []int64:	dogIDs
+//
+// return:
+//   - []model.DogrunMembership:	dogを含む会員登録
+//   - error:	エラー
+func (r *dogrunMembershipRepository) FindApprovedMembershipsByDogIDs(c echo.Context, dogrunID int64, dogIDs []int64) ([]model.DogrunMembership, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	memberships := []model.DogrunMembership{}
+	if err := preloadMembershipRelations(r.db).
+		Where("dogrun_id = ? AND status = ?", dogrunID, model.DOGRUN_MEMBERSHIP_STATUS_APPROVED).
+		Where("dogrun_membership_id IN (?)", r.db.Model(&model.DogrunMembershipDog{}).
+			Select("dogrun_membership_id").
+			Where("dog_id IN ?", dogIDs)).
+		Find(&memberships).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "会員登録の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return memberships, nil
+}
+
+// FindMembershipsByDogOwnerID: dogownerの会員登録一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogOwnerID
+//
+// return:
+//   - []model.DogrunMembership:	申請の新しい順の会員登録
+//   - error:	エラー
+func (r *dogrunMembershipRepository) FindMembershipsByDogOwnerID(c echo.Context, dogOwnerID int64) ([]model.DogrunMembership, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	memberships := []model.DogrunMembership{}
+	if err := preloadMembershipRelations(r.db).
+		Where("dog_owner_id = ?", dogOwnerID).
+		Order("reg_at DESC").
+		Find(&memberships).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "会員登録一覧の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return memberships, nil
+}
+
+// FindMembershipsByDogrunID: ドッグランの会員登録一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - string:	ステータス。空の場合は全て
+//
+// return:
+//   - []model.DogrunMembership:	申請の古い順の会員登録(dogownerを含む)
+//   - error:	エラー
+func (r *dogrunMembershipRepository) FindMembershipsByDogrunID(c echo.Context, dogrunID int64, status string) ([]model.DogrunMembership, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	query := preloadMembershipRelations(r.db).
+		Preload("DogOwner").
+		Where("dogrun_id = ?", dogrunID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	memberships := []model.DogrunMembership{}
+	if err := query.Order("reg_at ASC").Find(&memberships).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "ドッグランの会員登録一覧の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return memberships, nil
+}
+
+// CreateMembership: 会員登録の申請の登録(dog、添付書類を含む)
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - *model.DogrunMembership:	会員登録
+//
+// return:
+//   - error:	エラー
+func (r *dogrunMembershipRepository) CreateMembership(c echo.Context, membership *model.DogrunMembership) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.Create(membership).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "会員登録の申請に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// UpdateMembershipStatus: 会員登録のステータスの更新
+// 指定のステータスの場合のみ更新する。同時に審査・取り下げされた場合は先に更新した方のみ成功する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunMembershipID
+//   - []string:	更新可能な現在のステータス
+//   - map[string]any:	更新内容
+//
+// return:
+//   - bool:	更新したか
+//   - error:	エラー
+func (r *dogrunMembershipRepository) UpdateMembershipStatus(c echo.Context, membershipID int64, fromStatuses []string, updates map[string]any) (bool, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	result := r.db.Model(&model.DogrunMembership{}).
+		Where("dogrun_membership_id = ? AND status IN ?", membershipID, fromStatuses).
+		Updates(updates)
+	if result.Error != nil {
+		logger.Error(result.Error)
+		return false, errors.NewWRError(result.Error, "会員登録の更新に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return result.RowsAffected > 0, nil
+}
+
+/*
+会員登録のリレーション(dog、添付書類)のpreload
+*/
+func preloadMembershipRelations(db *gorm.DB) *gorm.DB {
+	return db.Preload("DogrunMembershipDogs").
+		Preload("DogrunMembershipDogs.Dog").
+		Preload("DogrunMembershipDocuments")
+}
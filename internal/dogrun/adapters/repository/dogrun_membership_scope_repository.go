@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+)
+
+type IDogrunMembershipScopeRepository interface {
+	DetachDog(tx *gorm.DB, c echo.Context, dogID int64) error
+}
+
+type dogrunMembershipScopeRepository struct {
+}
+
+func NewDogrunMembershipScopeRepository() IDogrunMembershipScopeRepository {
+	return &dogrunMembershipScopeRepository{}
+}
+
+// DetachDog: 会員登録からのdogの除外(dogの削除時)
+// dogがいなくなった審査中・承認済みの会員登録は取り下げとする
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogID
+//
+// return:
+//   - error: error情報
+func (msr *dogrunMembershipScopeRepository) DetachDog(
+	tx *gorm.DB,
+	c echo.Context,
+	dogID int64,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	membershipIDs := []int64{}
+	if err := tx.Model(&model.DogrunMembershipDog{}).
+		Where("dog_id = ?", dogID).
+		Pluck("dogrun_membership_id", &membershipIDs).Error; err != nil {
+		logger.Error("Failed to find DogrunMembershipDogs: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"会員登録したdogの取得に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	if len(membershipIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Where("dog_id = ?", dogID).Delete(&model.DogrunMembershipDog{}).Error; err != nil {
+		logger.Error("Failed to delete DogrunMembershipDogs: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"会員登録したdogの削除に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+
+	if err := tx.Model(&model.DogrunMembership{}).
+		Where("dogrun_membership_id IN ?", membershipIDs).
+		Where("status IN ?", model.DOGRUN_MEMBERSHIP_ACTIVE_STATUSES).
+		Where("NOT EXISTS (?)", tx.Model(&model.DogrunMembershipDog{}).
+			Select("1").
+			Where("dogrun_membership_dogs.dogrun_membership_id = dogrun_memberships.dogrun_membership_id")).
+		Update("status", model.DOGRUN_MEMBERSHIP_STATUS_WITHDRAWN).Error; err != nil {
+		logger.Error("Failed to withdraw DogrunMemberships: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"会員登録の取り下げに失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return nil
+}
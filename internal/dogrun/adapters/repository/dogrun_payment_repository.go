@@ -0,0 +1,326 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IDogrunPaymentRepository interface {
+	FindFeePlans(echo.Context, int64, bool) ([]model.DogrunFeePlan, error)
+	FindFeePlanByID(echo.Context, int64) (model.DogrunFeePlan, error)
+	SaveFeePlan(echo.Context, *model.DogrunFeePlan) error
+	DeactivateFeePlan(echo.Context, int64) error
+	FindPaymentSetting(echo.Context, int64) (model.DogrunPaymentSetting, error)
+	SavePaymentSetting(echo.Context, *model.DogrunPaymentSetting) error
+	CreatePayment(echo.Context, *model.DogrunPayment) error
+	UpdatePaymentResult(echo.Context, model.DogrunPayment) error
+	FindPaymentByID(echo.Context, int64) (model.DogrunPayment, error)
+	FindPaymentByIdempotencyKey(echo.Context, int64, string) (model.DogrunPayment, error)
+	FindPaymentsByDogOwnerID(echo.Context, int64) ([]model.DogrunPayment, error)
+	FindPaymentsByDogrunID(echo.Context, int64, time.Time, time.Time) ([]model.DogrunPayment, error)
+	FindValidPayments(echo.Context, int64, int64, time.Time) ([]model.DogrunPayment, error)
+}
+
+type dogrunPaymentRepository struct {
+	db *gorm.DB
+}
+
+func NewDogrunPaymentRepository(db *gorm.DB) IDogrunPaymentRepository {
+	return &dogrunPaymentRepository{db}
+}
+
+// FindFeePlans: ドッグランの料金プラン一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - bool:	販売停止中のプランを含めるか
+//
+// return:
+//   - []model.DogrunFeePlan:	料金プラン
+//   - error:	エラー
+func (r *dogrunPaymentRepository) FindFeePlans(c echo.Context, dogrunID int64, includeInactive bool) ([]model.DogrunFeePlan, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	query := r.db.Where("dogrun_id = ?", dogrunID)
+	if !includeInactive {
+		query = query.Where("is_active = ?", true)
+	}
+
+	feePlans := []model.DogrunFeePlan{}
+	if err := query.Order("dogrun_fee_plan_id ASC").Find(&feePlans).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "料金プランの取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return feePlans, nil
+}
+
+// FindFeePlanByID: 料金プランの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunFeePlanID
+//
+// return:
+//   - model.DogrunFeePlan:	料金プラン。存在しない場合は空
+//   - error:	エラー
+func (r *dogrunPaymentRepository) FindFeePlanByID(c echo.Context, feePlanID int64) (model.DogrunFeePlan, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	feePlan := model.DogrunFeePlan{}
+	if err := r.db.Where("dogrun_fee_plan_id = ?", feePlanID).Find(&feePlan).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunFeePlan{}, errors.NewWRError(err, "料金プランの取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return feePlan, nil
+}
+
+// SaveFeePlan: 料金プランの登録・更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - *model.DogrunFeePlan:	料金プラン
+//
+// return:
+//   - error:	エラー
+func (r *dogrunPaymentRepository) SaveFeePlan(c echo.Context, feePlan *model.DogrunFeePlan) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.Save(feePlan).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "料金プランの保存に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// DeactivateFeePlan: 料金プランの販売停止
+// 支払い履歴から参照されるため削除はしない
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunFeePlanID
+//
+// return:
+//   - error:	エラー
+func (r *dogrunPaymentRepository) DeactivateFeePlan(c echo.Context, feePlanID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.Model(&model.DogrunFeePlan{}).
+		Where("dogrun_fee_plan_id = ?", feePlanID).
+		Update("is_active", false).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "料金プランの販売停止に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// FindPaymentSetting: ドッグランの支払い設定の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - model.DogrunPaymentSetting:	支払い設定。未設定の場合は支払い不要の設定
+//   - error:	エラー
+func (r *dogrunPaymentRepository) FindPaymentSetting(c echo.Context, dogrunID int64) (model.DogrunPaymentSetting, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	setting := model.DogrunPaymentSetting{}
+	if err := r.db.Where("dogrun_id = ?", dogrunID).Find(&setting).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunPaymentSetting{}, errors.NewWRError(err, "支払い設定の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return setting, nil
+}
+
+// SavePaymentSetting: ドッグランの支払い設定の登録・更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - *model.DogrunPaymentSetting:	支払い設定
+//
+// return:
+//   - error:	エラー
+func (r *dogrunPaymentRepository) SavePaymentSetting(c echo.Context, setting *model.DogrunPaymentSetting) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "dogrun_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"checkin_payment_required", "reservation_payment_required", "upd_at"}),
+	}).Create(setting).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "支払い設定の保存に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// CreatePayment: 支払いの登録
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - *model.DogrunPayment:	支払い
+//
+// return:
+//   - error:	エラー
+func (r *dogrunPaymentRepository) CreatePayment(c echo.Context, payment *model.DogrunPayment) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.Create(payment).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "支払いの登録に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// UpdatePaymentResult: 決済結果の更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.DogrunPayment:	決済結果を反映した支払い
+//
+// return:
+//   - error:	エラー
+func (r *dogrunPaymentRepository) UpdatePaymentResult(c echo.Context, payment model.DogrunPayment) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.Model(&model.DogrunPayment{}).
+		Where("dogrun_payment_id = ?", payment.DogrunPaymentID).
+		Updates(map[string]any{
+			"provider_payment_id": payment.ProviderPaymentID,
+			"status":              payment.Status,
+			"failure_reason":      payment.FailureReason,
+			"paid_at":             payment.PaidAt,
+			"valid_until":         payment.ValidUntil,
+			"upd_at":              time.Now(),
+		}).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "決済結果の更新に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// FindPaymentByID: 支払いの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunPaymentID
+//
+// return:
+//   - model.DogrunPayment:	料金プランを含む支払い。存在しない場合は空
+//   - error:	エラー
+func (r *dogrunPaymentRepository) FindPaymentByID(c echo.Context, paymentID int64) (model.DogrunPayment, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	payment := model.DogrunPayment{}
+	if err := r.db.Preload("DogrunFeePlan").
+		Where("dogrun_payment_id = ?", paymentID).
+		Find(&payment).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunPayment{}, errors.NewWRError(err, "支払いの取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return payment, nil
+}
+
+// FindPaymentByIdempotencyKey: 二重決済防止のキーでの支払いの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogOwnerID
+//   - string:	二重決済防止のキー
+//
+// return:
+//   - model.DogrunPayment:	料金プランを含む支払い。存在しない場合は空
+//   - error:	エラー
+func (r *dogrunPaymentRepository) FindPaymentByIdempotencyKey(c echo.Context, dogOwnerID int64, idempotencyKey string) (model.DogrunPayment, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	payment := model.DogrunPayment{}
+	if err := r.db.Preload("DogrunFeePlan").
+		Where("dog_owner_id = ? AND idempotency_key = ?", dogOwnerID, idempotencyKey).
+		Find(&payment).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunPayment{}, errors.NewWRError(err, "支払いの取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return payment, nil
+}
+
+// FindPaymentsByDogOwnerID: dogownerの支払い履歴の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogOwnerID
+//
+// return:
+//   - []model.DogrunPayment:	新しい順の支払い
+//   - error:	エラー
+func (r *dogrunPaymentRepository) FindPaymentsByDogOwnerID(c echo.Context, dogOwnerID int64) ([]model.DogrunPayment, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	payments := []model.DogrunPayment{}
+	if err := r.db.Preload("DogrunFeePlan").
+		Where("dog_owner_id = ?", dogOwnerID).
+		Order("reg_at DESC").
+		Find(&payments).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "支払い履歴の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return payments, nil
+}
+
+// FindPaymentsByDogrunID: ドッグランの期間内の支払い履歴の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - time.Time:	期間の開始(含む)
+//   - time.Time:	期間の終了(含まない)
+//
+// return:
+//   - []model.DogrunPayment:	料金プラン、dogownerを含む新しい順の支払い
+//   - error:	エラー
+func (r *dogrunPaymentRepository) FindPaymentsByDogrunID(c echo.Context, dogrunID int64, from time.Time, to time.Time) ([]model.DogrunPayment, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	payments := []model.DogrunPayment{}
+	if err := r.db.Preload("DogrunFeePlan").
+		Preload("DogOwner").
+		Where("dogrun_id = ? AND reg_at >= ? AND reg_at < ?", dogrunID, from, to).
+		Order("reg_at DESC").
+		Find(&payments).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "ドッグランの支払い履歴の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return payments, nil
+}
+
+// FindValidPayments: 入場に使える期限内の支払い完了済みの支払いの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - int64:	dogOwnerID
+//   - time.Time:	基準日時
+//
+// return:
+//   - []model.DogrunPayment:	支払い
+//   - error:	エラー
+func (r *dogrunPaymentRepository) FindValidPayments(c echo.Context, dogrunID int64, dogOwnerID int64, now time.Time) ([]model.DogrunPayment, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	payments := []model.DogrunPayment{}
+	if err := r.db.
+		Where("dogrun_id = ? AND dog_owner_id = ? AND status = ?", dogrunID, dogOwnerID, model.DOGRUN_PAYMENT_STATUS_SUCCEEDED).
+		Where("valid_until > ?", now).
+		Find(&payments).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "支払いの取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return payments, nil
+}
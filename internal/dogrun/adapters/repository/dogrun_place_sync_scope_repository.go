@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+)
+
+type IDogrunPlaceSyncScopeRepository interface {
+	UpdateDogrunPlaceInfo(tx *gorm.DB, c echo.Context, dogrunID int64, updates map[string]any) error
+	CreatePlaceSyncHistories(tx *gorm.DB, c echo.Context, histories []model.DogrunPlaceSyncHistory) error
+	SaveFieldProvenances(tx *gorm.DB, c echo.Context, provenances []model.DogrunFieldProvenance) error
+}
+
+type dogrunPlaceSyncScopeRepository struct {
+}
+
+func NewDogrunPlaceSyncScopeRepository() IDogrunPlaceSyncScopeRepository {
+	return &dogrunPlaceSyncScopeRepository{}
+}
+
+// UpdateDogrunPlaceInfo: google place情報の同期結果でドッグランを更新
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunID
+//   - map[string]any: 更新内容
+//
+// return:
+//   - error: error情報
+func (psr *dogrunPlaceSyncScopeRepository) UpdateDogrunPlaceInfo(
+	tx *gorm.DB,
+	c echo.Context,
+	dogrunID int64,
+	updates map[string]any,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := tx.Model(&model.Dogrun{}).
+		Where("dogrun_id = ?", dogrunID).
+		Updates(updates).Error; err != nil {
+		logger.Error("Failed to update Dogrun place info: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"google place情報の同期結果の更新に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return nil
+}
+
+// CreatePlaceSyncHistories: google place情報の同期による変更履歴の登録
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - []model.DogrunPlaceSyncHistory: 変更された項目ごとの履歴
+//
+// return:
+//   - error: error情報
+func (psr *dogrunPlaceSyncScopeRepository) CreatePlaceSyncHistories(
+	tx *gorm.DB,
+	c echo.Context,
+	histories []model.DogrunPlaceSyncHistory,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if len(histories) == 0 {
+		return nil
+	}
+	if err := tx.Create(&histories).Error; err != nil {
+		logger.Error("Failed to create DogrunPlaceSyncHistory: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"google place情報の同期履歴の登録に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return nil
+}
+
+// SaveFieldProvenances: 同期で確認した項目の出所の登録・更新
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - []model.DogrunFieldProvenance: 項目ごとの出所
+//
+// return:
+//   - error: error情報
+func (psr *dogrunPlaceSyncScopeRepository) SaveFieldProvenances(
+	tx *gorm.DB,
+	c echo.Context,
+	provenances []model.DogrunFieldProvenance,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := upsertFieldProvenances(tx, provenances); err != nil {
+		logger.Error("Failed to save DogrunFieldProvenance: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"項目の出所の更新に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/labstack/echo/v4"
 	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
 	model "github.com/wanrun-develop/wanrun/internal/models"
@@ -8,16 +10,40 @@ import (
 	"github.com/wanrun-develop/wanrun/pkg/log"
 	"github.com/wanrun-develop/wanrun/pkg/util"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// キーワード検索の対象の文字列。idx_dogruns_search_textのインデックス式と同じにする
+	DOGRUN_SEARCH_TEXT = "normalize_search_text(coalesce(dogruns.name, '') || ' ' || coalesce(dogruns.address, '') || ' ' || coalesce(dogruns.description, ''))"
+	// 正規化したキーワードの部分一致のパターン
+	SEARCH_LIKE_PATTERN = `'%' || replace(replace(replace(normalize_search_text(?), '\', '\\'), '%', '\%'), '_', '\_') || '%'`
+	// google place情報の同期のアドバイザリロックのキー(複数プロセスでの同時実行の防止)
+	PLACE_SYNC_LOCK_KEY int64 = 727_001
 )
 
 type IDogrunRepository interface {
 	GetDogrunByPlaceID(echo.Context, string) (model.Dogrun, error)
 	GetDogrunByID(string) (model.Dogrun, error)
 	FindDogrunByIDs([]int64) ([]model.Dogrun, error)
+	FindDogrunWithRelationsByIDs(echo.Context, []int64) ([]model.Dogrun, error)
 	GetDogrunByRectanglePointerOrPlaceId(echo.Context, dto.SearchAroundRectangleCondition, []string) ([]model.Dogrun, error)
-	GetDogrunByRectanglePointerAndDogrunTags(echo.Context, dto.SearchAroundRectangleCondition) ([]model.Dogrun, error)
 	GetTagMst(echo.Context) ([]model.TagMst, error)
 	RegistDogrunPlaceId(echo.Context, string) (int64, error)
+	FindDogrunImages(echo.Context, int64) ([]model.DogrunImage, error)
+	CreateDogrunImage(echo.Context, *model.DogrunImage) error
+	UpdateDogrunImageSortOrders(echo.Context, []model.DogrunImage) error
+	DeleteDogrunImage(echo.Context, model.DogrunImage) error
+	FindDogrunEntryCriteria(echo.Context, int64) (model.DogrunEntryCriteria, error)
+	SaveDogrunEntryCriteria(echo.Context, *model.DogrunEntryCriteria) error
+	CountDogrunTagFacets(echo.Context, dto.SearchAroundRectangleCondition) ([]dto.TagFacetRes, error)
+	FindDogrunTags(echo.Context, int64) ([]model.DogrunTag, error)
+	ReplaceDogrunTags(echo.Context, int64, []int64) error
+	CountTagMstByIDs(echo.Context, []int64) (int64, error)
+	UpdateDogrunInfo(echo.Context, int64, map[string]any, []model.DogrunFieldProvenance) error
+	FindDogrunsLackingLocation(echo.Context, time.Time, int) ([]model.Dogrun, error)
+	FindDogrunsToSync(echo.Context, int) ([]model.Dogrun, error)
+	RunWithPlaceSyncLock(echo.Context, func() error) (bool, error)
 }
 
 type dogrunRepository struct {
@@ -30,6 +56,7 @@ func NewDogrunRepository(db *gorm.DB) IDogrunRepository {
 
 /*
 PlaceIDで、ドッグランの取得
+統合済みのドッグランのPlaceIDの場合は、統合先のドッグランを返す
 */
 func (drr *dogrunRepository) GetDogrunByPlaceID(c echo.Context, placeID string) (model.Dogrun, error) {
 	logger := log.GetLogger(c).Sugar()
@@ -37,7 +64,37 @@ func (drr *dogrunRepository) GetDogrunByPlaceID(c echo.Context, placeID string)
 	if err := drr.db.Preload("DogrunTags").
 		Preload("RegularBusinessHours").
 		Preload("SpecialBusinessHours").
+		Preload("DogrunImages", orderDogrunImages).
+		Preload("FieldProvenances").
 		Where("place_id = ?", placeID).
+		Where("merged_into_dogrun_id IS NULL").
+		Limit(1).
+		Find(&dogrun).Error; err != nil {
+		logger.Error(err)
+		return model.Dogrun{}, errors.NewWRError(err, "DBからのデータ取得に失敗", errors.NewDogrunServerErrorEType())
+	}
+	if dogrun.IsNotEmpty() {
+		return dogrun, nil
+	}
+
+	// 統合済みのドッグランのみ存在する場合は統合先を取得
+	merged := model.Dogrun{}
+	if err := drr.db.Where("place_id = ?", placeID).
+		Where("merged_into_dogrun_id IS NOT NULL").
+		Limit(1).
+		Find(&merged).Error; err != nil {
+		logger.Error(err)
+		return model.Dogrun{}, errors.NewWRError(err, "DBからのデータ取得に失敗", errors.NewDogrunServerErrorEType())
+	}
+	if merged.IsEmpty() {
+		return model.Dogrun{}, nil
+	}
+	if err := drr.db.Preload("DogrunTags").
+		Preload("RegularBusinessHours").
+		Preload("SpecialBusinessHours").
+		Preload("DogrunImages", orderDogrunImages).
+		Preload("FieldProvenances").
+		Where("dogrun_id = ?", merged.MergedIntoID.Int64).
 		Find(&dogrun).Error; err != nil {
 		logger.Error(err)
 		return model.Dogrun{}, errors.NewWRError(err, "DBからのデータ取得に失敗", errors.NewDogrunServerErrorEType())
@@ -73,26 +130,24 @@ func (drr *dogrunRepository) FindDogrunByIDs(ids []int64) ([]model.Dogrun, error
 	return dogruns, nil
 }
 
-// GetDogrunByRectanglePointerOrPlaceId: 条件の範囲内 または 指定のPlaceIDのdogrunを取得
+// FindDogrunWithRelationsByIDs: 複数IDのドッグランをタグ・営業時間情報込みで検索
 //
 // args:
 //   - echo.Context:	コンテキスト
-//   - to.SearchAroundRectangleCondition:	条件
-//   - []string:	placeIDs
+//   - []int64: dogrunIDs
 //
 // return:
-//   - []model.Dogrun:	ドッグランの検索結果
+//   - []model.Dogrun:	検索結果
 //   - error:	エラー
-func (drr *dogrunRepository) GetDogrunByRectanglePointerOrPlaceId(c echo.Context, condition dto.SearchAroundRectangleCondition, placeIDs []string) ([]model.Dogrun, error) {
+func (drr *dogrunRepository) FindDogrunWithRelationsByIDs(c echo.Context, ids []int64) ([]model.Dogrun, error) {
 	logger := log.GetLogger(c).Sugar()
 	dogruns := []model.Dogrun{}
 	if err := drr.db.Preload("DogrunTags").
 		Preload("RegularBusinessHours").
 		Preload("SpecialBusinessHours").
-		Where("(longitude BETWEEN ? AND ?) AND (latitude BETWEEN ? AND ?)",
-			condition.Target.Southwest.Longitude, condition.Target.Northeast.Longitude,
-			condition.Target.Southwest.Latitude, condition.Target.Northeast.Latitude).
-		Or("place_id IN ?", placeIDs).
+		Preload("DogrunImages", orderDogrunImages).
+		Preload("FieldProvenances").
+		Where("dogrun_id IN ?", ids).
 		Find(&dogruns).Error; err != nil {
 		logger.Error(err)
 		return nil, errors.NewWRError(err, "DBからのデータ取得に失敗", errors.NewDogrunServerErrorEType())
@@ -100,27 +155,34 @@ func (drr *dogrunRepository) GetDogrunByRectanglePointerOrPlaceId(c echo.Context
 	return dogruns, nil
 }
 
-// GetDogrunByRectanglePointerAndDogrunTags: 条件の範囲内 かつ ドッグランタグのdogrunを取得
+// GetDogrunByRectanglePointerOrPlaceId: 条件の範囲内 または 指定のPlaceIDのdogrunを取得
+// キーワードの指定がある場合は、範囲内のdogrunはキーワードに一致するもののみ
 //
 // args:
 //   - echo.Context:	コンテキスト
-//   - to.SearchAroundRectangleCondition:	条件
+//   - dto.SearchAroundRectangleCondition:	条件
+//   - []string:	placeIDs
 //
 // return:
 //   - []model.Dogrun:	ドッグランの検索結果
 //   - error:	エラー
-func (drr *dogrunRepository) GetDogrunByRectanglePointerAndDogrunTags(c echo.Context, condition dto.SearchAroundRectangleCondition) ([]model.Dogrun, error) {
+func (drr *dogrunRepository) GetDogrunByRectanglePointerOrPlaceId(c echo.Context, condition dto.SearchAroundRectangleCondition, placeIDs []string) ([]model.Dogrun, error) {
 	logger := log.GetLogger(c).Sugar()
 	dogruns := []model.Dogrun{}
-	if err := drr.db.Joins("LEFT OUTER JOIN dogrun_tags on dogruns.dogrun_id = dogrun_tags.dogrun_id").
-		Where("(longitude BETWEEN ? AND ?) AND (latitude BETWEEN ? AND ?)",
-			condition.Target.Southwest.Longitude, condition.Target.Northeast.Longitude,
-			condition.Target.Southwest.Latitude, condition.Target.Northeast.Latitude).
-		Where("dogrun_tags.tag_id IN ?", condition.IncludeDogrunTags).
-		Group("dogruns.dogrun_id"). // dogruns の重複を排除
-		Preload("DogrunTags").
+	rectangle := drr.db.Where("(longitude BETWEEN ? AND ?) AND (latitude BETWEEN ? AND ?)",
+		condition.Target.Southwest.Longitude, condition.Target.Northeast.Longitude,
+		condition.Target.Southwest.Latitude, condition.Target.Northeast.Latitude)
+	// 範囲内はキーワードでも絞り込む。google検索の結果はキーワードで検索済み
+	rectangle = whereSearchKeyword(rectangle, dto.ParseSearchKeyword(condition.Keyword))
+	rectangleOrPlaceID := drr.db.Where(rectangle).Or("place_id IN ?", placeIDs)
+	// 統合済みのドッグランは対象外
+	if err := drr.db.Preload("DogrunTags").
 		Preload("RegularBusinessHours").
 		Preload("SpecialBusinessHours").
+		Preload("DogrunImages", orderDogrunImages).
+		Preload("FieldProvenances").
+		Where(rectangleOrPlaceID).
+		Where("merged_into_dogrun_id IS NULL").
 		Find(&dogruns).Error; err != nil {
 		logger.Error(err)
 		return nil, errors.NewWRError(err, "DBからのデータ取得に失敗", errors.NewDogrunServerErrorEType())
@@ -128,6 +190,80 @@ func (drr *dogrunRepository) GetDogrunByRectanglePointerAndDogrunTags(c echo.Con
 	return dogruns, nil
 }
 
+// CountDogrunTagFacets: 条件に一致するdogrunのタグごとの件数を取得
+// 該当するdogrunがないタグも0件として含める
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - dto.SearchAroundRectangleCondition:	条件
+//
+// return:
+//   - []dto.TagFacetRes:	タグID順のタグごとの件数
+//   - error:	エラー
+func (drr *dogrunRepository) CountDogrunTagFacets(c echo.Context, condition dto.SearchAroundRectangleCondition) ([]dto.TagFacetRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunIDs := drr.searchDogrunQuery(condition).Select("dogruns.dogrun_id")
+
+	facets := []dto.TagFacetRes{}
+	if err := drr.db.Table("tag_mst").
+		Select("tag_mst.tag_id, tag_mst.tag_name, tag_mst.category, COUNT(dogrun_tags.dogrun_id) AS count").
+		Joins("LEFT OUTER JOIN dogrun_tags ON dogrun_tags.tag_id = tag_mst.tag_id AND dogrun_tags.dogrun_id IN (?)", dogrunIDs).
+		Group("tag_mst.tag_id").
+		Order("tag_mst.tag_id").
+		Scan(&facets).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "タグごとの件数の取得に失敗", errors.NewDogrunServerErrorEType())
+	}
+	return facets, nil
+}
+
+/*
+範囲とキーワードとドッグランタグ条件でのdogrunの検索クエリ。統合済みのドッグランは対象外
+*/
+func (drr *dogrunRepository) searchDogrunQuery(condition dto.SearchAroundRectangleCondition) *gorm.DB {
+	query := drr.db.Model(&model.Dogrun{}).
+		Where("(dogruns.longitude BETWEEN ? AND ?) AND (dogruns.latitude BETWEEN ? AND ?)",
+			condition.Target.Southwest.Longitude, condition.Target.Northeast.Longitude,
+			condition.Target.Southwest.Latitude, condition.Target.Northeast.Latitude).
+		Where("dogruns.merged_into_dogrun_id IS NULL")
+	query = whereSearchKeyword(query, dto.ParseSearchKeyword(condition.Keyword))
+
+	if len(condition.IncludeDogrunTags) > 0 {
+		taggedDogrunIDs := drr.db.Table("dogrun_tags").
+			Select("dogrun_id").
+			Where("tag_id IN ?", condition.IncludeDogrunTags)
+		if condition.IsMatchAllTags() {
+			// 指定した全てのタグを持つdogrunのみ
+			taggedDogrunIDs = taggedDogrunIDs.
+				Group("dogrun_id").
+				Having("COUNT(DISTINCT tag_id) = ?", len(util.ConvertSliceToMap(condition.IncludeDogrunTags, func(i int64) int64 { return i })))
+		}
+		query = query.Where("dogruns.dogrun_id IN (?)", taggedDogrunIDs)
+	}
+	if len(condition.ExcludeDogrunTags) > 0 {
+		excludedDogrunIDs := drr.db.Table("dogrun_tags").
+			Select("dogrun_id").
+			Where("tag_id IN ?", condition.ExcludeDogrunTags)
+		query = query.Where("dogruns.dogrun_id NOT IN (?)", excludedDogrunIDs)
+	}
+	return query
+}
+
+/*
+キーワードの語句を名前・住所・説明のいずれかに、都道府県を住所に含むdogrunに絞り込む
+大文字小文字・全角半角・カタカナひらがな・異体字は区別しない
+*/
+func whereSearchKeyword(query *gorm.DB, keyword dto.SearchKeyword) *gorm.DB {
+	for _, word := range keyword.Words {
+		query = query.Where(DOGRUN_SEARCH_TEXT+" LIKE "+SEARCH_LIKE_PATTERN, word)
+	}
+	for _, prefecture := range keyword.Prefectures {
+		query = query.Where("normalize_search_text(dogruns.address) LIKE "+SEARCH_LIKE_PATTERN, prefecture)
+	}
+	return query
+}
+
 // GetDogrunTagMst: tag_mstの全件select
 //
 // args:
@@ -170,3 +306,364 @@ func (drr *dogrunRepository) RegistDogrunPlaceId(c echo.Context, placeId string)
 	//主キー返す
 	return dogrun.DogrunID.Int64, nil
 }
+
+// FindDogrunImages: ドッグランのギャラリー画像を表示順で取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - []model.DogrunImage:	ギャラリー画像
+//   - error:	エラー
+func (drr *dogrunRepository) FindDogrunImages(c echo.Context, dogrunID int64) ([]model.DogrunImage, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunImages := []model.DogrunImage{}
+	if err := orderDogrunImages(drr.db).
+		Where("dogrun_id = ?", dogrunID).
+		Find(&dogrunImages).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "ドッグラン画像の取得に失敗", errors.NewDogrunServerErrorEType())
+	}
+	return dogrunImages, nil
+}
+
+// CreateDogrunImage: ドッグランのギャラリー画像の登録
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - *model.DogrunImage:	登録するギャラリー画像。登録後にIDがセットされる
+//
+// return:
+//   - error:	エラー
+func (drr *dogrunRepository) CreateDogrunImage(c echo.Context, dogrunImage *model.DogrunImage) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := drr.db.Create(dogrunImage).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "ドッグラン画像の登録に失敗", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// UpdateDogrunImageSortOrders: ドッグランのギャラリー画像の表示順を一括更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - []model.DogrunImage:	表示順を更新するギャラリー画像
+//
+// return:
+//   - error:	エラー
+func (drr *dogrunRepository) UpdateDogrunImageSortOrders(c echo.Context, dogrunImages []model.DogrunImage) error {
+	logger := log.GetLogger(c).Sugar()
+
+	err := drr.db.Transaction(func(tx *gorm.DB) error {
+		for _, dogrunImage := range dogrunImages {
+			if err := tx.Model(&model.DogrunImage{}).
+				Where("dogrun_image_id = ?", dogrunImage.DogrunImageID).
+				Update("sort_order", dogrunImage.SortOrder).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "ドッグラン画像の表示順の更新に失敗", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// DeleteDogrunImage: ドッグランのギャラリー画像の削除
+// 次の登録で表示順が重複しないよう、残りの画像の表示順を同じトランザクションで1から振り直す
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.DogrunImage:	削除するギャラリー画像
+//
+// return:
+//   - error:	エラー
+func (drr *dogrunRepository) DeleteDogrunImage(c echo.Context, dogrunImage model.DogrunImage) error {
+	logger := log.GetLogger(c).Sugar()
+
+	err := drr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("dogrun_image_id = ?", dogrunImage.DogrunImageID).
+			Delete(&model.DogrunImage{}).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`
+			UPDATE dogrun_images AS di SET sort_order = ordered.new_sort_order
+			FROM (
+				SELECT dogrun_image_id, ROW_NUMBER() OVER (ORDER BY sort_order ASC NULLS LAST, dogrun_image_id ASC) AS new_sort_order
+				FROM dogrun_images
+				WHERE dogrun_id = ?
+			) AS ordered
+			WHERE di.dogrun_image_id = ordered.dogrun_image_id`,
+			dogrunImage.DogrunID,
+		).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "ドッグラン画像の削除に失敗", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// FindDogrunEntryCriteria: ドッグランの入場条件の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - model.DogrunEntryCriteria:	入場条件。未設定の場合は空
+//   - error:	エラー
+func (drr *dogrunRepository) FindDogrunEntryCriteria(c echo.Context, dogrunID int64) (model.DogrunEntryCriteria, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	entryCriteria := model.DogrunEntryCriteria{}
+	if err := drr.db.Where("dogrun_id = ?", dogrunID).Find(&entryCriteria).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunEntryCriteria{}, errors.NewWRError(err, "ドッグランの入場条件の取得に失敗", errors.NewDogrunServerErrorEType())
+	}
+	return entryCriteria, nil
+}
+
+// SaveDogrunEntryCriteria: ドッグランの入場条件の登録・更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - *model.DogrunEntryCriteria:	入場条件
+//
+// return:
+//   - error:	エラー
+func (drr *dogrunRepository) SaveDogrunEntryCriteria(c echo.Context, entryCriteria *model.DogrunEntryCriteria) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := drr.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "dogrun_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"allowed_size_classes", "neutered_required", "microchip_required", "min_age_months", "members_only", "upd_at"}),
+	}).Create(entryCriteria).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "ドッグランの入場条件の保存に失敗", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// UpdateDogrunInfo: ドッグランの基本情報と、更新した項目の出所の更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - map[string]any:	更新内容
+//   - []model.DogrunFieldProvenance:	更新した項目の出所
+//
+// return:
+//   - error:	エラー
+func (drr *dogrunRepository) UpdateDogrunInfo(c echo.Context, dogrunID int64, updates map[string]any, provenances []model.DogrunFieldProvenance) error {
+	logger := log.GetLogger(c).Sugar()
+
+	err := drr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Dogrun{}).
+			Where("dogrun_id = ?", dogrunID).
+			Updates(updates).Error; err != nil {
+			return err
+		}
+		return upsertFieldProvenances(tx, provenances)
+	})
+	if err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "ドッグランの基本情報の更新に失敗", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// FindDogrunsLackingLocation: 座標か郵便番号がないDBのみのドッグランの取得
+// google側の情報で補完されるplaceIdのあるドッグランと、統合済みのドッグランは対象外
+// 指定日時以降に補完を試みたドッグランも対象外とし、補完できないドッグランが上限を占め続けないようにする
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - time.Time:	この日時以降に補完を試みたドッグランは除く
+//   - int:	取得件数の上限
+//
+// return:
+//   - []model.Dogrun:	最後に補完を試みた日時の古い順(未実施が先頭)の検索結果
+//   - error:	エラー
+func (drr *dogrunRepository) FindDogrunsLackingLocation(c echo.Context, attemptedBefore time.Time, limit int) ([]model.Dogrun, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogruns := []model.Dogrun{}
+	if err := drr.db.
+		Where("place_id IS NULL").
+		Where("merged_into_dogrun_id IS NULL").
+		Where("(latitude IS NULL OR longitude IS NULL OR latitude = 0 OR longitude = 0 OR postcode IS NULL OR postcode = '')").
+		Where("(geocode_attempted_at IS NULL OR geocode_attempted_at < ?)", attemptedBefore).
+		Order("geocode_attempted_at ASC NULLS FIRST").
+		Order("dogrun_id").
+		Limit(limit).
+		Find(&dogruns).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "DBからのデータ取得に失敗", errors.NewDogrunServerErrorEType())
+	}
+	return dogruns, nil
+}
+
+// FindDogrunsToSync: google place情報の同期対象のドッグランの取得
+// placeIdのある統合されていないドッグランを、最終同期日時の古い順(未同期が先頭)に取得する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int:	取得件数の上限
+//
+// return:
+//   - []model.Dogrun:	同期対象のドッグラン
+//   - error:	エラー
+func (drr *dogrunRepository) FindDogrunsToSync(c echo.Context, limit int) ([]model.Dogrun, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogruns := []model.Dogrun{}
+	if err := drr.db.Preload("FieldProvenances").
+		Where("place_id IS NOT NULL").
+		Where("merged_into_dogrun_id IS NULL").
+		Order("place_synced_at ASC NULLS FIRST").
+		Order("dogrun_id").
+		Limit(limit).
+		Find(&dogruns).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "DBからのデータ取得に失敗", errors.NewDogrunServerErrorEType())
+	}
+	return dogruns, nil
+}
+
+/*
+項目の出所の登録・更新(ドッグランと項目ごとに1件)
+*/
+func upsertFieldProvenances(tx *gorm.DB, provenances []model.DogrunFieldProvenance) error {
+	if len(provenances) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "dogrun_id"}, {Name: "field_name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"source", "verified_at"}),
+	}).Create(&provenances).Error
+}
+
+/*
+ドッグラン画像を表示順(未設定は末尾)に並べる
+*/
+func orderDogrunImages(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order ASC NULLS LAST").Order("dogrun_image_id ASC")
+}
+
+// FindDogrunTags: ドッグランに設定されているタグの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - []model.DogrunTag:	タグID順のタグ(マスターを含む)
+//   - error:	エラー
+func (drr *dogrunRepository) FindDogrunTags(c echo.Context, dogrunID int64) ([]model.DogrunTag, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunTags := []model.DogrunTag{}
+	if err := drr.db.Preload("TagMst").
+		Where("dogrun_id = ?", dogrunID).
+		Order("tag_id").
+		Find(&dogrunTags).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "ドッグランタグの取得に失敗", errors.NewDogrunServerErrorEType())
+	}
+	return dogrunTags, nil
+}
+
+// ReplaceDogrunTags: ドッグランに設定されているタグの置き換え
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - []int64:	設定するtagID。空の場合は全て解除
+//
+// return:
+//   - error:	エラー
+func (drr *dogrunRepository) ReplaceDogrunTags(c echo.Context, dogrunID int64, tagIDs []int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	err := drr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("dogrun_id = ?", dogrunID).Delete(&model.DogrunTag{}).Error; err != nil {
+			return err
+		}
+		if len(tagIDs) == 0 {
+			return nil
+		}
+		dogrunTags := []model.DogrunTag{}
+		for _, tagID := range tagIDs {
+			dogrunTags = append(dogrunTags, model.DogrunTag{
+				DogrunID: util.NewSqlNullInt64(dogrunID),
+				TagID:    util.NewSqlNullInt64(tagID),
+			})
+		}
+		return tx.Omit("TagMst").Create(&dogrunTags).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "ドッグランタグの更新に失敗", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// CountTagMstByIDs: 指定のtagIDのうちtag_mstに存在する件数の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - []int64:	tagID
+//
+// return:
+//   - int64:	存在する件数
+//   - error:	エラー
+func (drr *dogrunRepository) CountTagMstByIDs(c echo.Context, tagIDs []int64) (int64, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	var count int64
+	if err := drr.db.Model(&model.TagMst{}).Where("tag_id IN ?", tagIDs).Count(&count).Error; err != nil {
+		logger.Error(err)
+		return 0, errors.NewWRError(err, "tag_mstの存在チェックに失敗", errors.NewDogrunServerErrorEType())
+	}
+	return count, nil
+}
+
+// RunWithPlaceSyncLock: google place情報の同期のアドバイザリロックを取得して処理を実行する
+// ロックはDBのセッション単位のため、同じ接続でロックの取得と解放を行う
+// 他のプロセスがロック中の場合は実行しない
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - func() error:	ロック中に実行する処理
+//
+// return:
+//   - bool:	ロックを取得して実行したか
+//   - error:	エラー
+func (drr *dogrunRepository) RunWithPlaceSyncLock(c echo.Context, f func() error) (bool, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	locked := false
+	err := drr.db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Raw("SELECT pg_try_advisory_lock(?)", PLACE_SYNC_LOCK_KEY).Scan(&locked).Error; err != nil {
+			logger.Error(err)
+			return errors.NewWRError(err, "同期のロックの取得に失敗", errors.NewDogrunServerErrorEType())
+		}
+		if !locked {
+			return nil
+		}
+		defer func() {
+			if err := conn.Exec("SELECT pg_advisory_unlock(?)", PLACE_SYNC_LOCK_KEY).Error; err != nil {
+				logger.Error(err)
+			}
+		}()
+		return f()
+	})
+	return locked, err
+}
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IDogrunReservationRepository interface {
+	FindBookingSetting(echo.Context, int64) (model.DogrunBookingSetting, error)
+	SaveBookingSetting(echo.Context, *model.DogrunBookingSetting) error
+	FindBookingSlotByID(echo.Context, int64) (model.DogrunBookingSlot, error)
+	FindBookingSlots(echo.Context, int64, time.Time, time.Time) ([]model.DogrunBookingSlot, error)
+	CreateBookingSlots(echo.Context, []model.DogrunBookingSlot) (int64, error)
+	FindReservationByID(echo.Context, int64) (model.DogrunReservation, error)
+	FindReservationsByDogOwnerID(echo.Context, int64) ([]model.DogrunReservation, error)
+	FindReservationsByDogrunID(echo.Context, int64, time.Time, time.Time) ([]model.DogrunReservation, error)
+	CountNoShows(echo.Context, []int64, time.Time) (map[int64]int64, error)
+	UpdateReservationNoShow(echo.Context, int64) error
+}
+
+type dogrunReservationRepository struct {
+	db *gorm.DB
+}
+
+func NewDogrunReservationRepository(db *gorm.DB) IDogrunReservationRepository {
+	return &dogrunReservationRepository{db}
+}
+
+// FindBookingSetting: ドッグランの予約設定の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - model.DogrunBookingSetting:	予約設定。予約を受け付けていない場合は空
+//   - error:	エラー
+func (r *dogrunReservationRepository) FindBookingSetting(c echo.Context, dogrunID int64) (model.DogrunBookingSetting, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	setting := model.DogrunBookingSetting{}
+	if err := r.db.Where("dogrun_id = ?", dogrunID).Find(&setting).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunBookingSetting{}, errors.NewWRError(err, "予約設定の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return setting, nil
+}
+
+// SaveBookingSetting: ドッグランの予約設定の登録・更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - *model.DogrunBookingSetting:	予約設定
+//
+// return:
+//   - error:	エラー
+func (r *dogrunReservationRepository) SaveBookingSetting(c echo.Context, setting *model.DogrunBookingSetting) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "dogrun_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"slot_minutes", "slot_capacity", "allow_private_rental", "upd_at"}),
+	}).Create(setting).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "予約設定の保存に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+// FindBookingSlotByID: 予約枠の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunBookingSlotID
+//
+// return:
+//   - model.DogrunBookingSlot:	予約枠。存在しない場合は空
+//   - error:	エラー
+func (r *dogrunReservationRepository) FindBookingSlotByID(c echo.Context, slotID int64) (model.DogrunBookingSlot, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	slot := model.DogrunBookingSlot{}
+	if err := r.db.Where("dogrun_booking_slot_id = ?", slotID).Find(&slot).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunBookingSlot{}, errors.NewWRError(err, "予約枠の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return slot, nil
+}
+
+// FindBookingSlots: 期間内に開始する予約枠の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - time.Time:	期間の開始(含む)
+//   - time.Time:	期間の終了(含まない)
+//
+// return:
+//   - []model.DogrunBookingSlot:	開始日時順の予約枠
+//   - error:	エラー
+func (r *dogrunReservationRepository) FindBookingSlots(c echo.Context, dogrunID int64, from time.Time, to time.Time) ([]model.DogrunBookingSlot, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	slots := []model.DogrunBookingSlot{}
+	if err := r.db.
+		Where("dogrun_id = ? AND start_at >= ? AND start_at < ?", dogrunID, from, to).
+		Order("start_at ASC").
+		Find(&slots).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "予約枠の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return slots, nil
+}
+
+// CreateBookingSlots: 予約枠の一括登録
+// 同じ開始日時の予約枠が既にある場合はスキップする
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - []model.DogrunBookingSlot:	予約枠
+//
+// return:
+//   - int64:	登録した件数
+//   - error:	エラー
+func (r *dogrunReservationRepository) CreateBookingSlots(c echo.Context, slots []model.DogrunBookingSlot) (int64, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if len(slots) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "dogrun_id"}, {Name: "start_at"}},
+		DoNothing: true,
+	}).Create(&slots)
+	if result.Error != nil {
+		logger.Error(result.Error)
+		return 0, errors.NewWRError(result.Error, "予約枠の登録に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return result.RowsAffected, nil
+}
+
+// FindReservationByID: 予約の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunReservationID
+//
+// return:
+//   - model.DogrunReservation:	予約枠、予約したdogを含む予約。存在しない場合は空
+//   - error:	エラー
+func (r *dogrunReservationRepository) FindReservationByID(c echo.Context, reservationID int64) (model.DogrunReservation, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	reservation := model.DogrunReservation{}
+	if err := preloadReservationRelations(r.db).
+		Where("dogrun_reservation_id = ?", reservationID).
+		Find(&reservation).Error; err != nil {
+		logger.Error(err)
+		return model.DogrunReservation{}, errors.NewWRError(err, "予約の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return reservation, nil
+}
+
+// FindReservationsByDogOwnerID: dogownerの予約一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogOwnerID
+//
+// return:
+//   - []model.DogrunReservation:	予約枠の開始日時の新しい順の予約
+//   - error:	エラー
+func (r *dogrunReservationRepository) FindReservationsByDogOwnerID(c echo.Context, dogOwnerID int64) ([]model.DogrunReservation, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	reservations := []model.DogrunReservation{}
+	if err := preloadReservationRelations(r.db).
+		Joins("JOIN dogrun_booking_slots ON dogrun_booking_slots.dogrun_booking_slot_id = dogrun_reservations.dogrun_booking_slot_id").
+		Where("dogrun_reservations.dog_owner_id = ?", dogOwnerID).
+		Order("dogrun_booking_slots.start_at DESC").
+		Find(&reservations).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "予約一覧の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return reservations, nil
+}
+
+// FindReservationsByDogrunID: ドッグランの期間内の予約枠の予約一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - time.Time:	期間の開始(含む)
+//   - time.Time:	期間の終了(含まない)
+//
+// return:
+//   - []model.DogrunReservation:	予約枠の開始日時順の予約(dogownerを含む)
+//   - error:	エラー
+func (r *dogrunReservationRepository) FindReservationsByDogrunID(c echo.Context, dogrunID int64, from time.Time, to time.Time) ([]model.DogrunReservation, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	reservations := []model.DogrunReservation{}
+	if err := preloadReservationRelations(r.db).
+		Preload("DogOwner").
+		Joins("JOIN dogrun_booking_slots ON dogrun_booking_slots.dogrun_booking_slot_id = dogrun_reservations.dogrun_booking_slot_id").
+		Where("dogrun_reservations.dogrun_id = ?", dogrunID).
+		Where("dogrun_booking_slots.start_at >= ? AND dogrun_booking_slots.start_at < ?", from, to).
+		Order("dogrun_booking_slots.start_at ASC").
+		Order("dogrun_reservations.dogrun_reservation_id ASC").
+		Find(&reservations).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "ドッグランの予約一覧の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return reservations, nil
+}
+
+// CountNoShows: dogownerごとの無断キャンセルの件数
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - []int64:	dogOwnerIDs
+//   - time.Time:	この日時以降に記録された無断キャンセルを対象とする
+//
+// return:
+//   - map[int64]int64:	dogOwnerIDごとの件数
+//   - error:	エラー
+func (r *dogrunReservationRepository) CountNoShows(c echo.Context, dogOwnerIDs []int64, since time.Time) (map[int64]int64, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	type noShowCount struct {
+		DogOwnerID int64
+		Count      int64
+	}
+	counts := []noShowCount{}
+	if err := r.db.Model(&model.DogrunReservation{}).
+		Select("dog_owner_id, COUNT(*) AS count").
+		Where("dog_owner_id IN ? AND status = ? AND no_show_at >= ?", dogOwnerIDs, model.DOGRUN_RESERVATION_STATUS_NO_SHOW, since).
+		Group("dog_owner_id").
+		Scan(&counts).Error; err != nil {
+		logger.Error(err)
+		return nil, errors.NewWRError(err, "無断キャンセルの件数の取得に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+
+	noShows := map[int64]int64{}
+	for _, count := range counts {
+		noShows[count.DogOwnerID] = count.Count
+	}
+	return noShows, nil
+}
+
+// UpdateReservationNoShow: 予約を無断キャンセルとして記録
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunReservationID
+//
+// return:
+//   - error:	エラー
+func (r *dogrunReservationRepository) UpdateReservationNoShow(c echo.Context, reservationID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.Model(&model.DogrunReservation{}).
+		Where("dogrun_reservation_id = ? AND status = ?", reservationID, model.DOGRUN_RESERVATION_STATUS_RESERVED).
+		Updates(map[string]any{
+			"status":     model.DOGRUN_RESERVATION_STATUS_NO_SHOW,
+			"no_show_at": time.Now(),
+		}).Error; err != nil {
+		logger.Error(err)
+		return errors.NewWRError(err, "無断キャンセルの記録に失敗しました。", errors.NewDogrunServerErrorEType())
+	}
+	return nil
+}
+
+/*
+予約のリレーション(予約枠、予約したdog)のpreload
+*/
+func preloadReservationRelations(db *gorm.DB) *gorm.DB {
+	return db.Preload("DogrunBookingSlot").
+		Preload("DogrunReservationDogs").
+		Preload("DogrunReservationDogs.Dog")
+}
@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IDogrunReservationScopeRepository interface {
+	FindBookingSlotForUpdate(tx *gorm.DB, c echo.Context, slotID int64) (model.DogrunBookingSlot, error)
+	FindReservationForUpdate(tx *gorm.DB, c echo.Context, reservationID int64) (model.DogrunReservation, error)
+	ExistsReservedDogs(tx *gorm.DB, c echo.Context, slotID int64, dogIDs []int64) (bool, error)
+	CreateReservation(tx *gorm.DB, c echo.Context, reservation *model.DogrunReservation) error
+	UpdateBookingSlotReservedCount(tx *gorm.DB, c echo.Context, slot model.DogrunBookingSlot) error
+	CancelReservation(tx *gorm.DB, c echo.Context, reservationID int64) error
+	LinkPaymentToReservation(tx *gorm.DB, c echo.Context, paymentID int64, reservationID int64, validUntil time.Time) (bool, error)
+	DetachDog(tx *gorm.DB, c echo.Context, dogID int64) error
+}
+
+type dogrunReservationScopeRepository struct {
+}
+
+func NewDogrunReservationScopeRepository() IDogrunReservationScopeRepository {
+	return &dogrunReservationScopeRepository{}
+}
+
+// FindBookingSlotForUpdate: 予約枠を行ロックして取得
+// 同じ予約枠への予約、キャンセルはトランザクション終了まで待機させる
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunBookingSlotID
+//
+// return:
+//   - model.DogrunBookingSlot: 予約枠。存在しない場合は空
+//   - error: error情報
+func (rsr *dogrunReservationScopeRepository) FindBookingSlotForUpdate(
+	tx *gorm.DB,
+	c echo.Context,
+	slotID int64,
+) (model.DogrunBookingSlot, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	slot := model.DogrunBookingSlot{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("dogrun_booking_slot_id = ?", slotID).
+		Find(&slot).Error; err != nil {
+		logger.Error("Failed to lock DogrunBookingSlot: ", err)
+		return model.DogrunBookingSlot{}, wrErrors.NewWRError(
+			err,
+			"予約枠の取得に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return slot, nil
+}
+
+// FindReservationForUpdate: 予約を行ロックして取得
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunReservationID
+//
+// return:
+//   - model.DogrunReservation: 予約。存在しない場合は空
+//   - error: error情報
+func (rsr *dogrunReservationScopeRepository) FindReservationForUpdate(
+	tx *gorm.DB,
+	c echo.Context,
+	reservationID int64,
+) (model.DogrunReservation, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	reservation := model.DogrunReservation{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("dogrun_reservation_id = ?", reservationID).
+		Find(&reservation).Error; err != nil {
+		logger.Error("Failed to lock DogrunReservation: ", err)
+		return model.DogrunReservation{}, wrErrors.NewWRError(
+			err,
+			"予約の取得に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return reservation, nil
+}
+
+// ExistsReservedDogs: 予約枠に予約中のdogが含まれるか
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunBookingSlotID
+//   - []int64: dogIDs
+//
+// return:
+//   - bool: 含まれる場合はtrue
+//   - error: error情報
+func (rsr *dogrunReservationScopeRepository) ExistsReservedDogs(
+	tx *gorm.DB,
+	c echo.Context,
+	slotID int64,
+	dogIDs []int64,
+) (bool, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	var count int64
+	if err := tx.Model(&model.DogrunReservationDog{}).
+		Joins("JOIN dogrun_reservations ON dogrun_reservations.dogrun_reservation_id = dogrun_reservation_dogs.dogrun_reservation_id").
+		Where("dogrun_reservations.dogrun_booking_slot_id = ? AND dogrun_reservations.status = ?", slotID, model.DOGRUN_RESERVATION_STATUS_RESERVED).
+		Where("dogrun_reservation_dogs.dog_id IN ?", dogIDs).
+		Count(&count).Error; err != nil {
+		logger.Error("Failed to count DogrunReservationDogs: ", err)
+		return false, wrErrors.NewWRError(
+			err,
+			"予約済みのdogの確認に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return count > 0, nil
+}
+
+// CreateReservation: 予約(予約したdogを含む)の登録
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - *model.DogrunReservation: 予約
+//
+// return:
+//   - error: error情報
+func (rsr *dogrunReservationScopeRepository) CreateReservation(
+	tx *gorm.DB,
+	c echo.Context,
+	reservation *model.DogrunReservation,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := tx.Create(reservation).Error; err != nil {
+		logger.Error("Failed to create DogrunReservation: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"予約の登録に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return nil
+}
+
+// UpdateBookingSlotReservedCount: 予約枠の予約数、貸切の更新
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - model.DogrunBookingSlot: 更新後の予約枠
+//
+// return:
+//   - error: error情報
+func (rsr *dogrunReservationScopeRepository) UpdateBookingSlotReservedCount(
+	tx *gorm.DB,
+	c echo.Context,
+	slot model.DogrunBookingSlot,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := tx.Model(&model.DogrunBookingSlot{}).
+		Where("dogrun_booking_slot_id = ?", slot.DogrunBookingSlotID).
+		Updates(map[string]any{
+			"reserved_count": slot.ReservedCount,
+			"is_private":     slot.IsPrivate,
+			"upd_at":         time.Now(),
+		}).Error; err != nil {
+		logger.Error("Failed to update DogrunBookingSlot: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"予約枠の更新に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return nil
+}
+
+// CancelReservation: 予約のキャンセル
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunReservationID
+//
+// return:
+//   - error: error情報
+func (rsr *dogrunReservationScopeRepository) CancelReservation(
+	tx *gorm.DB,
+	c echo.Context,
+	reservationID int64,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := tx.Model(&model.DogrunReservation{}).
+		Where("dogrun_reservation_id = ?", reservationID).
+		Updates(map[string]any{
+			"status":       model.DOGRUN_RESERVATION_STATUS_CANCELLED,
+			"cancelled_at": time.Now(),
+		}).Error; err != nil {
+		logger.Error("Failed to cancel DogrunReservation: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"予約のキャンセルに失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return nil
+}
+
+// LinkPaymentToReservation: 支払いを予約に充てる
+// 他の予約に充てられていない支払いのみ更新し、入場に使える期限を予約枠の終了日時にする
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogrunPaymentID
+//   - int64: dogrunReservationID
+//   - time.Time: 入場に使える期限
+//
+// return:
+//   - bool: 充てられた場合はtrue。既に他の予約に充てられている場合はfalse
+//   - error: error情報
+func (rsr *dogrunReservationScopeRepository) LinkPaymentToReservation(
+	tx *gorm.DB,
+	c echo.Context,
+	paymentID int64,
+	reservationID int64,
+	validUntil time.Time,
+) (bool, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	result := tx.Model(&model.DogrunPayment{}).
+		Where("dogrun_payment_id = ? AND dogrun_reservation_id IS NULL", paymentID).
+		Updates(map[string]any{
+			"dogrun_reservation_id": reservationID,
+			"valid_until":           validUntil,
+			"upd_at":                time.Now(),
+		})
+	if result.Error != nil {
+		logger.Error("Failed to link DogrunPayment: ", result.Error)
+		return false, wrErrors.NewWRError(
+			result.Error,
+			"支払いの予約への紐付けに失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DetachDog: 予約からのdogの除外(dogの削除時)
+// 開始前の予約中の予約は予約枠の予約数を戻し、dogがいなくなった予約はキャンセルする
+// 予約のキャンセルと同じく、予約、予約枠の順で行ロックする
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - int64: dogID
+//
+// return:
+//   - error: error情報
+func (rsr *dogrunReservationScopeRepository) DetachDog(
+	tx *gorm.DB,
+	c echo.Context,
+	dogID int64,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	reservations := []model.DogrunReservation{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "dogrun_reservations"}}).
+		Joins("JOIN dogrun_booking_slots ON dogrun_booking_slots.dogrun_booking_slot_id = dogrun_reservations.dogrun_booking_slot_id").
+		Where("dogrun_reservations.status = ?", model.DOGRUN_RESERVATION_STATUS_RESERVED).
+		Where("dogrun_booking_slots.start_at > ?", time.Now()).
+		Where("dogrun_reservations.dogrun_reservation_id IN (?)", tx.Model(&model.DogrunReservationDog{}).
+			Select("dogrun_reservation_id").
+			Where("dog_id = ?", dogID)).
+		Find(&reservations).Error; err != nil {
+		logger.Error("Failed to lock DogrunReservations: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"予約の取得に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+
+	for _, reservation := range reservations {
+		slot, wrErr := rsr.FindBookingSlotForUpdate(tx, c, reservation.DogrunBookingSlotID.Int64)
+		if wrErr != nil {
+			return wrErr
+		}
+
+		if reservation.DogCount.Int64 <= 1 {
+			slot.Release(reservation.DogCount.Int64, reservation.IsPrivate())
+			if wrErr := rsr.CancelReservation(tx, c, reservation.DogrunReservationID.Int64); wrErr != nil {
+				return wrErr
+			}
+		} else {
+			slot.Release(1, false)
+			if err := tx.Model(&model.DogrunReservation{}).
+				Where("dogrun_reservation_id = ?", reservation.DogrunReservationID).
+				Updates(map[string]any{
+					"dog_count": reservation.DogCount.Int64 - 1,
+					"upd_at":    time.Now(),
+				}).Error; err != nil {
+				logger.Error("Failed to update DogrunReservation: ", err)
+				return wrErrors.NewWRError(
+					err,
+					"予約の更新に失敗しました。",
+					wrErrors.NewDogrunServerErrorEType(),
+				)
+			}
+		}
+
+		if wrErr := rsr.UpdateBookingSlotReservedCount(tx, c, slot); wrErr != nil {
+			return wrErr
+		}
+	}
+
+	// 開始済み・キャンセル済みの予約も含め、予約したdogから除く
+	if err := tx.Where("dog_id = ?", dogID).Delete(&model.DogrunReservationDog{}).Error; err != nil {
+		logger.Error("Failed to delete DogrunReservationDogs: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"予約したdogの削除に失敗しました。",
+			wrErrors.NewDogrunServerErrorEType(),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+/*
+SQLの生成のみ行うDB。DBへの接続は行わず、生成したSQLを記録する
+*/
+func newDryRunDB(t *testing.T) (*gorm.DB, *[]string) {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+
+	queries := []string{}
+	if err := db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		queries = append(queries, tx.Statement.SQL.String())
+	}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	return db, &queries
+}
+
+func newTestContext() echo.Context {
+	log.SetLogger(zap.NewNop())
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func TestDogrunReservationScopeRepositoryLocking(t *testing.T) {
+	rsr := NewDogrunReservationScopeRepository()
+
+	tests := []struct {
+		name      string
+		find      func(tx *gorm.DB, c echo.Context) error
+		wantTable string
+		wantWhere string
+	}{
+		{
+			name: "予約枠",
+			find: func(tx *gorm.DB, c echo.Context) error {
+				_, err := rsr.FindBookingSlotForUpdate(tx, c, 1)
+				return err
+			},
+			wantTable: `"dogrun_booking_slots"`,
+			wantWhere: "dogrun_booking_slot_id = $1",
+		},
+		{
+			name: "予約",
+			find: func(tx *gorm.DB, c echo.Context) error {
+				_, err := rsr.FindReservationForUpdate(tx, c, 1)
+				return err
+			},
+			wantTable: `"dogrun_reservations"`,
+			wantWhere: "dogrun_reservation_id = $1",
+		},
+		{
+			name: "dogの除外対象の予約",
+			find: func(tx *gorm.DB, c echo.Context) error {
+				return rsr.DetachDog(tx, c, 1)
+			},
+			wantTable: `FOR UPDATE OF "dogrun_reservations"`,
+			wantWhere: "dog_id = $",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, queries := newDryRunDB(t)
+			if err := tt.find(tx, newTestContext()); err != nil {
+				t.Fatalf("find error = %v", err)
+			}
+			if len(*queries) == 0 {
+				t.Fatalf("queries = %q, want locking query", *queries)
+			}
+
+			// サブクエリも記録されるため、最後の(外側の)クエリを確認する
+			query := (*queries)[len(*queries)-1]
+			for _, want := range []string{tt.wantTable, tt.wantWhere, "FOR UPDATE"} {
+				if !strings.Contains(query, want) {
+					t.Errorf("query = %q, want to contain %q", query, want)
+				}
+			}
+		})
+	}
+}
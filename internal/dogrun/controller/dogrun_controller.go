@@ -1,11 +1,17 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
+	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	cmsDTO "github.com/wanrun-develop/wanrun/internal/cms/core/dto"
 	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
 	"github.com/wanrun-develop/wanrun/internal/dogrun/core/handler"
 	"github.com/wanrun-develop/wanrun/pkg/errors"
@@ -16,16 +22,80 @@ type IDogrunController interface {
 	GetDogrunDetail(echo.Context) error
 	GetDogrun(echo.Context) error
 	GetDogrunTagMst(echo.Context) error
+	GetDogrunTags(echo.Context) error
+	SaveDogrunTags(echo.Context) error
+	SearchDogrunTagFacets(echo.Context) error
 	SearchAroundDogruns(echo.Context) error
 	GetDogrunPhoto(echo.Context) error
+	GetDogrunImages(echo.Context) error
+	UploadDogrunImage(echo.Context) error
+	SortDogrunImages(echo.Context) error
+	DeleteDogrunImage(echo.Context) error
+	GetDogrunEntryCriteria(echo.Context) error
+	SaveDogrunEntryCriteria(echo.Context) error
+	GetDogrunEvents(echo.Context) error
+	GetDogrunEvent(echo.Context) error
+	CreateDogrunMeetup(echo.Context) error
+	CreateDogrunOfficialEvent(echo.Context) error
+	CancelDogrunEvent(echo.Context) error
+	RsvpDogrunEvent(echo.Context) error
+	CancelDogrunEventRsvp(echo.Context) error
+	GetDogrunBookingSetting(echo.Context) error
+	SaveDogrunBookingSetting(echo.Context) error
+	GenerateDogrunBookingSlots(echo.Context) error
+	GetDogrunBookingSlots(echo.Context) error
+	ReserveDogrunBookingSlot(echo.Context) error
+	GetMyDogrunReservations(echo.Context) error
+	CancelDogrunReservation(echo.Context) error
+	GetDogrunReservations(echo.Context) error
+	MarkDogrunReservationNoShow(echo.Context) error
+	GetDogrunFeePlans(echo.Context) error
+	CreateDogrunFeePlan(echo.Context) error
+	UpdateDogrunFeePlan(echo.Context) error
+	DeactivateDogrunFeePlan(echo.Context) error
+	GetDogrunPaymentSetting(echo.Context) error
+	SaveDogrunPaymentSetting(echo.Context) error
+	PayDogrunFee(echo.Context) error
+	GetMyDogrunPayments(echo.Context) error
+	GetDogrunPaymentReport(echo.Context) error
+	ApplyDogrunMembership(echo.Context) error
+	GetMyDogrunMemberships(echo.Context) error
+	WithdrawDogrunMembership(echo.Context) error
+	GetDogrunMemberships(echo.Context) error
+	GetManagedDogrunMembership(echo.Context) error
+	ApproveDogrunMembership(echo.Context) error
+	RejectDogrunMembership(echo.Context) error
+	SubmitDogrunClaim(echo.Context) error
+	GetMyDogrunClaims(echo.Context) error
+	WithdrawDogrunClaim(echo.Context) error
+	GetDogrunClaims(echo.Context) error
+	GetDogrunClaim(echo.Context) error
+	ApproveDogrunClaim(echo.Context) error
+	RejectDogrunClaim(echo.Context) error
+	SaveDogrunInfo(echo.Context) error
+	RepairDogrunLocations(echo.Context) error
+	SyncDogrunPlaces(echo.Context) error
 }
 
+// ギャラリー画像として許可する拡張子
+var allowedDogrunImageExtensions = []string{"jpg", "jpeg", "png", "webp"}
+
 type dogrunController struct {
-	h handler.IDogrunHandler
+	h   handler.IDogrunHandler
+	dih handler.IDogrunImageHandler
+	deh handler.IDogrunEntryHandler
+	evh handler.IDogrunEventHandler
+	rvh handler.IDogrunReservationHandler
+	dph handler.IDogrunPaymentHandler
+	dmh handler.IDogrunMembershipHandler
+	dch handler.IDogrunClaimHandler
+	dth handler.IDogrunTagHandler
+	dgh handler.IDogrunGeocodeHandler
+	dsh handler.IDogrunSyncHandler
 }
 
-func NewDogrunController(h handler.IDogrunHandler) IDogrunController {
-	return &dogrunController{h}
+func NewDogrunController(h handler.IDogrunHandler, dih handler.IDogrunImageHandler, deh handler.IDogrunEntryHandler, evh handler.IDogrunEventHandler, rvh handler.IDogrunReservationHandler, dph handler.IDogrunPaymentHandler, dmh handler.IDogrunMembershipHandler, dch handler.IDogrunClaimHandler, dth handler.IDogrunTagHandler, dgh handler.IDogrunGeocodeHandler, dsh handler.IDogrunSyncHandler) IDogrunController {
+	return &dogrunController{h, dih, deh, evh, rvh, dph, dmh, dch, dth, dgh, dsh}
 }
 
 // ドッグラン詳細情報の取得
@@ -69,13 +139,171 @@ func (dc *dogrunController) GetDogrunTagMst(c echo.Context) error {
 
 // ドッグランの周辺検索
 func (dc *dogrunController) SearchAroundDogruns(c echo.Context) error {
+	condition, err := bindSearchCondition(c)
+	if err != nil {
+		return err
+	}
+
+	resDogruns, err := dc.h.SearchAroundDogruns(c, condition)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resDogruns)
+}
+
+// SearchDogrunTagFacets: 周辺検索の条件に一致するドッグランのタグごとの件数の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) SearchDogrunTagFacets(c echo.Context) error {
+	condition, err := bindSearchCondition(c)
+	if err != nil {
+		return err
+	}
+
+	facets, err := dc.dth.GetTagFacets(c, condition)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, facets)
+}
+
+// GetDogrunTags: ドッグランに設定されているタグの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetDogrunTags(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	dogrunTags, err := dc.dth.GetDogrunTags(c, dogrunID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, dogrunTags)
+}
+
+// SaveDogrunTags: ドッグランに設定するタグの置き換え
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) SaveDogrunTags(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var reqBody dto.DogrunTagReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	dogrunTags, err := dc.dth.SaveDogrunTags(c, dogrunID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, dogrunTags)
+}
+
+// SaveDogrunInfo: ドッグランの基本情報の更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) SaveDogrunInfo(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var reqBody dto.DogrunInfoReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	dogrunInfo, err := dc.dgh.SaveDogrunInfo(c, dogrunID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, dogrunInfo)
+}
+
+// RepairDogrunLocations: 座標か郵便番号がないドッグランの一括補完
+// dryRun=falseを指定した場合のみ更新する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) RepairDogrunLocations(c echo.Context) error {
+	dryRun := c.QueryParam("dryRun") != "false"
+
+	repairRes, err := dc.dgh.RepairDogrunLocations(c, dryRun)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, repairRes)
+}
+
+// SyncDogrunPlaces: google place情報の同期を手動で実行
+// 定期同期と同じく、最終同期日時の古いドッグランからバッチサイズ分を同期する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) SyncDogrunPlaces(c echo.Context) error {
+	syncRes, err := dc.dsh.SyncPlaces(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, syncRes)
+}
+
+/*
+周辺検索の条件のバインドとバリデーション
+*/
+func bindSearchCondition(c echo.Context) (dto.SearchAroundRectangleCondition, error) {
 	logger := log.GetLogger(c).Sugar()
 	//リクエストボディをバインド
 	var condition dto.SearchAroundRectangleCondition
 	if err := c.Bind(&condition); err != nil {
 		err = errors.NewWRError(err, "検索条件が不正です", errors.NewDogrunClientErrorEType())
 		logger.Error(err)
-		return err
+		return condition, err
 	}
 	// バリデータのインスタンス作成
 	validate := validator.New()
@@ -87,27 +315,9 @@ func (dc *dogrunController) SearchAroundDogruns(c echo.Context) error {
 	if err := validate.Struct(condition); err != nil {
 		err = errors.NewWRError(err, "検索条件のバリデーションに違反しています", errors.NewDogrunClientErrorEType())
 		logger.Error(err)
-		return err
-	}
-
-	if len(condition.IncludeDogrunTags) == 0 {
-		//ドッグランタグ検索条件がない場合は、google検索を基準とする
-		if resDogruns, err := dc.h.SearchAroundDogruns(c, condition); err != nil {
-			return err
-		} else {
-			return c.JSON(http.StatusOK, resDogruns)
-
-		}
-	} else {
-		//ドッグランタグ検索条件がある場合は、DB検索を基準とする
-		if resDogruns, err := dc.h.SearchAroundAndTagDogruns(c, condition); err != nil {
-			return err
-		} else {
-			return c.JSON(http.StatusOK, resDogruns)
-
-		}
+		return condition, err
 	}
-
+	return condition, nil
 }
 
 // ドッグランの画像nameよりsrcUrlの取得
@@ -142,6 +352,1219 @@ func (dc *dogrunController) GetDogrunPhoto(c echo.Context) error {
 	})
 }
 
+// GetDogrunImages: ドッグランのギャラリー画像一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetDogrunImages(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	dogrunImages, err := dc.dih.GetDogrunImages(c, dogrunID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, dogrunImages)
+}
+
+// UploadDogrunImage: ドッグランのギャラリー画像のアップロード
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) UploadDogrunImage(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	// フォームからファイルの取得
+	file, err := c.FormFile("file")
+	if err != nil {
+		err = errors.NewWRError(err, "ファイルデータに不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	// 拡張子のチェック
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(file.Filename), "."))
+	if !slices.Contains(allowedDogrunImageExtensions, ext) {
+		err = errors.NewWRError(nil, "画像ファイルの拡張子が不正です。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		err = errors.NewWRError(err, "ファイルデータに不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	defer src.Close()
+
+	fuq := cmsDTO.FileUploadReq{
+		FileName:  strings.TrimSuffix(file.Filename, filepath.Ext(file.Filename)),
+		Extension: ext,
+		Src:       src,
+	}
+
+	dogrunImage, err := dc.dih.UploadDogrunImage(c, dogrunID, fuq)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, dogrunImage)
+}
+
+// SortDogrunImages: ドッグランのギャラリー画像の並び替え
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) SortDogrunImages(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var reqBody dto.DogrunImageSortReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	dogrunImages, err := dc.dih.SortDogrunImages(c, dogrunID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, dogrunImages)
+}
+
+// DeleteDogrunImage: ドッグランのギャラリー画像の削除
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) DeleteDogrunImage(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+	dogrunImageID, err := parseIDParam(c, "imageId")
+	if err != nil {
+		return err
+	}
+
+	if err := dc.dih.DeleteDogrunImage(c, dogrunID, dogrunImageID); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetDogrunEntryCriteria: ドッグランの入場条件の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetDogrunEntryCriteria(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	entryCriteria, err := dc.deh.GetEntryCriteria(c, dogrunID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, entryCriteria)
+}
+
+// SaveDogrunEntryCriteria: ドッグランの入場条件の登録・更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) SaveDogrunEntryCriteria(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var reqBody dto.DogrunEntryCriteriaReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	entryCriteria, err := dc.deh.SaveEntryCriteria(c, dogrunID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, entryCriteria)
+}
+
+// GetDogrunEvents: ドッグランの開催予定のイベント一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetDogrunEvents(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	events, err := dc.evh.GetEvents(c, dogrunID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, events)
+}
+
+// GetDogrunEvent: イベントの詳細の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetDogrunEvent(c echo.Context) error {
+	eventID, err := parseIDParam(c, "eventId")
+	if err != nil {
+		return err
+	}
+
+	event, err := dc.evh.GetEvent(c, eventID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, event)
+}
+
+// CreateDogrunMeetup: オフ会の登録
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) CreateDogrunMeetup(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+	reqBody, err := bindDogrunEventReq(c)
+	if err != nil {
+		return err
+	}
+
+	event, err := dc.evh.CreateMeetup(c, dogrunID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, event)
+}
+
+// CreateDogrunOfficialEvent: 公式イベントの登録
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) CreateDogrunOfficialEvent(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+	reqBody, err := bindDogrunEventReq(c)
+	if err != nil {
+		return err
+	}
+
+	event, err := dc.evh.CreateOfficialEvent(c, dogrunID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, event)
+}
+
+// CancelDogrunEvent: イベントの中止
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) CancelDogrunEvent(c echo.Context) error {
+	eventID, err := parseIDParam(c, "eventId")
+	if err != nil {
+		return err
+	}
+
+	if err := dc.evh.CancelEvent(c, eventID); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RsvpDogrunEvent: イベントへの参加表明
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) RsvpDogrunEvent(c echo.Context) error {
+	eventID, err := parseIDParam(c, "eventId")
+	if err != nil {
+		return err
+	}
+	reqBody, err := bindDogrunEventRsvpReq(c)
+	if err != nil {
+		return err
+	}
+
+	event, err := dc.evh.Rsvp(c, eventID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, event)
+}
+
+// CancelDogrunEventRsvp: イベントへの参加表明の取消
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) CancelDogrunEventRsvp(c echo.Context) error {
+	eventID, err := parseIDParam(c, "eventId")
+	if err != nil {
+		return err
+	}
+	reqBody, err := bindDogrunEventRsvpReq(c)
+	if err != nil {
+		return err
+	}
+
+	if err := dc.evh.CancelRsvp(c, eventID, reqBody); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetDogrunBookingSetting: ドッグランの予約設定の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetDogrunBookingSetting(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	setting, err := dc.rvh.GetBookingSetting(c, dogrunID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, setting)
+}
+
+// SaveDogrunBookingSetting: ドッグランの予約設定の登録・更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) SaveDogrunBookingSetting(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var reqBody dto.DogrunBookingSettingReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	setting, err := dc.rvh.SaveBookingSetting(c, dogrunID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, setting)
+}
+
+// GenerateDogrunBookingSlots: 営業時間からの予約枠の生成
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GenerateDogrunBookingSlots(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var reqBody dto.DogrunBookingSlotGenerateReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	slots, err := dc.rvh.GenerateBookingSlots(c, dogrunID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, slots)
+}
+
+// GetDogrunBookingSlots: 指定日の予約枠の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetDogrunBookingSlots(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+	date, err := parseDateQuery(c)
+	if err != nil {
+		return err
+	}
+
+	slots, err := dc.rvh.GetBookingSlots(c, dogrunID, date)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, slots)
+}
+
+// ReserveDogrunBookingSlot: 予約枠の予約
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) ReserveDogrunBookingSlot(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	var reqBody dto.DogrunReservationReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	reservation, err := dc.rvh.Reserve(c, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, reservation)
+}
+
+// GetMyDogrunReservations: ログインユーザーの予約一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetMyDogrunReservations(c echo.Context) error {
+	reservations, err := dc.rvh.GetMyReservations(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, reservations)
+}
+
+// CancelDogrunReservation: 予約のキャンセル
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) CancelDogrunReservation(c echo.Context) error {
+	reservationID, err := parseIDParam(c, "reservationId")
+	if err != nil {
+		return err
+	}
+
+	if err := dc.rvh.CancelReservation(c, reservationID); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetDogrunReservations: マネージャー向けの指定日の予約一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetDogrunReservations(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+	date, err := parseDateQuery(c)
+	if err != nil {
+		return err
+	}
+
+	reservations, err := dc.rvh.GetDogrunReservations(c, dogrunID, date)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, reservations)
+}
+
+// MarkDogrunReservationNoShow: 予約を無断キャンセルとして記録
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) MarkDogrunReservationNoShow(c echo.Context) error {
+	reservationID, err := parseIDParam(c, "reservationId")
+	if err != nil {
+		return err
+	}
+
+	if err := dc.rvh.MarkNoShow(c, reservationID); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetDogrunFeePlans: ドッグランの販売中の料金プラン一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetDogrunFeePlans(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	feePlans, err := dc.dph.GetFeePlans(c, dogrunID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, feePlans)
+}
+
+// CreateDogrunFeePlan: 料金プランの登録
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) CreateDogrunFeePlan(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+	reqBody, err := bindDogrunFeePlanReq(c)
+	if err != nil {
+		return err
+	}
+
+	feePlan, err := dc.dph.CreateFeePlan(c, dogrunID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, feePlan)
+}
+
+// UpdateDogrunFeePlan: 料金プランの更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) UpdateDogrunFeePlan(c echo.Context) error {
+	feePlanID, err := parseIDParam(c, "feePlanId")
+	if err != nil {
+		return err
+	}
+	reqBody, err := bindDogrunFeePlanReq(c)
+	if err != nil {
+		return err
+	}
+
+	feePlan, err := dc.dph.UpdateFeePlan(c, feePlanID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, feePlan)
+}
+
+// DeactivateDogrunFeePlan: 料金プランの販売停止
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) DeactivateDogrunFeePlan(c echo.Context) error {
+	feePlanID, err := parseIDParam(c, "feePlanId")
+	if err != nil {
+		return err
+	}
+
+	if err := dc.dph.DeactivateFeePlan(c, feePlanID); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetDogrunPaymentSetting: ドッグランの支払い設定の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetDogrunPaymentSetting(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	setting, err := dc.dph.GetPaymentSetting(c, dogrunID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, setting)
+}
+
+// SaveDogrunPaymentSetting: ドッグランの支払い設定の登録・更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) SaveDogrunPaymentSetting(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var reqBody dto.DogrunPaymentSettingReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	setting, err := dc.dph.SavePaymentSetting(c, dogrunID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, setting)
+}
+
+// PayDogrunFee: 料金プランの支払い
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) PayDogrunFee(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	var reqBody dto.DogrunPaymentReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	payment, err := dc.dph.Pay(c, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, payment)
+}
+
+// GetMyDogrunPayments: ログインユーザーの支払い履歴の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetMyDogrunPayments(c echo.Context) error {
+	payments, err := dc.dph.GetMyPayments(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, payments)
+}
+
+// GetDogrunPaymentReport: マネージャー向けの支払い履歴レポート
+// 期間の指定がない場合は当月1日から当日まで
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetDogrunPaymentReport(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+	today := truncateToDate(time.Now())
+	from, err := parseDateQueryParam(c, "from", today.AddDate(0, 0, 1-today.Day()))
+	if err != nil {
+		return err
+	}
+	to, err := parseDateQueryParam(c, "to", today)
+	if err != nil {
+		return err
+	}
+
+	report, err := dc.dph.GetPaymentReport(c, dogrunID, from, to)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, report)
+}
+
+// ApplyDogrunMembership: ドッグランへの会員登録の申請
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) ApplyDogrunMembership(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var reqBody dto.DogrunMembershipReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	membership, err := dc.dmh.ApplyMembership(c, dogrunID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, membership)
+}
+
+// GetMyDogrunMemberships: ログインユーザーの会員登録一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetMyDogrunMemberships(c echo.Context) error {
+	memberships, err := dc.dmh.GetMyMemberships(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, memberships)
+}
+
+// WithdrawDogrunMembership: 会員登録の取り下げ
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) WithdrawDogrunMembership(c echo.Context) error {
+	membershipID, err := parseIDParam(c, "membershipId")
+	if err != nil {
+		return err
+	}
+
+	if err := dc.dmh.WithdrawMembership(c, membershipID); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetDogrunMemberships: マネージャー向けのドッグランの会員登録一覧の取得
+// statusの指定がない場合は全て
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetDogrunMemberships(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	memberships, err := dc.dmh.GetDogrunMemberships(c, dogrunID, c.QueryParam("status"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, memberships)
+}
+
+// GetManagedDogrunMembership: マネージャー向けの会員登録の詳細(添付書類のURLを含む)の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetManagedDogrunMembership(c echo.Context) error {
+	membershipID, err := parseIDParam(c, "membershipId")
+	if err != nil {
+		return err
+	}
+
+	membership, err := dc.dmh.GetManagedMembership(c, membershipID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, membership)
+}
+
+// ApproveDogrunMembership: 会員登録の承認
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) ApproveDogrunMembership(c echo.Context) error {
+	membershipID, err := parseIDParam(c, "membershipId")
+	if err != nil {
+		return err
+	}
+	reqBody, err := bindDogrunMembershipReviewReq(c)
+	if err != nil {
+		return err
+	}
+
+	membership, err := dc.dmh.ApproveMembership(c, membershipID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, membership)
+}
+
+// RejectDogrunMembership: 会員登録の却下
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) RejectDogrunMembership(c echo.Context) error {
+	membershipID, err := parseIDParam(c, "membershipId")
+	if err != nil {
+		return err
+	}
+	reqBody, err := bindDogrunMembershipReviewReq(c)
+	if err != nil {
+		return err
+	}
+
+	membership, err := dc.dmh.RejectMembership(c, membershipID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, membership)
+}
+
+// SubmitDogrunClaim: ドッグランの管理申請
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) SubmitDogrunClaim(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	var reqBody dto.DogrunClaimReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	claim, err := dc.dch.SubmitClaim(c, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, claim)
+}
+
+// GetMyDogrunClaims: ログイン中のマネージャーの管理申請一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetMyDogrunClaims(c echo.Context) error {
+	claims, err := dc.dch.GetMyClaims(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, claims)
+}
+
+// WithdrawDogrunClaim: 管理申請の取り下げ
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) WithdrawDogrunClaim(c echo.Context) error {
+	claimID, err := parseIDParam(c, "claimId")
+	if err != nil {
+		return err
+	}
+
+	if err := dc.dch.WithdrawClaim(c, claimID); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetDogrunClaims: 審査向けの管理申請一覧の取得
+// statusの指定がない場合は全て
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetDogrunClaims(c echo.Context) error {
+	claims, err := dc.dch.GetClaims(c, c.QueryParam("status"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, claims)
+}
+
+// GetDogrunClaim: 審査向けの管理申請の詳細(証拠書類のURL、履歴を含む)の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) GetDogrunClaim(c echo.Context) error {
+	claimID, err := parseIDParam(c, "claimId")
+	if err != nil {
+		return err
+	}
+
+	claim, err := dc.dch.GetClaim(c, claimID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, claim)
+}
+
+// ApproveDogrunClaim: 管理申請の承認
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) ApproveDogrunClaim(c echo.Context) error {
+	claimID, err := parseIDParam(c, "claimId")
+	if err != nil {
+		return err
+	}
+	reqBody, err := bindDogrunClaimReviewReq(c)
+	if err != nil {
+		return err
+	}
+
+	claim, err := dc.dch.ApproveClaim(c, claimID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, claim)
+}
+
+// RejectDogrunClaim: 管理申請の却下
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (dc *dogrunController) RejectDogrunClaim(c echo.Context) error {
+	claimID, err := parseIDParam(c, "claimId")
+	if err != nil {
+		return err
+	}
+	reqBody, err := bindDogrunClaimReviewReq(c)
+	if err != nil {
+		return err
+	}
+
+	claim, err := dc.dch.RejectClaim(c, claimID, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, claim)
+}
+
+/*
+イベント登録のリクエストボディのバインドとバリデーション
+*/
+func bindDogrunEventReq(c echo.Context) (dto.DogrunEventReq, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	var reqBody dto.DogrunEventReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunEventReq{}, err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunEventReq{}, err
+	}
+	return reqBody, nil
+}
+
+/*
+参加表明のリクエストボディのバインドとバリデーション
+*/
+func bindDogrunEventRsvpReq(c echo.Context) (dto.DogrunEventRsvpReq, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	var reqBody dto.DogrunEventRsvpReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunEventRsvpReq{}, err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunEventRsvpReq{}, err
+	}
+	return reqBody, nil
+}
+
+/*
+料金プランのリクエストボディのバインドとバリデーション
+*/
+func bindDogrunFeePlanReq(c echo.Context) (dto.DogrunFeePlanReq, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	var reqBody dto.DogrunFeePlanReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunFeePlanReq{}, err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunFeePlanReq{}, err
+	}
+	return reqBody, nil
+}
+
+/*
+会員登録の審査のリクエストボディのバインドとバリデーション
+*/
+func bindDogrunMembershipReviewReq(c echo.Context) (dto.DogrunMembershipReviewReq, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	var reqBody dto.DogrunMembershipReviewReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunMembershipReviewReq{}, err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunMembershipReviewReq{}, err
+	}
+	return reqBody, nil
+}
+
+/*
+管理申請の審査のリクエストボディのバインドとバリデーション
+*/
+func bindDogrunClaimReviewReq(c echo.Context) (dto.DogrunClaimReviewReq, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	var reqBody dto.DogrunClaimReviewReq
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunClaimReviewReq{}, err
+	}
+	if err := validator.New().Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "入力項目に不正があります。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunClaimReviewReq{}, err
+	}
+	return reqBody, nil
+}
+
+/*
+パスパラメータのIDの変換
+*/
+func parseIDParam(c echo.Context, name string) (int64, error) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id <= 0 {
+		err = errors.NewWRError(err, "パスパラメータのIDが不正です。", errors.NewDogrunClientErrorEType())
+		log.GetLogger(c).Sugar().Error(err)
+		return 0, err
+	}
+	return id, nil
+}
+
+/*
+クエリパラメータのdate(YYYY-MM-DD)の変換。未指定の場合は当日
+*/
+func parseDateQuery(c echo.Context) (time.Time, error) {
+	return parseDateQueryParam(c, "date", truncateToDate(time.Now()))
+}
+
+/*
+日付(YYYY-MM-DD)のクエリパラメータの変換。未指定の場合はデフォルト値
+*/
+func parseDateQueryParam(c echo.Context, name string, defaultDate time.Time) (time.Time, error) {
+	dateParam := c.QueryParam(name)
+	if dateParam == "" {
+		return defaultDate, nil
+	}
+	date, err := time.ParseInLocation(time.DateOnly, dateParam, time.Local)
+	if err != nil {
+		err = errors.NewWRError(err, fmt.Sprintf("クエリパラメータの%sが不正です。", name), errors.NewDogrunClientErrorEType())
+		log.GetLogger(c).Sugar().Error(err)
+		return time.Time{}, err
+	}
+	return date, nil
+}
+
+/*
+日時を当日の0時に切り捨て
+*/
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
 /*
 リクエストのクエリパラメータのpxのバリデーション
 */
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 /*
 円型検索のリクエストボディ
 */
@@ -24,12 +26,56 @@ type centerTarget struct {
 	Radius int `json:"radius" validate:"required,gte=0,lte=50000"` // 半径（0以上）
 }
 
+// ドッグランタグ検索の一致条件
+const (
+	TAG_MATCH_ANY = "any" // いずれかのタグを持つ
+	TAG_MATCH_ALL = "all" // 全てのタグを持つ
+)
+
+// 周辺検索の並び順
+const (
+	SEARCH_SORT_DISTANCE   = "distance"   // 検索範囲の中心から近い順
+	SEARCH_SORT_RATING     = "rating"     // googleの評価が高い順
+	SEARCH_SORT_POPULARITY = "popularity" // googleの評価件数が多い順
+)
+
 /*
 長方形型検索のリクエストボディ
 */
 type SearchAroundRectangleCondition struct {
 	Target            rectangleTarget `json:"target" validate:"required"`
+	Keyword           string          `json:"keyword" validate:"max=100"` // 名前・住所・説明・都道府県のキーワード。空白区切りで全て一致
 	IncludeDogrunTags []int64         `json:"includeDogrunTags" validate:"min=0,max=100"`
+	TagMatch          string          `json:"tagMatch" validate:"omitempty,oneof=any all"` // 未指定の場合はany
+	ExcludeDogrunTags []int64         `json:"excludeDogrunTags" validate:"min=0,max=100"`
+	NowOpen           bool            `json:"nowOpen"`                                                    // 営業中のみ
+	MinRating         float32         `json:"minRating" validate:"gte=0,lte=5"`                           // googleの評価の下限
+	ManagedOnly       bool            `json:"managedOnly"`                                                // 管理者のいるドッグランのみ
+	Sort              string          `json:"sort" validate:"omitempty,oneof=distance rating popularity"` // 未指定の場合はdistance
+	Cursor            string          `json:"cursor" validate:"max=512"`                                  // 前回のレスポンスのnextCursor
+	Limit             int             `json:"limit" validate:"gte=0,lte=100"`                             // 未指定の場合は20件
+}
+
+/*
+ドッグランタグ条件があるか
+*/
+func (c SearchAroundRectangleCondition) HasTagCondition() bool {
+	return len(c.IncludeDogrunTags) > 0 || len(c.ExcludeDogrunTags) > 0
+}
+
+/*
+全てのタグを持つドッグランのみを対象にするか
+*/
+func (c SearchAroundRectangleCondition) IsMatchAllTags() bool {
+	return c.TagMatch == TAG_MATCH_ALL
+}
+
+/*
+検索範囲の中心点
+*/
+func (c SearchAroundRectangleCondition) Center() (float64, float64) {
+	return (c.Target.Southwest.Latitude + c.Target.Northeast.Latitude) / 2,
+		(c.Target.Southwest.Longitude + c.Target.Northeast.Longitude) / 2
 }
 
 /*
@@ -39,3 +85,152 @@ type rectangleTarget struct {
 	Southwest pointer `json:"southwest" validate:"required"`
 	Northeast pointer `json:"northeast" validate:"required"`
 }
+
+/*
+ドッグランタグの設定のリクエストボディ
+指定したタグで置き換える
+*/
+type DogrunTagReq struct {
+	TagIDs []int64 `json:"tagIds" validate:"max=50,unique,dive,gt=0"`
+}
+
+/*
+ドッグランの基本情報の更新のリクエストボディ
+郵便番号・座標が未指定の場合は、住所から補完する
+*/
+type DogrunInfoReq struct {
+	Name        string   `json:"name" validate:"required,max=256"`
+	Address     string   `json:"address" validate:"required,max=256"`
+	PostCode    string   `json:"postcode" validate:"omitempty,len=8"` // ハイフンあり 例:100-0005
+	Latitude    *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude   *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+	Description string   `json:"description" validate:"max=2000"`
+}
+
+/*
+ギャラリー画像の並び替えのリクエストボディ
+指定順に表示順を振り直す
+*/
+type DogrunImageSortReq struct {
+	DogrunImageIDs []int64 `json:"dogrunImageIds" validate:"required,min=1,max=100,unique"`
+}
+
+/*
+入場条件の登録・更新のリクエストボディ
+*/
+type DogrunEntryCriteriaReq struct {
+	AllowedSizeClasses []string `json:"allowedSizeClasses" validate:"max=3,unique,dive,oneof=small medium large"` // 空の場合は制限なし
+	NeuteredRequired   bool     `json:"neuteredRequired"`
+	MicrochipRequired  bool     `json:"microchipRequired"`
+	MinAgeMonths       *int64   `json:"minAgeMonths" validate:"omitempty,gte=0,lte=240"`
+	MembersOnly        bool     `json:"membersOnly"` // 承認済みの会員のみ入場可
+}
+
+/*
+イベントの登録のリクエストボディ
+*/
+type DogrunEventReq struct {
+	Title                    string    `json:"title" validate:"required,max=128"`
+	Description              string    `json:"description" validate:"max=2000"`
+	StartAt                  time.Time `json:"startAt" validate:"required"`
+	EndAt                    time.Time `json:"endAt" validate:"required,gtfield=StartAt"`
+	Capacity                 *int64    `json:"capacity" validate:"omitempty,gte=1,lte=500"`                              // 参加可能なdogの数。未指定は制限なし
+	AllowedSizeClasses       []string  `json:"allowedSizeClasses" validate:"max=3,unique,dive,oneof=small medium large"` // 空の場合は制限なし
+	ApplySpecialBusinessHour bool      `json:"applySpecialBusinessHour"`                                                 // 公式イベントのみ。営業時間外の場合に特別営業時間を設定する
+}
+
+/*
+イベントへの参加表明・取消のリクエストボディ
+*/
+type DogrunEventRsvpReq struct {
+	DogIDs []int64 `json:"dogIds" validate:"required,min=1,max=10,unique"`
+}
+
+/*
+予約設定の登録・更新のリクエストボディ
+*/
+type DogrunBookingSettingReq struct {
+	SlotMinutes        int64 `json:"slotMinutes" validate:"required,gte=15,lte=240"`
+	SlotCapacity       int64 `json:"slotCapacity" validate:"required,gte=1,lte=500"`
+	AllowPrivateRental bool  `json:"allowPrivateRental"`
+}
+
+/*
+予約枠の生成のリクエストボディ
+*/
+type DogrunBookingSlotGenerateReq struct {
+	From string `json:"from" validate:"required,datetime=2006-01-02"` // 生成開始日
+	To   string `json:"to" validate:"required,datetime=2006-01-02"`   // 生成終了日(含む)
+}
+
+/*
+予約のリクエストボディ
+*/
+type DogrunReservationReq struct {
+	DogrunBookingSlotID int64   `json:"dogrunBookingSlotId" validate:"required"`
+	DogIDs              []int64 `json:"dogIds" validate:"required,min=1,max=10,unique"`
+	PrivateRental       bool    `json:"privateRental"`   // 貸切予約
+	DogrunPaymentID     int64   `json:"dogrunPaymentId"` // 予約に支払いが必須の場合に充てる支払い
+}
+
+/*
+料金プランのリクエストボディ
+*/
+type DogrunFeePlanReq struct {
+	Name           string `json:"name" validate:"required,max=64"`
+	FeeType        string `json:"feeType" validate:"required,oneof=per_visit per_dog membership"`
+	Amount         int64  `json:"amount" validate:"gte=0,lte=1000000"`                               // 料金(円)
+	MembershipDays int64  `json:"membershipDays" validate:"required_if=FeeType membership,lte=3660"` // 会員の有効日数
+}
+
+/*
+支払い設定のリクエストボディ
+*/
+type DogrunPaymentSettingReq struct {
+	CheckinPaymentRequired     bool `json:"checkinPaymentRequired"`
+	ReservationPaymentRequired bool `json:"reservationPaymentRequired"`
+}
+
+/*
+支払いのリクエストボディ
+*/
+type DogrunPaymentReq struct {
+	DogrunFeePlanID    int64   `json:"dogrunFeePlanId" validate:"required"`
+	DogIDs             []int64 `json:"dogIds" validate:"required,min=1,max=10,unique"`
+	PaymentMethodToken string  `json:"paymentMethodToken" validate:"required"`
+	IdempotencyKey     string  `json:"idempotencyKey" validate:"required,max=64"` // 二重決済防止のキー
+}
+
+/*
+会員登録の申請のリクエストボディ
+*/
+type DogrunMembershipReq struct {
+	DogIDs          []int64  `json:"dogIds" validate:"required,min=1,max=10,unique"`
+	DocumentFileIDs []string `json:"documentFileIds" validate:"required,min=1,max=5,unique,dive,required,max=64"` // ワクチン証明書などのfileID
+	Note            string   `json:"note" validate:"max=512"`
+}
+
+/*
+会員登録の審査のリクエストボディ
+*/
+type DogrunMembershipReviewReq struct {
+	Note string `json:"note" validate:"max=512"` // 却下の場合は必須
+}
+
+/*
+管理申請のリクエストボディ
+dogrunIdかplaceIdのどちらかを指定する
+*/
+type DogrunClaimReq struct {
+	DogrunID        int64    `json:"dogrunId" validate:"required_without=PlaceID"`
+	PlaceID         string   `json:"placeId" validate:"required_without=DogrunID,max=256"`
+	EvidenceNote    string   `json:"evidenceNote" validate:"required,max=1024"`                    // 管理者であることの説明
+	DocumentFileIDs []string `json:"documentFileIds" validate:"max=5,unique,dive,required,max=64"` // 営業許可証などのfileID
+}
+
+/*
+管理申請の審査のリクエストボディ
+*/
+type DogrunClaimReviewReq struct {
+	Note string `json:"note" validate:"max=512"` // 却下の場合は必須
+}
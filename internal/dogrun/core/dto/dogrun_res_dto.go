@@ -19,8 +19,10 @@ type DogrunDetail struct {
 	GoogleRating    float32      `json:"googleRating,omitempty"`
 	UserRatingCount int          `json:"userRatingCount,omitempty"`
 	DogrunTags      []int64      `json:"dogrunTagId,omitempty"`
+	Photos          []PhotoInfo  `json:"photos,omitempty"`
 	CreateAt        *time.Time   `json:"createAt,omitempty"`
 	UpdateAt        *time.Time   `json:"updateAt,omitempty"`
+	Provenance      Provenance   `json:"provenance,omitempty"` // 項目ごとの出所
 }
 
 // ドッグラン一覧での表示情報
@@ -40,6 +42,16 @@ type DogrunLists struct {
 	Photos            []PhotoInfo     `json:"photos,omitempty"`
 	IsBookmarked      bool            `json:"isBookmarked"`
 	IsManaged         bool            `json:"isManaged"`
+	Provenance        Provenance      `json:"provenance,omitempty"` // 項目ごとの出所
+}
+
+// 項目(name, address, postcode, location, description)ごとの出所
+type Provenance map[string]FieldProvenance
+
+// 項目の値の出所
+type FieldProvenance struct {
+	Source     string     `json:"source"`     // manager, imported, google
+	VerifiedAt *time.Time `json:"verifiedAt"` // 最終確認日時。不明な場合はnull
 }
 
 /*
@@ -63,6 +75,12 @@ func (d *DogrunLists) IsSufficientInfo() bool {
 	return true
 }
 
+// 周辺検索のレスポンス
+type DogrunSearchRes struct {
+	Dogruns    []DogrunLists `json:"dogruns"`
+	NextCursor string        `json:"nextCursor,omitempty"` // 次のページがない場合は空
+}
+
 // 営業日情報
 type BusinessHour struct {
 	Regular RegularBusinessHour   `json:"regular"`
@@ -93,10 +111,18 @@ type SpecialBusinessHour struct {
 	DayBusinessTime
 }
 
+// 画像の提供元
+const (
+	PHOTO_SOURCE_DOGRUN = "dogrun" // ドッグランマネージャーの登録画像
+	PHOTO_SOURCE_GOOGLE = "google" // google place photo
+)
+
 type PhotoInfo struct {
-	PhotoKey string `json:"photoKey"`
+	PhotoKey string `json:"photoKey,omitempty"` // google place photoのリソース名
+	FileID   string `json:"fileId,omitempty"`   // ギャラリー画像のfileID。/cms/file/:fileId/urlで取得する
 	WidthPx  uint   `json:"widthPx"`
 	HeightPx uint   `json:"heightPx"`
+	Source   string `json:"source"`
 }
 
 // 軽度・緯度情報
@@ -116,4 +142,310 @@ type TagMstRes struct {
 	TagID       int64  `json:"tagId"`
 	TagName     string `json:"tagName"`
 	Description string `json:"description"`
+	Category    string `json:"category"`
+}
+
+// 検索範囲内のタグごとのドッグラン数
+type TagFacetRes struct {
+	TagID    int64  `json:"tagId"`
+	TagName  string `json:"tagName"`
+	Category string `json:"category"`
+	Count    int64  `json:"count"`
+}
+
+// ギャラリー画像情報
+type DogrunImageRes struct {
+	DogrunImageID int64      `json:"dogrunImageId"`
+	DogrunID      int64      `json:"dogrunId"`
+	FileID        string     `json:"fileId"`
+	SortOrder     int64      `json:"sortOrder"`
+	UploadAt      *time.Time `json:"uploadAt,omitempty"`
+}
+
+// ドッグランの入場条件
+type DogrunEntryCriteriaRes struct {
+	DogrunID           int64    `json:"dogrunId"`
+	AllowedSizeClasses []string `json:"allowedSizeClasses"`
+	NeuteredRequired   bool     `json:"neuteredRequired"`
+	MicrochipRequired  bool     `json:"microchipRequired"`
+	MinAgeMonths       *int64   `json:"minAgeMonths,omitempty"`
+	MembersOnly        bool     `json:"membersOnly"`
+}
+
+// イベント情報
+type DogrunEventRes struct {
+	DogrunEventID              int64     `json:"dogrunEventId"`
+	DogrunID                   int64     `json:"dogrunId"`
+	EventType                  string    `json:"eventType"` // meetup: オフ会, official: 公式イベント
+	OrganizerDogOwnerID        int64     `json:"organizerDogOwnerId,omitempty"`
+	Title                      string    `json:"title"`
+	Description                string    `json:"description,omitempty"`
+	StartAt                    time.Time `json:"startAt"`
+	EndAt                      time.Time `json:"endAt"`
+	Capacity                   *int64    `json:"capacity,omitempty"`
+	AllowedSizeClasses         []string  `json:"allowedSizeClasses"`
+	Status                     string    `json:"status"`
+	RsvpCount                  int       `json:"rsvpCount"`
+	SpecialBusinessHourApplied bool      `json:"specialBusinessHourApplied"` // イベントに合わせて特別営業時間を設定したか
+}
+
+// イベントの詳細情報
+type DogrunEventDetailRes struct {
+	DogrunEventRes
+	Rsvps []DogrunEventRsvpRes `json:"rsvps"`
+}
+
+// イベントへの参加表明
+type DogrunEventRsvpRes struct {
+	DogID      int64  `json:"dogId"`
+	DogName    string `json:"dogName"`
+	SizeClass  string `json:"sizeClass,omitempty"`
+	DogOwnerID int64  `json:"dogOwnerId"`
+}
+
+// 予約設定
+type DogrunBookingSettingRes struct {
+	DogrunID           int64 `json:"dogrunId"`
+	SlotMinutes        int64 `json:"slotMinutes"`
+	SlotCapacity       int64 `json:"slotCapacity"`
+	AllowPrivateRental bool  `json:"allowPrivateRental"`
+}
+
+// 予約枠
+type DogrunBookingSlotRes struct {
+	DogrunBookingSlotID int64     `json:"dogrunBookingSlotId"`
+	DogrunID            int64     `json:"dogrunId"`
+	StartAt             time.Time `json:"startAt"`
+	EndAt               time.Time `json:"endAt"`
+	Capacity            int64     `json:"capacity"`
+	ReservedCount       int64     `json:"reservedCount"`
+	Remaining           int64     `json:"remaining"`
+	IsPrivate           bool      `json:"isPrivate"`
+}
+
+// 予約枠の生成結果
+type DogrunBookingSlotGenerateRes struct {
+	CreatedCount int64                  `json:"createdCount"`
+	Slots        []DogrunBookingSlotRes `json:"slots"`
+}
+
+// 予約
+type DogrunReservationRes struct {
+	DogrunReservationID int64                     `json:"dogrunReservationId"`
+	DogrunID            int64                     `json:"dogrunId"`
+	Slot                DogrunBookingSlotRes      `json:"slot"`
+	ReservationType     string                    `json:"reservationType"` // normal: 通常予約, private: 貸切予約
+	Status              string                    `json:"status"`          // reserved, cancelled, no_show
+	Dogs                []DogrunReservationDogRes `json:"dogs"`
+	CancelledAt         *time.Time                `json:"cancelledAt,omitempty"`
+	NoShowAt            *time.Time                `json:"noShowAt,omitempty"`
+	CreateAt            time.Time                 `json:"createAt"`
+}
+
+// 予約したdog
+type DogrunReservationDogRes struct {
+	DogID     int64  `json:"dogId"`
+	Name      string `json:"name"`
+	SizeClass string `json:"sizeClass,omitempty"`
+}
+
+// マネージャー向けの予約
+type DogrunManagedReservationRes struct {
+	DogrunReservationRes
+	DogOwnerID   int64  `json:"dogOwnerId"`
+	DogOwnerName string `json:"dogOwnerName"`
+	NoShowCount  int64  `json:"noShowCount"` // dogownerの直近の無断キャンセル数
+}
+
+// 料金プラン
+type DogrunFeePlanRes struct {
+	DogrunFeePlanID int64  `json:"dogrunFeePlanId"`
+	DogrunID        int64  `json:"dogrunId"`
+	Name            string `json:"name"`
+	FeeType         string `json:"feeType"` // per_visit, per_dog, membership
+	Amount          int64  `json:"amount"`
+	MembershipDays  int64  `json:"membershipDays,omitempty"`
+	IsActive        bool   `json:"isActive"`
+}
+
+// 支払い設定
+type DogrunPaymentSettingRes struct {
+	DogrunID                   int64 `json:"dogrunId"`
+	CheckinPaymentRequired     bool  `json:"checkinPaymentRequired"`
+	ReservationPaymentRequired bool  `json:"reservationPaymentRequired"`
+}
+
+// 支払い
+type DogrunPaymentRes struct {
+	DogrunPaymentID     int64      `json:"dogrunPaymentId"`
+	DogrunID            int64      `json:"dogrunId"`
+	DogrunFeePlanID     int64      `json:"dogrunFeePlanId"`
+	FeePlanName         string     `json:"feePlanName"`
+	FeeType             string     `json:"feeType"`
+	Amount              int64      `json:"amount"`
+	DogCount            int64      `json:"dogCount"`
+	DogrunReservationID int64      `json:"dogrunReservationId,omitempty"`
+	Status              string     `json:"status"` // pending, succeeded, failed
+	FailureReason       string     `json:"failureReason,omitempty"`
+	PaidAt              *time.Time `json:"paidAt,omitempty"`
+	ValidUntil          *time.Time `json:"validUntil,omitempty"`
+	CreateAt            time.Time  `json:"createAt"`
+}
+
+// マネージャー向けの支払い
+type DogrunManagedPaymentRes struct {
+	DogrunPaymentRes
+	DogOwnerID   int64  `json:"dogOwnerId"`
+	DogOwnerName string `json:"dogOwnerName"`
+}
+
+// 料金プランの種別ごとの集計
+type DogrunPaymentSummaryRes struct {
+	FeeType string `json:"feeType"`
+	Count   int64  `json:"count"`
+	Amount  int64  `json:"amount"`
+}
+
+// マネージャー向けの支払い履歴レポート
+type DogrunPaymentReportRes struct {
+	From           string                    `json:"from"`
+	To             string                    `json:"to"`
+	SucceededCount int64                     `json:"succeededCount"`
+	FailedCount    int64                     `json:"failedCount"`
+	TotalAmount    int64                     `json:"totalAmount"` // 支払い完了の合計金額
+	Summaries      []DogrunPaymentSummaryRes `json:"summaries"`
+	Payments       []DogrunManagedPaymentRes `json:"payments"`
+}
+
+// 会員登録
+type DogrunMembershipRes struct {
+	DogrunMembershipID int64                     `json:"dogrunMembershipId"`
+	DogrunID           int64                     `json:"dogrunId"`
+	Status             string                    `json:"status"`                     // pending, approved, rejected, withdrawn
+	MembershipNumber   string                    `json:"membershipNumber,omitempty"` // 承認時に発行
+	Dogs               []DogrunReservationDogRes `json:"dogs"`
+	DocumentFileIDs    []string                  `json:"documentFileIds"`
+	ApplicationNote    string                    `json:"applicationNote,omitempty"`
+	ReviewNote         string                    `json:"reviewNote,omitempty"`
+	ReviewedAt         *time.Time                `json:"reviewedAt,omitempty"`
+	CreateAt           time.Time                 `json:"createAt"`
+}
+
+// マネージャー向けの会員登録
+type DogrunManagedMembershipRes struct {
+	DogrunMembershipRes
+	DogOwnerID   int64                         `json:"dogOwnerId"`
+	DogOwnerName string                        `json:"dogOwnerName"`
+	Documents    []DogrunMembershipDocumentRes `json:"documents,omitempty"` // 詳細取得時のみ
+}
+
+// 会員登録の添付書類
+type DogrunMembershipDocumentRes struct {
+	FileID    string    `json:"fileId"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// 管理申請
+type DogrunClaimRes struct {
+	DogrunClaimID    int64                    `json:"dogrunClaimId"`
+	DogrunID         int64                    `json:"dogrunId"`
+	PlaceID          string                   `json:"placeId,omitempty"`
+	DogrunName       string                   `json:"dogrunName,omitempty"`
+	DogrunmgID       int64                    `json:"dogrunmgId"`
+	OrganizationID   int64                    `json:"organizationId"`
+	OrganizationName string                   `json:"organizationName"`
+	Status           string                   `json:"status"` // pending, approved, rejected, withdrawn
+	EvidenceNote     string                   `json:"evidenceNote"`
+	DocumentFileIDs  []string                 `json:"documentFileIds"`
+	ReviewNote       string                   `json:"reviewNote,omitempty"`
+	ReviewedAt       *time.Time               `json:"reviewedAt,omitempty"`
+	CreateAt         time.Time                `json:"createAt"`
+	Documents        []DogrunClaimDocumentRes `json:"documents,omitempty"` // 審査向けの詳細取得時のみ
+	Histories        []DogrunClaimHistoryRes  `json:"histories,omitempty"` // 審査向けの詳細取得時のみ
+}
+
+// 管理申請の証拠書類
+type DogrunClaimDocumentRes struct {
+	FileID    string    `json:"fileId"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// 管理申請の履歴
+type DogrunClaimHistoryRes struct {
+	Action     string    `json:"action"` // submit, approve, reject, withdraw, supersede
+	FromStatus string    `json:"fromStatus,omitempty"`
+	ToStatus   string    `json:"toStatus"`
+	ActorRole  int64     `json:"actorRole"`
+	ActorID    int64     `json:"actorId"`
+	Note       string    `json:"note,omitempty"`
+	CreateAt   time.Time `json:"createAt"`
+}
+
+// ドッグランの基本情報
+type DogrunInfoRes struct {
+	DogrunID    int64    `json:"dogrunId"`
+	Name        string   `json:"name"`
+	Address     Address  `json:"address"`
+	Location    Location `json:"location"`
+	Description string   `json:"description,omitempty"`
+	Geocoded    []string `json:"geocoded,omitempty"` // 住所・座標から補完した項目(postcode, location, address)
+}
+
+// 座標・郵便番号の補完結果の項目(項目の出所の記録と同じ名前)
+const (
+	GEOCODED_FIELD_POSTCODE = "postcode"
+	GEOCODED_FIELD_LOCATION = "location"
+	GEOCODED_FIELD_ADDRESS  = "address"
+)
+
+// 座標・郵便番号を補完できなかった理由
+const (
+	GEOCODE_UNRESOLVED_NO_ADDRESS = "no_address" // 住所も座標もない
+	GEOCODE_UNRESOLVED_NOT_FOUND  = "not_found"  // 該当する住所・座標がない
+	GEOCODE_UNRESOLVED_ERROR      = "error"      // ジオコーディングのエラー
+)
+
+// 座標・郵便番号の一括補完の結果
+type GeocodeRepairRes struct {
+	DryRun        bool                   `json:"dryRun"`
+	Geocoder      string                 `json:"geocoder"`
+	Scanned       int                    `json:"scanned"`
+	RepairedCount int                    `json:"repairedCount"`
+	Repaired      []GeocodeRepairedRes   `json:"repaired"`
+	Unresolved    []GeocodeUnresolvedRes `json:"unresolved"`
+}
+
+// 補完したドッグラン
+type GeocodeRepairedRes struct {
+	DogrunID int64    `json:"dogrunId"`
+	Name     string   `json:"name"`
+	Address  Address  `json:"address"`
+	Location Location `json:"location"`
+	Geocoded []string `json:"geocoded"`
+}
+
+// 補完できなかったドッグラン
+type GeocodeUnresolvedRes struct {
+	DogrunID int64  `json:"dogrunId"`
+	Name     string `json:"name"`
+	Address  string `json:"address,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+// google place情報の同期の結果
+type PlaceSyncRes struct {
+	Scanned      int                  `json:"scanned"`
+	SyncedCount  int                  `json:"syncedCount"`
+	ChangedCount int                  `json:"changedCount"`
+	FailedCount  int                  `json:"failedCount"`
+	Closed       []PlaceSyncClosedRes `json:"closed"`
+}
+
+// 同期で閉業と報告されたドッグラン
+type PlaceSyncClosedRes struct {
+	DogrunID int64  `json:"dogrunId"`
+	PlaceId  string `json:"placeId"`
+	Name     string `json:"name"`
 }
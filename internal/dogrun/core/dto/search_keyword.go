@@ -0,0 +1,143 @@
+package dto
+
+import "strings"
+
+/*
+都道府県
+*/
+type prefecture struct {
+	name          string // 正式名称
+	reading       string // 読み(ひらがな)
+	suffixReading string // 都道府県の部分の読み
+}
+
+var prefectures = []prefecture{
+	{"北海道", "ほっかいどう", ""},
+	{"青森県", "あおもり", "けん"},
+	{"岩手県", "いわて", "けん"},
+	{"宮城県", "みやぎ", "けん"},
+	{"秋田県", "あきた", "けん"},
+	{"山形県", "やまがた", "けん"},
+	{"福島県", "ふくしま", "けん"},
+	{"茨城県", "いばらき", "けん"},
+	{"栃木県", "とちぎ", "けん"},
+	{"群馬県", "ぐんま", "けん"},
+	{"埼玉県", "さいたま", "けん"},
+	{"千葉県", "ちば", "けん"},
+	{"東京都", "とうきょう", "と"},
+	{"神奈川県", "かながわ", "けん"},
+	{"新潟県", "にいがた", "けん"},
+	{"富山県", "とやま", "けん"},
+	{"石川県", "いしかわ", "けん"},
+	{"福井県", "ふくい", "けん"},
+	{"山梨県", "やまなし", "けん"},
+	{"長野県", "ながの", "けん"},
+	{"岐阜県", "ぎふ", "けん"},
+	{"静岡県", "しずおか", "けん"},
+	{"愛知県", "あいち", "けん"},
+	{"三重県", "みえ", "けん"},
+	{"滋賀県", "しが", "けん"},
+	{"京都府", "きょうと", "ふ"},
+	{"大阪府", "おおさか", "ふ"},
+	{"兵庫県", "ひょうご", "けん"},
+	{"奈良県", "なら", "けん"},
+	{"和歌山県", "わかやま", "けん"},
+	{"鳥取県", "とっとり", "けん"},
+	{"島根県", "しまね", "けん"},
+	{"岡山県", "おかやま", "けん"},
+	{"広島県", "ひろしま", "けん"},
+	{"山口県", "やまぐち", "けん"},
+	{"徳島県", "とくしま", "けん"},
+	{"香川県", "かがわ", "けん"},
+	{"愛媛県", "えひめ", "けん"},
+	{"高知県", "こうち", "けん"},
+	{"福岡県", "ふくおか", "けん"},
+	{"佐賀県", "さが", "けん"},
+	{"長崎県", "ながさき", "けん"},
+	{"熊本県", "くまもと", "けん"},
+	{"大分県", "おおいた", "けん"},
+	{"宮崎県", "みやざき", "けん"},
+	{"鹿児島県", "かごしま", "けん"},
+	{"沖縄県", "おきなわ", "けん"},
+}
+
+/*
+都道府県名を省略した名前(東京都 -> 東京)
+北海道はそのまま
+*/
+func (p prefecture) shortName() string {
+	if p.suffixReading == "" {
+		return p.name
+	}
+	r := []rune(p.name)
+	return string(r[:len(r)-1])
+}
+
+/*
+キーワードが都道府県を指しているか
+漢字・ひらがな・カタカナ、都道府県の有無のいずれでも一致とする
+*/
+func (p prefecture) matches(word string) bool {
+	return word == p.name || word == p.shortName() ||
+		word == p.reading || word == p.reading+p.suffixReading
+}
+
+/*
+キーワード検索の条件
+*/
+type SearchKeyword struct {
+	Words       []string // 名前・住所・説明のいずれかに含まれる語句(全て一致)
+	Prefectures []string // 住所に含まれる都道府県の正式名称(全て一致)
+}
+
+// ParseSearchKeyword: 検索キーワードを空白(全角含む)で区切り、都道府県とそれ以外の語句に分ける
+// 都道府県は読みや省略形でも正式名称に変換する
+//
+// args:
+//   - string:	検索キーワード
+//
+// return:
+//   - SearchKeyword:	キーワード検索の条件
+func ParseSearchKeyword(keyword string) SearchKeyword {
+	var searchKeyword SearchKeyword
+	for _, word := range strings.Fields(keyword) {
+		if prefectureName, ok := findPrefecture(word); ok {
+			searchKeyword.Prefectures = append(searchKeyword.Prefectures, prefectureName)
+			continue
+		}
+		searchKeyword.Words = append(searchKeyword.Words, word)
+	}
+	return searchKeyword
+}
+
+/*
+キーワード検索の条件がないか
+*/
+func (k SearchKeyword) IsEmpty() bool {
+	return len(k.Words) == 0 && len(k.Prefectures) == 0
+}
+
+/*
+語句に一致する都道府県の正式名称を探す
+*/
+func findPrefecture(word string) (string, bool) {
+	hiragana := toHiragana(word)
+	for _, p := range prefectures {
+		if p.matches(word) || p.matches(hiragana) {
+			return p.name, true
+		}
+	}
+	return "", false
+}
+
+/*
+全角カタカナをひらがなに変換する
+*/
+func toHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - ('ァ' - 'ぁ')
+		}
+		return r
+	}, s)
+}
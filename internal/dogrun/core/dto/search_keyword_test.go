@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSearchKeyword(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyword string
+		want    SearchKeyword
+	}{
+		{name: "空", keyword: "", want: SearchKeyword{}},
+		{name: "空白のみ", keyword: " 　 ", want: SearchKeyword{}},
+		{name: "語句のみ", keyword: "ドッグラン", want: SearchKeyword{Words: []string{"ドッグラン"}}},
+		{name: "正式名称", keyword: "東京都", want: SearchKeyword{Prefectures: []string{"東京都"}}},
+		{
+			name:    "全角空白区切り",
+			keyword: "東京　ドッグラン",
+			want:    SearchKeyword{Words: []string{"ドッグラン"}, Prefectures: []string{"東京都"}},
+		},
+		{
+			name:    "複数の都道府県と語句",
+			keyword: "おおさか 公園 キョウトフ 芝生",
+			want:    SearchKeyword{Words: []string{"公園", "芝生"}, Prefectures: []string{"大阪府", "京都府"}},
+		},
+		{
+			name:    "都道府県を含む語句は語句のまま",
+			keyword: "東京タワー",
+			want:    SearchKeyword{Words: []string{"東京タワー"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseSearchKeyword(tt.keyword); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSearchKeyword(%q) = %+v, want %+v", tt.keyword, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchKeywordIsEmpty(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyword SearchKeyword
+		want    bool
+	}{
+		{name: "条件なし", keyword: SearchKeyword{}, want: true},
+		{name: "語句あり", keyword: SearchKeyword{Words: []string{"公園"}}, want: false},
+		{name: "都道府県あり", keyword: SearchKeyword{Prefectures: []string{"東京都"}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.keyword.IsEmpty(); got != tt.want {
+				t.Errorf("IsEmpty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindPrefecture(t *testing.T) {
+	tests := []struct {
+		name   string
+		word   string
+		want   string
+		wantOK bool
+	}{
+		{name: "正式名称", word: "神奈川県", want: "神奈川県", wantOK: true},
+		{name: "省略形", word: "神奈川", want: "神奈川県", wantOK: true},
+		{name: "ひらがな", word: "かながわ", want: "神奈川県", wantOK: true},
+		{name: "ひらがなで県まで", word: "かながわけん", want: "神奈川県", wantOK: true},
+		{name: "カタカナ", word: "カナガワ", want: "神奈川県", wantOK: true},
+		{name: "カタカナで県まで", word: "カナガワケン", want: "神奈川県", wantOK: true},
+		{name: "東京都の読み", word: "とうきょうと", want: "東京都", wantOK: true},
+		{name: "北海道", word: "北海道", want: "北海道", wantOK: true},
+		{name: "北海道の読み", word: "ほっかいどう", want: "北海道", wantOK: true},
+		{name: "北海を省略形としない", word: "北海", wantOK: false},
+		{name: "京都の読み違い", word: "きょうとけん", wantOK: false},
+		{name: "都道府県以外", word: "渋谷", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := findPrefecture(tt.word)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("findPrefecture(%q) = (%q, %v), want (%q, %v)", tt.word, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestToHiragana(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{name: "カタカナ", s: "トウキョウ", want: "とうきょう"},
+		{name: "小書き文字", s: "ホッカイドウ", want: "ほっかいどう"},
+		{name: "濁音・半濁音", s: "ギフ パピヨン", want: "ぎふ ぱぴよん"},
+		{name: "ヶとヵ", s: "ヶヵ", want: "ゖゕ"},
+		{name: "長音はそのまま", s: "ドッグラン・パーク", want: "どっぐらん・ぱーく"},
+		{name: "漢字・英数字はそのまま", s: "東京dog1", want: "東京dog1"},
+		{name: "ひらがなはそのまま", s: "ちば", want: "ちば"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toHiragana(tt.s); got != tt.want {
+				t.Errorf("toHiragana(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
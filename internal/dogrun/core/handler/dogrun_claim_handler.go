@@ -0,0 +1,557 @@
+package handler
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	cmsDTO "github.com/wanrun-develop/wanrun/internal/cms/core/dto"
+	cmsFacade "github.com/wanrun-develop/wanrun/internal/cms/facade"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/transaction"
+	"github.com/wanrun-develop/wanrun/internal/wrcontext"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+	"gorm.io/gorm"
+)
+
+const (
+	CLAIM_SUPERSEDED_NOTE = "他の管理申請が承認されたため却下" // 他の申請の承認による却下時のメモ
+)
+
+type IDogrunClaimHandler interface {
+	SubmitClaim(echo.Context, dto.DogrunClaimReq) (dto.DogrunClaimRes, error)
+	GetMyClaims(echo.Context) ([]dto.DogrunClaimRes, error)
+	WithdrawClaim(echo.Context, int64) error
+	GetClaims(echo.Context, string) ([]dto.DogrunClaimRes, error)
+	GetClaim(echo.Context, int64) (dto.DogrunClaimRes, error)
+	ApproveClaim(echo.Context, int64, dto.DogrunClaimReviewReq) (dto.DogrunClaimRes, error)
+	RejectClaim(echo.Context, int64, dto.DogrunClaimReviewReq) (dto.DogrunClaimRes, error)
+}
+
+type dogrunClaimHandler struct {
+	drr repository.IDogrunRepository
+	dh  IDogrunHandler
+	cr  repository.IDogrunClaimRepository
+	csr repository.IDogrunClaimScopeRepository
+	tm  transaction.ITransactionManager
+	cf  cmsFacade.ICmsFacade
+}
+
+func NewDogrunClaimHandler(
+	drr repository.IDogrunRepository,
+	dh IDogrunHandler,
+	cr repository.IDogrunClaimRepository,
+	csr repository.IDogrunClaimScopeRepository,
+	tm transaction.ITransactionManager,
+	cf cmsFacade.ICmsFacade,
+) IDogrunClaimHandler {
+	return &dogrunClaimHandler{
+		drr: drr,
+		dh:  dh,
+		cr:  cr,
+		csr: csr,
+		tm:  tm,
+		cf:  cf,
+	}
+}
+
+// SubmitClaim: ドッグランの管理申請
+// 管理されていないドッグランのみ申請可能。placeIdのみ指定された場合はDBにドッグランを登録する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - dto.DogrunClaimReq:	申請内容
+//
+// return:
+//   - dto.DogrunClaimRes:	申請した管理申請
+//   - error:	エラー
+func (h *dogrunClaimHandler) SubmitClaim(c echo.Context, req dto.DogrunClaimReq) (dto.DogrunClaimRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	actorRole, userID, err := getLoginActor(c)
+	if err != nil {
+		return dto.DogrunClaimRes{}, err
+	}
+
+	dogrun, err := h.resolveClaimDogrun(c, req)
+	if err != nil {
+		return dto.DogrunClaimRes{}, err
+	}
+	if dogrun.IsManaged.Bool {
+		err := errors.NewWRError(nil, "既に管理されているドッグランです", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunClaimRes{}, err
+	}
+
+	//証拠書類はdogrunmgがアップロードしたファイルのみ
+	if err := h.cf.CheckFilesOwnedBy(c, cmsDTO.OWNER_TYPE_DOGRUNMG, userID, req.DocumentFileIDs); err != nil {
+		return dto.DogrunClaimRes{}, err
+	}
+
+	dogrunID := dogrun.DogrunID.Int64
+	pendingClaim, err := h.cr.FindPendingClaim(c, dogrunID, userID)
+	if err != nil {
+		return dto.DogrunClaimRes{}, err
+	}
+	if !pendingClaim.IsEmpty() {
+		err := errors.NewWRError(nil, "このドッグランには審査中の管理申請があります", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunClaimRes{}, err
+	}
+
+	claim := model.DogrunClaim{
+		DogrunID:     util.NewSqlNullInt64(dogrunID),
+		DogrunmgID:   util.NewSqlNullInt64(userID),
+		Status:       util.NewSqlNullString(model.DOGRUN_CLAIM_STATUS_PENDING),
+		EvidenceNote: util.NewSqlNullString(req.EvidenceNote),
+		DogrunClaimHistories: []model.DogrunClaimHistory{
+			newClaimHistory(model.DOGRUN_CLAIM_ACTION_SUBMIT, "", model.DOGRUN_CLAIM_STATUS_PENDING, actorRole, userID, ""),
+		},
+	}
+	for _, fileID := range req.DocumentFileIDs {
+		claim.DogrunClaimDocuments = append(claim.DogrunClaimDocuments, model.DogrunClaimDocument{
+			FileID: util.NewSqlNullString(fileID),
+		})
+	}
+	if err := h.cr.CreateClaim(c, &claim); err != nil {
+		return dto.DogrunClaimRes{}, err
+	}
+	logger.Infof("ドッグラン:%d の管理申請:%d を登録", dogrunID, claim.DogrunClaimID.Int64)
+
+	created, err := h.cr.FindClaimByID(c, claim.DogrunClaimID.Int64)
+	if err != nil {
+		return dto.DogrunClaimRes{}, err
+	}
+	return convertDogrunClaimRes(created), nil
+}
+
+// GetMyClaims: ログイン中のマネージャーの管理申請一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - []dto.DogrunClaimRes:	管理申請一覧
+//   - error:	エラー
+func (h *dogrunClaimHandler) GetMyClaims(c echo.Context) ([]dto.DogrunClaimRes, error) {
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := h.cr.FindClaimsByDogrunmgID(c, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	claimsRes := []dto.DogrunClaimRes{}
+	for _, claim := range claims {
+		claimsRes = append(claimsRes, convertDogrunClaimRes(claim))
+	}
+	return claimsRes, nil
+}
+
+// WithdrawClaim: 審査中の管理申請の取り下げ
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunClaimID
+//
+// return:
+//   - error:	エラー
+func (h *dogrunClaimHandler) WithdrawClaim(c echo.Context, claimID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	actorRole, userID, err := getLoginActor(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	return h.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		claim, wrErr := h.csr.FindClaimForUpdate(tx, c, claimID)
+		if wrErr != nil {
+			return wrErr
+		}
+		if claim.IsEmpty() || claim.DogrunmgID.Int64 != userID {
+			wrErr := errors.NewWRError(nil, fmt.Sprintf("指定された管理申請ID:%dが存在しません", claimID), errors.NewDogrunClientErrorEType())
+			logger.Error(wrErr)
+			return wrErr
+		}
+		if !claim.IsPending() {
+			wrErr := errors.NewWRError(nil, "審査中の管理申請ではないため取り下げできません", errors.NewDogrunClientErrorEType())
+			logger.Error(wrErr)
+			return wrErr
+		}
+
+		if wrErr := h.csr.UpdateClaimStatus(tx, c, claimID, map[string]any{
+			"status": model.DOGRUN_CLAIM_STATUS_WITHDRAWN,
+		}); wrErr != nil {
+			return wrErr
+		}
+		history := newClaimHistory(model.DOGRUN_CLAIM_ACTION_WITHDRAW, claim.Status.String, model.DOGRUN_CLAIM_STATUS_WITHDRAWN, actorRole, userID, "")
+		history.DogrunClaimID = claim.DogrunClaimID
+		return h.csr.CreateClaimHistory(tx, c, &history)
+	})
+}
+
+// GetClaims: 審査向けの管理申請一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - string:	ステータス。空の場合は全て
+//
+// return:
+//   - []dto.DogrunClaimRes:	管理申請一覧(証拠書類のURL、履歴は含まない)
+//   - error:	エラー
+func (h *dogrunClaimHandler) GetClaims(c echo.Context, status string) ([]dto.DogrunClaimRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	claimStatuses := []string{
+		model.DOGRUN_CLAIM_STATUS_PENDING,
+		model.DOGRUN_CLAIM_STATUS_APPROVED,
+		model.DOGRUN_CLAIM_STATUS_REJECTED,
+		model.DOGRUN_CLAIM_STATUS_WITHDRAWN,
+	}
+	if status != "" && !slices.Contains(claimStatuses, status) {
+		err := errors.NewWRError(nil, fmt.Sprintf("指定されたステータス:%sは不正です", status), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return nil, err
+	}
+
+	claims, err := h.cr.FindClaims(c, status)
+	if err != nil {
+		return nil, err
+	}
+
+	claimsRes := []dto.DogrunClaimRes{}
+	for _, claim := range claims {
+		claimsRes = append(claimsRes, convertDogrunClaimRes(claim))
+	}
+	return claimsRes, nil
+}
+
+// GetClaim: 審査向けの管理申請の詳細の取得
+// 審査のため、証拠書類の閲覧用URLを発行する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunClaimID
+//
+// return:
+//   - dto.DogrunClaimRes:	証拠書類のURL、履歴を含む管理申請
+//   - error:	エラー
+func (h *dogrunClaimHandler) GetClaim(c echo.Context, claimID int64) (dto.DogrunClaimRes, error) {
+	claim, err := h.findClaim(c, claimID)
+	if err != nil {
+		return dto.DogrunClaimRes{}, err
+	}
+	return h.convertDogrunClaimDetailRes(c, claim)
+}
+
+// ApproveClaim: 管理申請の承認
+// ドッグランの管理者を申請したマネージャーに設定し、同じドッグランへの他の審査中の申請は却下する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunClaimID
+//   - dto.DogrunClaimReviewReq:	審査内容
+//
+// return:
+//   - dto.DogrunClaimRes:	承認後の管理申請
+//   - error:	エラー
+func (h *dogrunClaimHandler) ApproveClaim(c echo.Context, claimID int64, req dto.DogrunClaimReviewReq) (dto.DogrunClaimRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	actorRole, userID, err := getLoginActor(c)
+	if err != nil {
+		return dto.DogrunClaimRes{}, err
+	}
+
+	claim, err := h.findClaim(c, claimID)
+	if err != nil {
+		return dto.DogrunClaimRes{}, err
+	}
+
+	ctx := c.Request().Context()
+	if err := h.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		//同じドッグランへの審査中の申請を全てロックする
+		pendingClaims, wrErr := h.csr.FindPendingClaimsByDogrunIDForUpdate(tx, c, claim.DogrunID.Int64)
+		if wrErr != nil {
+			return wrErr
+		}
+		if !slices.ContainsFunc(pendingClaims, func(pending model.DogrunClaim) bool {
+			return pending.DogrunClaimID.Int64 == claimID
+		}) {
+			wrErr := errors.NewWRError(nil, "審査中の管理申請ではありません", errors.NewDogrunClientErrorEType())
+			logger.Error(wrErr)
+			return wrErr
+		}
+
+		assigned, wrErr := h.csr.AssignDogrunManager(tx, c, claim.DogrunID.Int64, claim.DogrunmgID.Int64)
+		if wrErr != nil {
+			return wrErr
+		}
+		if !assigned {
+			wrErr := errors.NewWRError(nil, "既に管理されているドッグランです", errors.NewDogrunClientErrorEType())
+			logger.Error(wrErr)
+			return wrErr
+		}
+
+		now := time.Now()
+		for _, pending := range pendingClaims {
+			action, toStatus, note := model.DOGRUN_CLAIM_ACTION_SUPERSEDE, model.DOGRUN_CLAIM_STATUS_REJECTED, CLAIM_SUPERSEDED_NOTE
+			if pending.DogrunClaimID.Int64 == claimID {
+				action, toStatus, note = model.DOGRUN_CLAIM_ACTION_APPROVE, model.DOGRUN_CLAIM_STATUS_APPROVED, req.Note
+			}
+			if wrErr := h.reviewClaim(tx, c, pending, action, toStatus, note, actorRole, userID, now); wrErr != nil {
+				return wrErr
+			}
+		}
+		return nil
+	}); err != nil {
+		return dto.DogrunClaimRes{}, err
+	}
+	logger.Infof("ドッグラン:%d の管理者をマネージャー:%d に設定", claim.DogrunID.Int64, claim.DogrunmgID.Int64)
+
+	return h.GetClaim(c, claimID)
+}
+
+// RejectClaim: 管理申請の却下
+// 却下理由の入力を必須とする
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunClaimID
+//   - dto.DogrunClaimReviewReq:	審査内容
+//
+// return:
+//   - dto.DogrunClaimRes:	却下後の管理申請
+//   - error:	エラー
+func (h *dogrunClaimHandler) RejectClaim(c echo.Context, claimID int64, req dto.DogrunClaimReviewReq) (dto.DogrunClaimRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if req.Note == "" {
+		err := errors.NewWRError(nil, "却下理由を入力してください", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunClaimRes{}, err
+	}
+
+	actorRole, userID, err := getLoginActor(c)
+	if err != nil {
+		return dto.DogrunClaimRes{}, err
+	}
+
+	ctx := c.Request().Context()
+	if err := h.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		claim, wrErr := h.csr.FindClaimForUpdate(tx, c, claimID)
+		if wrErr != nil {
+			return wrErr
+		}
+		if claim.IsEmpty() {
+			wrErr := errors.NewWRError(nil, fmt.Sprintf("指定された管理申請ID:%dが存在しません", claimID), errors.NewDogrunClientErrorEType())
+			logger.Error(wrErr)
+			return wrErr
+		}
+		if !claim.IsPending() {
+			wrErr := errors.NewWRError(nil, "審査中の管理申請ではありません", errors.NewDogrunClientErrorEType())
+			logger.Error(wrErr)
+			return wrErr
+		}
+		return h.reviewClaim(tx, c, claim, model.DOGRUN_CLAIM_ACTION_REJECT, model.DOGRUN_CLAIM_STATUS_REJECTED, req.Note, actorRole, userID, time.Now())
+	}); err != nil {
+		return dto.DogrunClaimRes{}, err
+	}
+
+	return h.GetClaim(c, claimID)
+}
+
+// resolveClaimDogrun: 管理申請の対象のドッグランの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - dto.DogrunClaimReq:	申請内容
+//
+// return:
+//   - model.Dogrun:	対象のドッグラン
+//   - error:	存在しない場合はエラー
+func (h *dogrunClaimHandler) resolveClaimDogrun(c echo.Context, req dto.DogrunClaimReq) (model.Dogrun, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunID := req.DogrunID
+	if dogrunID == 0 {
+		resolvedID, err := h.dh.ResolveDogrunIDByPlaceID(c, req.PlaceID)
+		if err != nil {
+			return model.Dogrun{}, err
+		}
+		dogrunID = resolvedID
+	}
+
+	dogruns, err := h.drr.FindDogrunByIDs([]int64{dogrunID})
+	if err != nil {
+		err = errors.NewWRError(err, "dogrun存在チェックでエラー", errors.NewDogrunServerErrorEType())
+		logger.Error(err)
+		return model.Dogrun{}, err
+	}
+	if len(dogruns) == 0 {
+		err := errors.NewWRError(nil, "指定されたドッグランが存在しません", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return model.Dogrun{}, err
+	}
+	if req.PlaceID != "" && dogruns[0].PlaceId.String != req.PlaceID {
+		err := errors.NewWRError(nil, "指定されたドッグランとplaceIdが一致しません", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return model.Dogrun{}, err
+	}
+	return dogruns[0], nil
+}
+
+// findClaim: 管理申請の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunClaimID
+//
+// return:
+//   - model.DogrunClaim:	管理申請
+//   - error:	存在しない場合はエラー
+func (h *dogrunClaimHandler) findClaim(c echo.Context, claimID int64) (model.DogrunClaim, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	claim, err := h.cr.FindClaimByID(c, claimID)
+	if err != nil {
+		return model.DogrunClaim{}, err
+	}
+	if claim.IsEmpty() {
+		err := errors.NewWRError(nil, fmt.Sprintf("指定された管理申請ID:%dが存在しません", claimID), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return model.DogrunClaim{}, err
+	}
+	return claim, nil
+}
+
+// reviewClaim: 審査中の管理申請の審査結果の更新と履歴の登録
+//
+// args:
+//   - *gorm.DB:	トランザクションを張っているtx情報
+//   - echo.Context:	コンテキスト
+//   - model.DogrunClaim:	審査中の管理申請
+//   - string:	操作
+//   - string:	審査後のステータス
+//   - string:	審査時のメモ
+//   - int:	審査したユーザーのロール
+//   - int64:	審査したユーザーのID
+//   - time.Time:	審査日時
+//
+// return:
+//   - error:	エラー
+func (h *dogrunClaimHandler) reviewClaim(tx *gorm.DB, c echo.Context, claim model.DogrunClaim, action string, toStatus string, note string, actorRole int, actorID int64, reviewedAt time.Time) error {
+	if err := h.csr.UpdateClaimStatus(tx, c, claim.DogrunClaimID.Int64, map[string]any{
+		"status":      toStatus,
+		"review_note": util.NewSqlNullString(note),
+		"reviewed_by": actorID,
+		"reviewed_at": reviewedAt,
+	}); err != nil {
+		return err
+	}
+	history := newClaimHistory(action, claim.Status.String, toStatus, actorRole, actorID, note)
+	history.DogrunClaimID = claim.DogrunClaimID
+	return h.csr.CreateClaimHistory(tx, c, &history)
+}
+
+// convertDogrunClaimDetailRes: 審査向けの管理申請の詳細のレスポンスへの変換
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.DogrunClaim:	管理申請
+//
+// return:
+//   - dto.DogrunClaimRes:	証拠書類のURL、履歴を含む管理申請
+//   - error:	エラー
+func (h *dogrunClaimHandler) convertDogrunClaimDetailRes(c echo.Context, claim model.DogrunClaim) (dto.DogrunClaimRes, error) {
+	res := convertDogrunClaimRes(claim)
+	res.Documents = []dto.DogrunClaimDocumentRes{}
+	for _, fileID := range claim.DocumentFileIDs() {
+		fileURL, err := h.cf.PresignFileURL(c, fileID)
+		if err != nil {
+			return dto.DogrunClaimRes{}, err
+		}
+		res.Documents = append(res.Documents, dto.DogrunClaimDocumentRes{
+			FileID:    fileID,
+			URL:       fileURL.URL,
+			ExpiresAt: fileURL.ExpiresAt,
+		})
+	}
+	res.Histories = []dto.DogrunClaimHistoryRes{}
+	for _, history := range claim.DogrunClaimHistories {
+		res.Histories = append(res.Histories, dto.DogrunClaimHistoryRes{
+			Action:     history.Action.String,
+			FromStatus: history.FromStatus.String,
+			ToStatus:   history.ToStatus.String,
+			ActorRole:  history.ActorRole.Int64,
+			ActorID:    history.ActorID.Int64,
+			Note:       history.Note.String,
+			CreateAt:   history.CreateAt.Time,
+		})
+	}
+	return res, nil
+}
+
+/*
+ログインユーザーのロールとIDの取得
+*/
+func getLoginActor(c echo.Context) (int, int64, error) {
+	role, err := wrcontext.GetLoginUserRole(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	return role, userID, nil
+}
+
+/*
+管理申請の履歴の生成
+*/
+func newClaimHistory(action string, fromStatus string, toStatus string, actorRole int, actorID int64, note string) model.DogrunClaimHistory {
+	return model.DogrunClaimHistory{
+		Action:     util.NewSqlNullString(action),
+		FromStatus: util.NewSqlNullString(fromStatus),
+		ToStatus:   util.NewSqlNullString(toStatus),
+		ActorRole:  sql.NullInt64{Int64: int64(actorRole), Valid: true}, // システムユーザーのロールは0のため直接設定
+		ActorID:    util.NewSqlNullInt64(actorID),
+		Note:       util.NewSqlNullString(note),
+	}
+}
+
+/*
+管理申請をレスポンスに変換
+*/
+func convertDogrunClaimRes(claim model.DogrunClaim) dto.DogrunClaimRes {
+	res := dto.DogrunClaimRes{
+		DogrunClaimID:    claim.DogrunClaimID.Int64,
+		DogrunID:         claim.DogrunID.Int64,
+		PlaceID:          claim.Dogrun.PlaceId.String,
+		DogrunName:       claim.Dogrun.Name.String,
+		DogrunmgID:       claim.DogrunmgID.Int64,
+		OrganizationID:   claim.Dogrunmg.OrganizationID.Int64,
+		OrganizationName: claim.Dogrunmg.Organization.Name.String,
+		Status:           claim.Status.String,
+		EvidenceNote:     claim.EvidenceNote.String,
+		DocumentFileIDs:  claim.DocumentFileIDs(),
+		ReviewNote:       claim.ReviewNote.String,
+		CreateAt:         claim.CreateAt.Time,
+	}
+	if claim.ReviewedAt.Valid {
+		res.ReviewedAt = &claim.ReviewedAt.Time
+	}
+	return res
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+)
+
+type IDogrunEntryHandler interface {
+	GetEntryCriteria(echo.Context, int64) (dto.DogrunEntryCriteriaRes, error)
+	SaveEntryCriteria(echo.Context, int64, dto.DogrunEntryCriteriaReq) (dto.DogrunEntryCriteriaRes, error)
+}
+
+type dogrunEntryHandler struct {
+	drr repository.IDogrunRepository
+}
+
+func NewDogrunEntryHandler(drr repository.IDogrunRepository) IDogrunEntryHandler {
+	return &dogrunEntryHandler{drr}
+}
+
+// GetEntryCriteria: ドッグランの入場条件の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - dto.DogrunEntryCriteriaRes:	入場条件。未設定の場合は制限なし
+//   - error:	エラー
+func (h *dogrunEntryHandler) GetEntryCriteria(c echo.Context, dogrunID int64) (dto.DogrunEntryCriteriaRes, error) {
+	entryCriteria, err := h.drr.FindDogrunEntryCriteria(c, dogrunID)
+	if err != nil {
+		return dto.DogrunEntryCriteriaRes{}, err
+	}
+	return convertDogrunEntryCriteriaRes(dogrunID, entryCriteria), nil
+}
+
+// SaveEntryCriteria: ドッグランの入場条件の登録・更新
+// 管理対象のドッグランのみ更新可能
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - dto.DogrunEntryCriteriaReq:	入場条件
+//
+// return:
+//   - dto.DogrunEntryCriteriaRes:	更新後の入場条件
+//   - error:	エラー
+func (h *dogrunEntryHandler) SaveEntryCriteria(c echo.Context, dogrunID int64, req dto.DogrunEntryCriteriaReq) (dto.DogrunEntryCriteriaRes, error) {
+	if err := checkManagedDogrun(c, h.drr, dogrunID); err != nil {
+		return dto.DogrunEntryCriteriaRes{}, err
+	}
+
+	entryCriteria := model.DogrunEntryCriteria{
+		DogrunID:           util.NewSqlNullInt64(dogrunID),
+		AllowedSizeClasses: util.NewSqlNullString(strings.Join(req.AllowedSizeClasses, ",")),
+		NeuteredRequired:   util.NewSqlNullBool(req.NeuteredRequired),
+		MicrochipRequired:  util.NewSqlNullBool(req.MicrochipRequired),
+		MembersOnly:        util.NewSqlNullBool(req.MembersOnly),
+	}
+	if req.MinAgeMonths != nil {
+		entryCriteria.MinAgeMonths = util.NewSqlNullInt64(*req.MinAgeMonths)
+	}
+
+	if err := h.drr.SaveDogrunEntryCriteria(c, &entryCriteria); err != nil {
+		return dto.DogrunEntryCriteriaRes{}, err
+	}
+	return convertDogrunEntryCriteriaRes(dogrunID, entryCriteria), nil
+}
+
+/*
+入場条件をレスポンスに変換
+*/
+func convertDogrunEntryCriteriaRes(dogrunID int64, entryCriteria model.DogrunEntryCriteria) dto.DogrunEntryCriteriaRes {
+	res := dto.DogrunEntryCriteriaRes{
+		DogrunID:           dogrunID,
+		AllowedSizeClasses: entryCriteria.AllowedSizeClassList(),
+		NeuteredRequired:   entryCriteria.NeuteredRequired.Bool,
+		MicrochipRequired:  entryCriteria.MicrochipRequired.Bool,
+		MembersOnly:        entryCriteria.MembersOnly.Bool,
+	}
+	if entryCriteria.MinAgeMonths.Valid {
+		minAgeMonths := entryCriteria.MinAgeMonths.Int64
+		res.MinAgeMonths = &minAgeMonths
+	}
+	return res
+}
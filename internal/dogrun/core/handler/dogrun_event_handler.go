@@ -0,0 +1,559 @@
+package handler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/auth/core"
+	dogFacade "github.com/wanrun-develop/wanrun/internal/dog/facade"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/wrcontext"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+)
+
+type IDogrunEventHandler interface {
+	GetEvents(echo.Context, int64) ([]dto.DogrunEventRes, error)
+	GetEvent(echo.Context, int64) (dto.DogrunEventDetailRes, error)
+	CreateMeetup(echo.Context, int64, dto.DogrunEventReq) (dto.DogrunEventRes, error)
+	CreateOfficialEvent(echo.Context, int64, dto.DogrunEventReq) (dto.DogrunEventRes, error)
+	CancelEvent(echo.Context, int64) error
+	Rsvp(echo.Context, int64, dto.DogrunEventRsvpReq) (dto.DogrunEventDetailRes, error)
+	CancelRsvp(echo.Context, int64, dto.DogrunEventRsvpReq) error
+}
+
+type dogrunEventHandler struct {
+	drr repository.IDogrunRepository
+	der repository.IDogrunEventRepository
+	df  dogFacade.IDogFacade
+}
+
+func NewDogrunEventHandler(drr repository.IDogrunRepository, der repository.IDogrunEventRepository, df dogFacade.IDogFacade) IDogrunEventHandler {
+	return &dogrunEventHandler{drr, der, df}
+}
+
+// GetEvents: ドッグランの開催予定のイベント一覧の取得
+// 終了していないイベントを開始日時順で返す
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - []dto.DogrunEventRes:	イベント一覧
+//   - error:	エラー
+func (h *dogrunEventHandler) GetEvents(c echo.Context, dogrunID int64) ([]dto.DogrunEventRes, error) {
+	if _, err := h.findDogrun(c, dogrunID); err != nil {
+		return nil, err
+	}
+
+	events, err := h.der.FindDogrunEvents(c, dogrunID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	eventsRes := []dto.DogrunEventRes{}
+	for _, event := range events {
+		eventsRes = append(eventsRes, convertDogrunEventRes(event))
+	}
+	return eventsRes, nil
+}
+
+// GetEvent: イベントの詳細(参加表明を含む)の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunEventID
+//
+// return:
+//   - dto.DogrunEventDetailRes:	イベントの詳細
+//   - error:	エラー
+func (h *dogrunEventHandler) GetEvent(c echo.Context, dogrunEventID int64) (dto.DogrunEventDetailRes, error) {
+	event, err := h.findEvent(c, dogrunEventID)
+	if err != nil {
+		return dto.DogrunEventDetailRes{}, err
+	}
+	return convertDogrunEventDetailRes(event), nil
+}
+
+// CreateMeetup: dogownerによるオフ会の登録
+// 開催日時がドッグランの営業時間内であること、参加可能なサイズが入場条件の範囲内であることをチェックする
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - dto.DogrunEventReq:	イベント
+//
+// return:
+//   - dto.DogrunEventRes:	登録したイベント
+//   - error:	エラー
+func (h *dogrunEventHandler) CreateMeetup(c echo.Context, dogrunID int64, req dto.DogrunEventReq) (dto.DogrunEventRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogOwnerID, err := wrcontext.GetLoginDogownerID(c)
+	if err != nil {
+		return dto.DogrunEventRes{}, err
+	}
+
+	dogrun, err := h.findDogrun(c, dogrunID)
+	if err != nil {
+		return dto.DogrunEventRes{}, err
+	}
+	if err := checkEventSchedule(c, dogrun, req.StartAt, req.EndAt); err != nil {
+		return dto.DogrunEventRes{}, err
+	}
+
+	//入場条件でサイズ制限がある場合は、その範囲内のみ指定可能
+	entryCriteria, err := h.drr.FindDogrunEntryCriteria(c, dogrunID)
+	if err != nil {
+		return dto.DogrunEventRes{}, err
+	}
+	if allowed := entryCriteria.AllowedSizeClassList(); len(allowed) > 0 {
+		if len(req.AllowedSizeClasses) == 0 {
+			req.AllowedSizeClasses = allowed
+		}
+		for _, sizeClass := range req.AllowedSizeClasses {
+			if !slices.Contains(allowed, sizeClass) {
+				err := errors.NewWRError(nil, fmt.Sprintf("このドッグランに入場可能なサイズは%sです", strings.Join(allowed, ",")), errors.NewDogrunClientErrorEType())
+				logger.Error(err)
+				return dto.DogrunEventRes{}, err
+			}
+		}
+	}
+
+	event := newDogrunEvent(dogrunID, model.DOGRUN_EVENT_TYPE_MEETUP, req)
+	event.OrganizerDogOwnerID = util.NewSqlNullInt64(dogOwnerID)
+
+	if err := h.der.CreateDogrunEvent(c, &event, nil); err != nil {
+		return dto.DogrunEventRes{}, err
+	}
+	return convertDogrunEventRes(event), nil
+}
+
+// CreateOfficialEvent: ドッグランマネージャーによる公式イベントの登録
+// 管理対象のドッグランのみ登録可能。applySpecialBusinessHourの指定がある場合は、
+// 営業時間外の開催でもイベントの時間帯を含むように特別営業時間を設定する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - dto.DogrunEventReq:	イベント
+//
+// return:
+//   - dto.DogrunEventRes:	登録したイベント
+//   - error:	エラー
+func (h *dogrunEventHandler) CreateOfficialEvent(c echo.Context, dogrunID int64, req dto.DogrunEventReq) (dto.DogrunEventRes, error) {
+	if err := checkManagedDogrun(c, h.drr, dogrunID); err != nil {
+		return dto.DogrunEventRes{}, err
+	}
+
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return dto.DogrunEventRes{}, err
+	}
+
+	dogrun, err := h.findDogrun(c, dogrunID)
+	if err != nil {
+		return dto.DogrunEventRes{}, err
+	}
+
+	var specialBusinessHour *model.SpecialBusinessHour
+	if req.ApplySpecialBusinessHour {
+		specialBusinessHour = resolveEventSpecialBusinessHour(dogrun, req.StartAt.In(time.Local), req.EndAt.In(time.Local))
+	}
+	if specialBusinessHour == nil {
+		if err := checkEventSchedule(c, dogrun, req.StartAt, req.EndAt); err != nil {
+			return dto.DogrunEventRes{}, err
+		}
+	} else if err := checkEventStartAt(c, req.StartAt); err != nil {
+		return dto.DogrunEventRes{}, err
+	}
+
+	event := newDogrunEvent(dogrunID, model.DOGRUN_EVENT_TYPE_OFFICIAL, req)
+	event.OrganizerDogrunManagerID = util.NewSqlNullInt64(userID)
+
+	if err := h.der.CreateDogrunEvent(c, &event, specialBusinessHour); err != nil {
+		return dto.DogrunEventRes{}, err
+	}
+	return convertDogrunEventRes(event), nil
+}
+
+// CancelEvent: イベントの中止
+// オフ会は主催したdogowner、公式イベントはドッグランのマネージャーのみ中止可能
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunEventID
+//
+// return:
+//   - error:	エラー
+func (h *dogrunEventHandler) CancelEvent(c echo.Context, dogrunEventID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	event, err := h.findEvent(c, dogrunEventID)
+	if err != nil {
+		return err
+	}
+	if event.Status.String == model.DOGRUN_EVENT_STATUS_CANCELLED {
+		err := errors.NewWRError(nil, "すでに中止されたイベントです", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	if event.IsOfficial() {
+		role, err := wrcontext.GetLoginUserRole(c)
+		if err != nil {
+			return err
+		}
+		if role == core.DOGOWNER_ROLE {
+			err := errors.NewWRError(nil, "公式イベントはドッグランのマネージャーのみ中止できます", errors.NewDogrunClientErrorEType())
+			logger.Error(err)
+			return err
+		}
+		if err := checkManagedDogrun(c, h.drr, event.DogrunID.Int64); err != nil {
+			return err
+		}
+	} else {
+		dogOwnerID, err := wrcontext.GetLoginDogownerID(c)
+		if err != nil {
+			return err
+		}
+		if event.OrganizerDogOwnerID.Int64 != dogOwnerID {
+			err := errors.NewWRError(nil, "主催者以外はイベントを中止できません", errors.NewDogrunClientErrorEType())
+			logger.Error(err)
+			return err
+		}
+	}
+
+	return h.der.CancelDogrunEvent(c, dogrunEventID)
+}
+
+// Rsvp: イベントへの参加表明
+// dogがイベントの参加可能サイズ、ドッグランの入場条件、開催時間帯の公式イベントの参加可能サイズを満たすかをチェックする
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunEventID
+//   - dto.DogrunEventRsvpReq:	参加するdog
+//
+// return:
+//   - dto.DogrunEventDetailRes:	参加表明後のイベントの詳細
+//   - error:	エラー
+func (h *dogrunEventHandler) Rsvp(c echo.Context, dogrunEventID int64, req dto.DogrunEventRsvpReq) (dto.DogrunEventDetailRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogOwnerID, err := wrcontext.GetLoginDogownerID(c)
+	if err != nil {
+		return dto.DogrunEventDetailRes{}, err
+	}
+
+	event, err := h.findEvent(c, dogrunEventID)
+	if err != nil {
+		return dto.DogrunEventDetailRes{}, err
+	}
+	if !event.IsOpenForRsvp(time.Now()) {
+		err := errors.NewWRError(nil, "参加表明を受け付けていないイベントです", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunEventDetailRes{}, err
+	}
+
+	if err := h.df.CheckDogownerValid(c, req.DogIDs); err != nil {
+		return dto.DogrunEventDetailRes{}, err
+	}
+	if err := h.checkRsvpEligibility(c, event, req.DogIDs); err != nil {
+		return dto.DogrunEventDetailRes{}, err
+	}
+
+	rsvps := []model.DogrunEventRsvp{}
+	for _, dogID := range req.DogIDs {
+		rsvps = append(rsvps, model.DogrunEventRsvp{
+			DogrunEventID: util.NewSqlNullInt64(dogrunEventID),
+			DogID:         util.NewSqlNullInt64(dogID),
+			DogOwnerID:    util.NewSqlNullInt64(dogOwnerID),
+		})
+	}
+	if err := h.der.CreateDogrunEventRsvps(c, dogrunEventID, rsvps); err != nil {
+		return dto.DogrunEventDetailRes{}, err
+	}
+
+	return h.GetEvent(c, dogrunEventID)
+}
+
+// CancelRsvp: イベントへの参加表明の取消
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunEventID
+//   - dto.DogrunEventRsvpReq:	取消するdog
+//
+// return:
+//   - error:	エラー
+func (h *dogrunEventHandler) CancelRsvp(c echo.Context, dogrunEventID int64, req dto.DogrunEventRsvpReq) error {
+	if _, err := h.findEvent(c, dogrunEventID); err != nil {
+		return err
+	}
+	if err := h.df.CheckDogownerValid(c, req.DogIDs); err != nil {
+		return err
+	}
+	return h.der.DeleteDogrunEventRsvps(c, dogrunEventID, req.DogIDs)
+}
+
+// checkRsvpEligibility: 参加するdogがイベントに参加可能かのチェック
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.DogrunEvent:	イベント
+//   - []int64:	参加するdogのID
+//
+// return:
+//   - error:	エラー
+func (h *dogrunEventHandler) checkRsvpEligibility(c echo.Context, event model.DogrunEvent, dogIDs []int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	entryCriteria, err := h.drr.FindDogrunEntryCriteria(c, event.DogrunID.Int64)
+	if err != nil {
+		return err
+	}
+
+	//開催時間帯の公式イベント(小型犬タイムなど)のサイズ制限も満たす必要がある
+	sizeRestrictions := [][]string{}
+	if allowed := event.AllowedSizeClassList(); len(allowed) > 0 {
+		sizeRestrictions = append(sizeRestrictions, allowed)
+	}
+	officialEvents, err := h.der.FindOverlappingOfficialEvents(c, event.DogrunID.Int64, event.StartAt.Time, event.EndAt.Time)
+	if err != nil {
+		return err
+	}
+	for _, officialEvent := range officialEvents {
+		if officialEvent.DogrunEventID == event.DogrunEventID {
+			continue
+		}
+		if allowed := officialEvent.AllowedSizeClassList(); len(allowed) > 0 {
+			sizeRestrictions = append(sizeRestrictions, allowed)
+		}
+	}
+
+	dogs, err := h.df.FindDogsByIDs(c, dogIDs)
+	if err != nil {
+		return err
+	}
+	for _, dog := range dogs {
+		//月齢はイベントの開始日時で判定
+		reasons := entryCriteria.UnmetReasons(dog, event.StartAt.Time)
+		for _, allowed := range sizeRestrictions {
+			if !slices.Contains(allowed, dog.SizeClass()) {
+				reasons = append(reasons, fmt.Sprintf("参加可能なサイズは%sです", strings.Join(allowed, ",")))
+				break
+			}
+		}
+		if len(reasons) > 0 {
+			err := errors.NewWRError(nil, fmt.Sprintf("ドッグID:%dはイベントの参加条件を満たしていません(%s)", dog.DogID.Int64, strings.Join(reasons, "、")), errors.NewDogrunClientErrorEType())
+			logger.Error(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// findDogrun: 営業時間を含むドッグランの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - model.Dogrun:	ドッグラン
+//   - error:	エラー
+func (h *dogrunEventHandler) findDogrun(c echo.Context, dogrunID int64) (model.Dogrun, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogruns, err := h.drr.FindDogrunWithRelationsByIDs(c, []int64{dogrunID})
+	if err != nil {
+		return model.Dogrun{}, err
+	}
+	if len(dogruns) == 0 {
+		err := errors.NewWRError(nil, "指定されたドッグランが存在しません", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return model.Dogrun{}, err
+	}
+	return dogruns[0], nil
+}
+
+// findEvent: イベントの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunEventID
+//
+// return:
+//   - model.DogrunEvent:	イベント
+//   - error:	エラー
+func (h *dogrunEventHandler) findEvent(c echo.Context, dogrunEventID int64) (model.DogrunEvent, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	event, err := h.der.FindDogrunEventByID(c, dogrunEventID)
+	if err != nil {
+		return model.DogrunEvent{}, err
+	}
+	if event.IsEmpty() {
+		err := errors.NewWRError(nil, fmt.Sprintf("指定されたイベントID:%dが存在しません", dogrunEventID), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return model.DogrunEvent{}, err
+	}
+	return event, nil
+}
+
+// checkEventSchedule: イベントの開催日時のチェック
+// 開始日時が未来であり、開催時間帯がドッグランの営業時間内であること
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.Dogrun:	営業時間を含むドッグラン
+//   - time.Time:	開始日時
+//   - time.Time:	終了日時
+//
+// return:
+//   - error:	エラー
+func checkEventSchedule(c echo.Context, dogrun model.Dogrun, startAt time.Time, endAt time.Time) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := checkEventStartAt(c, startAt); err != nil {
+		return err
+	}
+
+	startAt, endAt = startAt.In(time.Local), endAt.In(time.Local)
+	window := dogrun.BusinessWindowOn(startAt)
+	if window.Covers(startAt, endAt) {
+		return nil
+	}
+
+	var err error
+	if window.IsClosed {
+		err = errors.NewWRError(nil, fmt.Sprintf("%sはドッグランの休業日です", startAt.Format("2006/01/02")), errors.NewDogrunClientErrorEType())
+	} else {
+		err = errors.NewWRError(nil, fmt.Sprintf("ドッグランの営業時間(%s〜%s)外です", window.OpenAt.Format("15:04"), window.CloseAt.Format("15:04")), errors.NewDogrunClientErrorEType())
+	}
+	logger.Error(err)
+	return err
+}
+
+/*
+イベントの開始日時が未来であるかのチェック
+*/
+func checkEventStartAt(c echo.Context, startAt time.Time) error {
+	if startAt.After(time.Now()) {
+		return nil
+	}
+	err := errors.NewWRError(nil, "開始日時は現在より後である必要があります", errors.NewDogrunClientErrorEType())
+	log.GetLogger(c).Sugar().Error(err)
+	return err
+}
+
+/*
+公式イベントの時間帯を含むように調整した特別営業時間を返す。調整が不要な場合はnil
+すでに開催日の特別営業時間がある場合は、その営業時間を広げる
+*/
+func resolveEventSpecialBusinessHour(dogrun model.Dogrun, startAt time.Time, endAt time.Time) *model.SpecialBusinessHour {
+	window := dogrun.BusinessWindowOn(startAt)
+	if window.Covers(startAt, endAt) {
+		return nil
+	}
+
+	day := time.Date(startAt.Year(), startAt.Month(), startAt.Day(), 0, 0, 0, 0, startAt.Location())
+	specialBusinessHour := model.SpecialBusinessHour{
+		DogrunID: dogrun.DogrunID,
+		Date:     util.NewSqlNullTime(day),
+	}
+	for _, v := range dogrun.SpecialBusinessHours {
+		if v.IsValid() && v.Date.Time.Format(time.DateOnly) == day.Format(time.DateOnly) {
+			specialBusinessHour = v
+			break
+		}
+	}
+
+	openAt, closeAt := startAt, endAt
+	if !window.IsClosed {
+		if window.OpenAt.Before(openAt) {
+			openAt = window.OpenAt
+		}
+		if window.CloseAt.After(closeAt) {
+			closeAt = window.CloseAt
+		}
+	}
+
+	specialBusinessHour.IsClosed = util.NewSqlNullBool(false)
+	specialBusinessHour.IsAllDay = util.NewSqlNullBool(closeAt.Sub(openAt) >= 24*time.Hour)
+	specialBusinessHour.OpenTime = util.NewSqlNullString(openAt.Format(time.TimeOnly))
+	specialBusinessHour.CloseTime = util.NewSqlNullString(closeAt.Format(time.TimeOnly))
+	return &specialBusinessHour
+}
+
+/*
+リクエストからイベントを作成
+*/
+func newDogrunEvent(dogrunID int64, eventType string, req dto.DogrunEventReq) model.DogrunEvent {
+	event := model.DogrunEvent{
+		DogrunID:           util.NewSqlNullInt64(dogrunID),
+		EventType:          util.NewSqlNullString(eventType),
+		Title:              util.NewSqlNullString(req.Title),
+		Description:        util.NewSqlNullString(req.Description),
+		StartAt:            util.NewSqlNullTime(req.StartAt),
+		EndAt:              util.NewSqlNullTime(req.EndAt),
+		AllowedSizeClasses: util.NewSqlNullString(strings.Join(req.AllowedSizeClasses, ",")),
+		Status:             util.NewSqlNullString(model.DOGRUN_EVENT_STATUS_SCHEDULED),
+	}
+	if req.Capacity != nil {
+		event.Capacity = util.NewSqlNullInt64(*req.Capacity)
+	}
+	return event
+}
+
+/*
+イベントをレスポンスに変換
+*/
+func convertDogrunEventRes(event model.DogrunEvent) dto.DogrunEventRes {
+	res := dto.DogrunEventRes{
+		DogrunEventID:              event.DogrunEventID.Int64,
+		DogrunID:                   event.DogrunID.Int64,
+		EventType:                  event.EventType.String,
+		OrganizerDogOwnerID:        event.OrganizerDogOwnerID.Int64,
+		Title:                      event.Title.String,
+		Description:                event.Description.String,
+		StartAt:                    event.StartAt.Time,
+		EndAt:                      event.EndAt.Time,
+		AllowedSizeClasses:         event.AllowedSizeClassList(),
+		Status:                     event.Status.String,
+		RsvpCount:                  len(event.DogrunEventRsvps),
+		SpecialBusinessHourApplied: event.SpecialBusinessHourID.Valid,
+	}
+	if event.Capacity.Valid {
+		capacity := event.Capacity.Int64
+		res.Capacity = &capacity
+	}
+	return res
+}
+
+/*
+イベントを参加表明を含む詳細レスポンスに変換
+*/
+func convertDogrunEventDetailRes(event model.DogrunEvent) dto.DogrunEventDetailRes {
+	rsvpsRes := []dto.DogrunEventRsvpRes{}
+	for _, rsvp := range event.DogrunEventRsvps {
+		rsvpsRes = append(rsvpsRes, dto.DogrunEventRsvpRes{
+			DogID:      rsvp.DogID.Int64,
+			DogName:    rsvp.Dog.Name.String,
+			SizeClass:  rsvp.Dog.SizeClass(),
+			DogOwnerID: rsvp.DogOwnerID.Int64,
+		})
+	}
+	return dto.DogrunEventDetailRes{
+		DogrunEventRes: convertDogrunEventRes(event),
+		Rsvps:          rsvpsRes,
+	}
+}
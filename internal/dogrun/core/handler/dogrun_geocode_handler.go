@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"slices"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/geocoder"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+)
+
+const (
+	GEOCODE_REPAIR_MAX_DOGRUNS = 500                // 一括補完の1回あたりの上限。ジオコーディングAPIの利用量を抑えるため
+	GEOCODE_RETRY_INTERVAL     = 7 * 24 * time.Hour // 補完できなかったドッグランを、一括補完で再度試みるまでの間隔
+)
+
+type IDogrunGeocodeHandler interface {
+	SaveDogrunInfo(echo.Context, int64, dto.DogrunInfoReq) (dto.DogrunInfoRes, error)
+	RepairDogrunLocations(echo.Context, bool) (dto.GeocodeRepairRes, error)
+}
+
+type dogrunGeocodeHandler struct {
+	drr repository.IDogrunRepository
+	gc  geocoder.IGeocoder
+}
+
+func NewDogrunGeocodeHandler(drr repository.IDogrunRepository, gc geocoder.IGeocoder) IDogrunGeocodeHandler {
+	return &dogrunGeocodeHandler{drr, gc}
+}
+
+// SaveDogrunInfo: ドッグランの基本情報の更新
+// 管理対象のドッグランのみ更新可能。郵便番号・座標がない場合はジオコーディングで補完する
+// 補完に失敗した場合も、入力された内容で更新する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - dto.DogrunInfoReq:	更新内容
+//
+// return:
+//   - dto.DogrunInfoRes:	更新後の基本情報
+//   - error:	エラー
+func (h *dogrunGeocodeHandler) SaveDogrunInfo(c echo.Context, dogrunID int64, req dto.DogrunInfoReq) (dto.DogrunInfoRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := checkManagedDogrun(c, h.drr, dogrunID); err != nil {
+		return dto.DogrunInfoRes{}, err
+	}
+	dogruns, err := h.drr.FindDogrunByIDs([]int64{dogrunID})
+	if err != nil {
+		err = errors.NewWRError(err, "DBからのデータ取得に失敗", errors.NewDogrunServerErrorEType())
+		logger.Error(err)
+		return dto.DogrunInfoRes{}, err
+	}
+	dogrun := dogruns[0]
+
+	// 住所が変わった場合、未指定の郵便番号・座標は変更前の住所のものなので補完し直す
+	addressChanged := dogrun.Address.String != req.Address
+	dogrun.Name = util.NewSqlNullString(req.Name)
+	dogrun.Address = util.NewSqlNullString(req.Address)
+	dogrun.Description = util.NewSqlNullString(req.Description)
+	if req.PostCode != "" || addressChanged {
+		dogrun.PostCode = util.NewSqlNullString(req.PostCode)
+	}
+	if req.Latitude != nil && req.Longitude != nil {
+		dogrun.Latitude = util.NewSqlNullFloat64(*req.Latitude)
+		dogrun.Longitude = util.NewSqlNullFloat64(*req.Longitude)
+	} else if addressChanged {
+		dogrun.Latitude = util.NewSqlNullFloat64(0)
+		dogrun.Longitude = util.NewSqlNullFloat64(0)
+	}
+
+	geocoded, reason := fillDogrunLocation(c, h.gc, &dogrun)
+	if reason != "" {
+		logger.Warnf("ドッグラン:%d の座標・郵便番号を補完できませんでした。理由:%s", dogrunID, reason)
+	}
+
+	// 補完した項目以外はマネージャーによる編集とする
+	now := time.Now()
+	managerFields := slices.DeleteFunc([]string{
+		model.PROVENANCE_FIELD_NAME,
+		model.PROVENANCE_FIELD_ADDRESS,
+		model.PROVENANCE_FIELD_POSTCODE,
+		model.PROVENANCE_FIELD_LOCATION,
+		model.PROVENANCE_FIELD_DESCRIPTION,
+	}, func(field string) bool {
+		return slices.Contains(geocoded, field)
+	})
+	provenances := append(
+		newFieldProvenances(dogrunID, model.FIELD_SOURCE_MANAGER, now, managerFields...),
+		newFieldProvenances(dogrunID, model.FIELD_SOURCE_IMPORTED, now, geocoded...)...,
+	)
+
+	updates := map[string]any{
+		"name":        dogrun.Name,
+		"address":     dogrun.Address,
+		"postcode":    dogrun.PostCode,
+		"latitude":    dogrun.Latitude,
+		"longitude":   dogrun.Longitude,
+		"description": dogrun.Description,
+	}
+	if len(geocoded) > 0 || reason != "" {
+		updates["geocode_attempted_at"] = now
+	}
+	if err := h.drr.UpdateDogrunInfo(c, dogrunID, updates, provenances); err != nil {
+		return dto.DogrunInfoRes{}, err
+	}
+
+	return dto.DogrunInfoRes{
+		DogrunID: dogrunID,
+		Name:     dogrun.Name.String,
+		Address:  dto.Address{PostCode: dogrun.PostCode.String, Address: dogrun.Address.String},
+		Location: dto.Location{
+			Latitude:  dogrun.Latitude.Float64,
+			Longitude: dogrun.Longitude.Float64,
+		},
+		Description: dogrun.Description.String,
+		Geocoded:    geocoded,
+	}, nil
+}
+
+// RepairDogrunLocations: 座標か郵便番号がないドッグランの一括補完
+// 補完を試みたドッグランは日時を記録し、一定期間は対象外とする
+// dry-runの場合は補完結果の返却のみ行い、更新しない
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - bool:	dry-runかどうか
+//
+// return:
+//   - dto.GeocodeRepairRes:	補完結果と補完できなかったドッグラン
+//   - error:	エラー
+func (h *dogrunGeocodeHandler) RepairDogrunLocations(c echo.Context, dryRun bool) (dto.GeocodeRepairRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	now := time.Now()
+	dogruns, err := h.drr.FindDogrunsLackingLocation(c, now.Add(-GEOCODE_RETRY_INTERVAL), GEOCODE_REPAIR_MAX_DOGRUNS)
+	if err != nil {
+		return dto.GeocodeRepairRes{}, err
+	}
+
+	repairRes := dto.GeocodeRepairRes{
+		DryRun:     dryRun,
+		Geocoder:   h.gc.Name(),
+		Scanned:    len(dogruns),
+		Repaired:   []dto.GeocodeRepairedRes{},
+		Unresolved: []dto.GeocodeUnresolvedRes{},
+	}
+
+	for _, dogrun := range dogruns {
+		geocoded, reason := fillDogrunLocation(c, h.gc, &dogrun)
+		if reason != "" {
+			repairRes.Unresolved = append(repairRes.Unresolved, dto.GeocodeUnresolvedRes{
+				DogrunID: dogrun.DogrunID.Int64,
+				Name:     dogrun.Name.String,
+				Address:  dogrun.Address.String,
+				Reason:   reason,
+			})
+		}
+
+		if !dryRun {
+			// 補完できなかった場合も、試みた日時を記録する
+			updates := map[string]any{"geocode_attempted_at": now}
+			if len(geocoded) > 0 {
+				updates["address"] = dogrun.Address
+				updates["postcode"] = dogrun.PostCode
+				updates["latitude"] = dogrun.Latitude
+				updates["longitude"] = dogrun.Longitude
+			}
+			if err := h.drr.UpdateDogrunInfo(c, dogrun.DogrunID.Int64, updates,
+				newFieldProvenances(dogrun.DogrunID.Int64, model.FIELD_SOURCE_IMPORTED, now, geocoded...)); err != nil {
+				logger.Warnf("ドッグラン:%d の補完結果の更新に失敗", dogrun.DogrunID.Int64)
+				continue
+			}
+		}
+		if len(geocoded) == 0 {
+			continue
+		}
+		repairRes.Repaired = append(repairRes.Repaired, dto.GeocodeRepairedRes{
+			DogrunID: dogrun.DogrunID.Int64,
+			Name:     dogrun.Name.String,
+			Address:  dto.Address{PostCode: dogrun.PostCode.String, Address: dogrun.Address.String},
+			Location: dto.Location{
+				Latitude:  dogrun.Latitude.Float64,
+				Longitude: dogrun.Longitude.Float64,
+			},
+			Geocoded: geocoded,
+		})
+	}
+	repairRes.RepairedCount = len(repairRes.Repaired)
+
+	logger.Infof("Geocode repair finished. dryRun: %v, scanned: %d, repaired: %d, unresolved: %d",
+		dryRun, repairRes.Scanned, repairRes.RepairedCount, len(repairRes.Unresolved))
+
+	return repairRes, nil
+}
+
+/*
+ドッグランの座標・郵便番号がない場合に、住所からのジオコーディングで補完する
+住所から求められない場合は、座標からの逆ジオコーディングで郵便番号(住所がなければ住所も)を補完する
+補完した項目と、全て補完できなかった場合はその理由を返す
+*/
+func fillDogrunLocation(c echo.Context, gc geocoder.IGeocoder, dogrun *model.Dogrun) ([]string, string) {
+	logger := log.GetLogger(c).Sugar()
+
+	hasAddress := dogrun.Address.Valid && dogrun.Address.String != ""
+	needsLocation := func() bool {
+		return !dogrun.Latitude.Valid || !dogrun.Longitude.Valid || dogrun.Latitude.Float64 == 0 || dogrun.Longitude.Float64 == 0
+	}
+	needsPostCode := func() bool {
+		return !dogrun.PostCode.Valid || dogrun.PostCode.String == ""
+	}
+	if !needsLocation() && !needsPostCode() {
+		return nil, ""
+	}
+	if !hasAddress && needsLocation() {
+		return nil, dto.GEOCODE_UNRESOLVED_NO_ADDRESS
+	}
+
+	geocoded := []string{}
+	var geocodeErr error
+	if hasAddress {
+		result, err := gc.Geocode(c, dogrun.Address.String)
+		if err != nil {
+			geocodeErr = err
+		}
+		if needsLocation() && result.HasLocation() {
+			dogrun.Latitude = util.NewSqlNullFloat64(result.Latitude)
+			dogrun.Longitude = util.NewSqlNullFloat64(result.Longitude)
+			geocoded = append(geocoded, dto.GEOCODED_FIELD_LOCATION)
+		}
+		if needsPostCode() && result.PostCode != "" {
+			dogrun.PostCode = util.NewSqlNullString(result.PostCode)
+			geocoded = append(geocoded, dto.GEOCODED_FIELD_POSTCODE)
+		}
+	}
+
+	if needsPostCode() && !needsLocation() {
+		result, err := gc.ReverseGeocode(c, dogrun.Latitude.Float64, dogrun.Longitude.Float64)
+		if err != nil {
+			geocodeErr = err
+		}
+		if result.PostCode != "" {
+			dogrun.PostCode = util.NewSqlNullString(result.PostCode)
+			geocoded = append(geocoded, dto.GEOCODED_FIELD_POSTCODE)
+		}
+		if !hasAddress && result.Address != "" {
+			dogrun.Address = util.NewSqlNullString(result.Address)
+			geocoded = append(geocoded, dto.GEOCODED_FIELD_ADDRESS)
+		}
+	}
+
+	if !needsLocation() && !needsPostCode() {
+		return geocoded, ""
+	}
+	if geocodeErr != nil {
+		logger.Warnf("ドッグラン:%d のジオコーディングでエラー: %v", dogrun.DogrunID.Int64, geocodeErr)
+		return geocoded, dto.GEOCODE_UNRESOLVED_ERROR
+	}
+	return geocoded, dto.GEOCODE_UNRESOLVED_NOT_FOUND
+}
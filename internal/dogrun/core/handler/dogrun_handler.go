@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"cmp"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"slices"
@@ -15,20 +17,24 @@ import (
 	"github.com/wanrun-develop/wanrun/pkg/errors"
 	"github.com/wanrun-develop/wanrun/pkg/log"
 	"github.com/wanrun-develop/wanrun/pkg/util"
+	"golang.org/x/sync/errgroup"
 )
 
 const (
-	SEARCH_TEXT_MAX_REQUEST_TIMES = 3 //searchTextの最大リクエスト数。pageSizeを20に指定すると、20*3=60個まで取得する
+	SEARCH_TEXT_MAX_REQUEST_TIMES = 3  //searchTextの最大リクエスト数。pageSizeを20に指定すると、20*3=60個まで取得する
+	DEFAULT_SEARCH_LIMIT          = 20 //周辺検索の取得件数の指定がない場合の件数
+	PLACE_INFO_FETCH_CONCURRENCY  = 5  //google情報を並行して取得する最大数
 )
 
 type IDogrunHandler interface {
 	GetDogrunDetail(echo.Context, string) (dto.DogrunDetail, error)
 	GetDogrunByID(string)
 	GetDogrunTagMst(echo.Context) ([]dto.TagMstRes, error)
-	SearchAroundDogruns(echo.Context, dto.SearchAroundRectangleCondition) ([]dto.DogrunLists, error)
-	SearchAroundAndTagDogruns(echo.Context, dto.SearchAroundRectangleCondition) ([]dto.DogrunLists, error)
+	SearchAroundDogruns(echo.Context, dto.SearchAroundRectangleCondition) (dto.DogrunSearchRes, error)
 	getBookmarkedDogrunIDs(echo.Context, chan<- []int64)
 	GetDogrunPhotoSrc(echo.Context, string, string, string) (string, error)
+	GetDogrunListsByIDs(echo.Context, []int64) ([]dto.DogrunLists, error)
+	ResolveDogrunIDByPlaceID(echo.Context, string) (int64, error)
 }
 
 type dogrunHandler struct {
@@ -106,30 +112,31 @@ func (h *dogrunHandler) GetDogrunTagMst(c echo.Context) ([]dto.TagMstRes, error)
 	}
 
 	for _, m := range tagMst {
-		mst := dto.TagMstRes{
-			TagID:       m.TagID.Int64,
-			TagName:     m.TagName.String,
-			Description: m.Description.String,
-		}
-		mstRes = append(mstRes, mst)
+		mstRes = append(mstRes, convertTagMstRes(m))
 	}
 
 	return mstRes, nil
 }
 
-// SearchAroundDogruns: 指定内（長方形）のドッグランをgoogle検索して、DB情報と照合して返す
+// SearchAroundDogruns: 指定内（長方形）のドッグランをgoogle検索とDB検索の両方から取得して統合し、
+// 統合後に絞り込み条件を適用して、並び替えた結果を指定件数ずつ返す
 //
 // args:
 //   - echo.Context:	コンテキスト
 //   - dto.SearchAroundRectangleCondition:	条件
 //
 // return:
-//   - []dto.DogrunLists:	リストDTO
+//   - dto.DogrunSearchRes:	リストDTOと次のページのカーソル
 //   - error:	エラー
-func (h *dogrunHandler) SearchAroundDogruns(c echo.Context, condition dto.SearchAroundRectangleCondition) ([]dto.DogrunLists, error) {
+func (h *dogrunHandler) SearchAroundDogruns(c echo.Context, condition dto.SearchAroundRectangleCondition) (dto.DogrunSearchRes, error) {
 	logger := log.GetLogger(c).Sugar()
 	logger.Debugw("検索条件", "condition", condition)
 
+	cursor, err := decodeSearchCursor(c, condition.Cursor, condition)
+	if err != nil {
+		return dto.DogrunSearchRes{}, err
+	}
+
 	payload := googleplace.ConvertReqToSearchTextPayload(condition)
 
 	//ブックマーク済みを並列で取得
@@ -142,7 +149,7 @@ func (h *dogrunHandler) SearchAroundDogruns(c echo.Context, condition dto.Search
 	//place情報の取得
 	dogrunsG, err := h.searchTextUpToSpecifiedTimes(c, payload, baseFiled)
 	if err != nil {
-		return nil, err
+		return dto.DogrunSearchRes{}, err
 	}
 	logger.Infof("googleレスポンスplace数:%d", len(dogrunsG))
 	dogrunGPlaceIDs := []string{}
@@ -153,77 +160,184 @@ func (h *dogrunHandler) SearchAroundDogruns(c echo.Context, condition dto.Search
 	//DBにある指定場所内のドッグランを取得
 	dogrunsD, err := h.drr.GetDogrunByRectanglePointerOrPlaceId(c, condition, dogrunGPlaceIDs)
 	if err != nil {
-		return nil, err
+		return dto.DogrunSearchRes{}, err
 	}
 	logger.Infof("DBから取得数:%d", len(dogrunsD))
 
 	//検索結果からレスポンスを作成
 	dogrunLists, err := h.integrateDogrunInfos(dogrunsG, dogrunsD)
-	logger.Infof("レスポンス件数:%d", len(dogrunLists))
+	logger.Infof("統合件数:%d", len(dogrunLists))
 	if err != nil {
-		return nil, err
-	}
-
-	//dogrunIDがないデータのメンテしてセット
-	if err = h.GenerateSetDogrunIDs(c, dogrunLists); err != nil {
-		return nil, err
+		return dto.DogrunSearchRes{}, err
 	}
 
 	//ドッグラン情報の過不足フィルター
 	dogrunLists = excludeInsufficientDogrunInfo(c, dogrunLists)
 
+	//統合後の情報で絞り込み、並び替え
+	dogrunLists = filterDogrunLists(dogrunLists, condition)
+	sortDogrunLists(dogrunLists, condition)
+	logger.Infof("絞り込み後の件数:%d", len(dogrunLists))
+
+	//指定のページのみに絞る
+	limit := condition.Limit
+	if limit == 0 {
+		limit = DEFAULT_SEARCH_LIMIT
+	}
+	//前のページの最後のドッグランより後ろから返す。ページ間で件数が変わっても重複・欠落しないようにする
+	start := 0
+	if cursor != nil {
+		start = slices.IndexFunc(dogrunLists, func(d dto.DogrunLists) bool {
+			return compareSearchCursor(newSearchCursor(d, condition), *cursor) > 0
+		})
+		if start < 0 {
+			start = len(dogrunLists)
+		}
+	}
+	var res dto.DogrunSearchRes
+	end := min(start+limit, len(dogrunLists))
+	res.Dogruns = dogrunLists[start:end]
+	if end < len(dogrunLists) {
+		res.NextCursor = encodeSearchCursor(newSearchCursor(dogrunLists[end-1], condition))
+	}
+
+	//dogrunIDがないデータのメンテしてセット
+	if err = h.GenerateSetDogrunIDs(c, res.Dogruns); err != nil {
+		return dto.DogrunSearchRes{}, err
+	}
+
 	//ブックマーク済みdogrunにフラグ付与
-	dogrunLists, err = setIsBookmarked(c, dogrunLists, bookmarkedDogrunIDsCH)
+	res.Dogruns, err = setIsBookmarked(c, res.Dogruns, bookmarkedDogrunIDsCH)
 	if err != nil {
-		return nil, err
+		return dto.DogrunSearchRes{}, err
 	}
 
-	return dogrunLists, nil
+	return res, nil
 }
 
-// SearchAroundAndTagDogruns: 指定内（長方形）とドッグランタグ条件でDB検索して、返す
-// google検索は行わない
-//
-// args:
-//   - echo.Context:	コンテキスト
-//   - dto.SearchAroundRectangleCondition:	条件
-//
-// return:
-//   - []dto.DogrunLists:	リストDTO
-//   - error:	エラー
-func (h *dogrunHandler) SearchAroundAndTagDogruns(c echo.Context, condition dto.SearchAroundRectangleCondition) ([]dto.DogrunLists, error) {
+/*
+統合後のドッグラン情報を、タグ・営業中・評価・管理者有無の条件で絞り込む
+タグはDB情報のみが持つため、google側にしかないドッグランはタグを持たないものとして扱う
+*/
+func filterDogrunLists(dogrunLists []dto.DogrunLists, condition dto.SearchAroundRectangleCondition) []dto.DogrunLists {
+	includeTags := util.ConvertSliceToMap(condition.IncludeDogrunTags, func(i int64) int64 { return i })
+	excludeTags := util.ConvertSliceToMap(condition.ExcludeDogrunTags, func(i int64) int64 { return i })
+
+	filtered := []dto.DogrunLists{}
+	for _, dogrun := range dogrunLists {
+		if condition.NowOpen && !dogrun.NowOpen {
+			continue
+		}
+		if condition.MinRating > 0 && dogrun.GoogleRating < condition.MinRating {
+			continue
+		}
+		if condition.ManagedOnly && !dogrun.IsManaged {
+			continue
+		}
+		if !matchDogrunTags(dogrun.DogrunTags, includeTags, excludeTags, condition.IsMatchAllTags()) {
+			continue
+		}
+		filtered = append(filtered, dogrun)
+	}
+	return filtered
+}
 
-	logger := log.GetLogger(c).Sugar()
-	logger.Debugw("検索条件", "condition", condition)
+/*
+ドッグランタグが条件に一致するか
+*/
+func matchDogrunTags(dogrunTags []int64, includeTags, excludeTags map[int64]int64, matchAll bool) bool {
+	matched := map[int64]struct{}{}
+	for _, tagID := range dogrunTags {
+		if _, exist := excludeTags[tagID]; exist {
+			return false
+		}
+		if _, exist := includeTags[tagID]; exist {
+			matched[tagID] = struct{}{}
+		}
+	}
+	if len(includeTags) == 0 {
+		return true
+	}
+	if matchAll {
+		return len(matched) == len(includeTags)
+	}
+	return len(matched) > 0
+}
 
-	//ブックマーク済みを並列で取得
-	bookmarkedDogrunIDsCH := make(chan []int64)
-	go h.getBookmarkedDogrunIDs(c, bookmarkedDogrunIDsCH)
+/*
+ページングのカーソル。前のページの最後のドッグランの並び順のキー
+*/
+type searchCursor struct {
+	Sort     string    `json:"s"`           // 並び順
+	Keys     []float64 `json:"k"`           // 並び順の値(昇順で比較できるようにしたもの)
+	PlaceID  string    `json:"p,omitempty"` // 同順の場合の順番
+	DogrunID int64     `json:"d,omitempty"` // 同順の場合の順番
+}
 
-	//DBにある指定場所内のドッグランを取得
-	dogrunsD, err := h.drr.GetDogrunByRectanglePointerAndDogrunTags(c, condition)
-	if err != nil {
-		return nil, err
+/*
+ドッグランの並び順のキー
+*/
+func newSearchCursor(d dto.DogrunLists, condition dto.SearchAroundRectangleCondition) searchCursor {
+	cursor := searchCursor{Sort: condition.Sort, PlaceID: d.PlaceId, DogrunID: d.DogrunID}
+	switch condition.Sort {
+	case dto.SEARCH_SORT_RATING:
+		cursor.Keys = []float64{-float64(d.GoogleRating), -float64(d.UserRatingCount)}
+	case dto.SEARCH_SORT_POPULARITY:
+		cursor.Keys = []float64{-float64(d.UserRatingCount), -float64(d.GoogleRating)}
+	default:
+		centerLat, centerLng := condition.Center()
+		cursor.Keys = []float64{util.CalcDistance(centerLat, centerLng, d.Location.Latitude, d.Location.Longitude)}
 	}
-	logger.Infof("DBから取得数:%d", len(dogrunsD))
+	return cursor
+}
 
-	dogrunLists := []dto.DogrunLists{}
+/*
+並び順のキーの比較
+*/
+func compareSearchCursor(a, b searchCursor) int {
+	return cmp.Or(slices.Compare(a.Keys, b.Keys), cmp.Compare(a.PlaceID, b.PlaceID), cmp.Compare(a.DogrunID, b.DogrunID))
+}
 
-	for _, dogrun := range dogrunsD {
-		dogrunLists = append(dogrunLists, resolveDogrunListByOnlyDB(dogrun))
-	}
-	logger.Infof("レスポンス件数:%d", len(dogrunLists))
+/*
+指定の並び順で並び替える
+同順の場合は、ページングで順番が揺れないようにplaceId、dogrunIDの順で並べる
+*/
+func sortDogrunLists(dogrunLists []dto.DogrunLists, condition dto.SearchAroundRectangleCondition) {
+	slices.SortStableFunc(dogrunLists, func(a, b dto.DogrunLists) int {
+		return compareSearchCursor(newSearchCursor(a, condition), newSearchCursor(b, condition))
+	})
+}
 
-	//ドッグラン情報の過不足フィルター
-	dogrunLists = excludeInsufficientDogrunInfo(c, dogrunLists)
+/*
+次のページのカーソルを作成する
+*/
+func encodeSearchCursor(cursor searchCursor) string {
+	encoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
 
-	//ブックマーク済みdogrunにフラグ付与
-	dogrunLists, err = setIsBookmarked(c, dogrunLists, bookmarkedDogrunIDsCH)
+/*
+カーソルを復元する。未指定の場合はnil
+並び順が異なるカーソルは不正とする
+*/
+func decodeSearchCursor(c echo.Context, cursor string, condition dto.SearchAroundRectangleCondition) (*searchCursor, error) {
+	logger := log.GetLogger(c).Sugar()
+	if cursor == "" {
+		return nil, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
 	if err != nil {
+		err = errors.NewWRError(err, "カーソルが不正です", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
 		return nil, err
 	}
-
-	return dogrunLists, nil
+	var sc searchCursor
+	if err := json.Unmarshal(decoded, &sc); err != nil || sc.Sort != condition.Sort || len(sc.Keys) == 0 {
+		err = errors.NewWRError(err, "カーソルが不正です", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return nil, err
+	}
+	return &sc, nil
 }
 
 // getBookmarkedDogrunIDs: サブルーチンでログチンユーザーのブックマーク済みDogrunIDを全て取得する
@@ -271,6 +385,102 @@ func setIsBookmarked(c echo.Context, dogrunLists []dto.DogrunLists, bookmarkedDo
 	return dogrunLists, nil
 }
 
+// GetDogrunListsByIDs: 指定のdogrunIDのドッグラン一覧情報を、DB情報とgoogle情報を照合して返す
+// 引数のdogrunIDの順番でレスポンスを返す。存在しないdogrunIDは除外する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - []int64:	dogrunIDs
+//
+// return:
+//   - []dto.DogrunLists:	リストDTO
+//   - error:	エラー
+func (h *dogrunHandler) GetDogrunListsByIDs(c echo.Context, dogrunIDs []int64) ([]dto.DogrunLists, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunsD, err := h.drr.FindDogrunWithRelationsByIDs(c, dogrunIDs)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("DBから取得数:%d", len(dogrunsD))
+	dogrunsDMap := util.ConvertSliceToMap(dogrunsD, func(d model.Dogrun) int64 { return d.DogrunID.Int64 })
+
+	//base情報のFieldを使用
+	var baseFiled googleplace.IFieldMask = googleplace.BaseField{}
+
+	//placeIdがあるものはgoogle情報と照合するため、並行して取得する
+	//取得できない場合はDB情報のみで返すため、エラーでは中断しない
+	dogrunsG := make([]googleplace.BaseResource, len(dogrunIDs))
+	var eg errgroup.Group
+	eg.SetLimit(PLACE_INFO_FETCH_CONCURRENCY)
+	for i, dogrunID := range dogrunIDs {
+		dogrunD, exist := dogrunsDMap[dogrunID]
+		if !exist || !dogrunD.PlaceId.Valid {
+			continue
+		}
+		eg.Go(func() error {
+			dogrunG, err := h.fetchPlaceInfo(c, dogrunD.PlaceId.String, baseFiled)
+			if err != nil {
+				logger.Warnf("placeId\"%s\"のgoogle情報の取得に失敗: %v", dogrunD.PlaceId.String, err)
+				return nil
+			}
+			dogrunsG[i] = dogrunG
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	dogrunLists := []dto.DogrunLists{}
+	for i, dogrunID := range dogrunIDs {
+		dogrunD, exist := dogrunsDMap[dogrunID]
+		if !exist {
+			logger.Infof("ドッグラン:%d がDBに存在しないため除外", dogrunID)
+			continue
+		}
+		if !dogrunD.PlaceId.Valid {
+			dogrunLists = append(dogrunLists, resolveDogrunListByOnlyDB(dogrunD))
+			continue
+		}
+
+		if dogrunsG[i].IsEmpty() {
+			//google情報が取得できない場合はDB情報のみで返す
+			logger.Warnf("placeId\"%s\"のgoogle情報が取得できないため、DB情報のみで作成", dogrunD.PlaceId.String)
+			dogrunLists = append(dogrunLists, resolveDogrunListByOnlyDB(dogrunD))
+			continue
+		}
+		dogrunLists = append(dogrunLists, resolveDogrunList(dogrunsG[i], dogrunD))
+	}
+
+	return dogrunLists, nil
+}
+
+// fetchPlaceInfo: placeIdでgoogleのplace情報を取得し、構造体に変換する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - string:	placeID
+//   - googleplace.IFieldMask:	field mask
+//
+// return:
+//   - googleplace.BaseResource:	place情報
+//   - error:	エラー
+func (h *dogrunHandler) fetchPlaceInfo(c echo.Context, placeID string, fields googleplace.IFieldMask) (googleplace.BaseResource, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	resG, err := h.rest.GETPlaceInfo(c, placeID, fields)
+	if err != nil {
+		return googleplace.BaseResource{}, err
+	}
+
+	var dogrunG googleplace.BaseResource
+	if err := json.Unmarshal(resG, &dogrunG); err != nil {
+		err := errors.NewWRError(nil, "google apiレスポンスの変換に失敗しました。", errors.NewDogrunServerErrorEType())
+		logger.Error(err)
+		return googleplace.BaseResource{}, err
+	}
+	return dogrunG, nil
+}
+
 /*
 ドッグランのgoogle画像をnameからsource用のURLを取得する
 */
@@ -296,7 +506,7 @@ func (h *dogrunHandler) GetDogrunPhotoSrc(c echo.Context, name, widthPx, heightP
 
 /*
 Google情報とDB情報から、ドッグラン詳細情報を作成
-基本的に、DB情報をドッグランマネージャーからの手動更新がある前提で、優先情報とする
+名前・住所・座標・説明は、項目ごとのマージの方針に従って選ぶ
 */
 func resolveDogrunDetail(dogrunG googleplace.BaseResource, dogrunD model.Dogrun) dto.DogrunDetail {
 
@@ -310,29 +520,29 @@ func resolveDogrunDetail(dogrunG googleplace.BaseResource, dogrunD model.Dogrun)
 	if dogrunD.DogrunManagerID.Valid {
 		dogrunManager = dogrunD.DogrunManagerID.Int64
 	}
+	merged := mergeDogrunFields(dogrunG, dogrunD)
 
 	return dto.DogrunDetail{
 		DogrunID:        dogrunD.DogrunID.Int64,
 		DogrunManagerID: dogrunManager,
 		PlaceId:         dogrunG.ID,
-		Name:            util.ChooseStringValidValue(dogrunD.Name, dogrunG.DisplayName.Text),
-		Address:         resolveDogrunAddress(dogrunG, dogrunD),
-		Location: dto.Location{
-			Latitude:  util.ChooseFloat64ValidValue(dogrunD.Latitude, dogrunG.Location.Latitude),
-			Longitude: util.ChooseFloat64ValidValue(dogrunD.Longitude, dogrunG.Location.Longitude),
-		},
-		BusinessStatus: dogrunG.BusinessStatus,
-		NowOpen:        resolveNowOpening(dogrunG, dogrunD),
+		Name:            merged.name,
+		Address:         merged.address,
+		Location:        merged.location,
+		BusinessStatus:  dogrunG.BusinessStatus,
+		NowOpen:         resolveNowOpening(dogrunG, dogrunD),
 		BusinessHour: dto.BusinessHour{
 			Regular: resolveRegularBusinessHour(dogrunG, dogrunD),
 			Special: resolveSpecialBusinessHour(dogrunD),
 		},
-		Description:     util.ChooseStringValidValue(dogrunD.Description, dogrunG.Summary.Text),
+		Description:     merged.description,
 		GoogleRating:    dogrunG.Rating,
 		UserRatingCount: dogrunG.UserRatingCount,
 		DogrunTags:      resolveDogrunTagInfo(dogrunD), // ドッグランタグ情報
+		Photos:          resolvePhotos(dogrunG, dogrunD),
 		CreateAt:        &dogrunD.CreateAt.Time,
 		UpdateAt:        &dogrunD.UpdateAt.Time,
+		Provenance:      merged.provenance,
 	}
 
 }
@@ -342,49 +552,52 @@ DBにデータがない場合、google情報のみでドッグラン詳細情報
 */
 func resolveDogrunDetailByOnlyGoogle(dogrunG googleplace.BaseResource) dto.DogrunDetail {
 	var emptyDogrunD model.Dogrun
+	merged := mergeDogrunFields(dogrunG, emptyDogrunD)
 	return dto.DogrunDetail{
-		PlaceId: dogrunG.ID,
-		Name:    dogrunG.DisplayName.Text,
-		Address: resolveDogrunAddress(dogrunG, emptyDogrunD),
-		Location: dto.Location{
-			Latitude:  dogrunG.Location.Latitude,
-			Longitude: dogrunG.Location.Longitude,
-		},
+		PlaceId:        dogrunG.ID,
+		Name:           merged.name,
+		Address:        merged.address,
+		Location:       merged.location,
 		BusinessStatus: dogrunG.BusinessStatus,
 		NowOpen:        resolveNowOpening(dogrunG, emptyDogrunD),
 		BusinessHour: dto.BusinessHour{
 			Regular: resolveRegularBusinessHour(dogrunG, emptyDogrunD),
 			Special: resolveSpecialBusinessHour(emptyDogrunD),
 		},
-		Description:     dogrunG.Summary.Text,
+		Description:     merged.description,
 		GoogleRating:    dogrunG.Rating,
 		UserRatingCount: dogrunG.UserRatingCount,
+		Photos:          resolvePlacePhotos(dogrunG),
+		Provenance:      merged.provenance,
 	}
 }
 
 func resolveDogrunDetailByOnlyDB(dogrunD model.Dogrun) dto.DogrunDetail {
 
 	var emptyDogrunG googleplace.BaseResource
+	merged := mergeDogrunFields(emptyDogrunG, dogrunD)
 
 	return dto.DogrunDetail{
 		DogrunID:        dogrunD.DogrunID.Int64,
 		DogrunManagerID: dogrunD.DogrunManagerID.Int64,
 		PlaceId:         dogrunD.PlaceId.String,
-		Name:            dogrunD.Name.String,
-		Address:         resolveDogrunAddress(emptyDogrunG, dogrunD),
-		Location: dto.Location{
-			Latitude:  dogrunD.Latitude.Float64,
-			Longitude: dogrunD.Longitude.Float64,
-		},
-		NowOpen: resolveNowOpening(emptyDogrunG, dogrunD),
+		Name:            merged.name,
+		Address:         merged.address,
+		Location:        merged.location,
+		BusinessStatus:  dogrunD.BusinessStatus.String, // google place情報の同期結果
+		NowOpen:         resolveNowOpening(emptyDogrunG, dogrunD),
 		BusinessHour: dto.BusinessHour{
 			Regular: resolveRegularBusinessHour(emptyDogrunG, dogrunD),
 			Special: resolveSpecialBusinessHour(dogrunD),
 		},
-		Description: dogrunD.Description.String,
-		DogrunTags:  resolveDogrunTagInfo(dogrunD), // ドッグランタグ情報
-		CreateAt:    &dogrunD.CreateAt.Time,
-		UpdateAt:    &dogrunD.UpdateAt.Time,
+		Description:     merged.description,
+		GoogleRating:    float32(dogrunD.GoogleRating.Float64),
+		UserRatingCount: int(dogrunD.GoogleUserRatingCount.Int64),
+		DogrunTags:      resolveDogrunTagInfo(dogrunD), // ドッグランタグ情報
+		Photos:          resolvePhotos(emptyDogrunG, dogrunD),
+		CreateAt:        &dogrunD.CreateAt.Time,
+		UpdateAt:        &dogrunD.UpdateAt.Time,
+		Provenance:      merged.provenance,
 	}
 }
 
@@ -406,35 +619,6 @@ func resolveDogrunTagInfo(dogrunD model.Dogrun) []int64 {
 	return dogrunTagIds
 }
 
-/*
-住所情報の選定
-*/
-func resolveDogrunAddress(dogrunG googleplace.BaseResource, dogrunD model.Dogrun) dto.Address {
-
-	var gPostCodeComponent googleplace.AddressComponent
-	for _, v := range dogrunG.AddressComponents {
-		if slices.Contains(v.Types, googleplace.ADDRESSCOMPONENT_TYPES_POSTAL_CODE) {
-			gPostCodeComponent = v
-			break
-		}
-	}
-	var address string
-	var postCode string
-
-	if dogrunD.IsNotEmpty() && dogrunG.IsNotEmpty() { //両方にある場合
-		address = util.ChooseStringValidValue(dogrunD.Address, dogrunG.ShortFormattedAddress)
-		postCode = util.ChooseStringValidValue(dogrunD.PostCode, gPostCodeComponent.LongText)
-	} else if dogrunD.IsNotEmpty() { //DBにのみある場合
-		address = dogrunD.Address.String
-		postCode = dogrunD.PostCode.String
-	} else if dogrunG.IsNotEmpty() { //google側にのみ
-		address = dogrunG.ShortFormattedAddress
-		postCode = gPostCodeComponent.LongText
-	}
-
-	return dto.Address{PostCode: postCode, Address: address}
-}
-
 /*
 営業時間から、現在が営業中かを判定
 */
@@ -688,27 +872,26 @@ func (h *dogrunHandler) integrateDogrunInfos(dogrunsG []googleplace.BaseResource
 
 /*
 Google情報とDB情報から、ドッグラン一覧情報を作成
-基本的に、DB情報をドッグランマネージャーからの手動更新がある前提で、優先情報とする
+名前・住所・座標・説明は、項目ごとのマージの方針に従って選ぶ
 */
 func resolveDogrunList(dogrunG googleplace.BaseResource, dogrunD model.Dogrun) dto.DogrunLists {
+	merged := mergeDogrunFields(dogrunG, dogrunD)
 	return dto.DogrunLists{
-		DogrunID: dogrunD.DogrunID.Int64,
-		PlaceId:  dogrunG.ID,
-		Name:     util.ChooseStringValidValue(dogrunD.Name, dogrunG.DisplayName.Text),
-		Address:  resolveDogrunAddress(dogrunG, dogrunD),
-		Location: dto.Location{
-			Latitude:  util.ChooseFloat64ValidValue(dogrunD.Latitude, dogrunG.Location.Latitude),
-			Longitude: util.ChooseFloat64ValidValue(dogrunD.Longitude, dogrunG.Location.Longitude),
-		},
+		DogrunID:          dogrunD.DogrunID.Int64,
+		PlaceId:           dogrunG.ID,
+		Name:              merged.name,
+		Address:           merged.address,
+		Location:          merged.location,
 		BusinessStatus:    dogrunG.BusinessStatus,
 		NowOpen:           resolveNowOpening(dogrunG, dogrunD),
 		ToadyBusinessHour: resolveTodayBusinessHour(dogrunG, dogrunD),
-		Description:       util.ChooseStringValidValue(dogrunD.Description, dogrunG.Summary.Text),
+		Description:       merged.description,
 		GoogleRating:      dogrunG.Rating,
 		UserRatingCount:   dogrunG.UserRatingCount,
-		Photos:            resolvePlacePhotos(dogrunG),
+		Photos:            resolvePhotos(dogrunG, dogrunD),
 		DogrunTags:        resolveDogrunTagInfo(dogrunD), // ドッグランタグ情報
-		IsManaged:         true,
+		IsManaged:         dogrunD.IsManaged.Bool,
+		Provenance:        merged.provenance,
 	}
 
 }
@@ -718,23 +901,22 @@ DBにデータがない場合、google情報のみでドッグラン一覧情報
 */
 func resolveDogrunListByOnlyGoogle(dogrunG googleplace.BaseResource) dto.DogrunLists {
 	var emptyDogrunD model.Dogrun
+	merged := mergeDogrunFields(dogrunG, emptyDogrunD)
 
 	return dto.DogrunLists{
-		PlaceId: dogrunG.ID,
-		Name:    dogrunG.DisplayName.Text,
-		Address: resolveDogrunAddress(dogrunG, emptyDogrunD),
-		Location: dto.Location{
-			Latitude:  dogrunG.Location.Latitude,
-			Longitude: dogrunG.Location.Longitude,
-		},
+		PlaceId:           dogrunG.ID,
+		Name:              merged.name,
+		Address:           merged.address,
+		Location:          merged.location,
 		BusinessStatus:    dogrunG.BusinessStatus,
 		NowOpen:           resolveNowOpening(dogrunG, emptyDogrunD),
 		ToadyBusinessHour: resolveTodayBusinessHour(dogrunG, emptyDogrunD),
-		Description:       dogrunG.Summary.Text,
+		Description:       merged.description,
 		GoogleRating:      dogrunG.Rating,
 		UserRatingCount:   dogrunG.UserRatingCount,
 		Photos:            resolvePlacePhotos(dogrunG),
 		IsManaged:         false,
+		Provenance:        merged.provenance,
 	}
 
 }
@@ -744,20 +926,23 @@ google側にデータがない場合、DB情報のみでドッグラン一覧情
 */
 func resolveDogrunListByOnlyDB(dogrunD model.Dogrun) dto.DogrunLists {
 	var emptyDogrunG googleplace.BaseResource
+	merged := mergeDogrunFields(emptyDogrunG, dogrunD)
 	return dto.DogrunLists{
-		DogrunID: dogrunD.DogrunID.Int64,
-		PlaceId:  dogrunD.PlaceId.String,
-		Name:     dogrunD.Name.String,
-		Address:  resolveDogrunAddress(emptyDogrunG, dogrunD),
-		Location: dto.Location{
-			Latitude:  dogrunD.Latitude.Float64,
-			Longitude: dogrunD.Longitude.Float64,
-		},
+		DogrunID:          dogrunD.DogrunID.Int64,
+		PlaceId:           dogrunD.PlaceId.String,
+		Name:              merged.name,
+		Address:           merged.address,
+		Location:          merged.location,
+		BusinessStatus:    dogrunD.BusinessStatus.String, // google place情報の同期結果
 		NowOpen:           resolveNowOpening(emptyDogrunG, dogrunD),
 		ToadyBusinessHour: resolveTodayBusinessHour(emptyDogrunG, dogrunD),
-		Description:       dogrunD.Description.String,
+		Description:       merged.description,
+		GoogleRating:      float32(dogrunD.GoogleRating.Float64),
+		UserRatingCount:   int(dogrunD.GoogleUserRatingCount.Int64),
 		DogrunTags:        resolveDogrunTagInfo(dogrunD), // ドッグランタグ情報
-		IsManaged:         true,
+		Photos:            resolvePhotos(emptyDogrunG, dogrunD),
+		IsManaged:         dogrunD.IsManaged.Bool,
+		Provenance:        merged.provenance,
 	}
 
 }
@@ -812,6 +997,7 @@ func resolvePlacePhotos(dogrunG googleplace.BaseResource) []dto.PhotoInfo {
 			PhotoKey: photo.Name,
 			HeightPx: photo.HeightPx,
 			WidthPx:  photo.WidthPx,
+			Source:   dto.PHOTO_SOURCE_GOOGLE,
 		}
 		photos = append(photos, photoInfo)
 	}
@@ -819,6 +1005,22 @@ func resolvePlacePhotos(dogrunG googleplace.BaseResource) []dto.PhotoInfo {
 	return photos
 }
 
+/*
+DBのギャラリー画像を先頭に、google情報の画像を後ろに統合する
+*/
+func resolvePhotos(dogrunG googleplace.BaseResource, dogrunD model.Dogrun) []dto.PhotoInfo {
+	var photos []dto.PhotoInfo
+
+	for _, dogrunImage := range dogrunD.DogrunImages {
+		photos = append(photos, dto.PhotoInfo{
+			FileID: dogrunImage.Image.String,
+			Source: dto.PHOTO_SOURCE_DOGRUN,
+		})
+	}
+
+	return append(photos, resolvePlacePhotos(dogrunG)...)
+}
+
 // GenerateSetDogrunIDs: dogrunIDがないデータに対して、dogrunsテーブルに登録し、IDをdtoにセットする
 //
 // args:
@@ -841,6 +1043,40 @@ func (h *dogrunHandler) GenerateSetDogrunIDs(c echo.Context, dogrunLists []dto.D
 	return nil
 }
 
+// ResolveDogrunIDByPlaceID: placeIdからdogrunIDを取得する
+// DBにない場合は、google上に存在するplaceIdのみDBへ保存してPKを発行させる
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - string:	placeID
+//
+// return:
+//   - int64:	dogrunテーブルのPK
+//   - error:	エラー
+func (h *dogrunHandler) ResolveDogrunIDByPlaceID(c echo.Context, placeID string) (int64, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunD, err := h.drr.GetDogrunByPlaceID(c, placeID)
+	if err != nil {
+		return 0, err
+	}
+	if dogrunD.IsNotEmpty() {
+		return dogrunD.DogrunID.Int64, nil
+	}
+
+	//google上に存在するplaceIdか
+	dogrunG, err := h.fetchPlaceInfo(c, placeID, googleplace.BaseField{})
+	if err != nil {
+		return 0, err
+	}
+	if dogrunG.ID == "" {
+		err := errors.NewWRError(nil, "指定されたPlaceIdのデータが存在しません。", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return 0, err
+	}
+	return h.persistenceDogrunPlaceId(c, placeID)
+}
+
 // persistenceDogrunPlaceId: DBにないplaceIdをDBへ保存して、PKを発行させる
 //
 // args:
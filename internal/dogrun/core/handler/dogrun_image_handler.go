@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	cmsDTO "github.com/wanrun-develop/wanrun/internal/cms/core/dto"
+	cmsFacade "github.com/wanrun-develop/wanrun/internal/cms/facade"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/wrcontext"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+)
+
+const (
+	DOGRUN_IMAGE_MAX_COUNT = 20 //ドッグランごとのギャラリー画像の上限数
+)
+
+type IDogrunImageHandler interface {
+	GetDogrunImages(echo.Context, int64) ([]dto.DogrunImageRes, error)
+	UploadDogrunImage(echo.Context, int64, cmsDTO.FileUploadReq) (dto.DogrunImageRes, error)
+	SortDogrunImages(echo.Context, int64, dto.DogrunImageSortReq) ([]dto.DogrunImageRes, error)
+	DeleteDogrunImage(echo.Context, int64, int64) error
+}
+
+type dogrunImageHandler struct {
+	drr repository.IDogrunRepository
+	cf  cmsFacade.ICmsFacade
+}
+
+func NewDogrunImageHandler(drr repository.IDogrunRepository, cf cmsFacade.ICmsFacade) IDogrunImageHandler {
+	return &dogrunImageHandler{drr, cf}
+}
+
+// GetDogrunImages: ドッグランのギャラリー画像一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - []dto.DogrunImageRes:	ギャラリー画像(表示順)
+//   - error:	エラー
+func (h *dogrunImageHandler) GetDogrunImages(c echo.Context, dogrunID int64) ([]dto.DogrunImageRes, error) {
+	dogrunImages, err := h.drr.FindDogrunImages(c, dogrunID)
+	if err != nil {
+		return nil, err
+	}
+	return convertDogrunImageRes(dogrunImages), nil
+}
+
+// UploadDogrunImage: ドッグランのギャラリー画像のアップロード
+// cmsにマネージャー所有のファイルとして登録後、fileIDをdogrun_imagesの末尾に登録する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - cmsDTO.FileUploadReq:	アップロードファイル
+//
+// return:
+//   - dto.DogrunImageRes:	登録したギャラリー画像
+//   - error:	エラー
+func (h *dogrunImageHandler) UploadDogrunImage(c echo.Context, dogrunID int64, fuq cmsDTO.FileUploadReq) (dto.DogrunImageRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	//管理しているドッグランかのチェック
+	if err := checkManagedDogrun(c, h.drr, dogrunID); err != nil {
+		return dto.DogrunImageRes{}, err
+	}
+
+	dogrunImages, err := h.drr.FindDogrunImages(c, dogrunID)
+	if err != nil {
+		return dto.DogrunImageRes{}, err
+	}
+	if len(dogrunImages) >= DOGRUN_IMAGE_MAX_COUNT {
+		err := errors.NewWRError(nil, fmt.Sprintf("ギャラリー画像は%d枚までです", DOGRUN_IMAGE_MAX_COUNT), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunImageRes{}, err
+	}
+
+	//cmsへのアップロード
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return dto.DogrunImageRes{}, err
+	}
+	fileID, err := h.cf.UploadFile(c, cmsDTO.OWNER_TYPE_DOGRUNMG, userID, fuq)
+	if err != nil {
+		return dto.DogrunImageRes{}, err
+	}
+
+	dogrunImage := model.DogrunImage{
+		DogrunID:  util.NewSqlNullInt64(dogrunID),
+		Image:     util.NewSqlNullString(fileID),
+		SortOrder: util.NewSqlNullInt64(int64(len(dogrunImages) + 1)),
+		UploadAt:  sql.NullTime{Time: time.Now(), Valid: true},
+	}
+	if err := h.drr.CreateDogrunImage(c, &dogrunImage); err != nil {
+		//登録に失敗した場合は、アップロードしたファイルを削除する
+		if delErr := h.cf.DeleteFile(c, fileID); delErr != nil {
+			logger.Warnf("アップロード済みのファイルの削除に失敗: %v", fileID)
+		}
+		return dto.DogrunImageRes{}, err
+	}
+
+	return convertDogrunImageRes([]model.DogrunImage{dogrunImage})[0], nil
+}
+
+// SortDogrunImages: ドッグランのギャラリー画像の並び替え
+// 指定されたIDの順に表示順を振り直し、指定されなかった画像はその後ろに並べる
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - dto.DogrunImageSortReq:	並び替えのリクエスト
+//
+// return:
+//   - []dto.DogrunImageRes:	並び替え後のギャラリー画像
+//   - error:	エラー
+func (h *dogrunImageHandler) SortDogrunImages(c echo.Context, dogrunID int64, req dto.DogrunImageSortReq) ([]dto.DogrunImageRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	//管理しているドッグランかのチェック
+	if err := checkManagedDogrun(c, h.drr, dogrunID); err != nil {
+		return nil, err
+	}
+
+	dogrunImages, err := h.drr.FindDogrunImages(c, dogrunID)
+	if err != nil {
+		return nil, err
+	}
+	dogrunImageMap := util.ConvertSliceToMap(dogrunImages, func(di model.DogrunImage) int64 { return di.DogrunImageID.Int64 })
+
+	sortedImages := []model.DogrunImage{}
+	for _, dogrunImageID := range req.DogrunImageIDs {
+		dogrunImage, exist := dogrunImageMap[dogrunImageID]
+		if !exist {
+			err := errors.NewWRError(nil, fmt.Sprintf("指定されたドッグラン画像ID:%dが存在しません", dogrunImageID), errors.NewDogrunClientErrorEType())
+			logger.Error(err)
+			return nil, err
+		}
+		sortedImages = append(sortedImages, dogrunImage)
+		delete(dogrunImageMap, dogrunImageID)
+	}
+	//指定されなかった画像は、現在の表示順のまま後ろに並べる
+	for _, dogrunImage := range dogrunImages {
+		if _, exist := dogrunImageMap[dogrunImage.DogrunImageID.Int64]; exist {
+			sortedImages = append(sortedImages, dogrunImage)
+		}
+	}
+
+	for i := range sortedImages {
+		sortedImages[i].SortOrder = util.NewSqlNullInt64(int64(i + 1))
+	}
+	if err := h.drr.UpdateDogrunImageSortOrders(c, sortedImages); err != nil {
+		return nil, err
+	}
+
+	return convertDogrunImageRes(sortedImages), nil
+}
+
+// DeleteDogrunImage: ドッグランのギャラリー画像の削除
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - int64:	dogrunImageID
+//
+// return:
+//   - error:	エラー
+func (h *dogrunImageHandler) DeleteDogrunImage(c echo.Context, dogrunID, dogrunImageID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	//管理しているドッグランかのチェック
+	if err := checkManagedDogrun(c, h.drr, dogrunID); err != nil {
+		return err
+	}
+
+	dogrunImages, err := h.drr.FindDogrunImages(c, dogrunID)
+	if err != nil {
+		return err
+	}
+	var target model.DogrunImage
+	for _, dogrunImage := range dogrunImages {
+		if dogrunImage.DogrunImageID.Int64 == dogrunImageID {
+			target = dogrunImage
+			break
+		}
+	}
+	if target.IsEmpty() {
+		err := errors.NewWRError(nil, "指定されたドッグラン画像が存在しません", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	if err := h.drr.DeleteDogrunImage(c, target); err != nil {
+		return err
+	}
+
+	//ファイルの削除に失敗してもDBからは削除済みのため、ログのみ残す。残ったファイルは孤立ファイルとして回収される
+	if err := h.cf.DeleteFile(c, target.Image.String); err != nil {
+		logger.Warnf("ドッグラン画像のファイルの削除に失敗: %v", target.Image.String)
+	}
+
+	return nil
+}
+
+// checkManagedDogrun: ログインユーザーが対象ドッグランのマネージャーかのチェック
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - error:	エラー
+func checkManagedDogrun(c echo.Context, drr repository.IDogrunRepository, dogrunID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return err
+	}
+
+	dogruns, err := drr.FindDogrunByIDs([]int64{dogrunID})
+	if err != nil {
+		err = errors.NewWRError(err, "dogrun存在チェックでエラー", errors.NewDogrunServerErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if len(dogruns) == 0 {
+		err := errors.NewWRError(nil, "指定されたドッグランが存在しません", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	if !dogruns[0].DogrunManagerID.Valid || dogruns[0].DogrunManagerID.Int64 != userID {
+		err := errors.NewWRError(nil, "管理対象外のドッグランです", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+/*
+ギャラリー画像のレスポンスへの変換
+*/
+func convertDogrunImageRes(dogrunImages []model.DogrunImage) []dto.DogrunImageRes {
+	dogrunImageRes := []dto.DogrunImageRes{}
+	for _, dogrunImage := range dogrunImages {
+		res := dto.DogrunImageRes{
+			DogrunImageID: dogrunImage.DogrunImageID.Int64,
+			DogrunID:      dogrunImage.DogrunID.Int64,
+			FileID:        dogrunImage.Image.String,
+			SortOrder:     dogrunImage.SortOrder.Int64,
+		}
+		if dogrunImage.UploadAt.Valid {
+			uploadAt := dogrunImage.UploadAt.Time
+			res.UploadAt = &uploadAt
+		}
+		dogrunImageRes = append(dogrunImageRes, res)
+	}
+	return dogrunImageRes
+}
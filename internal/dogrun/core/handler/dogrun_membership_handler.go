@@ -0,0 +1,483 @@
+package handler
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	cmsDTO "github.com/wanrun-develop/wanrun/internal/cms/core/dto"
+	cmsFacade "github.com/wanrun-develop/wanrun/internal/cms/facade"
+	dogFacade "github.com/wanrun-develop/wanrun/internal/dog/facade"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/wrcontext"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+)
+
+type IDogrunMembershipHandler interface {
+	ApplyMembership(echo.Context, int64, dto.DogrunMembershipReq) (dto.DogrunMembershipRes, error)
+	GetMyMemberships(echo.Context) ([]dto.DogrunMembershipRes, error)
+	WithdrawMembership(echo.Context, int64) error
+	GetDogrunMemberships(echo.Context, int64, string) ([]dto.DogrunManagedMembershipRes, error)
+	GetManagedMembership(echo.Context, int64) (dto.DogrunManagedMembershipRes, error)
+	ApproveMembership(echo.Context, int64, dto.DogrunMembershipReviewReq) (dto.DogrunManagedMembershipRes, error)
+	RejectMembership(echo.Context, int64, dto.DogrunMembershipReviewReq) (dto.DogrunManagedMembershipRes, error)
+	CheckEntryMembership(echo.Context, int64, string, []int64) error
+}
+
+type dogrunMembershipHandler struct {
+	drr repository.IDogrunRepository
+	mr  repository.IDogrunMembershipRepository
+	cf  cmsFacade.ICmsFacade
+	df  dogFacade.IDogFacade
+}
+
+func NewDogrunMembershipHandler(
+	drr repository.IDogrunRepository,
+	mr repository.IDogrunMembershipRepository,
+	cf cmsFacade.ICmsFacade,
+	df dogFacade.IDogFacade,
+) IDogrunMembershipHandler {
+	return &dogrunMembershipHandler{
+		drr: drr,
+		mr:  mr,
+		cf:  cf,
+		df:  df,
+	}
+}
+
+// ApplyMembership: ドッグランへの会員登録の申請
+// 会員限定のドッグランのみ申請可能。審査中・承認済みの会員登録がある場合は申請できない
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - dto.DogrunMembershipReq:	申請内容
+//
+// return:
+//   - dto.DogrunMembershipRes:	申請した会員登録
+//   - error:	エラー
+func (h *dogrunMembershipHandler) ApplyMembership(c echo.Context, dogrunID int64, req dto.DogrunMembershipReq) (dto.DogrunMembershipRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogOwnerID, err := wrcontext.GetLoginDogownerID(c)
+	if err != nil {
+		return dto.DogrunMembershipRes{}, err
+	}
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return dto.DogrunMembershipRes{}, err
+	}
+
+	entryCriteria, err := h.drr.FindDogrunEntryCriteria(c, dogrunID)
+	if err != nil {
+		return dto.DogrunMembershipRes{}, err
+	}
+	if !entryCriteria.MembersOnly.Bool {
+		err := errors.NewWRError(nil, "このドッグランは会員登録を受け付けていません", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunMembershipRes{}, err
+	}
+
+	if err := h.df.CheckDogownerValid(c, req.DogIDs); err != nil {
+		return dto.DogrunMembershipRes{}, err
+	}
+	//添付書類はdogownerがアップロードしたファイルのみ
+	if err := h.cf.CheckFilesOwnedBy(c, cmsDTO.OWNER_TYPE_DOGOWNER, userID, req.DocumentFileIDs); err != nil {
+		return dto.DogrunMembershipRes{}, err
+	}
+
+	activeMembership, err := h.mr.FindActiveMembership(c, dogrunID, dogOwnerID)
+	if err != nil {
+		return dto.DogrunMembershipRes{}, err
+	}
+	if !activeMembership.IsEmpty() {
+		err := errors.NewWRError(nil, "このドッグランには審査中または承認済みの会員登録があります", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunMembershipRes{}, err
+	}
+
+	membership := model.DogrunMembership{
+		DogrunID:        util.NewSqlNullInt64(dogrunID),
+		DogOwnerID:      util.NewSqlNullInt64(dogOwnerID),
+		Status:          util.NewSqlNullString(model.DOGRUN_MEMBERSHIP_STATUS_PENDING),
+		ApplicationNote: util.NewSqlNullString(req.Note),
+	}
+	for _, dogID := range req.DogIDs {
+		membership.DogrunMembershipDogs = append(membership.DogrunMembershipDogs, model.DogrunMembershipDog{
+			DogID: util.NewSqlNullInt64(dogID),
+		})
+	}
+	for _, fileID := range req.DocumentFileIDs {
+		membership.DogrunMembershipDocuments = append(membership.DogrunMembershipDocuments, model.DogrunMembershipDocument{
+			FileID: util.NewSqlNullString(fileID),
+		})
+	}
+	if err := h.mr.CreateMembership(c, &membership); err != nil {
+		return dto.DogrunMembershipRes{}, err
+	}
+
+	created, err := h.mr.FindMembershipByID(c, membership.DogrunMembershipID.Int64)
+	if err != nil {
+		return dto.DogrunMembershipRes{}, err
+	}
+	return convertDogrunMembershipRes(created), nil
+}
+
+// GetMyMemberships: ログイン中のdogownerの会員登録一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - []dto.DogrunMembershipRes:	会員登録一覧
+//   - error:	エラー
+func (h *dogrunMembershipHandler) GetMyMemberships(c echo.Context) ([]dto.DogrunMembershipRes, error) {
+	dogOwnerID, err := wrcontext.GetLoginDogownerID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := h.mr.FindMembershipsByDogOwnerID(c, dogOwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	membershipsRes := []dto.DogrunMembershipRes{}
+	for _, membership := range memberships {
+		membershipsRes = append(membershipsRes, convertDogrunMembershipRes(membership))
+	}
+	return membershipsRes, nil
+}
+
+// WithdrawMembership: 会員登録の取り下げ
+// 審査中の申請の取り下げ、承認済みの会員の退会のどちらにも使う
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunMembershipID
+//
+// return:
+//   - error:	エラー
+func (h *dogrunMembershipHandler) WithdrawMembership(c echo.Context, membershipID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogOwnerID, err := wrcontext.GetLoginDogownerID(c)
+	if err != nil {
+		return err
+	}
+
+	membership, err := h.mr.FindMembershipByID(c, membershipID)
+	if err != nil {
+		return err
+	}
+	if membership.IsEmpty() || membership.DogOwnerID.Int64 != dogOwnerID {
+		err := errors.NewWRError(nil, fmt.Sprintf("指定された会員登録ID:%dが存在しません", membershipID), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	updated, err := h.mr.UpdateMembershipStatus(c, membershipID,
+		model.DOGRUN_MEMBERSHIP_ACTIVE_STATUSES,
+		map[string]any{"status": model.DOGRUN_MEMBERSHIP_STATUS_WITHDRAWN},
+	)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		err := errors.NewWRError(nil, "審査中または承認済みの会員登録ではないため取り下げできません", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// GetDogrunMemberships: 管理対象のドッグランの会員登録一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - string:	ステータス。空の場合は全て
+//
+// return:
+//   - []dto.DogrunManagedMembershipRes:	会員登録一覧(添付書類のURLは含まない)
+//   - error:	エラー
+func (h *dogrunMembershipHandler) GetDogrunMemberships(c echo.Context, dogrunID int64, status string) ([]dto.DogrunManagedMembershipRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	membershipStatuses := []string{
+		model.DOGRUN_MEMBERSHIP_STATUS_PENDING,
+		model.DOGRUN_MEMBERSHIP_STATUS_APPROVED,
+		model.DOGRUN_MEMBERSHIP_STATUS_REJECTED,
+		model.DOGRUN_MEMBERSHIP_STATUS_WITHDRAWN,
+	}
+	if status != "" && !slices.Contains(membershipStatuses, status) {
+		err := errors.NewWRError(nil, fmt.Sprintf("指定されたステータス:%sは不正です", status), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return nil, err
+	}
+	if err := checkManagedDogrun(c, h.drr, dogrunID); err != nil {
+		return nil, err
+	}
+
+	memberships, err := h.mr.FindMembershipsByDogrunID(c, dogrunID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	membershipsRes := []dto.DogrunManagedMembershipRes{}
+	for _, membership := range memberships {
+		membershipsRes = append(membershipsRes, convertDogrunManagedMembershipRes(membership))
+	}
+	return membershipsRes, nil
+}
+
+// GetManagedMembership: 管理対象のドッグランの会員登録の詳細の取得
+// 審査のため、添付書類の閲覧用URLを発行する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunMembershipID
+//
+// return:
+//   - dto.DogrunManagedMembershipRes:	添付書類のURLを含む会員登録
+//   - error:	エラー
+func (h *dogrunMembershipHandler) GetManagedMembership(c echo.Context, membershipID int64) (dto.DogrunManagedMembershipRes, error) {
+	membership, err := h.findManagedMembership(c, membershipID)
+	if err != nil {
+		return dto.DogrunManagedMembershipRes{}, err
+	}
+
+	res := convertDogrunManagedMembershipRes(membership)
+	res.Documents = []dto.DogrunMembershipDocumentRes{}
+	for _, fileID := range membership.DocumentFileIDs() {
+		fileURL, err := h.cf.PresignFileURL(c, fileID)
+		if err != nil {
+			return dto.DogrunManagedMembershipRes{}, err
+		}
+		res.Documents = append(res.Documents, dto.DogrunMembershipDocumentRes{
+			FileID:    fileID,
+			URL:       fileURL.URL,
+			ExpiresAt: fileURL.ExpiresAt,
+		})
+	}
+	return res, nil
+}
+
+// ApproveMembership: 会員登録の承認
+// 承認時に会員番号を発行する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunMembershipID
+//   - dto.DogrunMembershipReviewReq:	審査内容
+//
+// return:
+//   - dto.DogrunManagedMembershipRes:	承認後の会員登録
+//   - error:	エラー
+func (h *dogrunMembershipHandler) ApproveMembership(c echo.Context, membershipID int64, req dto.DogrunMembershipReviewReq) (dto.DogrunManagedMembershipRes, error) {
+	membership, err := h.findManagedMembership(c, membershipID)
+	if err != nil {
+		return dto.DogrunManagedMembershipRes{}, err
+	}
+
+	return h.reviewMembership(c, membership, map[string]any{
+		"status":            model.DOGRUN_MEMBERSHIP_STATUS_APPROVED,
+		"membership_number": generateMembershipNumber(membership),
+		"review_note":       util.NewSqlNullString(req.Note),
+		"reviewed_at":       time.Now(),
+	})
+}
+
+// RejectMembership: 会員登録の却下
+// 却下理由の入力を必須とする
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunMembershipID
+//   - dto.DogrunMembershipReviewReq:	審査内容
+//
+// return:
+//   - dto.DogrunManagedMembershipRes:	却下後の会員登録
+//   - error:	エラー
+func (h *dogrunMembershipHandler) RejectMembership(c echo.Context, membershipID int64, req dto.DogrunMembershipReviewReq) (dto.DogrunManagedMembershipRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if req.Note == "" {
+		err := errors.NewWRError(nil, "却下理由を入力してください", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunManagedMembershipRes{}, err
+	}
+
+	membership, err := h.findManagedMembership(c, membershipID)
+	if err != nil {
+		return dto.DogrunManagedMembershipRes{}, err
+	}
+
+	return h.reviewMembership(c, membership, map[string]any{
+		"status":      model.DOGRUN_MEMBERSHIP_STATUS_REJECTED,
+		"review_note": req.Note,
+		"reviewed_at": time.Now(),
+	})
+}
+
+// CheckEntryMembership: チェックインするdogが承認済みの会員かのチェック
+// 会員限定でないドッグランはチェックしない
+// 会員登録はdogから求めるため、共同飼い主が申請した会員登録のdogもチェックインできる
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - string:	会員番号
+//   - []int64:	チェックインするdogのID
+//
+// return:
+//   - error:	会員でない場合はエラー
+func (h *dogrunMembershipHandler) CheckEntryMembership(c echo.Context, dogrunID int64, membershipNumber string, dogIDs []int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	entryCriteria, err := h.drr.FindDogrunEntryCriteria(c, dogrunID)
+	if err != nil {
+		return err
+	}
+	if !entryCriteria.MembersOnly.Bool {
+		return nil
+	}
+
+	if membershipNumber == "" {
+		err := errors.NewWRError(nil, "このドッグランは会員限定です。有効な会員番号を指定してください", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	// ログインユーザーのdog(共同飼い主のdogを含む)であること
+	if err := h.df.CheckDogownerValid(c, dogIDs); err != nil {
+		return err
+	}
+
+	memberships, err := h.mr.FindApprovedMembershipsByDogIDs(c, dogrunID, dogIDs)
+	if err != nil {
+		return err
+	}
+	for _, dogID := range dogIDs {
+		isMember := slices.ContainsFunc(memberships, func(membership model.DogrunMembership) bool {
+			return membership.MembershipNumber.String == membershipNumber && slices.Contains(membership.DogIDs(), dogID)
+		})
+		if !isMember {
+			err := errors.NewWRError(nil, fmt.Sprintf("ドッグID:%dは会員番号:%sで会員登録されていません", dogID, membershipNumber), errors.NewDogrunClientErrorEType())
+			logger.Error(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// findManagedMembership: 管理対象のドッグランの会員登録の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunMembershipID
+//
+// return:
+//   - model.DogrunMembership:	会員登録
+//   - error:	存在しない、管理対象外の場合はエラー
+func (h *dogrunMembershipHandler) findManagedMembership(c echo.Context, membershipID int64) (model.DogrunMembership, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	membership, err := h.mr.FindMembershipByID(c, membershipID)
+	if err != nil {
+		return model.DogrunMembership{}, err
+	}
+	if membership.IsEmpty() {
+		err := errors.NewWRError(nil, fmt.Sprintf("指定された会員登録ID:%dが存在しません", membershipID), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return model.DogrunMembership{}, err
+	}
+	if err := checkManagedDogrun(c, h.drr, membership.DogrunID.Int64); err != nil {
+		return model.DogrunMembership{}, err
+	}
+	return membership, nil
+}
+
+// reviewMembership: 審査中の会員登録の審査結果の更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.DogrunMembership:	会員登録
+//   - map[string]any:	更新内容
+//
+// return:
+//   - dto.DogrunManagedMembershipRes:	審査後の会員登録
+//   - error:	審査中でない場合はエラー
+func (h *dogrunMembershipHandler) reviewMembership(c echo.Context, membership model.DogrunMembership, updates map[string]any) (dto.DogrunManagedMembershipRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	membershipID := membership.DogrunMembershipID.Int64
+	updated, err := h.mr.UpdateMembershipStatus(c, membershipID, []string{model.DOGRUN_MEMBERSHIP_STATUS_PENDING}, updates)
+	if err != nil {
+		return dto.DogrunManagedMembershipRes{}, err
+	}
+	if !updated {
+		err := errors.NewWRError(nil, "審査中の会員登録ではありません", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunManagedMembershipRes{}, err
+	}
+
+	reviewed, err := h.mr.FindMembershipByID(c, membershipID)
+	if err != nil {
+		return dto.DogrunManagedMembershipRes{}, err
+	}
+	return convertDogrunManagedMembershipRes(reviewed), nil
+}
+
+/*
+会員番号の生成。ドッグランIDと会員登録IDから一意に決まる
+*/
+func generateMembershipNumber(membership model.DogrunMembership) string {
+	return fmt.Sprintf("M%d-%06d", membership.DogrunID.Int64, membership.DogrunMembershipID.Int64)
+}
+
+/*
+会員登録をレスポンスに変換
+*/
+func convertDogrunMembershipRes(membership model.DogrunMembership) dto.DogrunMembershipRes {
+	dogsRes := []dto.DogrunReservationDogRes{}
+	for _, membershipDog := range membership.DogrunMembershipDogs {
+		dogsRes = append(dogsRes, dto.DogrunReservationDogRes{
+			DogID:     membershipDog.DogID.Int64,
+			Name:      membershipDog.Dog.Name.String,
+			SizeClass: membershipDog.Dog.SizeClass(),
+		})
+	}
+
+	res := dto.DogrunMembershipRes{
+		DogrunMembershipID: membership.DogrunMembershipID.Int64,
+		DogrunID:           membership.DogrunID.Int64,
+		Status:             membership.Status.String,
+		MembershipNumber:   membership.MembershipNumber.String,
+		Dogs:               dogsRes,
+		DocumentFileIDs:    membership.DocumentFileIDs(),
+		ApplicationNote:    membership.ApplicationNote.String,
+		ReviewNote:         membership.ReviewNote.String,
+		CreateAt:           membership.CreateAt.Time,
+	}
+	if membership.ReviewedAt.Valid {
+		reviewedAt := membership.ReviewedAt.Time
+		res.ReviewedAt = &reviewedAt
+	}
+	return res
+}
+
+/*
+マネージャー向けの会員登録をレスポンスに変換
+*/
+func convertDogrunManagedMembershipRes(membership model.DogrunMembership) dto.DogrunManagedMembershipRes {
+	return dto.DogrunManagedMembershipRes{
+		DogrunMembershipRes: convertDogrunMembershipRes(membership),
+		DogOwnerID:          membership.DogOwnerID.Int64,
+		DogOwnerName:        membership.DogOwner.Name.String,
+	}
+}
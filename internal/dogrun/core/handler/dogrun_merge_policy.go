@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wanrun-develop/wanrun/configs"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/googleplace"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+)
+
+/*
+値の出所と最終確認日時
+*/
+type fieldOrigin struct {
+	source     string
+	verifiedAt time.Time
+}
+
+/*
+マージする値の候補
+*/
+type mergeCandidate[T any] struct {
+	fieldOrigin
+	value T
+	ok    bool // 値があるか
+}
+
+/*
+項目ごとのマージの方針
+*/
+type fieldMergePolicy struct {
+	priority  []string       // 優先する出所の順
+	staleDays map[string]int // 出所ごとの、値を古いとみなす日数(0は無期限)
+}
+
+/*
+Google情報とDB情報をマージした項目
+*/
+type mergedDogrunFields struct {
+	name        string
+	address     dto.Address
+	location    dto.Location
+	description string
+	provenance  dto.Provenance
+}
+
+// loadFieldMergePolicy: 設定から項目のマージの方針を取得する
+// 設定ファイルの変更を反映するため、都度取得する
+//
+// args:
+//   - string:	項目(model.PROVENANCE_FIELD_*)
+//
+// return:
+//   - fieldMergePolicy:	マージの方針
+func loadFieldMergePolicy(fieldName string) fieldMergePolicy {
+	priority := configs.FetchConfigStr("dogrun.merge.field." + fieldName + ".priority")
+	if priority == "" {
+		priority = configs.FetchConfigStr("dogrun.merge.priority")
+	}
+
+	policy := fieldMergePolicy{staleDays: map[string]int{}}
+	for _, source := range strings.Split(priority, ",") {
+		if source = strings.TrimSpace(source); source != "" {
+			policy.priority = append(policy.priority, source)
+		}
+	}
+	for _, source := range []string{model.FIELD_SOURCE_MANAGER, model.FIELD_SOURCE_IMPORTED, model.FIELD_SOURCE_GOOGLE} {
+		policy.staleDays[source] = configs.FetchConfigInt("dogrun.merge.stale.days." + source)
+	}
+	return policy
+}
+
+/*
+出所の優先順位。優先順にない出所は最後
+*/
+func (p fieldMergePolicy) rank(source string) int {
+	if i := slices.Index(p.priority, source); i >= 0 {
+		return i
+	}
+	return len(p.priority)
+}
+
+/*
+値が古くなっていないか
+最終確認日時が不明な値は、期限のある出所では古いとみなす
+*/
+func (p fieldMergePolicy) isFresh(origin fieldOrigin, now time.Time) bool {
+	days := p.staleDays[origin.source]
+	if days <= 0 {
+		return true
+	}
+	return !origin.verifiedAt.IsZero() && now.Sub(origin.verifiedAt) <= time.Duration(days)*24*time.Hour
+}
+
+/*
+aの値をbの値より優先するか
+古くなっていない値、優先順位の高い出所、最終確認日時の新しい値の順で優先する
+*/
+func (p fieldMergePolicy) prefers(a, b fieldOrigin, now time.Time) bool {
+	if aFresh, bFresh := p.isFresh(a, now), p.isFresh(b, now); aFresh != bFresh {
+		return aFresh
+	}
+	if aRank, bRank := p.rank(a.source), p.rank(b.source); aRank != bRank {
+		return aRank < bRank
+	}
+	return a.verifiedAt.After(b.verifiedAt)
+}
+
+/*
+候補から方針に従って値を選び、選んだ値の出所を記録する
+値のある候補がない場合はゼロ値を返す
+*/
+func mergeField[T any](provenance dto.Provenance, fieldName string, now time.Time, candidates ...mergeCandidate[T]) T {
+	policy := loadFieldMergePolicy(fieldName)
+
+	chosen := -1
+	for i, candidate := range candidates {
+		if !candidate.ok {
+			continue
+		}
+		if chosen < 0 || policy.prefers(candidate.fieldOrigin, candidates[chosen].fieldOrigin, now) {
+			chosen = i
+		}
+	}
+
+	var zero T
+	if chosen < 0 {
+		return zero
+	}
+	fp := dto.FieldProvenance{Source: candidates[chosen].source}
+	if verifiedAt := candidates[chosen].verifiedAt; !verifiedAt.IsZero() {
+		fp.VerifiedAt = &verifiedAt
+	}
+	provenance[fieldName] = fp
+	return candidates[chosen].value
+}
+
+/*
+DB情報の値の候補。出所はドッグランの項目ごとの出所を使用する
+*/
+func dbCandidate[T any](dogrunD model.Dogrun, fieldName string, value T, ok bool) mergeCandidate[T] {
+	source, verifiedAt := dogrunD.FieldProvenance(fieldName)
+	return mergeCandidate[T]{
+		fieldOrigin: fieldOrigin{source, verifiedAt},
+		value:       value,
+		ok:          ok && dogrunD.IsNotEmpty(),
+	}
+}
+
+/*
+Google情報の値の候補。取得した時点を最終確認日時とする
+*/
+func googleCandidate[T any](dogrunG googleplace.BaseResource, value T, ok bool, now time.Time) mergeCandidate[T] {
+	return mergeCandidate[T]{
+		fieldOrigin: fieldOrigin{model.FIELD_SOURCE_GOOGLE, now},
+		value:       value,
+		ok:          ok && dogrunG.IsNotEmpty(),
+	}
+}
+
+// mergeDogrunFields: Google情報とDB情報を、項目ごとのマージの方針に従ってマージする
+// どちらか一方のみの場合も、もう一方を空として使用できる
+//
+// args:
+//   - googleplace.BaseResource:	Google情報
+//   - model.Dogrun:	DB情報(項目ごとの出所込み)
+//
+// return:
+//   - mergedDogrunFields:	マージした項目と、項目ごとの出所
+func mergeDogrunFields(dogrunG googleplace.BaseResource, dogrunD model.Dogrun) mergedDogrunFields {
+	now := time.Now()
+	provenance := dto.Provenance{}
+	gPostCode := placePostCode(dogrunG)
+	gLocation := dto.Location{Latitude: dogrunG.Location.Latitude, Longitude: dogrunG.Location.Longitude}
+	dLocation := dto.Location{Latitude: dogrunD.Latitude.Float64, Longitude: dogrunD.Longitude.Float64}
+
+	return mergedDogrunFields{
+		name: mergeField(provenance, model.PROVENANCE_FIELD_NAME, now,
+			dbCandidate(dogrunD, model.PROVENANCE_FIELD_NAME, dogrunD.Name.String, dogrunD.Name.Valid && dogrunD.Name.String != ""),
+			googleCandidate(dogrunG, dogrunG.DisplayName.Text, dogrunG.DisplayName.Text != "", now),
+		),
+		address: dto.Address{
+			PostCode: mergeField(provenance, model.PROVENANCE_FIELD_POSTCODE, now,
+				dbCandidate(dogrunD, model.PROVENANCE_FIELD_POSTCODE, dogrunD.PostCode.String, dogrunD.PostCode.Valid && dogrunD.PostCode.String != ""),
+				googleCandidate(dogrunG, gPostCode, gPostCode != "", now),
+			),
+			Address: mergeField(provenance, model.PROVENANCE_FIELD_ADDRESS, now,
+				dbCandidate(dogrunD, model.PROVENANCE_FIELD_ADDRESS, dogrunD.Address.String, dogrunD.Address.Valid && dogrunD.Address.String != ""),
+				googleCandidate(dogrunG, dogrunG.ShortFormattedAddress, dogrunG.ShortFormattedAddress != "", now),
+			),
+		},
+		location: mergeField(provenance, model.PROVENANCE_FIELD_LOCATION, now,
+			dbCandidate(dogrunD, model.PROVENANCE_FIELD_LOCATION, dLocation, dLocation.Latitude != 0 && dLocation.Longitude != 0),
+			googleCandidate(dogrunG, gLocation, gLocation.Latitude != 0 && gLocation.Longitude != 0, now),
+		),
+		description: mergeField(provenance, model.PROVENANCE_FIELD_DESCRIPTION, now,
+			dbCandidate(dogrunD, model.PROVENANCE_FIELD_DESCRIPTION, dogrunD.Description.String, dogrunD.Description.Valid && dogrunD.Description.String != ""),
+			googleCandidate(dogrunG, dogrunG.Summary.Text, dogrunG.Summary.Text != "", now),
+		),
+		provenance: provenance,
+	}
+}
+
+// prefersGoogle: DBの項目をGoogle情報で上書きするか
+// DBに値がない場合と、マージの方針でGoogle情報の値を優先する場合に上書きする
+//
+// args:
+//   - model.Dogrun:	DB情報(項目ごとの出所込み)
+//   - string:	項目(model.PROVENANCE_FIELD_*)
+//   - bool:	DBに値があるか
+//   - time.Time:	Google情報の取得日時
+//
+// return:
+//   - bool:	上書きするか
+func prefersGoogle(dogrunD model.Dogrun, fieldName string, hasValue bool, now time.Time) bool {
+	if !hasValue {
+		return true
+	}
+	source, verifiedAt := dogrunD.FieldProvenance(fieldName)
+	return loadFieldMergePolicy(fieldName).prefers(
+		fieldOrigin{model.FIELD_SOURCE_GOOGLE, now},
+		fieldOrigin{source, verifiedAt},
+		now,
+	)
+}
+
+/*
+項目の出所の記録を作成する
+*/
+func newFieldProvenances(dogrunID int64, source string, verifiedAt time.Time, fieldNames ...string) []model.DogrunFieldProvenance {
+	provenances := []model.DogrunFieldProvenance{}
+	for _, fieldName := range fieldNames {
+		provenances = append(provenances, model.DogrunFieldProvenance{
+			DogrunID:   util.NewSqlNullInt64(dogrunID),
+			FieldName:  util.NewSqlNullString(fieldName),
+			Source:     util.NewSqlNullString(source),
+			VerifiedAt: util.NewSqlNullTime(verifiedAt),
+		})
+	}
+	return provenances
+}
@@ -0,0 +1,369 @@
+package handler
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/googleplace"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+)
+
+var mergeTestNow = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+/*
+マージの方針を設定のデフォルト値に固定する
+*/
+func setMergeConfig(t *testing.T) {
+	t.Helper()
+	t.Setenv("DOGRUN_MERGE_PRIORITY", "manager,imported,google")
+	t.Setenv("DOGRUN_MERGE_STALE_DAYS_MANAGER", "0")
+	t.Setenv("DOGRUN_MERGE_STALE_DAYS_IMPORTED", "365")
+	t.Setenv("DOGRUN_MERGE_STALE_DAYS_GOOGLE", "0")
+}
+
+func newTestMergePolicy() fieldMergePolicy {
+	return fieldMergePolicy{
+		priority: []string{model.FIELD_SOURCE_MANAGER, model.FIELD_SOURCE_IMPORTED, model.FIELD_SOURCE_GOOGLE},
+		staleDays: map[string]int{
+			model.FIELD_SOURCE_MANAGER:  0,
+			model.FIELD_SOURCE_IMPORTED: 365,
+			model.FIELD_SOURCE_GOOGLE:   0,
+		},
+	}
+}
+
+func daysAgo(days int) time.Time {
+	return mergeTestNow.AddDate(0, 0, -days)
+}
+
+func TestFieldMergePolicyRank(t *testing.T) {
+	policy := newTestMergePolicy()
+
+	tests := []struct {
+		source string
+		want   int
+	}{
+		{source: model.FIELD_SOURCE_MANAGER, want: 0},
+		{source: model.FIELD_SOURCE_IMPORTED, want: 1},
+		{source: model.FIELD_SOURCE_GOOGLE, want: 2},
+		{source: "unknown", want: 3},
+		{source: "", want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			if got := policy.rank(tt.source); got != tt.want {
+				t.Errorf("rank(%q) = %d, want %d", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldMergePolicyIsFresh(t *testing.T) {
+	policy := newTestMergePolicy()
+
+	tests := []struct {
+		name   string
+		origin fieldOrigin
+		want   bool
+	}{
+		{name: "無期限の出所は確認日時なしでも有効", origin: fieldOrigin{model.FIELD_SOURCE_MANAGER, time.Time{}}, want: true},
+		{name: "無期限の出所は古くても有効", origin: fieldOrigin{model.FIELD_SOURCE_GOOGLE, daysAgo(1000)}, want: true},
+		{name: "期限内", origin: fieldOrigin{model.FIELD_SOURCE_IMPORTED, daysAgo(30)}, want: true},
+		{name: "期限ちょうど", origin: fieldOrigin{model.FIELD_SOURCE_IMPORTED, daysAgo(365)}, want: true},
+		{name: "期限切れ", origin: fieldOrigin{model.FIELD_SOURCE_IMPORTED, daysAgo(366)}, want: false},
+		{name: "期限のある出所で確認日時なし", origin: fieldOrigin{model.FIELD_SOURCE_IMPORTED, time.Time{}}, want: false},
+		{name: "方針にない出所", origin: fieldOrigin{"unknown", time.Time{}}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.isFresh(tt.origin, mergeTestNow); got != tt.want {
+				t.Errorf("isFresh(%+v) = %v, want %v", tt.origin, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldMergePolicyPrefers(t *testing.T) {
+	policy := newTestMergePolicy()
+
+	tests := []struct {
+		name string
+		a    fieldOrigin
+		b    fieldOrigin
+		want bool
+	}{
+		{
+			name: "優先順位の高い出所",
+			a:    fieldOrigin{model.FIELD_SOURCE_MANAGER, daysAgo(500)},
+			b:    fieldOrigin{model.FIELD_SOURCE_GOOGLE, mergeTestNow},
+			want: true,
+		},
+		{
+			name: "優先順位の低い出所",
+			a:    fieldOrigin{model.FIELD_SOURCE_GOOGLE, mergeTestNow},
+			b:    fieldOrigin{model.FIELD_SOURCE_IMPORTED, daysAgo(10)},
+			want: false,
+		},
+		{
+			name: "古くなった値より優先順位の低い新しい値",
+			a:    fieldOrigin{model.FIELD_SOURCE_GOOGLE, mergeTestNow},
+			b:    fieldOrigin{model.FIELD_SOURCE_IMPORTED, daysAgo(400)},
+			want: true,
+		},
+		{
+			name: "確認日時の不明な取り込みの値よりgoogle",
+			a:    fieldOrigin{model.FIELD_SOURCE_GOOGLE, mergeTestNow},
+			b:    fieldOrigin{model.FIELD_SOURCE_IMPORTED, time.Time{}},
+			want: true,
+		},
+		{
+			name: "同じ出所は確認日時の新しい値",
+			a:    fieldOrigin{model.FIELD_SOURCE_IMPORTED, daysAgo(1)},
+			b:    fieldOrigin{model.FIELD_SOURCE_IMPORTED, daysAgo(2)},
+			want: true,
+		},
+		{
+			name: "同じ出所で確認日時が同じ場合は優先しない",
+			a:    fieldOrigin{model.FIELD_SOURCE_MANAGER, daysAgo(1)},
+			b:    fieldOrigin{model.FIELD_SOURCE_MANAGER, daysAgo(1)},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.prefers(tt.a, tt.b, mergeTestNow); got != tt.want {
+				t.Errorf("prefers(%+v, %+v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeField(t *testing.T) {
+	setMergeConfig(t)
+	verifiedAt := daysAgo(10)
+
+	tests := []struct {
+		name           string
+		candidates     []mergeCandidate[string]
+		want           string
+		wantProvenance *dto.FieldProvenance
+	}{
+		{
+			name:           "候補なし",
+			candidates:     nil,
+			want:           "",
+			wantProvenance: nil,
+		},
+		{
+			name: "値のある候補なし",
+			candidates: []mergeCandidate[string]{
+				{fieldOrigin: fieldOrigin{model.FIELD_SOURCE_MANAGER, verifiedAt}, value: "", ok: false},
+			},
+			want:           "",
+			wantProvenance: nil,
+		},
+		{
+			name: "値のある候補のみ選ぶ",
+			candidates: []mergeCandidate[string]{
+				{fieldOrigin: fieldOrigin{model.FIELD_SOURCE_MANAGER, verifiedAt}, value: "manager", ok: false},
+				{fieldOrigin: fieldOrigin{model.FIELD_SOURCE_GOOGLE, mergeTestNow}, value: "google", ok: true},
+			},
+			want:           "google",
+			wantProvenance: &dto.FieldProvenance{Source: model.FIELD_SOURCE_GOOGLE, VerifiedAt: &mergeTestNow},
+		},
+		{
+			name: "優先順位の高い候補",
+			candidates: []mergeCandidate[string]{
+				{fieldOrigin: fieldOrigin{model.FIELD_SOURCE_GOOGLE, mergeTestNow}, value: "google", ok: true},
+				{fieldOrigin: fieldOrigin{model.FIELD_SOURCE_MANAGER, verifiedAt}, value: "manager", ok: true},
+			},
+			want:           "manager",
+			wantProvenance: &dto.FieldProvenance{Source: model.FIELD_SOURCE_MANAGER, VerifiedAt: &verifiedAt},
+		},
+		{
+			name: "確認日時が不明な場合はnull",
+			candidates: []mergeCandidate[string]{
+				{fieldOrigin: fieldOrigin{model.FIELD_SOURCE_MANAGER, time.Time{}}, value: "manager", ok: true},
+			},
+			want:           "manager",
+			wantProvenance: &dto.FieldProvenance{Source: model.FIELD_SOURCE_MANAGER},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provenance := dto.Provenance{}
+			got := mergeField(provenance, model.PROVENANCE_FIELD_NAME, mergeTestNow, tt.candidates...)
+			if got != tt.want {
+				t.Errorf("mergeField() = %q, want %q", got, tt.want)
+			}
+
+			fp, ok := provenance[model.PROVENANCE_FIELD_NAME]
+			if tt.wantProvenance == nil {
+				if ok {
+					t.Errorf("provenance = %+v, want none", fp)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("provenance not recorded, want %+v", *tt.wantProvenance)
+			}
+			if fp.Source != tt.wantProvenance.Source {
+				t.Errorf("provenance source = %q, want %q", fp.Source, tt.wantProvenance.Source)
+			}
+			if !equalTimePtr(fp.VerifiedAt, tt.wantProvenance.VerifiedAt) {
+				t.Errorf("provenance verifiedAt = %v, want %v", fp.VerifiedAt, tt.wantProvenance.VerifiedAt)
+			}
+		})
+	}
+}
+
+func TestMergeDogrunFields(t *testing.T) {
+	setMergeConfig(t)
+
+	dogrunG := googleplace.BaseResource{
+		ID:                    "place-1",
+		DisplayName:           googleplace.LocalizedText{Text: "Googleのドッグラン"},
+		ShortFormattedAddress: "東京都渋谷区1-1",
+		Location:              googleplace.Location{Latitude: 35.1, Longitude: 139.1},
+	}
+	newDogrunD := func(isManaged bool, provenances ...model.DogrunFieldProvenance) model.Dogrun {
+		return model.Dogrun{
+			DogrunID:         sql.NullInt64{Int64: 1, Valid: true},
+			Name:             sql.NullString{String: "DBのドッグラン", Valid: true},
+			Address:          sql.NullString{String: "東京都新宿区2-2", Valid: true},
+			Latitude:         sql.NullFloat64{Float64: 35.2, Valid: true},
+			Longitude:        sql.NullFloat64{Float64: 139.2, Valid: true},
+			IsManaged:        sql.NullBool{Bool: isManaged, Valid: true},
+			FieldProvenances: provenances,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		dogrunG        googleplace.BaseResource
+		dogrunD        model.Dogrun
+		wantName       string
+		wantNameSource string
+		wantAddress    string
+	}{
+		{
+			name:           "管理されたドッグランはDBの値",
+			dogrunG:        dogrunG,
+			dogrunD:        newDogrunD(true),
+			wantName:       "DBのドッグラン",
+			wantNameSource: model.FIELD_SOURCE_MANAGER,
+			wantAddress:    "東京都新宿区2-2",
+		},
+		{
+			name:           "出所の記録がない取り込みの値はgoogle",
+			dogrunG:        dogrunG,
+			dogrunD:        newDogrunD(false),
+			wantName:       "Googleのドッグラン",
+			wantNameSource: model.FIELD_SOURCE_GOOGLE,
+			wantAddress:    "東京都渋谷区1-1",
+		},
+		{
+			name:    "最近確認した取り込みの値はDBの値",
+			dogrunG: dogrunG,
+			dogrunD: newDogrunD(false,
+				model.DogrunFieldProvenance{
+					FieldName:  sql.NullString{String: model.PROVENANCE_FIELD_NAME, Valid: true},
+					Source:     sql.NullString{String: model.FIELD_SOURCE_IMPORTED, Valid: true},
+					VerifiedAt: sql.NullTime{Time: time.Now().AddDate(0, 0, -10), Valid: true},
+				},
+			),
+			wantName:       "DBのドッグラン",
+			wantNameSource: model.FIELD_SOURCE_IMPORTED,
+			wantAddress:    "東京都渋谷区1-1",
+		},
+		{
+			name:           "google情報がない場合はDBの値",
+			dogrunG:        googleplace.BaseResource{},
+			dogrunD:        newDogrunD(false),
+			wantName:       "DBのドッグラン",
+			wantNameSource: model.FIELD_SOURCE_IMPORTED,
+			wantAddress:    "東京都新宿区2-2",
+		},
+		{
+			name:           "DB情報がない場合はgoogle",
+			dogrunG:        dogrunG,
+			dogrunD:        model.Dogrun{},
+			wantName:       "Googleのドッグラン",
+			wantNameSource: model.FIELD_SOURCE_GOOGLE,
+			wantAddress:    "東京都渋谷区1-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeDogrunFields(tt.dogrunG, tt.dogrunD)
+			if got.name != tt.wantName {
+				t.Errorf("name = %q, want %q", got.name, tt.wantName)
+			}
+			if source := got.provenance[model.PROVENANCE_FIELD_NAME].Source; source != tt.wantNameSource {
+				t.Errorf("name source = %q, want %q", source, tt.wantNameSource)
+			}
+			if got.address.Address != tt.wantAddress {
+				t.Errorf("address = %q, want %q", got.address.Address, tt.wantAddress)
+			}
+			if _, ok := got.provenance[model.PROVENANCE_FIELD_DESCRIPTION]; ok {
+				t.Errorf("description provenance recorded without any value")
+			}
+		})
+	}
+}
+
+func TestPrefersGoogle(t *testing.T) {
+	setMergeConfig(t)
+
+	newDogrunD := func(isManaged bool, source string, verifiedAt time.Time) model.Dogrun {
+		dogrunD := model.Dogrun{
+			DogrunID:  sql.NullInt64{Int64: 1, Valid: true},
+			IsManaged: sql.NullBool{Bool: isManaged, Valid: true},
+		}
+		if source != "" {
+			dogrunD.FieldProvenances = []model.DogrunFieldProvenance{{
+				FieldName:  sql.NullString{String: model.PROVENANCE_FIELD_ADDRESS, Valid: true},
+				Source:     sql.NullString{String: source, Valid: true},
+				VerifiedAt: sql.NullTime{Time: verifiedAt, Valid: !verifiedAt.IsZero()},
+			}}
+		}
+		return dogrunD
+	}
+
+	tests := []struct {
+		name     string
+		dogrunD  model.Dogrun
+		hasValue bool
+		want     bool
+	}{
+		{name: "DBに値がない", dogrunD: newDogrunD(true, "", time.Time{}), hasValue: false, want: true},
+		{name: "管理されたドッグラン", dogrunD: newDogrunD(true, "", time.Time{}), hasValue: true, want: false},
+		{name: "出所の記録がない取り込み", dogrunD: newDogrunD(false, "", time.Time{}), hasValue: true, want: true},
+		{name: "最近確認した取り込み", dogrunD: newDogrunD(false, model.FIELD_SOURCE_IMPORTED, daysAgo(10)), hasValue: true, want: false},
+		{name: "古くなった取り込み", dogrunD: newDogrunD(false, model.FIELD_SOURCE_IMPORTED, daysAgo(400)), hasValue: true, want: true},
+		{name: "マネージャーの編集", dogrunD: newDogrunD(false, model.FIELD_SOURCE_MANAGER, daysAgo(1000)), hasValue: true, want: false},
+		{name: "以前のgoogleの値", dogrunD: newDogrunD(false, model.FIELD_SOURCE_GOOGLE, daysAgo(10)), hasValue: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prefersGoogle(tt.dogrunD, model.PROVENANCE_FIELD_ADDRESS, tt.hasValue, mergeTestNow); got != tt.want {
+				t.Errorf("prefersGoogle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
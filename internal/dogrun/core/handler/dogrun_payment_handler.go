@@ -0,0 +1,525 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	dogFacade "github.com/wanrun-develop/wanrun/internal/dog/facade"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/payment"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/wrcontext"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+)
+
+const (
+	PAYMENT_REPORT_MAX_DAYS = 366 // 支払い履歴レポートの最大期間
+)
+
+type IDogrunPaymentHandler interface {
+	GetFeePlans(echo.Context, int64) ([]dto.DogrunFeePlanRes, error)
+	CreateFeePlan(echo.Context, int64, dto.DogrunFeePlanReq) (dto.DogrunFeePlanRes, error)
+	UpdateFeePlan(echo.Context, int64, dto.DogrunFeePlanReq) (dto.DogrunFeePlanRes, error)
+	DeactivateFeePlan(echo.Context, int64) error
+	GetPaymentSetting(echo.Context, int64) (dto.DogrunPaymentSettingRes, error)
+	SavePaymentSetting(echo.Context, int64, dto.DogrunPaymentSettingReq) (dto.DogrunPaymentSettingRes, error)
+	Pay(echo.Context, dto.DogrunPaymentReq) (dto.DogrunPaymentRes, error)
+	GetMyPayments(echo.Context) ([]dto.DogrunPaymentRes, error)
+	GetPaymentReport(echo.Context, int64, time.Time, time.Time) (dto.DogrunPaymentReportRes, error)
+	CheckEntryPayment(echo.Context, int64, int64, int64) error
+}
+
+type dogrunPaymentHandler struct {
+	drr      repository.IDogrunRepository
+	pr       repository.IDogrunPaymentRepository
+	provider payment.IPaymentProvider
+	df       dogFacade.IDogFacade
+}
+
+func NewDogrunPaymentHandler(
+	drr repository.IDogrunRepository,
+	pr repository.IDogrunPaymentRepository,
+	provider payment.IPaymentProvider,
+	df dogFacade.IDogFacade,
+) IDogrunPaymentHandler {
+	return &dogrunPaymentHandler{
+		drr:      drr,
+		pr:       pr,
+		provider: provider,
+		df:       df,
+	}
+}
+
+// GetFeePlans: ドッグランの販売中の料金プラン一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - []dto.DogrunFeePlanRes:	料金プラン
+//   - error:	エラー
+func (h *dogrunPaymentHandler) GetFeePlans(c echo.Context, dogrunID int64) ([]dto.DogrunFeePlanRes, error) {
+	feePlans, err := h.pr.FindFeePlans(c, dogrunID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	feePlansRes := []dto.DogrunFeePlanRes{}
+	for _, feePlan := range feePlans {
+		feePlansRes = append(feePlansRes, convertDogrunFeePlanRes(feePlan))
+	}
+	return feePlansRes, nil
+}
+
+// CreateFeePlan: 料金プランの登録
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - dto.DogrunFeePlanReq:	料金プラン
+//
+// return:
+//   - dto.DogrunFeePlanRes:	登録した料金プラン
+//   - error:	エラー
+func (h *dogrunPaymentHandler) CreateFeePlan(c echo.Context, dogrunID int64, req dto.DogrunFeePlanReq) (dto.DogrunFeePlanRes, error) {
+	if err := checkManagedDogrun(c, h.drr, dogrunID); err != nil {
+		return dto.DogrunFeePlanRes{}, err
+	}
+
+	feePlan := model.DogrunFeePlan{
+		DogrunID: util.NewSqlNullInt64(dogrunID),
+		IsActive: util.NewSqlNullBool(true),
+	}
+	setDogrunFeePlan(&feePlan, req)
+	if err := h.pr.SaveFeePlan(c, &feePlan); err != nil {
+		return dto.DogrunFeePlanRes{}, err
+	}
+	return convertDogrunFeePlanRes(feePlan), nil
+}
+
+// UpdateFeePlan: 料金プランの更新
+// 支払い済みの支払いには影響しない
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunFeePlanID
+//   - dto.DogrunFeePlanReq:	料金プラン
+//
+// return:
+//   - dto.DogrunFeePlanRes:	更新した料金プラン
+//   - error:	エラー
+func (h *dogrunPaymentHandler) UpdateFeePlan(c echo.Context, feePlanID int64, req dto.DogrunFeePlanReq) (dto.DogrunFeePlanRes, error) {
+	feePlan, err := h.findManagedFeePlan(c, feePlanID)
+	if err != nil {
+		return dto.DogrunFeePlanRes{}, err
+	}
+
+	setDogrunFeePlan(&feePlan, req)
+	if err := h.pr.SaveFeePlan(c, &feePlan); err != nil {
+		return dto.DogrunFeePlanRes{}, err
+	}
+	return convertDogrunFeePlanRes(feePlan), nil
+}
+
+// DeactivateFeePlan: 料金プランの販売停止
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunFeePlanID
+//
+// return:
+//   - error:	エラー
+func (h *dogrunPaymentHandler) DeactivateFeePlan(c echo.Context, feePlanID int64) error {
+	if _, err := h.findManagedFeePlan(c, feePlanID); err != nil {
+		return err
+	}
+	return h.pr.DeactivateFeePlan(c, feePlanID)
+}
+
+// GetPaymentSetting: ドッグランの支払い設定の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - dto.DogrunPaymentSettingRes:	支払い設定
+//   - error:	エラー
+func (h *dogrunPaymentHandler) GetPaymentSetting(c echo.Context, dogrunID int64) (dto.DogrunPaymentSettingRes, error) {
+	setting, err := h.pr.FindPaymentSetting(c, dogrunID)
+	if err != nil {
+		return dto.DogrunPaymentSettingRes{}, err
+	}
+	setting.DogrunID = util.NewSqlNullInt64(dogrunID)
+	return convertDogrunPaymentSettingRes(setting), nil
+}
+
+// SavePaymentSetting: ドッグランの支払い設定の登録・更新
+// 支払いを必須にする場合は、販売中の料金プランが必要
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - dto.DogrunPaymentSettingReq:	支払い設定
+//
+// return:
+//   - dto.DogrunPaymentSettingRes:	更新後の支払い設定
+//   - error:	エラー
+func (h *dogrunPaymentHandler) SavePaymentSetting(c echo.Context, dogrunID int64, req dto.DogrunPaymentSettingReq) (dto.DogrunPaymentSettingRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := checkManagedDogrun(c, h.drr, dogrunID); err != nil {
+		return dto.DogrunPaymentSettingRes{}, err
+	}
+
+	if req.CheckinPaymentRequired || req.ReservationPaymentRequired {
+		feePlans, err := h.pr.FindFeePlans(c, dogrunID, false)
+		if err != nil {
+			return dto.DogrunPaymentSettingRes{}, err
+		}
+		if len(feePlans) == 0 {
+			err := errors.NewWRError(nil, "支払いを必須にするには販売中の料金プランが必要です", errors.NewDogrunClientErrorEType())
+			logger.Error(err)
+			return dto.DogrunPaymentSettingRes{}, err
+		}
+	}
+
+	setting := model.DogrunPaymentSetting{
+		DogrunID:                   util.NewSqlNullInt64(dogrunID),
+		CheckinPaymentRequired:     util.NewSqlNullBool(req.CheckinPaymentRequired),
+		ReservationPaymentRequired: util.NewSqlNullBool(req.ReservationPaymentRequired),
+	}
+	if err := h.pr.SavePaymentSetting(c, &setting); err != nil {
+		return dto.DogrunPaymentSettingRes{}, err
+	}
+	return convertDogrunPaymentSettingRes(setting), nil
+}
+
+// Pay: 料金プランの支払い
+// 同じ二重決済防止のキーで支払い済みの場合は、決済せずにその支払いを返す
+// 同じキーの決済が失敗・処理中の場合はエラーを返す(再決済には新しいキーが必要)
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - dto.DogrunPaymentReq:	支払い
+//
+// return:
+//   - dto.DogrunPaymentRes:	支払い
+//   - error:	エラー
+func (h *dogrunPaymentHandler) Pay(c echo.Context, req dto.DogrunPaymentReq) (dto.DogrunPaymentRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogOwnerID, err := wrcontext.GetLoginDogownerID(c)
+	if err != nil {
+		return dto.DogrunPaymentRes{}, err
+	}
+
+	existPayment, err := h.pr.FindPaymentByIdempotencyKey(c, dogOwnerID, req.IdempotencyKey)
+	if err != nil {
+		return dto.DogrunPaymentRes{}, err
+	}
+	if !existPayment.IsEmpty() {
+		switch existPayment.Status.String {
+		case model.DOGRUN_PAYMENT_STATUS_SUCCEEDED:
+			logger.Infof("支払い済みのキーのため決済をスキップ。dogrunPaymentID: %d", existPayment.DogrunPaymentID.Int64)
+			return convertDogrunPaymentRes(existPayment), nil
+		case model.DOGRUN_PAYMENT_STATUS_FAILED:
+			err := errors.NewWRError(nil, fmt.Sprintf("このキーの決済は失敗しています(%s)。新しいキーで支払ってください", existPayment.FailureReason.String), errors.NewDogrunClientErrorEType())
+			logger.Error(err)
+			return dto.DogrunPaymentRes{}, err
+		default:
+			err := errors.NewWRError(nil, "このキーの決済は処理中です", errors.NewDogrunClientErrorEType())
+			logger.Error(err)
+			return dto.DogrunPaymentRes{}, err
+		}
+	}
+
+	if err := h.df.CheckDogownerValid(c, req.DogIDs); err != nil {
+		return dto.DogrunPaymentRes{}, err
+	}
+
+	feePlan, err := h.pr.FindFeePlanByID(c, req.DogrunFeePlanID)
+	if err != nil {
+		return dto.DogrunPaymentRes{}, err
+	}
+	if feePlan.IsEmpty() || !feePlan.IsActive.Bool {
+		err := errors.NewWRError(nil, fmt.Sprintf("指定された料金プランID:%dは販売されていません", req.DogrunFeePlanID), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunPaymentRes{}, err
+	}
+
+	dogCount := int64(len(req.DogIDs))
+	dogrunPayment := model.DogrunPayment{
+		DogrunID:        feePlan.DogrunID,
+		DogrunFeePlanID: feePlan.DogrunFeePlanID,
+		DogOwnerID:      util.NewSqlNullInt64(dogOwnerID),
+		FeeType:         feePlan.FeeType,
+		Amount:          util.NewSqlNullInt64(feePlan.CalcAmount(dogCount)),
+		DogCount:        util.NewSqlNullInt64(dogCount),
+		Provider:        util.NewSqlNullString(h.provider.Name()),
+		Status:          util.NewSqlNullString(model.DOGRUN_PAYMENT_STATUS_PENDING),
+		IdempotencyKey:  util.NewSqlNullString(req.IdempotencyKey),
+		DogrunFeePlan:   feePlan,
+	}
+	if err := h.pr.CreatePayment(c, &dogrunPayment); err != nil {
+		return dto.DogrunPaymentRes{}, err
+	}
+
+	result, err := h.provider.Charge(c, payment.ChargeRequest{
+		Amount:             dogrunPayment.Amount.Int64,
+		Description:        feePlan.Name.String,
+		PaymentMethodToken: req.PaymentMethodToken,
+		IdempotencyKey:     fmt.Sprintf("dogrun-payment-%d", dogrunPayment.DogrunPaymentID.Int64),
+	})
+	if err != nil {
+		dogrunPayment.Status = util.NewSqlNullString(model.DOGRUN_PAYMENT_STATUS_FAILED)
+		dogrunPayment.FailureReason = util.NewSqlNullString(err.Error())
+		_ = h.pr.UpdatePaymentResult(c, dogrunPayment)
+		err = errors.NewWRError(err, "決済処理でエラーが発生しました", errors.NewDogrunServerErrorEType())
+		logger.Error(err)
+		return dto.DogrunPaymentRes{}, err
+	}
+
+	dogrunPayment.ProviderPaymentID = util.NewSqlNullString(result.ProviderPaymentID)
+	if result.Status == payment.CHARGE_STATUS_SUCCEEDED {
+		paidAt := time.Now()
+		dogrunPayment.Status = util.NewSqlNullString(model.DOGRUN_PAYMENT_STATUS_SUCCEEDED)
+		dogrunPayment.PaidAt = util.NewSqlNullTime(paidAt)
+		dogrunPayment.ValidUntil = util.NewSqlNullTime(feePlan.ValidUntil(paidAt))
+	} else {
+		dogrunPayment.Status = util.NewSqlNullString(model.DOGRUN_PAYMENT_STATUS_FAILED)
+		dogrunPayment.FailureReason = util.NewSqlNullString(result.FailureReason)
+	}
+	if err := h.pr.UpdatePaymentResult(c, dogrunPayment); err != nil {
+		return dto.DogrunPaymentRes{}, err
+	}
+
+	if !dogrunPayment.IsSucceeded() {
+		err := errors.NewWRError(nil, fmt.Sprintf("決済に失敗しました(%s)", result.FailureReason), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunPaymentRes{}, err
+	}
+	return convertDogrunPaymentRes(dogrunPayment), nil
+}
+
+// GetMyPayments: ログインユーザーの支払い履歴の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - []dto.DogrunPaymentRes:	支払い履歴
+//   - error:	エラー
+func (h *dogrunPaymentHandler) GetMyPayments(c echo.Context) ([]dto.DogrunPaymentRes, error) {
+	dogOwnerID, err := wrcontext.GetLoginDogownerID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := h.pr.FindPaymentsByDogOwnerID(c, dogOwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentsRes := []dto.DogrunPaymentRes{}
+	for _, dogrunPayment := range payments {
+		paymentsRes = append(paymentsRes, convertDogrunPaymentRes(dogrunPayment))
+	}
+	return paymentsRes, nil
+}
+
+// GetPaymentReport: マネージャー向けの支払い履歴レポート
+// 期間内の支払いと、料金プランの種別ごとの支払い完了の件数・金額を返す
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - time.Time:	期間の開始日
+//   - time.Time:	期間の終了日(含む)
+//
+// return:
+//   - dto.DogrunPaymentReportRes:	支払い履歴レポート
+//   - error:	エラー
+func (h *dogrunPaymentHandler) GetPaymentReport(c echo.Context, dogrunID int64, from time.Time, to time.Time) (dto.DogrunPaymentReportRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := checkManagedDogrun(c, h.drr, dogrunID); err != nil {
+		return dto.DogrunPaymentReportRes{}, err
+	}
+	if to.Before(from) || to.Sub(from) >= PAYMENT_REPORT_MAX_DAYS*24*time.Hour {
+		err := errors.NewWRError(nil, fmt.Sprintf("レポートの期間は%d日以内で指定してください", PAYMENT_REPORT_MAX_DAYS), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunPaymentReportRes{}, err
+	}
+
+	payments, err := h.pr.FindPaymentsByDogrunID(c, dogrunID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return dto.DogrunPaymentReportRes{}, err
+	}
+
+	report := dto.DogrunPaymentReportRes{
+		From:      from.Format(time.DateOnly),
+		To:        to.Format(time.DateOnly),
+		Summaries: []dto.DogrunPaymentSummaryRes{},
+		Payments:  []dto.DogrunManagedPaymentRes{},
+	}
+	summaries := map[string]*dto.DogrunPaymentSummaryRes{}
+	for _, feeType := range []string{model.DOGRUN_FEE_TYPE_PER_VISIT, model.DOGRUN_FEE_TYPE_PER_DOG, model.DOGRUN_FEE_TYPE_MEMBERSHIP} {
+		report.Summaries = append(report.Summaries, dto.DogrunPaymentSummaryRes{FeeType: feeType})
+		summaries[feeType] = &report.Summaries[len(report.Summaries)-1]
+	}
+	for _, dogrunPayment := range payments {
+		switch dogrunPayment.Status.String {
+		case model.DOGRUN_PAYMENT_STATUS_SUCCEEDED:
+			report.SucceededCount++
+			report.TotalAmount += dogrunPayment.Amount.Int64
+			if summary, exist := summaries[dogrunPayment.FeeType.String]; exist {
+				summary.Count++
+				summary.Amount += dogrunPayment.Amount.Int64
+			}
+		case model.DOGRUN_PAYMENT_STATUS_FAILED:
+			report.FailedCount++
+		}
+		report.Payments = append(report.Payments, dto.DogrunManagedPaymentRes{
+			DogrunPaymentRes: convertDogrunPaymentRes(dogrunPayment),
+			DogOwnerID:       dogrunPayment.DogOwnerID.Int64,
+			DogOwnerName:     dogrunPayment.DogOwner.Name.String,
+		})
+	}
+	return report, nil
+}
+
+// CheckEntryPayment: チェックインに必要な支払いが済んでいるかのチェック
+// チェックインに支払いが必須でないドッグランはチェックしない
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - int64:	dogOwnerID
+//   - int64:	チェックインするdogの数
+//
+// return:
+//   - error:	支払いが済んでいない場合はエラー
+func (h *dogrunPaymentHandler) CheckEntryPayment(c echo.Context, dogrunID int64, dogOwnerID int64, dogCount int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	setting, err := h.pr.FindPaymentSetting(c, dogrunID)
+	if err != nil {
+		return err
+	}
+	if !setting.CheckinPaymentRequired.Bool {
+		return nil
+	}
+
+	now := time.Now()
+	payments, err := h.pr.FindValidPayments(c, dogrunID, dogOwnerID, now)
+	if err != nil {
+		return err
+	}
+	for _, dogrunPayment := range payments {
+		if dogrunPayment.CoversEntry(dogCount, now) {
+			return nil
+		}
+	}
+
+	err = errors.NewWRError(nil, "このドッグランへのチェックインには支払いが必要です", errors.NewDogrunClientErrorEType())
+	logger.Error(err)
+	return err
+}
+
+// findManagedFeePlan: 管理対象のドッグランの料金プランの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunFeePlanID
+//
+// return:
+//   - model.DogrunFeePlan:	料金プラン
+//   - error:	存在しない、管理対象外の場合はエラー
+func (h *dogrunPaymentHandler) findManagedFeePlan(c echo.Context, feePlanID int64) (model.DogrunFeePlan, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	feePlan, err := h.pr.FindFeePlanByID(c, feePlanID)
+	if err != nil {
+		return model.DogrunFeePlan{}, err
+	}
+	if feePlan.IsEmpty() {
+		err := errors.NewWRError(nil, fmt.Sprintf("指定された料金プランID:%dが存在しません", feePlanID), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return model.DogrunFeePlan{}, err
+	}
+	if err := checkManagedDogrun(c, h.drr, feePlan.DogrunID.Int64); err != nil {
+		return model.DogrunFeePlan{}, err
+	}
+	return feePlan, nil
+}
+
+/*
+リクエストの料金プランの反映。会員以外は有効日数を持たない
+*/
+func setDogrunFeePlan(feePlan *model.DogrunFeePlan, req dto.DogrunFeePlanReq) {
+	feePlan.Name = util.NewSqlNullString(req.Name)
+	feePlan.FeeType = util.NewSqlNullString(req.FeeType)
+	feePlan.Amount = util.NewSqlNullInt64(req.Amount)
+	feePlan.MembershipDays = util.NewSqlNullInt64(0)
+	if req.FeeType == model.DOGRUN_FEE_TYPE_MEMBERSHIP {
+		feePlan.MembershipDays = util.NewSqlNullInt64(req.MembershipDays)
+	}
+}
+
+/*
+料金プランをレスポンスに変換
+*/
+func convertDogrunFeePlanRes(feePlan model.DogrunFeePlan) dto.DogrunFeePlanRes {
+	return dto.DogrunFeePlanRes{
+		DogrunFeePlanID: feePlan.DogrunFeePlanID.Int64,
+		DogrunID:        feePlan.DogrunID.Int64,
+		Name:            feePlan.Name.String,
+		FeeType:         feePlan.FeeType.String,
+		Amount:          feePlan.Amount.Int64,
+		MembershipDays:  feePlan.MembershipDays.Int64,
+		IsActive:        feePlan.IsActive.Bool,
+	}
+}
+
+/*
+支払い設定をレスポンスに変換
+*/
+func convertDogrunPaymentSettingRes(setting model.DogrunPaymentSetting) dto.DogrunPaymentSettingRes {
+	return dto.DogrunPaymentSettingRes{
+		DogrunID:                   setting.DogrunID.Int64,
+		CheckinPaymentRequired:     setting.CheckinPaymentRequired.Bool,
+		ReservationPaymentRequired: setting.ReservationPaymentRequired.Bool,
+	}
+}
+
+/*
+支払いをレスポンスに変換
+*/
+func convertDogrunPaymentRes(dogrunPayment model.DogrunPayment) dto.DogrunPaymentRes {
+	res := dto.DogrunPaymentRes{
+		DogrunPaymentID:     dogrunPayment.DogrunPaymentID.Int64,
+		DogrunID:            dogrunPayment.DogrunID.Int64,
+		DogrunFeePlanID:     dogrunPayment.DogrunFeePlanID.Int64,
+		FeePlanName:         dogrunPayment.DogrunFeePlan.Name.String,
+		FeeType:             dogrunPayment.FeeType.String,
+		Amount:              dogrunPayment.Amount.Int64,
+		DogCount:            dogrunPayment.DogCount.Int64,
+		DogrunReservationID: dogrunPayment.DogrunReservationID.Int64,
+		Status:              dogrunPayment.Status.String,
+		FailureReason:       dogrunPayment.FailureReason.String,
+		CreateAt:            dogrunPayment.CreateAt.Time,
+	}
+	if dogrunPayment.PaidAt.Valid {
+		res.PaidAt = &dogrunPayment.PaidAt.Time
+	}
+	if dogrunPayment.ValidUntil.Valid {
+		res.ValidUntil = &dogrunPayment.ValidUntil.Time
+	}
+	return res
+}
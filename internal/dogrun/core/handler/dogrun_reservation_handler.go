@@ -0,0 +1,725 @@
+package handler
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	dogFacade "github.com/wanrun-develop/wanrun/internal/dog/facade"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/transaction"
+	"github.com/wanrun-develop/wanrun/internal/wrcontext"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+	"gorm.io/gorm"
+)
+
+const (
+	BOOKING_SLOT_GENERATE_MAX_DAYS = 62 // 予約枠を一度に生成できる日数
+	NO_SHOW_LOOKBACK_DAYS          = 90 // 無断キャンセルを集計する日数
+	NO_SHOW_LIMIT                  = 3  // 集計期間内の無断キャンセルがこの件数以上の場合は予約不可
+)
+
+type IDogrunReservationHandler interface {
+	GetBookingSetting(echo.Context, int64) (dto.DogrunBookingSettingRes, error)
+	SaveBookingSetting(echo.Context, int64, dto.DogrunBookingSettingReq) (dto.DogrunBookingSettingRes, error)
+	GenerateBookingSlots(echo.Context, int64, dto.DogrunBookingSlotGenerateReq) (dto.DogrunBookingSlotGenerateRes, error)
+	GetBookingSlots(echo.Context, int64, time.Time) ([]dto.DogrunBookingSlotRes, error)
+	Reserve(echo.Context, dto.DogrunReservationReq) (dto.DogrunReservationRes, error)
+	GetMyReservations(echo.Context) ([]dto.DogrunReservationRes, error)
+	CancelReservation(echo.Context, int64) error
+	GetDogrunReservations(echo.Context, int64, time.Time) ([]dto.DogrunManagedReservationRes, error)
+	MarkNoShow(echo.Context, int64) error
+}
+
+type dogrunReservationHandler struct {
+	drr repository.IDogrunRepository
+	rr  repository.IDogrunReservationRepository
+	rsr repository.IDogrunReservationScopeRepository
+	pr  repository.IDogrunPaymentRepository
+	tm  transaction.ITransactionManager
+	df  dogFacade.IDogFacade
+}
+
+func NewDogrunReservationHandler(
+	drr repository.IDogrunRepository,
+	rr repository.IDogrunReservationRepository,
+	rsr repository.IDogrunReservationScopeRepository,
+	pr repository.IDogrunPaymentRepository,
+	tm transaction.ITransactionManager,
+	df dogFacade.IDogFacade,
+) IDogrunReservationHandler {
+	return &dogrunReservationHandler{
+		drr: drr,
+		rr:  rr,
+		rsr: rsr,
+		pr:  pr,
+		tm:  tm,
+		df:  df,
+	}
+}
+
+// GetBookingSetting: ドッグランの予約設定の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - dto.DogrunBookingSettingRes:	予約設定
+//   - error:	エラー
+func (h *dogrunReservationHandler) GetBookingSetting(c echo.Context, dogrunID int64) (dto.DogrunBookingSettingRes, error) {
+	setting, err := h.findBookingSetting(c, dogrunID)
+	if err != nil {
+		return dto.DogrunBookingSettingRes{}, err
+	}
+	return convertDogrunBookingSettingRes(setting), nil
+}
+
+// SaveBookingSetting: ドッグランの予約設定の登録・更新
+// 管理対象かつ管理ドッグラン(is_managed)のみ予約を受け付けられる
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - dto.DogrunBookingSettingReq:	予約設定
+//
+// return:
+//   - dto.DogrunBookingSettingRes:	更新後の予約設定
+//   - error:	エラー
+func (h *dogrunReservationHandler) SaveBookingSetting(c echo.Context, dogrunID int64, req dto.DogrunBookingSettingReq) (dto.DogrunBookingSettingRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := checkManagedDogrun(c, h.drr, dogrunID); err != nil {
+		return dto.DogrunBookingSettingRes{}, err
+	}
+	dogruns, err := h.drr.FindDogrunByIDs([]int64{dogrunID})
+	if err != nil {
+		err = errors.NewWRError(err, "dogrunの取得でエラー", errors.NewDogrunServerErrorEType())
+		logger.Error(err)
+		return dto.DogrunBookingSettingRes{}, err
+	}
+	if len(dogruns) == 0 || !dogruns[0].IsManaged.Bool {
+		err := errors.NewWRError(nil, "管理ドッグランのみ予約を受け付けられます", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunBookingSettingRes{}, err
+	}
+
+	setting := model.DogrunBookingSetting{
+		DogrunID:           util.NewSqlNullInt64(dogrunID),
+		SlotMinutes:        util.NewSqlNullInt64(req.SlotMinutes),
+		SlotCapacity:       util.NewSqlNullInt64(req.SlotCapacity),
+		AllowPrivateRental: util.NewSqlNullBool(req.AllowPrivateRental),
+	}
+	if err := h.rr.SaveBookingSetting(c, &setting); err != nil {
+		return dto.DogrunBookingSettingRes{}, err
+	}
+	return convertDogrunBookingSettingRes(setting), nil
+}
+
+// GenerateBookingSlots: 営業時間から予約枠を生成
+// 指定期間の各日の営業時間(特別営業時間があれば優先)を予約設定の時間で区切って予約枠にする。
+// 休業日、開始済みの枠、既に生成済みの枠はスキップする
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - dto.DogrunBookingSlotGenerateReq:	生成期間
+//
+// return:
+//   - dto.DogrunBookingSlotGenerateRes:	生成件数と期間内の予約枠
+//   - error:	エラー
+func (h *dogrunReservationHandler) GenerateBookingSlots(c echo.Context, dogrunID int64, req dto.DogrunBookingSlotGenerateReq) (dto.DogrunBookingSlotGenerateRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := checkManagedDogrun(c, h.drr, dogrunID); err != nil {
+		return dto.DogrunBookingSlotGenerateRes{}, err
+	}
+	setting, err := h.findBookingSetting(c, dogrunID)
+	if err != nil {
+		return dto.DogrunBookingSlotGenerateRes{}, err
+	}
+
+	from, errFrom := time.ParseInLocation(time.DateOnly, req.From, time.Local)
+	to, errTo := time.ParseInLocation(time.DateOnly, req.To, time.Local)
+	if errFrom != nil || errTo != nil || to.Before(from) {
+		err := errors.NewWRError(nil, "予約枠の生成期間が不正です", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunBookingSlotGenerateRes{}, err
+	}
+	if to.Sub(from) >= BOOKING_SLOT_GENERATE_MAX_DAYS*24*time.Hour {
+		err := errors.NewWRError(nil, fmt.Sprintf("予約枠は一度に%d日分まで生成できます", BOOKING_SLOT_GENERATE_MAX_DAYS), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunBookingSlotGenerateRes{}, err
+	}
+
+	dogruns, err := h.drr.FindDogrunWithRelationsByIDs(c, []int64{dogrunID})
+	if err != nil {
+		return dto.DogrunBookingSlotGenerateRes{}, err
+	}
+	dogrun := dogruns[0]
+	if dogrun.IsRegularBusinessHoursEmpty() {
+		err := errors.NewWRError(nil, "通常営業時間が登録されていないため予約枠を生成できません", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunBookingSlotGenerateRes{}, err
+	}
+
+	now := time.Now()
+	slotDuration := time.Duration(setting.SlotMinutes.Int64) * time.Minute
+	slots := []model.DogrunBookingSlot{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		window := dogrun.BusinessWindowOn(day)
+		if window.IsUnknown || window.IsClosed {
+			continue
+		}
+		for startAt := window.OpenAt; !startAt.Add(slotDuration).After(window.CloseAt); startAt = startAt.Add(slotDuration) {
+			if !startAt.After(now) {
+				continue
+			}
+			slots = append(slots, model.DogrunBookingSlot{
+				DogrunID:      util.NewSqlNullInt64(dogrunID),
+				StartAt:       util.NewSqlNullTime(startAt),
+				EndAt:         util.NewSqlNullTime(startAt.Add(slotDuration)),
+				Capacity:      setting.SlotCapacity,
+				ReservedCount: sql.NullInt64{Int64: 0, Valid: true},
+				IsPrivate:     util.NewSqlNullBool(false),
+			})
+		}
+	}
+
+	createdCount, err := h.rr.CreateBookingSlots(c, slots)
+	if err != nil {
+		return dto.DogrunBookingSlotGenerateRes{}, err
+	}
+	logger.Infof("予約枠を%d件生成しました。dogrunID: %d", createdCount, dogrunID)
+
+	generatedSlots, err := h.rr.FindBookingSlots(c, dogrunID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return dto.DogrunBookingSlotGenerateRes{}, err
+	}
+	return dto.DogrunBookingSlotGenerateRes{
+		CreatedCount: createdCount,
+		Slots:        convertDogrunBookingSlotsRes(generatedSlots),
+	}, nil
+}
+
+// GetBookingSlots: 指定日の予約枠の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - time.Time:	日付
+//
+// return:
+//   - []dto.DogrunBookingSlotRes:	予約枠
+//   - error:	エラー
+func (h *dogrunReservationHandler) GetBookingSlots(c echo.Context, dogrunID int64, date time.Time) ([]dto.DogrunBookingSlotRes, error) {
+	if _, err := h.findBookingSetting(c, dogrunID); err != nil {
+		return nil, err
+	}
+
+	slots, err := h.rr.FindBookingSlots(c, dogrunID, date, date.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	return convertDogrunBookingSlotsRes(slots), nil
+}
+
+// Reserve: 予約枠の予約
+// 予約枠を行ロックしたトランザクション内で残数を確認するため、定員を超えて予約されることはない。
+// 予約に支払いが必須のドッグランは、支払いを予約に充てる
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - dto.DogrunReservationReq:	予約
+//
+// return:
+//   - dto.DogrunReservationRes:	登録した予約
+//   - error:	エラー
+func (h *dogrunReservationHandler) Reserve(c echo.Context, req dto.DogrunReservationReq) (dto.DogrunReservationRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogOwnerID, err := wrcontext.GetLoginDogownerID(c)
+	if err != nil {
+		return dto.DogrunReservationRes{}, err
+	}
+	if err := h.df.CheckDogownerValid(c, req.DogIDs); err != nil {
+		return dto.DogrunReservationRes{}, err
+	}
+
+	//無断キャンセルが続いているdogownerは予約不可
+	noShows, err := h.rr.CountNoShows(c, []int64{dogOwnerID}, time.Now().AddDate(0, 0, -NO_SHOW_LOOKBACK_DAYS))
+	if err != nil {
+		return dto.DogrunReservationRes{}, err
+	}
+	if noShows[dogOwnerID] >= NO_SHOW_LIMIT {
+		err := errors.NewWRError(nil, fmt.Sprintf("直近%d日間の無断キャンセルが%d件以上のため予約できません", NO_SHOW_LOOKBACK_DAYS, NO_SHOW_LIMIT), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunReservationRes{}, err
+	}
+
+	slot, err := h.rr.FindBookingSlotByID(c, req.DogrunBookingSlotID)
+	if err != nil {
+		return dto.DogrunReservationRes{}, err
+	}
+	if slot.IsEmpty() {
+		err := errors.NewWRError(nil, fmt.Sprintf("指定された予約枠ID:%dが存在しません", req.DogrunBookingSlotID), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunReservationRes{}, err
+	}
+	setting, err := h.findBookingSetting(c, slot.DogrunID.Int64)
+	if err != nil {
+		return dto.DogrunReservationRes{}, err
+	}
+	if req.PrivateRental && !setting.AllowPrivateRental.Bool {
+		err := errors.NewWRError(nil, "このドッグランは貸切予約を受け付けていません", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return dto.DogrunReservationRes{}, err
+	}
+	if err := h.checkReservationEntryCriteria(c, slot, req.DogIDs); err != nil {
+		return dto.DogrunReservationRes{}, err
+	}
+	reservationPayment, err := h.findReservationPayment(c, slot, dogOwnerID, req)
+	if err != nil {
+		return dto.DogrunReservationRes{}, err
+	}
+
+	reservationType := model.DOGRUN_RESERVATION_TYPE_NORMAL
+	if req.PrivateRental {
+		reservationType = model.DOGRUN_RESERVATION_TYPE_PRIVATE
+	}
+	reservation := model.DogrunReservation{
+		DogrunBookingSlotID: slot.DogrunBookingSlotID,
+		DogrunID:            slot.DogrunID,
+		DogOwnerID:          util.NewSqlNullInt64(dogOwnerID),
+		ReservationType:     util.NewSqlNullString(reservationType),
+		DogCount:            util.NewSqlNullInt64(int64(len(req.DogIDs))),
+		Status:              util.NewSqlNullString(model.DOGRUN_RESERVATION_STATUS_RESERVED),
+	}
+	for _, dogID := range req.DogIDs {
+		reservation.DogrunReservationDogs = append(reservation.DogrunReservationDogs, model.DogrunReservationDog{
+			DogID: util.NewSqlNullInt64(dogID),
+		})
+	}
+
+	ctx := c.Request().Context()
+	if err := h.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		lockedSlot, wrErr := h.rsr.FindBookingSlotForUpdate(tx, c, slot.DogrunBookingSlotID.Int64)
+		if wrErr != nil {
+			return wrErr
+		}
+		if !lockedSlot.IsBookable(time.Now()) {
+			wrErr := errors.NewWRError(nil, "開始済みの予約枠は予約できません", errors.NewDogrunClientErrorEType())
+			logger.Error(wrErr)
+			return wrErr
+		}
+
+		alreadyReserved, wrErr := h.rsr.ExistsReservedDogs(tx, c, slot.DogrunBookingSlotID.Int64, req.DogIDs)
+		if wrErr != nil {
+			return wrErr
+		}
+		if alreadyReserved {
+			wrErr := errors.NewWRError(nil, "既にこの予約枠を予約しているdogが含まれています", errors.NewDogrunClientErrorEType())
+			logger.Error(wrErr)
+			return wrErr
+		}
+
+		dogCount := int64(len(req.DogIDs))
+		if req.PrivateRental {
+			//貸切は予約が1件もない枠のみ
+			if lockedSlot.IsPrivate.Bool || lockedSlot.ReservedCount.Int64 > 0 || dogCount > lockedSlot.Capacity.Int64 {
+				wrErr := errors.NewWRError(nil, "この予約枠は貸切予約できません", errors.NewDogrunClientErrorEType())
+				logger.Error(wrErr)
+				return wrErr
+			}
+			lockedSlot.IsPrivate = util.NewSqlNullBool(true)
+		} else if lockedSlot.Remaining() < dogCount {
+			wrErr := errors.NewWRError(nil, fmt.Sprintf("予約枠の残りは%d頭分です", lockedSlot.Remaining()), errors.NewDogrunClientErrorEType())
+			logger.Error(wrErr)
+			return wrErr
+		}
+		lockedSlot.ReservedCount.Int64 += dogCount
+
+		if wrErr := h.rsr.CreateReservation(tx, c, &reservation); wrErr != nil {
+			return wrErr
+		}
+
+		//支払いを予約に充てる。同じ支払いの同時利用は先に充てた方のみ成功する
+		if !reservationPayment.IsEmpty() {
+			linked, wrErr := h.rsr.LinkPaymentToReservation(tx, c, reservationPayment.DogrunPaymentID.Int64, reservation.DogrunReservationID.Int64, lockedSlot.EndAt.Time)
+			if wrErr != nil {
+				return wrErr
+			}
+			if !linked {
+				wrErr := errors.NewWRError(nil, "指定された支払いは既に他の予約に充てられています", errors.NewDogrunClientErrorEType())
+				logger.Error(wrErr)
+				return wrErr
+			}
+		}
+		return h.rsr.UpdateBookingSlotReservedCount(tx, c, lockedSlot)
+	}); err != nil {
+		return dto.DogrunReservationRes{}, err
+	}
+
+	saved, err := h.rr.FindReservationByID(c, reservation.DogrunReservationID.Int64)
+	if err != nil {
+		return dto.DogrunReservationRes{}, err
+	}
+	return convertDogrunReservationRes(saved), nil
+}
+
+// GetMyReservations: ログインユーザーの予約一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - []dto.DogrunReservationRes:	予約一覧
+//   - error:	エラー
+func (h *dogrunReservationHandler) GetMyReservations(c echo.Context) ([]dto.DogrunReservationRes, error) {
+	dogOwnerID, err := wrcontext.GetLoginDogownerID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, err := h.rr.FindReservationsByDogOwnerID(c, dogOwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	reservationsRes := []dto.DogrunReservationRes{}
+	for _, reservation := range reservations {
+		reservationsRes = append(reservationsRes, convertDogrunReservationRes(reservation))
+	}
+	return reservationsRes, nil
+}
+
+// CancelReservation: 予約のキャンセル
+// 予約枠の開始前のみキャンセルでき、予約枠の残数を戻す
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunReservationID
+//
+// return:
+//   - error:	エラー
+func (h *dogrunReservationHandler) CancelReservation(c echo.Context, reservationID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	dogOwnerID, err := wrcontext.GetLoginDogownerID(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	return h.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		reservation, wrErr := h.rsr.FindReservationForUpdate(tx, c, reservationID)
+		if wrErr != nil {
+			return wrErr
+		}
+		if reservation.IsEmpty() || reservation.DogOwnerID.Int64 != dogOwnerID {
+			wrErr := errors.NewWRError(nil, fmt.Sprintf("指定された予約ID:%dが存在しません", reservationID), errors.NewDogrunClientErrorEType())
+			logger.Error(wrErr)
+			return wrErr
+		}
+		if !reservation.IsReserved() {
+			wrErr := errors.NewWRError(nil, "予約中ではないためキャンセルできません", errors.NewDogrunClientErrorEType())
+			logger.Error(wrErr)
+			return wrErr
+		}
+
+		slot, wrErr := h.rsr.FindBookingSlotForUpdate(tx, c, reservation.DogrunBookingSlotID.Int64)
+		if wrErr != nil {
+			return wrErr
+		}
+		if !slot.IsBookable(time.Now()) {
+			wrErr := errors.NewWRError(nil, "開始済みの予約はキャンセルできません", errors.NewDogrunClientErrorEType())
+			logger.Error(wrErr)
+			return wrErr
+		}
+
+		slot.Release(reservation.DogCount.Int64, reservation.IsPrivate())
+
+		if wrErr := h.rsr.CancelReservation(tx, c, reservationID); wrErr != nil {
+			return wrErr
+		}
+		return h.rsr.UpdateBookingSlotReservedCount(tx, c, slot)
+	})
+}
+
+// GetDogrunReservations: マネージャー向けの指定日の予約一覧の取得
+// キャンセル、無断キャンセルを含み、dogownerの直近の無断キャンセル数を付与する
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - time.Time:	日付
+//
+// return:
+//   - []dto.DogrunManagedReservationRes:	予約一覧
+//   - error:	エラー
+func (h *dogrunReservationHandler) GetDogrunReservations(c echo.Context, dogrunID int64, date time.Time) ([]dto.DogrunManagedReservationRes, error) {
+	if err := checkManagedDogrun(c, h.drr, dogrunID); err != nil {
+		return nil, err
+	}
+
+	reservations, err := h.rr.FindReservationsByDogrunID(c, dogrunID, date, date.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	dogOwnerIDs := []int64{}
+	for _, reservation := range reservations {
+		dogOwnerIDs = append(dogOwnerIDs, reservation.DogOwnerID.Int64)
+	}
+	noShows := map[int64]int64{}
+	if len(dogOwnerIDs) > 0 {
+		noShows, err = h.rr.CountNoShows(c, dogOwnerIDs, time.Now().AddDate(0, 0, -NO_SHOW_LOOKBACK_DAYS))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reservationsRes := []dto.DogrunManagedReservationRes{}
+	for _, reservation := range reservations {
+		reservationsRes = append(reservationsRes, dto.DogrunManagedReservationRes{
+			DogrunReservationRes: convertDogrunReservationRes(reservation),
+			DogOwnerID:           reservation.DogOwnerID.Int64,
+			DogOwnerName:         reservation.DogOwner.Name.String,
+			NoShowCount:          noShows[reservation.DogOwnerID.Int64],
+		})
+	}
+	return reservationsRes, nil
+}
+
+// MarkNoShow: 予約を無断キャンセルとして記録
+// 管理対象のドッグランの、開始済みの予約中の予約のみ記録できる
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunReservationID
+//
+// return:
+//   - error:	エラー
+func (h *dogrunReservationHandler) MarkNoShow(c echo.Context, reservationID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	reservation, err := h.rr.FindReservationByID(c, reservationID)
+	if err != nil {
+		return err
+	}
+	if reservation.IsEmpty() {
+		err := errors.NewWRError(nil, fmt.Sprintf("指定された予約ID:%dが存在しません", reservationID), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if err := checkManagedDogrun(c, h.drr, reservation.DogrunID.Int64); err != nil {
+		return err
+	}
+	if !reservation.IsReserved() {
+		err := errors.NewWRError(nil, "予約中ではないため無断キャンセルにできません", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	if reservation.DogrunBookingSlot.IsBookable(time.Now()) {
+		err := errors.NewWRError(nil, "開始前の予約は無断キャンセルにできません", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	return h.rr.UpdateReservationNoShow(c, reservationID)
+}
+
+// checkReservationEntryCriteria: 予約するdogがドッグランの入場条件を満たすかのチェック
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.DogrunBookingSlot:	予約枠
+//   - []int64:	予約するdogのID
+//
+// return:
+//   - error:	エラー
+func (h *dogrunReservationHandler) checkReservationEntryCriteria(c echo.Context, slot model.DogrunBookingSlot, dogIDs []int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	entryCriteria, err := h.drr.FindDogrunEntryCriteria(c, slot.DogrunID.Int64)
+	if err != nil {
+		return err
+	}
+	if entryCriteria.IsEmpty() {
+		return nil
+	}
+
+	dogs, err := h.df.FindDogsByIDs(c, dogIDs)
+	if err != nil {
+		return err
+	}
+	for _, dog := range dogs {
+		//月齢は予約枠の開始日時で判定
+		if reasons := entryCriteria.UnmetReasons(dog, slot.StartAt.Time); len(reasons) > 0 {
+			err := errors.NewWRError(nil, fmt.Sprintf("ドッグID:%dは入場条件を満たしていません(%s)", dog.DogID.Int64, strings.Join(reasons, "、")), errors.NewDogrunClientErrorEType())
+			logger.Error(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// findReservationPayment: 予約に充てる支払いの取得
+// 予約に支払いが必須でない場合、予約枠の開始時点で有効な会員の場合は支払い不要として空を返す
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.DogrunBookingSlot:	予約枠
+//   - int64:	dogOwnerID
+//   - dto.DogrunReservationReq:	予約
+//
+// return:
+//   - model.DogrunPayment:	予約に充てる支払い。支払い不要の場合は空
+//   - error:	支払いが必要で、充てられる支払いがない場合はエラー
+func (h *dogrunReservationHandler) findReservationPayment(c echo.Context, slot model.DogrunBookingSlot, dogOwnerID int64, req dto.DogrunReservationReq) (model.DogrunPayment, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	paymentSetting, err := h.pr.FindPaymentSetting(c, slot.DogrunID.Int64)
+	if err != nil {
+		return model.DogrunPayment{}, err
+	}
+	if !paymentSetting.ReservationPaymentRequired.Bool {
+		return model.DogrunPayment{}, nil
+	}
+
+	validPayments, err := h.pr.FindValidPayments(c, slot.DogrunID.Int64, dogOwnerID, slot.StartAt.Time)
+	if err != nil {
+		return model.DogrunPayment{}, err
+	}
+	for _, validPayment := range validPayments {
+		if validPayment.IsMembership() {
+			return model.DogrunPayment{}, nil
+		}
+	}
+
+	if req.DogrunPaymentID == 0 {
+		err := errors.NewWRError(nil, "このドッグランの予約には支払いが必要です", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return model.DogrunPayment{}, err
+	}
+	reservationPayment, err := h.pr.FindPaymentByID(c, req.DogrunPaymentID)
+	if err != nil {
+		return model.DogrunPayment{}, err
+	}
+	if reservationPayment.IsEmpty() || reservationPayment.DogOwnerID.Int64 != dogOwnerID || reservationPayment.DogrunID.Int64 != slot.DogrunID.Int64 {
+		err := errors.NewWRError(nil, fmt.Sprintf("指定された支払いID:%dが存在しません", req.DogrunPaymentID), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return model.DogrunPayment{}, err
+	}
+	if !reservationPayment.IsSucceeded() || reservationPayment.IsMembership() || reservationPayment.DogrunReservationID.Valid {
+		err := errors.NewWRError(nil, "指定された支払いは予約に充てられません", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return model.DogrunPayment{}, err
+	}
+	if reservationPayment.FeeType.String == model.DOGRUN_FEE_TYPE_PER_DOG && reservationPayment.DogCount.Int64 < int64(len(req.DogIDs)) {
+		err := errors.NewWRError(nil, fmt.Sprintf("支払いは%d頭分のため予約できません", reservationPayment.DogCount.Int64), errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return model.DogrunPayment{}, err
+	}
+	return reservationPayment, nil
+}
+
+// findBookingSetting: 予約を受け付けているドッグランの予約設定の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - model.DogrunBookingSetting:	予約設定
+//   - error:	予約を受け付けていない場合はエラー
+func (h *dogrunReservationHandler) findBookingSetting(c echo.Context, dogrunID int64) (model.DogrunBookingSetting, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	setting, err := h.rr.FindBookingSetting(c, dogrunID)
+	if err != nil {
+		return model.DogrunBookingSetting{}, err
+	}
+	if setting.IsEmpty() {
+		err := errors.NewWRError(nil, "このドッグランは予約を受け付けていません", errors.NewDogrunClientErrorEType())
+		logger.Error(err)
+		return model.DogrunBookingSetting{}, err
+	}
+	return setting, nil
+}
+
+/*
+予約設定をレスポンスに変換
+*/
+func convertDogrunBookingSettingRes(setting model.DogrunBookingSetting) dto.DogrunBookingSettingRes {
+	return dto.DogrunBookingSettingRes{
+		DogrunID:           setting.DogrunID.Int64,
+		SlotMinutes:        setting.SlotMinutes.Int64,
+		SlotCapacity:       setting.SlotCapacity.Int64,
+		AllowPrivateRental: setting.AllowPrivateRental.Bool,
+	}
+}
+
+/*
+予約枠をレスポンスに変換
+*/
+func convertDogrunBookingSlotRes(slot model.DogrunBookingSlot) dto.DogrunBookingSlotRes {
+	return dto.DogrunBookingSlotRes{
+		DogrunBookingSlotID: slot.DogrunBookingSlotID.Int64,
+		DogrunID:            slot.DogrunID.Int64,
+		StartAt:             slot.StartAt.Time,
+		EndAt:               slot.EndAt.Time,
+		Capacity:            slot.Capacity.Int64,
+		ReservedCount:       slot.ReservedCount.Int64,
+		Remaining:           slot.Remaining(),
+		IsPrivate:           slot.IsPrivate.Bool,
+	}
+}
+
+/*
+予約枠の一覧をレスポンスに変換
+*/
+func convertDogrunBookingSlotsRes(slots []model.DogrunBookingSlot) []dto.DogrunBookingSlotRes {
+	slotsRes := []dto.DogrunBookingSlotRes{}
+	for _, slot := range slots {
+		slotsRes = append(slotsRes, convertDogrunBookingSlotRes(slot))
+	}
+	return slotsRes
+}
+
+/*
+予約をレスポンスに変換
+*/
+func convertDogrunReservationRes(reservation model.DogrunReservation) dto.DogrunReservationRes {
+	dogsRes := []dto.DogrunReservationDogRes{}
+	for _, reservationDog := range reservation.DogrunReservationDogs {
+		dogsRes = append(dogsRes, dto.DogrunReservationDogRes{
+			DogID:     reservationDog.DogID.Int64,
+			Name:      reservationDog.Dog.Name.String,
+			SizeClass: reservationDog.Dog.SizeClass(),
+		})
+	}
+
+	res := dto.DogrunReservationRes{
+		DogrunReservationID: reservation.DogrunReservationID.Int64,
+		DogrunID:            reservation.DogrunID.Int64,
+		Slot:                convertDogrunBookingSlotRes(reservation.DogrunBookingSlot),
+		ReservationType:     reservation.ReservationType.String,
+		Status:              reservation.Status.String,
+		Dogs:                dogsRes,
+		CreateAt:            reservation.CreateAt.Time,
+	}
+	if reservation.CancelledAt.Valid {
+		res.CancelledAt = &reservation.CancelledAt.Time
+	}
+	if reservation.NoShowAt.Valid {
+		res.NoShowAt = &reservation.NoShowAt.Time
+	}
+	return res
+}
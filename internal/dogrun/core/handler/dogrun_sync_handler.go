@@ -0,0 +1,380 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/googleplace"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/transaction"
+	"github.com/wanrun-develop/wanrun/internal/wrcontext"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"github.com/wanrun-develop/wanrun/pkg/util"
+	"gorm.io/gorm"
+)
+
+type IDogrunSyncHandler interface {
+	SyncPlaces(echo.Context) (dto.PlaceSyncRes, error)
+	StartSyncWorker(context.Context, time.Duration)
+}
+
+type dogrunSyncHandler struct {
+	rest      googleplace.IRest
+	drr       repository.IDogrunRepository
+	psr       repository.IDogrunPlaceSyncScopeRepository
+	tm        transaction.ITransactionManager
+	batchSize int
+}
+
+func NewDogrunSyncHandler(
+	rest googleplace.IRest,
+	drr repository.IDogrunRepository,
+	psr repository.IDogrunPlaceSyncScopeRepository,
+	tm transaction.ITransactionManager,
+	batchSize int,
+) IDogrunSyncHandler {
+	return &dogrunSyncHandler{
+		rest:      rest,
+		drr:       drr,
+		psr:       psr,
+		tm:        tm,
+		batchSize: batchSize,
+	}
+}
+
+/*
+同期で比較する項目
+*/
+type placeSyncField struct {
+	name   string // dogrunsのカラム名
+	before string // 変更前の値(履歴用)
+	after  string // 変更後の値(履歴用)
+	value  any    // 更新する値
+}
+
+/*
+DBに保存する営業時間
+openNowは取得時点の値のため保存しない
+*/
+type syncOpeningHours struct {
+	Periods             []googleplace.OpeningHoursPeriod `json:"periods"`
+	WeekdayDescriptions []string                         `json:"weekdayDescriptions"`
+}
+
+// SyncPlaces: placeIdのあるドッグランのgoogle place情報をDBに同期する
+// 最終同期日時の古い順にバッチサイズ分を同期し、変更された項目ごとに履歴を登録する
+// 名前・住所・座標は、マージの方針でgoogleの値を優先する項目のみ更新する(マネージャーの編集を優先する)
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - dto.PlaceSyncRes:	同期結果
+//   - error:	エラー
+func (h *dogrunSyncHandler) SyncPlaces(c echo.Context) (dto.PlaceSyncRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	// 複数プロセス(定期同期とAPI、複数台構成)での同時実行はDBのロックで防ぐ
+	var syncRes dto.PlaceSyncRes
+	locked, err := h.drr.RunWithPlaceSyncLock(c, func() error {
+		var err error
+		syncRes, err = h.syncPlaces(c)
+		return err
+	})
+	if err != nil {
+		return dto.PlaceSyncRes{}, err
+	}
+	if !locked {
+		err := errors.NewWRError(nil, "google place情報の同期が実行中です", errors.NewDogrunClientErrorEType())
+		logger.Warn(err)
+		return dto.PlaceSyncRes{}, err
+	}
+
+	logger.Infof("Place sync finished. scanned: %d, synced: %d, changed: %d, failed: %d, closed: %d",
+		syncRes.Scanned, syncRes.SyncedCount, syncRes.ChangedCount, syncRes.FailedCount, len(syncRes.Closed))
+
+	return syncRes, nil
+}
+
+/*
+バッチサイズ分のドッグランの同期
+停止(コンテキストのキャンセル)時は、同期中のドッグランまでで終了する
+*/
+func (h *dogrunSyncHandler) syncPlaces(c echo.Context) (dto.PlaceSyncRes, error) {
+	dogruns, err := h.drr.FindDogrunsToSync(c, h.batchSize)
+	if err != nil {
+		return dto.PlaceSyncRes{}, err
+	}
+
+	ctx := c.Request().Context()
+	syncRes := dto.PlaceSyncRes{
+		Scanned: len(dogruns),
+		Closed:  []dto.PlaceSyncClosedRes{},
+	}
+	for _, dogrun := range dogruns {
+		if ctx.Err() != nil {
+			break
+		}
+		changed, closed, err := h.syncPlace(c, dogrun)
+		if err != nil {
+			syncRes.FailedCount++
+			continue
+		}
+		syncRes.SyncedCount++
+		if changed {
+			syncRes.ChangedCount++
+		}
+		if closed {
+			syncRes.Closed = append(syncRes.Closed, dto.PlaceSyncClosedRes{
+				DogrunID: dogrun.DogrunID.Int64,
+				PlaceId:  dogrun.PlaceId.String,
+				Name:     dogrun.Name.String,
+			})
+		}
+	}
+	return syncRes, nil
+}
+
+// StartSyncWorker: google place情報の定期同期を実行する
+// ctxがキャンセルされるまで処理を返さないため、呼び出し側でgoroutineとして起動する
+// 間隔が0以下の場合は何もしない
+//
+// args:
+//   - context.Context:	停止時にキャンセルされるコンテキスト
+//   - time.Duration:	同期の間隔
+func (h *dogrunSyncHandler) StartSyncWorker(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.runScheduledSync(ctx)
+		}
+	}
+}
+
+/*
+定期同期の1回分の実行
+panicしても定期同期は継続するため、ここで回復してログに残す
+*/
+func (h *dogrunSyncHandler) runScheduledSync(ctx context.Context) {
+	c := wrcontext.NewJobContext(ctx, fmt.Sprintf("place-sync-%d", time.Now().Unix()))
+	logger := log.GetLogger(c).Sugar()
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf("google place情報の定期同期でpanicが発生: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	if _, err := h.SyncPlaces(c); err != nil {
+		logger.Warnf("google place情報の定期同期に失敗: %v", err)
+	}
+}
+
+/*
+1件のドッグランの同期
+変更があったか、今回閉業と判定されたかを返す
+*/
+func (h *dogrunSyncHandler) syncPlace(c echo.Context, dogrun model.Dogrun) (bool, bool, error) {
+	logger := log.GetLogger(c).Sugar()
+	dogrunID := dogrun.DogrunID.Int64
+	now := time.Now()
+
+	dogrunG, err := h.fetchPlace(c, dogrun.PlaceId.String)
+	if err != nil {
+		// 取得できないplaceIdで毎回バッチが埋まらないよう、同期日時は更新して後回しにする
+		logger.Warnf("ドッグラン:%d のgoogle place情報の取得に失敗", dogrunID)
+		if err := h.drr.UpdateDogrunInfo(c, dogrunID, map[string]any{"place_synced_at": now}, nil); err != nil {
+			logger.Warnf("ドッグラン:%d の同期日時の更新に失敗", dogrunID)
+		}
+		return false, false, err
+	}
+
+	fields, syncedFields := comparePlaceFields(dogrun, dogrunG, now)
+	updates := map[string]any{"place_synced_at": now}
+	histories := []model.DogrunPlaceSyncHistory{}
+	for _, field := range fields {
+		if field.before == field.after {
+			continue
+		}
+		updates[field.name] = field.value
+		histories = append(histories, model.DogrunPlaceSyncHistory{
+			DogrunID:    util.NewSqlNullInt64(dogrunID),
+			PlaceId:     dogrun.PlaceId,
+			FieldName:   util.NewSqlNullString(field.name),
+			BeforeValue: util.NewSqlNullString(field.before),
+			AfterValue:  util.NewSqlNullString(field.after),
+		})
+	}
+
+	ctx := c.Request().Context()
+	if err := h.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+		if err := h.psr.UpdateDogrunPlaceInfo(tx, c, dogrunID, updates); err != nil {
+			return err
+		}
+		if err := h.psr.CreatePlaceSyncHistories(tx, c, histories); err != nil {
+			return err
+		}
+		// googleの値を使用している項目は、変更がなくても最終確認日時を更新する
+		return h.psr.SaveFieldProvenances(tx, c, newFieldProvenances(dogrunID, model.FIELD_SOURCE_GOOGLE, now, syncedFields...))
+	}); err != nil {
+		return false, false, err
+	}
+
+	closed := !dogrun.IsClosed() && dogrunG.BusinessStatus == googleplace.BUSINESS_STATUS_CLOSED_PERMANENTLY
+	if closed {
+		logger.Infof("ドッグラン:%d (placeId:%s) がgoogleで閉業と報告されました", dogrunID, dogrun.PlaceId.String)
+	}
+	return len(histories) > 0, closed, nil
+}
+
+/*
+同期用の項目のみでgoogle place情報を取得する
+*/
+func (h *dogrunSyncHandler) fetchPlace(c echo.Context, placeID string) (googleplace.BaseResource, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	resG, err := h.rest.GETPlaceInfo(c, placeID, googleplace.SyncField{})
+	if err != nil {
+		return googleplace.BaseResource{}, err
+	}
+
+	var dogrunG googleplace.BaseResource
+	if err := json.Unmarshal(resG, &dogrunG); err != nil {
+		err := errors.NewWRError(err, "google apiレスポンスの変換に失敗しました。", errors.NewDogrunServerErrorEType())
+		logger.Error(err)
+		return googleplace.BaseResource{}, err
+	}
+	if dogrunG.IsEmpty() {
+		err := errors.NewWRError(nil, "google place情報が取得できませんでした。", errors.NewDogrunServerErrorEType())
+		logger.Error(err)
+		return googleplace.BaseResource{}, err
+	}
+	return dogrunG, nil
+}
+
+/*
+DBの値とgoogle place情報を項目ごとに並べる
+名前・住所・座標は、googleに値があり、マージの方針でgoogleの値を優先する場合のみ対象とする
+対象とした項目の出所の記録用の名前も返す
+*/
+func comparePlaceFields(dogrunD model.Dogrun, dogrunG googleplace.BaseResource, now time.Time) ([]placeSyncField, []string) {
+	fields := []placeSyncField{}
+	syncedFields := []string{}
+
+	if name := dogrunG.DisplayName.Text; name != "" &&
+		prefersGoogle(dogrunD, model.PROVENANCE_FIELD_NAME, dogrunD.Name.String != "", now) {
+		fields = append(fields, placeSyncField{"name", dogrunD.Name.String, name, util.NewSqlNullString(name)})
+		syncedFields = append(syncedFields, model.PROVENANCE_FIELD_NAME)
+	}
+	if address := dogrunG.ShortFormattedAddress; address != "" &&
+		prefersGoogle(dogrunD, model.PROVENANCE_FIELD_ADDRESS, dogrunD.Address.String != "", now) {
+		fields = append(fields, placeSyncField{"address", dogrunD.Address.String, address, util.NewSqlNullString(address)})
+		syncedFields = append(syncedFields, model.PROVENANCE_FIELD_ADDRESS)
+	}
+	if postCode := placePostCode(dogrunG); postCode != "" &&
+		prefersGoogle(dogrunD, model.PROVENANCE_FIELD_POSTCODE, dogrunD.PostCode.String != "", now) {
+		fields = append(fields, placeSyncField{"postcode", dogrunD.PostCode.String, postCode, util.NewSqlNullString(postCode)})
+		syncedFields = append(syncedFields, model.PROVENANCE_FIELD_POSTCODE)
+	}
+	if dogrunG.Location.Latitude != 0 && dogrunG.Location.Longitude != 0 &&
+		prefersGoogle(dogrunD, model.PROVENANCE_FIELD_LOCATION, dogrunD.Latitude.Float64 != 0 && dogrunD.Longitude.Float64 != 0, now) {
+		fields = append(fields,
+			placeSyncField{"latitude", formatCoordinate(dogrunD.Latitude), formatCoordinate(util.NewSqlNullFloat64(dogrunG.Location.Latitude)), util.NewSqlNullFloat64(dogrunG.Location.Latitude)},
+			placeSyncField{"longitude", formatCoordinate(dogrunD.Longitude), formatCoordinate(util.NewSqlNullFloat64(dogrunG.Location.Longitude)), util.NewSqlNullFloat64(dogrunG.Location.Longitude)},
+		)
+		syncedFields = append(syncedFields, model.PROVENANCE_FIELD_LOCATION)
+	}
+
+	isClosed := dogrunG.BusinessStatus == googleplace.BUSINESS_STATUS_CLOSED_PERMANENTLY
+	rating := util.NewSqlNullFloat64(math.Round(float64(dogrunG.Rating)*10) / 10)
+	ratingCount := util.NewSqlNullInt64(int64(dogrunG.UserRatingCount))
+	openingHours := formatOpeningHours(dogrunG.OpeningHours)
+
+	fields = append(fields,
+		placeSyncField{"business_status", dogrunD.BusinessStatus.String, dogrunG.BusinessStatus, util.NewSqlNullString(dogrunG.BusinessStatus)},
+		placeSyncField{"is_closed_permanently", strconv.FormatBool(dogrunD.IsClosed()), strconv.FormatBool(isClosed), isClosed},
+		placeSyncField{"google_rating", formatRating(dogrunD.GoogleRating), formatRating(rating), rating},
+		placeSyncField{"google_user_rating_count", formatCount(dogrunD.GoogleUserRatingCount), formatCount(ratingCount), ratingCount},
+		placeSyncField{"google_opening_hours", dogrunD.GoogleOpeningHours.String, openingHours, util.NewSqlNullString(openingHours)},
+	)
+	return fields, syncedFields
+}
+
+/*
+google place情報の住所から郵便番号を取得する
+*/
+func placePostCode(dogrunG googleplace.BaseResource) string {
+	for _, v := range dogrunG.AddressComponents {
+		if slices.Contains(v.Types, googleplace.ADDRESSCOMPONENT_TYPES_POSTAL_CODE) {
+			return v.LongText
+		}
+	}
+	return ""
+}
+
+/*
+営業時間をDBに保存するJSONにする。営業時間がない場合は空文字
+*/
+func formatOpeningHours(openingHours googleplace.OpeningHours) string {
+	if openingHours.IsEmpty() {
+		return ""
+	}
+	b, err := json.Marshal(syncOpeningHours{
+		Periods:             openingHours.Periods,
+		WeekdayDescriptions: openingHours.WeekdayDescriptions,
+	})
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+/*
+座標を比較用の文字列にする(googleの精度の小数点以下7桁)
+*/
+func formatCoordinate(v sql.NullFloat64) string {
+	if !v.Valid {
+		return ""
+	}
+	return strconv.FormatFloat(v.Float64, 'f', 7, 64)
+}
+
+/*
+評価を比較用の文字列にする(DBの精度の小数点以下1桁)
+*/
+func formatRating(v sql.NullFloat64) string {
+	if !v.Valid {
+		return ""
+	}
+	return strconv.FormatFloat(v.Float64, 'f', 1, 64)
+}
+
+/*
+評価件数を比較用の文字列にする
+*/
+func formatCount(v sql.NullInt64) string {
+	if !v.Valid {
+		return ""
+	}
+	return strconv.FormatInt(v.Int64, 10)
+}
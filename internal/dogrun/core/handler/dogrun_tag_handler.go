@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+)
+
+type IDogrunTagHandler interface {
+	GetDogrunTags(echo.Context, int64) ([]dto.TagMstRes, error)
+	SaveDogrunTags(echo.Context, int64, dto.DogrunTagReq) ([]dto.TagMstRes, error)
+	GetTagFacets(echo.Context, dto.SearchAroundRectangleCondition) ([]dto.TagFacetRes, error)
+}
+
+type dogrunTagHandler struct {
+	drr repository.IDogrunRepository
+}
+
+func NewDogrunTagHandler(drr repository.IDogrunRepository) IDogrunTagHandler {
+	return &dogrunTagHandler{drr}
+}
+
+// GetDogrunTags: ドッグランに設定されているタグの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//
+// return:
+//   - []dto.TagMstRes:	タグID順のタグ
+//   - error:	エラー
+func (h *dogrunTagHandler) GetDogrunTags(c echo.Context, dogrunID int64) ([]dto.TagMstRes, error) {
+	dogrunTags, err := h.drr.FindDogrunTags(c, dogrunID)
+	if err != nil {
+		return nil, err
+	}
+	return convertDogrunTagRes(dogrunTags), nil
+}
+
+// SaveDogrunTags: ドッグランに設定するタグの置き換え
+// 管理対象のドッグランのみ更新可能
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	dogrunID
+//   - dto.DogrunTagReq:	設定するタグ
+//
+// return:
+//   - []dto.TagMstRes:	更新後のタグ
+//   - error:	エラー
+func (h *dogrunTagHandler) SaveDogrunTags(c echo.Context, dogrunID int64, req dto.DogrunTagReq) ([]dto.TagMstRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := checkManagedDogrun(c, h.drr, dogrunID); err != nil {
+		return nil, err
+	}
+
+	//存在しないタグは設定できない
+	if len(req.TagIDs) > 0 {
+		count, err := h.drr.CountTagMstByIDs(c, req.TagIDs)
+		if err != nil {
+			return nil, err
+		}
+		if count != int64(len(req.TagIDs)) {
+			err := errors.NewWRError(nil, "存在しないタグが含まれています", errors.NewDogrunClientErrorEType())
+			logger.Error(err)
+			return nil, err
+		}
+	}
+
+	if err := h.drr.ReplaceDogrunTags(c, dogrunID, req.TagIDs); err != nil {
+		return nil, err
+	}
+	return h.GetDogrunTags(c, dogrunID)
+}
+
+// GetTagFacets: 検索条件に一致するドッグランのタグごとの件数の取得
+// 検索範囲内のDBに登録されているドッグランが対象
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - dto.SearchAroundRectangleCondition:	条件
+//
+// return:
+//   - []dto.TagFacetRes:	タグID順のタグごとの件数
+//   - error:	エラー
+func (h *dogrunTagHandler) GetTagFacets(c echo.Context, condition dto.SearchAroundRectangleCondition) ([]dto.TagFacetRes, error) {
+	logger := log.GetLogger(c).Sugar()
+	logger.Debugw("検索条件", "condition", condition)
+
+	return h.drr.CountDogrunTagFacets(c, condition)
+}
+
+/*
+ドッグランタグのレスポンスへの変換
+*/
+func convertDogrunTagRes(dogrunTags []model.DogrunTag) []dto.TagMstRes {
+	tagRes := []dto.TagMstRes{}
+	for _, dogrunTag := range dogrunTags {
+		tagRes = append(tagRes, convertTagMstRes(dogrunTag.TagMst))
+	}
+	return tagRes
+}
+
+/*
+タグマスターのレスポンスへの変換
+*/
+func convertTagMstRes(tagMst model.TagMst) dto.TagMstRes {
+	return dto.TagMstRes{
+		TagID:       tagMst.TagID.Int64,
+		TagName:     tagMst.TagName.String,
+		Description: tagMst.Description.String,
+		Category:    tagMst.Category.String,
+	}
+}
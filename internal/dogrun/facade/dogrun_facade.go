@@ -5,20 +5,31 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/wanrun-develop/wanrun/internal/dogrun/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
+	"github.com/wanrun-develop/wanrun/internal/dogrun/core/handler"
+	model "github.com/wanrun-develop/wanrun/internal/models"
 	"github.com/wanrun-develop/wanrun/pkg/errors"
 	"github.com/wanrun-develop/wanrun/pkg/log"
 )
 
 type IDogrunFacade interface {
 	CheckDogrunExistByIDs(echo.Context, []int64) error
+	FindExistDogrunIDs(echo.Context, []int64) ([]int64, error)
+	GetDogrunListsByIDs(echo.Context, []int64) ([]dto.DogrunLists, error)
+	FindDogrunEntryCriteria(echo.Context, int64) (model.DogrunEntryCriteria, error)
+	CheckEntryPayment(echo.Context, int64, int64, int64) error
+	CheckEntryMembership(echo.Context, int64, string, []int64) error
 }
 
 type dogrunFacade struct {
 	drr repository.IDogrunRepository
+	drh handler.IDogrunHandler
+	dph handler.IDogrunPaymentHandler
+	dmh handler.IDogrunMembershipHandler
 }
 
-func NewDogrunFacade(drr repository.IDogrunRepository) IDogrunFacade {
-	return &dogrunFacade{drr}
+func NewDogrunFacade(drr repository.IDogrunRepository, drh handler.IDogrunHandler, dph handler.IDogrunPaymentHandler, dmh handler.IDogrunMembershipHandler) IDogrunFacade {
+	return &dogrunFacade{drr, drh, dph, dmh}
 }
 
 // CheckDogrunExistByIds: ドッグランの存在チェック
@@ -56,3 +67,78 @@ func (h *dogrunFacade) CheckDogrunExistByIDs(c echo.Context, dogrunIDs []int64)
 	}
 	return nil
 }
+
+// FindExistDogrunIDs: 指定のドッグランIDのうち、存在するものを返す
+// args:
+//   - echo.Context:	コンテキスト
+//   - []int64:	ドッグランIDs
+//
+// return:
+//   - []int64:	存在するドッグランIDs
+//   - error:	エラー
+func (h *dogrunFacade) FindExistDogrunIDs(c echo.Context, dogrunIDs []int64) ([]int64, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrunResults, err := h.drr.FindDogrunByIDs(dogrunIDs)
+	if err != nil {
+		err = errors.NewWRError(err, "dogrun存在チェックでエラー", errors.NewDogrunServerErrorEType())
+		logger.Error(err)
+		return nil, err
+	}
+
+	existDogrunIDs := []int64{}
+	for _, dogrun := range dogrunResults {
+		existDogrunIDs = append(existDogrunIDs, dogrun.DogrunID.Int64)
+	}
+	return existDogrunIDs, nil
+}
+
+// GetDogrunListsByIDs: 指定のドッグランIDの一覧表示情報を取得
+// args:
+//   - echo.Context:	コンテキスト
+//   - []int64:	ドッグランIDs
+//
+// return:
+//   - []dto.DogrunLists:	ドッグラン一覧情報
+//   - error:	エラー
+func (h *dogrunFacade) GetDogrunListsByIDs(c echo.Context, dogrunIDs []int64) ([]dto.DogrunLists, error) {
+	return h.drh.GetDogrunListsByIDs(c, dogrunIDs)
+}
+
+// FindDogrunEntryCriteria: ドッグランの入場条件の取得
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	ドッグランID
+//
+// return:
+//   - model.DogrunEntryCriteria:	入場条件。未設定の場合は空
+//   - error:	エラー
+func (h *dogrunFacade) FindDogrunEntryCriteria(c echo.Context, dogrunID int64) (model.DogrunEntryCriteria, error) {
+	return h.drr.FindDogrunEntryCriteria(c, dogrunID)
+}
+
+// CheckEntryPayment: チェックインに必要な支払いが済んでいるかのチェック
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	ドッグランID
+//   - int64:	dogOwnerID
+//   - int64:	チェックインするdogの数
+//
+// return:
+//   - error:	支払いが済んでいない場合はエラー
+func (h *dogrunFacade) CheckEntryPayment(c echo.Context, dogrunID int64, dogOwnerID int64, dogCount int64) error {
+	return h.dph.CheckEntryPayment(c, dogrunID, dogOwnerID, dogCount)
+}
+
+// CheckEntryMembership: 会員限定のドッグランで、チェックインするdogが承認済みの会員かのチェック
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	ドッグランID
+//   - string:	会員番号
+//   - []int64:	チェックインするdogのID
+//
+// return:
+//   - error:	会員でない場合はエラー
+func (h *dogrunFacade) CheckEntryMembership(c echo.Context, dogrunID int64, membershipNumber string, dogIDs []int64) error {
+	return h.dmh.CheckEntryMembership(c, dogrunID, membershipNumber, dogIDs)
+}
@@ -7,15 +7,19 @@ import (
 	model "github.com/wanrun-develop/wanrun/internal/models"
 	"github.com/wanrun-develop/wanrun/pkg/errors"
 	"github.com/wanrun-develop/wanrun/pkg/log"
-	"github.com/wanrun-develop/wanrun/pkg/util"
 	"gorm.io/gorm"
 )
 
 type IBookmarkRepository interface {
 	GetBookmarks(echo.Context, int64) ([]model.DogrunBookmark, error)
-	AddBookmark(echo.Context, int64, int64) (int64, error)
+	GetBookmarksByFolder(echo.Context, int64, int64) ([]model.DogrunBookmark, error)
 	FindDogrunBookmark(echo.Context, int64, int64) (model.DogrunBookmark, error)
+	UpdateBookmark(echo.Context, model.DogrunBookmark) error
 	DeleteBookmark(echo.Context, []int64, int64) error
+	GetBookmarkFolders(echo.Context, int64) ([]model.BookmarkFolder, error)
+	FindBookmarkFolder(echo.Context, int64, int64) (model.BookmarkFolder, error)
+	SaveBookmarkFolder(echo.Context, model.BookmarkFolder) (model.BookmarkFolder, error)
+	DeleteBookmarkFolder(echo.Context, int64, int64) error
 }
 
 type bookmarkRepository struct {
@@ -50,29 +54,37 @@ func (r *bookmarkRepository) GetBookmarks(c echo.Context, dogownerID int64) ([]m
 	return bookmarks, nil
 }
 
-// AddBookmark: ドックランのブックマーク登録
+// GetBookmarksByFolder: dogownerのブックマークを並び順で取得
+//
+//	フォルダIDが0の場合は全フォルダを対象とする
 //
 // args:
 //   - echo.Context:	コンテキスト
-//   - int64:	ブックマーク対象のdogrunId
-//   - int64:	ブックマーク登録者のdogownerId
+//   - int64:	ドッグオーナーID
+//   - int64:	ブックマークフォルダID
 //
 // return:
-//   - int64:	発行されたdogrun_bookmark_id
+//   - []model.DogrunBookmark:	検索結果
 //   - error:	エラー
-func (r *bookmarkRepository) AddBookmark(c echo.Context, dogrunID int64, dogownerID int64) (int64, error) {
+func (r *bookmarkRepository) GetBookmarksByFolder(c echo.Context, dogownerID int64, folderID int64) ([]model.DogrunBookmark, error) {
 	logger := log.GetLogger(c).Sugar()
-	bookmark := model.DogrunBookmark{
-		DogOwnerID: util.NewSqlNullInt64(dogownerID),
-		DogrunID:   util.NewSqlNullInt64(dogrunID),
+
+	query := r.db.Where("dog_owner_id = ?", dogownerID)
+	if folderID != 0 {
+		query = query.Where("bookmark_folder_id = ?", folderID)
 	}
 
-	if err := r.db.Create(&bookmark).Error; err != nil {
+	bookmarks := []model.DogrunBookmark{}
+	if err := query.
+		Order("sort_order ASC NULLS LAST").
+		Order("saved_at DESC").
+		Find(&bookmarks).Error; err != nil {
 		logger.Error(err)
-		err := errors.NewWRError(err, "dogrun_bookmarkの登録に失敗しました。", errors.NewInteractionServerErrorEType())
-		return 0, err
+		err := errors.NewWRError(err, "dogrun_bookmarksの検索に失敗しました。", errors.NewInteractionServerErrorEType())
+		return nil, err
 	}
-	return bookmark.DogrunBookmarkID.Int64, nil
+
+	return bookmarks, nil
 }
 
 // FindDogrunBookmark: dogrunIdとdogownerIdでbookmarkへ検索
@@ -101,7 +113,32 @@ func (r *bookmarkRepository) FindDogrunBookmark(c echo.Context, dogrunID int64,
 	return bookmark, nil
 }
 
-// AddBookmark: 複数ドックランのブックマーク削除
+// UpdateBookmark: ブックマークのフォルダ・メモ・並び順の更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.DogrunBookmark:	更新対象のブックマーク
+//
+// return:
+//   - error:	エラー
+func (r *bookmarkRepository) UpdateBookmark(c echo.Context, bookmark model.DogrunBookmark) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.Model(&model.DogrunBookmark{}).
+		Where("dogrun_bookmark_id = ?", bookmark.DogrunBookmarkID).
+		Updates(map[string]interface{}{
+			"bookmark_folder_id": bookmark.BookmarkFolderID,
+			"note":               bookmark.Note,
+			"sort_order":         bookmark.SortOrder,
+		}).Error; err != nil {
+		logger.Error(err)
+		err := errors.NewWRError(err, "dogrun_bookmarkの更新に失敗しました。", errors.NewInteractionServerErrorEType())
+		return err
+	}
+	return nil
+}
+
+// DeleteBookmark: 複数ドックランのブックマーク削除
 //
 // args:
 //   - echo.Context:	コンテキスト
@@ -125,12 +162,119 @@ func (r *bookmarkRepository) DeleteBookmark(c echo.Context, dogrunIDs []int64, d
 	return nil
 }
 
+// GetBookmarkFolders: dogownerのブックマークフォルダを並び順で取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	ドッグオーナーID
+//
+// return:
+//   - []model.BookmarkFolder:	検索結果
+//   - error:	エラー
+func (r *bookmarkRepository) GetBookmarkFolders(c echo.Context, dogownerID int64) ([]model.BookmarkFolder, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	folders := []model.BookmarkFolder{}
+	if err := r.db.
+		Where("dog_owner_id = ?", dogownerID).
+		Order("sort_order ASC NULLS LAST").
+		Order("bookmark_folder_id ASC").
+		Find(&folders).Error; err != nil {
+		logger.Error(err)
+		err := errors.NewWRError(err, "bookmark_foldersの検索に失敗しました。", errors.NewInteractionServerErrorEType())
+		return nil, err
+	}
+
+	return folders, nil
+}
+
+// FindBookmarkFolder: フォルダIDとdogownerIDでブックマークフォルダを検索
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	ブックマークフォルダID
+//   - int64:	ドッグオーナーID
+//
+// return:
+//   - model.BookmarkFolder:	検索結果構造体
+//   - error:	エラー
+func (r *bookmarkRepository) FindBookmarkFolder(c echo.Context, folderID int64, dogownerID int64) (model.BookmarkFolder, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	folder := model.BookmarkFolder{}
+	if err := r.db.
+		Where("bookmark_folder_id = ?", folderID).
+		Where("dog_owner_id = ?", dogownerID).
+		Find(&folder).Error; err != nil {
+		logger.Error(err)
+		err := errors.NewWRError(err, "bookmark_folderの検索に失敗しました。", errors.NewInteractionServerErrorEType())
+		return folder, err
+	}
+
+	return folder, nil
+}
+
+// SaveBookmarkFolder: ブックマークフォルダの登録・更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - model.BookmarkFolder:	保存対象のフォルダ
+//
+// return:
+//   - model.BookmarkFolder:	保存結果
+//   - error:	エラー
+func (r *bookmarkRepository) SaveBookmarkFolder(c echo.Context, folder model.BookmarkFolder) (model.BookmarkFolder, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.Save(&folder).Error; err != nil {
+		logger.Error(err)
+		err := errors.NewWRError(err, "bookmark_folderの保存に失敗しました。", errors.NewInteractionServerErrorEType())
+		return model.BookmarkFolder{}, err
+	}
+
+	return folder, nil
+}
+
+// DeleteBookmarkFolder: ブックマークフォルダの削除
+//
+//	フォルダ内のブックマークは未分類に戻す
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	削除対象のブックマークフォルダID
+//   - int64:	ドッグオーナーID
+//
+// return:
+//   - error:	エラー
+func (r *bookmarkRepository) DeleteBookmarkFolder(c echo.Context, folderID int64, dogownerID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.DogrunBookmark{}).
+			Where("dog_owner_id = ?", dogownerID).
+			Where("bookmark_folder_id = ?", folderID).
+			Update("bookmark_folder_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.
+			Where("bookmark_folder_id = ?", folderID).
+			Where("dog_owner_id = ?", dogownerID).
+			Delete(&model.BookmarkFolder{}).Error
+	}); err != nil {
+		logger.Error(err)
+		err := errors.NewWRError(err, "bookmark_folderの削除に失敗しました。", errors.NewInteractionServerErrorEType())
+		return err
+	}
+	return nil
+}
+
 type ICheckInOutRepository interface {
 	FindTodayDogrunCheckin(echo.Context, int64, int64) (model.DogrunCheckin, error)
 	SaveDogrunCheckins(echo.Context, []model.DogrunCheckin) ([]model.DogrunCheckin, error)
 	FindTodayDogrunCheckout(echo.Context, int64, int64) (model.DogrunCheckout, error)
 	SaveDogrunCheckouts(echo.Context, []model.DogrunCheckout) ([]model.DogrunCheckout, error)
 	GetTodayCheckinsByDogownerID(echo.Context, int64) ([]model.DogrunCheckin, error)
+	GetTodayCheckinsByDogrunIDsAndDogIDs(echo.Context, []int64, []int64) ([]model.DogrunCheckin, error)
 }
 
 type checkInOutRepository struct {
@@ -247,7 +391,7 @@ func (r *checkInOutRepository) SaveDogrunCheckouts(c echo.Context, checkouts []m
 	return checkouts, nil
 }
 
-// GetCheckinsByDogownerID: dogownerIDよりその所有dogの今日分のチェックイン履歴を取得
+// GetCheckinsByDogownerID: dogownerIDよりその所有dog(共同飼い主のdogを含む)の今日分のチェックイン履歴を取得
 //
 // args:
 //   - echo.Context:	コンテキスト
@@ -263,8 +407,9 @@ func (r *checkInOutRepository) GetTodayCheckinsByDogownerID(c echo.Context, dogo
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
 	checkins := []model.DogrunCheckin{}
+	coOwnedDogIDs := r.db.Table("dog_co_owners").Select("dog_id").Where("dog_owner_id = ?", dogownerID)
 	if err := r.db.Joins("inner join dogs on dogrun_checkin.dog_id = dogs.dog_id").
-		Where("dogs.dog_owner_id = ?", dogownerID).
+		Where("dogs.dog_owner_id = ? OR dogs.dog_id IN (?)", dogownerID, coOwnedDogIDs).
 		Where("checkin_at >= ? AND checkin_at < ?", startOfDay, endOfDay).
 		Find(&checkins).Error; err != nil {
 
@@ -274,3 +419,37 @@ func (r *checkInOutRepository) GetTodayCheckinsByDogownerID(c echo.Context, dogo
 	}
 	return checkins, nil
 }
+
+// GetTodayCheckinsByDogrunIDsAndDogIDs: 指定のドッグランへの、指定のdogの今日分のチェックイン履歴を取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - []int64:	検索対象のdogrunIDs
+//   - []int64:	検索対象のdogIDs
+//
+// return:
+//   - []model.DogrunCheckin:	チェックイン履歴
+//   - error:	エラー
+func (r *checkInOutRepository) GetTodayCheckinsByDogrunIDsAndDogIDs(c echo.Context, dogrunIDs []int64, dogIDs []int64) ([]model.DogrunCheckin, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	checkins := []model.DogrunCheckin{}
+	if len(dogrunIDs) == 0 || len(dogIDs) == 0 {
+		return checkins, nil
+	}
+
+	startOfDay := time.Now().Truncate(24 * time.Hour)
+	endOfDay := startOfDay.Add(24 * time.Hour)
+
+	if err := r.db.
+		Where("dogrun_id IN ?", dogrunIDs).
+		Where("dog_id IN ?", dogIDs).
+		Where("checkin_at >= ? AND checkin_at < ?", startOfDay, endOfDay).
+		Order("checkin_at").
+		Find(&checkins).Error; err != nil {
+		logger.Error(err)
+		err := errors.NewWRError(err, "dogrun_checkinの検索に失敗しました。", errors.NewInteractionServerErrorEType())
+		return nil, err
+	}
+	return checkins, nil
+}
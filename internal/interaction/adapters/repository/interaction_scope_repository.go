@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IBookmarkScopeRepository interface {
+	FindDogrunBookmarksByDogrunIDs(tx *gorm.DB, c echo.Context, dogrunIDs []int64, dogownerID int64) ([]model.DogrunBookmark, error)
+	UpsertBookmarks(tx *gorm.DB, c echo.Context, bookmarks []model.DogrunBookmark) error
+}
+
+type bookmarkScopeRepository struct {
+}
+
+func NewBookmarkScopeRepository() IBookmarkScopeRepository {
+	return &bookmarkScopeRepository{}
+}
+
+// FindDogrunBookmarksByDogrunIDs: 複数dogrunIDとdogownerIDでbookmarkへ検索
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - []int64: dogrunIDsで条件指定
+//   - int64: dogownerIDで条件指定
+//
+// return:
+//   - []model.DogrunBookmark: 検索結果
+//   - error: error情報
+func (bsr *bookmarkScopeRepository) FindDogrunBookmarksByDogrunIDs(
+	tx *gorm.DB,
+	c echo.Context,
+	dogrunIDs []int64,
+	dogownerID int64,
+) ([]model.DogrunBookmark, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	bookmarks := []model.DogrunBookmark{}
+	if err := tx.
+		Where("dogrun_id IN ?", dogrunIDs).
+		Where("dog_owner_id = ?", dogownerID).
+		Find(&bookmarks).Error; err != nil {
+		logger.Error("Failed to find DogrunBookmarks: ", err)
+		return nil, wrErrors.NewWRError(
+			err,
+			"dogrun_bookmarkの検索に失敗しました。",
+			wrErrors.NewInteractionServerErrorEType(),
+		)
+	}
+
+	return bookmarks, nil
+}
+
+// UpsertBookmarks: ブックマークの一括登録
+// (dog_owner_id, dogrun_id)が既に存在する場合は何もしない
+// スキップされた行はRETURNINGで返らず、IDの対応がずれるため、登録結果は再検索で確認すること
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: コンテキスト
+//   - []model.DogrunBookmark: 登録対象のブックマーク
+//
+// return:
+//   - error: error情報
+func (bsr *bookmarkScopeRepository) UpsertBookmarks(
+	tx *gorm.DB,
+	c echo.Context,
+	bookmarks []model.DogrunBookmark,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if len(bookmarks) == 0 {
+		return nil
+	}
+
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "dog_owner_id"}, {Name: "dogrun_id"}},
+		DoNothing: true,
+	}).Create(&bookmarks).Error; err != nil {
+		logger.Error("Failed to upsert DogrunBookmarks: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"dogrun_bookmarkの登録に失敗しました。",
+			wrErrors.NewInteractionServerErrorEType(),
+		)
+	}
+
+	logger.Infof("Upserted DogrunBookmarks count: %d", len(bookmarks))
+
+	return nil
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -13,11 +14,18 @@ import (
 )
 
 type IInteractionController interface {
+	GetBookmarkedDogruns(echo.Context) error
 	AddBookmark(echo.Context) error
+	UpdateBookmark(echo.Context) error
 	DeleteBookmarks(echo.Context) error
+	GetBookmarkFolders(echo.Context) error
+	CreateBookmarkFolder(echo.Context) error
+	UpdateBookmarkFolder(echo.Context) error
+	DeleteBookmarkFolder(echo.Context) error
 	CheckinDogrun(echo.Context) error
 	CheckoutDogrun(echo.Context) error
 	GetTodayCheckins(echo.Context) error
+	GetTodayFriendCheckins(echo.Context) error
 }
 
 type interactionController struct {
@@ -29,6 +37,35 @@ func NewInteractionController(bh handler.IBookmarkHandler, ch handler.ICheckInOu
 	return &interactionController{bh, ch}
 }
 
+// GetBookmarkedDogruns: ブックマーク済みドッグラン一覧の取得
+// クエリパラメータのfolderIdでフォルダの絞り込み
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (ic *interactionController) GetBookmarkedDogruns(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	var folderID int64
+	if folderIDStr := c.QueryParam("folderId"); folderIDStr != "" {
+		var err error
+		folderID, err = strconv.ParseInt(folderIDStr, 10, 64)
+		if err != nil || folderID <= 0 {
+			err = errors.NewWRError(err, errors.M_REQUEST_PARAM_MUST_BE_NATURAL, errors.NewInteractionClientErrorEType())
+			logger.Error(err)
+			return err
+		}
+	}
+
+	bookmarkedDogruns, err := ic.bh.GetBookmarkedDogruns(c, folderID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, bookmarkedDogruns)
+}
+
 // AddBookmark: ブックマークの追加
 //
 // args:
@@ -57,14 +94,43 @@ func (ic *interactionController) AddBookmark(c echo.Context) error {
 	}
 
 	//本処理
-	bookmarkId, err := ic.bh.AddBookmark(c, reqBody)
+	results, err := ic.bh.AddBookmark(c, reqBody)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(http.StatusOK, map[string][]int64{
-		"dogrunBookmarkId": bookmarkId,
-	})
+	return c.JSON(http.StatusOK, results)
+}
+
+// UpdateBookmark: ブックマークのフォルダ・メモ・並び順の更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (ic *interactionController) UpdateBookmark(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	reqBody := dto.BookmarkUpdateReq{}
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "ブックマーク更新リクエストが不正です", errors.NewInteractionClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	// バリデータのインスタンス作成
+	validate := validator.New()
+	//リクエストボディのバリデーション
+	if err := validate.Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "リクエストがバリデーションに違反しています", errors.NewInteractionClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	if err := ic.bh.UpdateBookmark(c, reqBody); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
 }
 
 // DeleteBookmarks: ブックマーク削除
@@ -101,6 +167,111 @@ func (ic *interactionController) DeleteBookmarks(c echo.Context) error {
 
 }
 
+// GetBookmarkFolders: ブックマークフォルダ一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (ic *interactionController) GetBookmarkFolders(c echo.Context) error {
+	folders, err := ic.bh.GetBookmarkFolders(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, folders)
+}
+
+// CreateBookmarkFolder: ブックマークフォルダの作成
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (ic *interactionController) CreateBookmarkFolder(c echo.Context) error {
+	return ic.saveBookmarkFolder(c, common.VCreatePrimaryKey, http.StatusCreated)
+}
+
+// UpdateBookmarkFolder: ブックマークフォルダの更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (ic *interactionController) UpdateBookmarkFolder(c echo.Context) error {
+	return ic.saveBookmarkFolder(c, common.VUpdatePrimaryKey, http.StatusOK)
+}
+
+// saveBookmarkFolder: ブックマークフォルダの作成・更新の共通処理
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - validator.Func:	PKのバリデーション
+//   - int:	正常時のステータスコード
+//
+// return:
+//   - error:	エラー
+func (ic *interactionController) saveBookmarkFolder(c echo.Context, vPrimaryKey validator.Func, status int) error {
+	logger := log.GetLogger(c).Sugar()
+
+	reqBody := dto.BookmarkFolderSaveReq{}
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "ブックマークフォルダのリクエストが不正です", errors.NewInteractionClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	// バリデータのインスタンス作成
+	validate := validator.New()
+	_ = validate.RegisterValidation("primaryKey", vPrimaryKey)
+	//リクエストボディのバリデーション
+	if err := validate.Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "リクエストがバリデーションに違反しています", errors.NewInteractionClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	folderID, err := ic.bh.SaveBookmarkFolder(c, reqBody)
+	if err != nil {
+		return err
+	}
+	return c.JSON(status, map[string]int64{
+		"bookmarkFolderId": folderID,
+	})
+}
+
+// DeleteBookmarkFolder: ブックマークフォルダの削除
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - error:	エラー
+func (ic *interactionController) DeleteBookmarkFolder(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	reqBody := dto.BookmarkFolderDeleteReq{}
+	if err := c.Bind(&reqBody); err != nil {
+		err = errors.NewWRError(err, "ブックマークフォルダの削除リクエストが不正です", errors.NewInteractionClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+	// バリデータのインスタンス作成
+	validate := validator.New()
+	//リクエストボディのバリデーション
+	if err := validate.Struct(reqBody); err != nil {
+		err = errors.NewWRError(err, "リクエストがバリデーションに違反しています", errors.NewInteractionClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	if err := ic.bh.DeleteBookmarkFolder(c, reqBody); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
 // CheckinDogrun: ドッグランへのチェックイン（入場記録）
 //
 // args:
@@ -179,3 +350,18 @@ func (ic *interactionController) GetTodayCheckins(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, checkins)
 }
+
+// GetTodayFriendCheckins: 所有dogと同じドッグランに今日チェックインした友達のdogの取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+// error:	エラー
+func (ic *interactionController) GetTodayFriendCheckins(c echo.Context) error {
+	friendCheckins, err := ic.ch.GetTodayFriendCheckins(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, friendCheckins)
+}
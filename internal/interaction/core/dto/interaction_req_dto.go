@@ -10,11 +10,32 @@ type BookmarkDeleteReq struct {
 }
 
 type CheckinReq struct {
-	DogrunID int64   `json:"dogrun_id" validate:"required"`
-	DogIDs   []int64 `json:"dog_id" validate:"required"`
+	DogrunID         int64   `json:"dogrun_id" validate:"required"`
+	DogIDs           []int64 `json:"dog_id" validate:"required"`
+	MembershipNumber string  `json:"membership_number"` // 会員限定のドッグランの場合は必須
 }
 
 type CheckoutReq struct {
 	DogrunID int64   `json:"dogrun_id" validate:"required"`
 	DogIDs   []int64 `json:"dog_id" validate:"required"`
 }
+
+// bookmark 更新用
+type BookmarkUpdateReq struct {
+	DogrunID         int64  `json:"dogrun_id" validate:"required"`
+	BookmarkFolderID int64  `json:"bookmark_folder_id"` // 0の場合は未分類
+	Note             string `json:"note" validate:"max=1000"`
+	SortOrder        int64  `json:"sort_order" validate:"gte=0"`
+}
+
+// bookmarkフォルダ 登録/更新用
+type BookmarkFolderSaveReq struct {
+	BookmarkFolderID int64  `json:"bookmark_folder_id" validate:"primaryKey"`
+	Name             string `json:"name" validate:"required,max=64"`
+	SortOrder        int64  `json:"sort_order" validate:"gte=0"`
+}
+
+// bookmarkフォルダ 削除用
+type BookmarkFolderDeleteReq struct {
+	BookmarkFolderID int64 `json:"bookmark_folder_id" validate:"required"`
+}
@@ -1,6 +1,25 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	dogDTO "github.com/wanrun-develop/wanrun/internal/dog/core/dto"
+	dogrunDTO "github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
+)
+
+// ブックマーク一括登録の結果ステータス
+const (
+	BOOKMARK_ADD_STATUS_CREATED         = "created"
+	BOOKMARK_ADD_STATUS_ALREADY_PRESENT = "already_present"
+	BOOKMARK_ADD_STATUS_NOT_FOUND       = "not_found"
+)
+
+// ブックマーク一括登録のdogrunIDごとの結果
+type BookmarkAddRes struct {
+	DogrunID         int64  `json:"dogrun_id"`
+	DogrunBookmarkID int64  `json:"dogrun_bookmark_id,omitempty"`
+	Status           string `json:"status"`
+}
 
 type CheckinsRes struct {
 	DogID       int64     `json:"dog_id"`
@@ -8,3 +27,30 @@ type CheckinsRes struct {
 	CheckinAt   time.Time `json:"checkin_at"`
 	ReCheckinAt time.Time `json:"re_checkin_at"`
 }
+
+// 同じドッグランにチェックインしている友達
+type FriendCheckinsRes struct {
+	DogrunID      int64                `json:"dogrun_id"`
+	FriendDog     dogDTO.DogSummaryRes `json:"friend_dog"`
+	FriendOfDogID []int64              `json:"friend_of_dog_id"` // 友達になっている自分のdog
+	CheckinAt     time.Time            `json:"checkin_at"`
+	ReCheckinAt   time.Time            `json:"re_checkin_at"`
+}
+
+// ブックマーク済みドッグラン一覧
+type BookmarkedDogrunRes struct {
+	DogrunBookmarkID int64                 `json:"dogrun_bookmark_id"`
+	BookmarkFolderID int64                 `json:"bookmark_folder_id,omitempty"`
+	Note             string                `json:"note,omitempty"`
+	SortOrder        int64                 `json:"sort_order"`
+	SavedAt          time.Time             `json:"saved_at"`
+	Dogrun           dogrunDTO.DogrunLists `json:"dogrun"`
+}
+
+// ブックマークフォルダ
+type BookmarkFolderRes struct {
+	BookmarkFolderID int64  `json:"bookmark_folder_id"`
+	Name             string `json:"name"`
+	SortOrder        int64  `json:"sort_order"`
+	BookmarkCount    int    `json:"bookmark_count"`
+}
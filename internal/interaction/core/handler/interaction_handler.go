@@ -1,52 +1,135 @@
 package handler
 
 import (
+	"database/sql"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	dogDTO "github.com/wanrun-develop/wanrun/internal/dog/core/dto"
 	dogFacade "github.com/wanrun-develop/wanrun/internal/dog/facade"
+	dogrunDTO "github.com/wanrun-develop/wanrun/internal/dogrun/core/dto"
 	dogrunFacade "github.com/wanrun-develop/wanrun/internal/dogrun/facade"
 	"github.com/wanrun-develop/wanrun/internal/interaction/adapters/repository"
 	"github.com/wanrun-develop/wanrun/internal/interaction/core/dto"
 	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/transaction"
 	"github.com/wanrun-develop/wanrun/internal/wrcontext"
 	"github.com/wanrun-develop/wanrun/pkg/errors"
 	"github.com/wanrun-develop/wanrun/pkg/log"
 	"github.com/wanrun-develop/wanrun/pkg/util"
+	"gorm.io/gorm"
 )
 
 type IBookmarkHandler interface {
-	AddBookmark(echo.Context, dto.BookmarkAddReq) ([]int64, error)
+	GetBookmarkedDogruns(echo.Context, int64) ([]dto.BookmarkedDogrunRes, error)
+	AddBookmark(echo.Context, dto.BookmarkAddReq) ([]dto.BookmarkAddRes, error)
+	UpdateBookmark(echo.Context, dto.BookmarkUpdateReq) error
 	DeleteBookmark(echo.Context, dto.BookmarkDeleteReq) error
+	GetBookmarkFolders(echo.Context) ([]dto.BookmarkFolderRes, error)
+	SaveBookmarkFolder(echo.Context, dto.BookmarkFolderSaveReq) (int64, error)
+	DeleteBookmarkFolder(echo.Context, dto.BookmarkFolderDeleteReq) error
 }
 
 type bookmarkHandler struct {
 	r   repository.IBookmarkRepository
+	bsr repository.IBookmarkScopeRepository
+	tm  transaction.ITransactionManager
 	drf dogrunFacade.IDogrunFacade
 }
 
-func NewBookmarkHandler(br repository.IBookmarkRepository, drf dogrunFacade.IDogrunFacade) IBookmarkHandler {
-	return &bookmarkHandler{br, drf}
+func NewBookmarkHandler(
+	br repository.IBookmarkRepository,
+	bsr repository.IBookmarkScopeRepository,
+	tm transaction.ITransactionManager,
+	drf dogrunFacade.IDogrunFacade,
+) IBookmarkHandler {
+	return &bookmarkHandler{
+		r:   br,
+		bsr: bsr,
+		tm:  tm,
+		drf: drf,
+	}
 }
 
-// AddBookmark: ブックマークへのdogrunの追加
+// GetBookmarkedDogruns: ログインユーザーのブックマーク済みドッグラン一覧の取得
 //
 // args:
 //   - echo.Context:	コンテキスト
-//   - dto.BookmarkAddReq:	リクエストボディ
+//   - int64:	ブックマークフォルダID。0の場合は全件
 //
 // return:
-//   - int:	int
+//   - []dto.BookmarkedDogrunRes:	ブックマーク済みドッグラン一覧
 //   - error:	エラー
-func (h *bookmarkHandler) AddBookmark(c echo.Context, reqBody dto.BookmarkAddReq) ([]int64, error) {
-
+func (h *bookmarkHandler) GetBookmarkedDogruns(c echo.Context, folderID int64) ([]dto.BookmarkedDogrunRes, error) {
 	logger := log.GetLogger(c).Sugar()
-	logger.Info("dogrunのお気に入り登録. dogrunID: ", reqBody.DogrunIDs)
-	err := h.drf.CheckDogrunExistByIDs(c, reqBody.DogrunIDs)
+
+	//ログインユーザーIDの取得
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	//フォルダ指定がある場合は、ログインユーザーのフォルダかチェック
+	if folderID != 0 {
+		if _, err := h.findUserBookmarkFolder(c, folderID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	bookmarks, err := h.r.GetBookmarksByFolder(c, userID, folderID)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("ブックマーク件数:%d", len(bookmarks))
+
+	dogrunIDs := []int64{}
+	for _, bookmark := range bookmarks {
+		dogrunIDs = append(dogrunIDs, bookmark.DogrunID.Int64)
+	}
+
+	//ドッグランの一覧情報の取得
+	dogrunLists, err := h.drf.GetDogrunListsByIDs(c, dogrunIDs)
 	if err != nil {
 		return nil, err
 	}
-	logger.Info("dogrunの存在チェック済み")
+	dogrunListMap := util.ConvertSliceToMap(dogrunLists, func(d dogrunDTO.DogrunLists) int64 { return d.DogrunID })
+
+	bookmarkedDogruns := []dto.BookmarkedDogrunRes{}
+	for _, bookmark := range bookmarks {
+		dogrun, exist := dogrunListMap[bookmark.DogrunID.Int64]
+		if !exist {
+			continue
+		}
+		dogrun.IsBookmarked = true
+		bookmarkedDogruns = append(bookmarkedDogruns, dto.BookmarkedDogrunRes{
+			DogrunBookmarkID: bookmark.DogrunBookmarkID.Int64,
+			BookmarkFolderID: bookmark.BookmarkFolderID.Int64,
+			Note:             bookmark.Note.String,
+			SortOrder:        bookmark.SortOrder.Int64,
+			SavedAt:          bookmark.SavedAt.Time,
+			Dogrun:           dogrun,
+		})
+	}
+
+	return bookmarkedDogruns, nil
+}
+
+// AddBookmark: ブックマークへのdogrunの一括追加
+// 1トランザクションで登録し、登録済み・存在しないdogrunはスキップして結果を返す
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - dto.BookmarkAddReq:	リクエストボディ
+//
+// return:
+//   - []dto.BookmarkAddRes:	dogrunIDごとの登録結果
+//   - error:	エラー
+func (h *bookmarkHandler) AddBookmark(c echo.Context, reqBody dto.BookmarkAddReq) ([]dto.BookmarkAddRes, error) {
+	logger := log.GetLogger(c).Sugar()
+	logger.Info("dogrunのお気に入り登録. dogrunID: ", reqBody.DogrunIDs)
 
 	//ログインユーザーIDの取得
 	userID, err := wrcontext.GetLoginUserID(c)
@@ -54,28 +137,123 @@ func (h *bookmarkHandler) AddBookmark(c echo.Context, reqBody dto.BookmarkAddReq
 		return nil, err
 	}
 
-	bookmarkIDs := []int64{}
-
-	//ひとつずつ、すでにブックマーク済みかチェック
+	//リクエストの重複を除外
+	dogrunIDs := []int64{}
+	requested := make(map[int64]struct{})
 	for _, dogrunID := range reqBody.DogrunIDs {
-		bookmark, err := h.r.FindDogrunBookmark(c, dogrunID, userID)
-		if err != nil {
-			return nil, err
+		if _, exist := requested[dogrunID]; !exist {
+			requested[dogrunID] = struct{}{}
+			dogrunIDs = append(dogrunIDs, dogrunID)
 		}
-		if bookmark.IsNotEmpty() {
-			err = errors.NewWRError(nil, fmt.Sprintf("ドッグランID:%dはすでにブックマークに登録されています。", dogrunID), errors.NewInteractionClientErrorEType())
-			logger.Error("ブックマーク既存チェックでバリデーションエラー", err)
-			return nil, err
+	}
+
+	//dogrunの存在チェック
+	existDogrunIDs, err := h.drf.FindExistDogrunIDs(c, dogrunIDs)
+	if err != nil {
+		return nil, err
+	}
+	existDogrunIDMap := util.ConvertSliceToMap(existDogrunIDs, func(i int64) int64 { return i })
+
+	targetDogrunIDs := []int64{}
+	for _, dogrunID := range dogrunIDs {
+		if _, exist := existDogrunIDMap[dogrunID]; exist {
+			targetDogrunIDs = append(targetDogrunIDs, dogrunID)
 		}
-		//ブックマークに登録
-		bookmarkId, err := h.r.AddBookmark(c, dogrunID, userID)
-		if err != nil {
+	}
+
+	var alreadyBookmarks, savedBookmarks []model.DogrunBookmark
+	if len(targetDogrunIDs) > 0 {
+		ctx := c.Request().Context()
+		if err := h.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+			//登録前のブックマーク済みを取得
+			var wrErr error
+			alreadyBookmarks, wrErr = h.bsr.FindDogrunBookmarksByDogrunIDs(tx, c, targetDogrunIDs, userID)
+			if wrErr != nil {
+				return wrErr
+			}
+
+			bookmarks := []model.DogrunBookmark{}
+			for _, dogrunID := range targetDogrunIDs {
+				bookmarks = append(bookmarks, model.DogrunBookmark{
+					DogOwnerID: util.NewSqlNullInt64(userID),
+					DogrunID:   util.NewSqlNullInt64(dogrunID),
+				})
+			}
+			//ブックマーク済みはスキップして登録
+			if wrErr := h.bsr.UpsertBookmarks(tx, c, bookmarks); wrErr != nil {
+				return wrErr
+			}
+
+			//登録結果の取得
+			savedBookmarks, wrErr = h.bsr.FindDogrunBookmarksByDogrunIDs(tx, c, targetDogrunIDs, userID)
+			return wrErr
+		}); err != nil {
 			return nil, err
 		}
-		bookmarkIDs = append(bookmarkIDs, bookmarkId)
 	}
 
-	return bookmarkIDs, nil
+	alreadyBookmarkMap := util.ConvertSliceToMap(alreadyBookmarks, func(b model.DogrunBookmark) int64 { return b.DogrunID.Int64 })
+	savedBookmarkMap := util.ConvertSliceToMap(savedBookmarks, func(b model.DogrunBookmark) int64 { return b.DogrunID.Int64 })
+
+	results := []dto.BookmarkAddRes{}
+	for _, dogrunID := range dogrunIDs {
+		result := dto.BookmarkAddRes{DogrunID: dogrunID}
+		if _, exist := existDogrunIDMap[dogrunID]; !exist {
+			result.Status = dto.BOOKMARK_ADD_STATUS_NOT_FOUND
+		} else if bookmark, exist := alreadyBookmarkMap[dogrunID]; exist {
+			result.Status = dto.BOOKMARK_ADD_STATUS_ALREADY_PRESENT
+			result.DogrunBookmarkID = bookmark.DogrunBookmarkID.Int64
+		} else {
+			result.Status = dto.BOOKMARK_ADD_STATUS_CREATED
+			result.DogrunBookmarkID = savedBookmarkMap[dogrunID].DogrunBookmarkID.Int64
+		}
+		results = append(results, result)
+	}
+	logger.Infow("dogrunのお気に入り登録結果", "results", results)
+
+	return results, nil
+}
+
+// UpdateBookmark: ブックマークのフォルダ・メモ・並び順の更新
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - dto.BookmarkUpdateReq:	リクエストボディ
+//
+// return:
+//   - error:	エラー
+func (h *bookmarkHandler) UpdateBookmark(c echo.Context, reqBody dto.BookmarkUpdateReq) error {
+	logger := log.GetLogger(c).Sugar()
+	logger.Info("dogrunのお気に入り更新. dogrunID: ", reqBody.DogrunID)
+
+	//ログインユーザーIDの取得
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return err
+	}
+
+	bookmark, err := h.r.FindDogrunBookmark(c, reqBody.DogrunID, userID)
+	if err != nil {
+		return err
+	}
+	if bookmark.IsEmpty() {
+		err = errors.NewWRError(nil, fmt.Sprintf("ドッグランID:%dはブックマークに登録されていません。", reqBody.DogrunID), errors.NewInteractionClientErrorEType())
+		logger.Error(err)
+		return err
+	}
+
+	//フォルダ指定がある場合は、ログインユーザーのフォルダかチェック
+	if reqBody.BookmarkFolderID != 0 {
+		if _, err := h.findUserBookmarkFolder(c, reqBody.BookmarkFolderID, userID); err != nil {
+			return err
+		}
+	}
+
+	bookmark.BookmarkFolderID = util.NewSqlNullInt64(reqBody.BookmarkFolderID)
+	bookmark.Note = util.NewSqlNullString(reqBody.Note)
+	bookmark.SortOrder = sql.NullInt64{Int64: reqBody.SortOrder, Valid: true}
+
+	return h.r.UpdateBookmark(c, bookmark)
 }
 
 // DeleteBookmark: ブックマークへのdogrunの削除
@@ -104,10 +282,140 @@ func (h *bookmarkHandler) DeleteBookmark(c echo.Context, reqBody dto.BookmarkDel
 	return nil
 }
 
+// GetBookmarkFolders: ログインユーザーのブックマークフォルダ一覧の取得
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - []dto.BookmarkFolderRes:	フォルダ一覧
+//   - error:	エラー
+func (h *bookmarkHandler) GetBookmarkFolders(c echo.Context) ([]dto.BookmarkFolderRes, error) {
+	//ログインユーザーIDの取得
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	folders, err := h.r.GetBookmarkFolders(c, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	//フォルダごとのブックマーク件数
+	bookmarks, err := h.r.GetBookmarks(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	bookmarkCounts := make(map[int64]int)
+	for _, bookmark := range bookmarks {
+		if bookmark.BookmarkFolderID.Valid {
+			bookmarkCounts[bookmark.BookmarkFolderID.Int64]++
+		}
+	}
+
+	foldersRes := []dto.BookmarkFolderRes{}
+	for _, folder := range folders {
+		foldersRes = append(foldersRes, dto.BookmarkFolderRes{
+			BookmarkFolderID: folder.BookmarkFolderID.Int64,
+			Name:             folder.Name.String,
+			SortOrder:        folder.SortOrder.Int64,
+			BookmarkCount:    bookmarkCounts[folder.BookmarkFolderID.Int64],
+		})
+	}
+	return foldersRes, nil
+}
+
+// SaveBookmarkFolder: ブックマークフォルダの登録・更新
+// bookmarkFolderIDの指定がない場合は新規登録
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - dto.BookmarkFolderSaveReq:	リクエストボディ
+//
+// return:
+//   - int64:	保存したbookmarkFolderID
+//   - error:	エラー
+func (h *bookmarkHandler) SaveBookmarkFolder(c echo.Context, reqBody dto.BookmarkFolderSaveReq) (int64, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	//ログインユーザーIDの取得
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return 0, err
+	}
+
+	folder := model.BookmarkFolder{DogOwnerID: util.NewSqlNullInt64(userID)}
+	if reqBody.BookmarkFolderID != 0 {
+		if folder, err = h.findUserBookmarkFolder(c, reqBody.BookmarkFolderID, userID); err != nil {
+			return 0, err
+		}
+	}
+	folder.Name = util.NewSqlNullString(reqBody.Name)
+	folder.SortOrder = sql.NullInt64{Int64: reqBody.SortOrder, Valid: true}
+
+	folder, err = h.r.SaveBookmarkFolder(c, folder)
+	if err != nil {
+		return 0, err
+	}
+	logger.Infof("ブックマークフォルダ:%dを保存", folder.BookmarkFolderID.Int64)
+
+	return folder.BookmarkFolderID.Int64, nil
+}
+
+// DeleteBookmarkFolder: ブックマークフォルダの削除
+// フォルダ内のブックマークは削除せず、未分類にする
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - dto.BookmarkFolderDeleteReq:	リクエストボディ
+//
+// return:
+//   - error:	エラー
+func (h *bookmarkHandler) DeleteBookmarkFolder(c echo.Context, reqBody dto.BookmarkFolderDeleteReq) error {
+	//ログインユーザーIDの取得
+	userID, err := wrcontext.GetLoginUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.findUserBookmarkFolder(c, reqBody.BookmarkFolderID, userID); err != nil {
+		return err
+	}
+
+	return h.r.DeleteBookmarkFolder(c, reqBody.BookmarkFolderID, userID)
+}
+
+// findUserBookmarkFolder: ログインユーザーのブックマークフォルダを取得。存在しなければエラー
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	ブックマークフォルダID
+//   - int64:	ログインユーザーID
+//
+// return:
+//   - model.BookmarkFolder:	ブックマークフォルダ
+//   - error:	エラー
+func (h *bookmarkHandler) findUserBookmarkFolder(c echo.Context, folderID int64, userID int64) (model.BookmarkFolder, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	folder, err := h.r.FindBookmarkFolder(c, folderID, userID)
+	if err != nil {
+		return model.BookmarkFolder{}, err
+	}
+	if folder.IsEmpty() {
+		err = errors.NewWRError(nil, fmt.Sprintf("指定されたブックマークフォルダID:%dが存在しません", folderID), errors.NewInteractionClientErrorEType())
+		logger.Error(err)
+		return model.BookmarkFolder{}, err
+	}
+	return folder, nil
+}
+
 type ICheckInOutHandler interface {
 	CheckinDogrun(echo.Context, dto.CheckinReq) error
 	CheckoutDogrun(echo.Context, dto.CheckoutReq) error
 	GetTodayCheckins(c echo.Context) ([]dto.CheckinsRes, error)
+	GetTodayFriendCheckins(c echo.Context) ([]dto.FriendCheckinsRes, error)
 }
 
 type checkInOutHandler struct {
@@ -144,6 +452,25 @@ func (h checkInOutHandler) CheckinDogrun(c echo.Context, reqBody dto.CheckinReq)
 		return err
 	}
 
+	//入場条件チェック
+	if err := h.checkEntryCriteria(c, dogrunID, checkinDogIDs); err != nil {
+		return err
+	}
+
+	//支払いチェック
+	dogOwnerID, err := wrcontext.GetLoginDogownerID(c)
+	if err != nil {
+		return err
+	}
+	if err := h.drf.CheckEntryPayment(c, dogrunID, dogOwnerID, int64(len(checkinDogIDs))); err != nil {
+		return err
+	}
+
+	//会員チェック
+	if err := h.drf.CheckEntryMembership(c, dogrunID, reqBody.MembershipNumber, checkinDogIDs); err != nil {
+		return err
+	}
+
 	saveCheckins := []model.DogrunCheckin{}
 	for _, dogID := range checkinDogIDs {
 		checkinResult, err := h.r.FindTodayDogrunCheckin(c, dogrunID, dogID)
@@ -159,11 +486,45 @@ func (h checkInOutHandler) CheckinDogrun(c echo.Context, reqBody dto.CheckinReq)
 	}
 
 	//保存
-	_, err := h.r.SaveDogrunCheckins(c, saveCheckins)
+	if _, err := h.r.SaveDogrunCheckins(c, saveCheckins); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkEntryCriteria: チェックインするdogがドッグランの入場条件を満たすかのチェック
+//
+// args:
+//   - echo.Context:	コンテキスト
+//   - int64:	ドッグランID
+//   - []int64:	チェックインするdogのID
+//
+// return:
+//   - error:	エラー
+func (h checkInOutHandler) checkEntryCriteria(c echo.Context, dogrunID int64, dogIDs []int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	entryCriteria, err := h.drf.FindDogrunEntryCriteria(c, dogrunID)
 	if err != nil {
 		return err
 	}
+	if entryCriteria.IsEmpty() {
+		return nil
+	}
 
+	dogs, err := h.df.FindDogsByIDs(c, dogIDs)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, dog := range dogs {
+		if reasons := entryCriteria.UnmetReasons(dog, now); len(reasons) > 0 {
+			err := errors.NewWRError(nil, fmt.Sprintf("ドッグID:%dは入場条件を満たしていません(%s)", dog.DogID.Int64, strings.Join(reasons, "、")), errors.NewInteractionClientErrorEType())
+			logger.Error(err)
+			return err
+		}
+	}
 	return nil
 }
 
@@ -257,3 +618,66 @@ func (h checkInOutHandler) GetTodayCheckins(c echo.Context) ([]dto.CheckinsRes,
 	}
 	return checkinsRes, nil
 }
+
+// GetTodayFriendCheckins: 所有dogが今日チェックインしたドッグランに、今日チェックインした友達のdogの取得
+// チェックインを非公開にしている友達は含まない
+//
+// args:
+//   - echo.Context:	コンテキスト
+//
+// return:
+//   - []dto.FriendCheckinsRes:	友達のチェックイン
+//   - error:	エラー
+func (h checkInOutHandler) GetTodayFriendCheckins(c echo.Context) ([]dto.FriendCheckinsRes, error) {
+	dogownerID, err := wrcontext.GetLoginDogownerID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	//自分のdogが今日チェックインしたドッグラン
+	myCheckins, err := h.r.GetTodayCheckinsByDogownerID(c, dogownerID)
+	if err != nil {
+		return nil, err
+	}
+	dogrunIDs := []int64{}
+	for _, checkin := range myCheckins {
+		if !slices.Contains(dogrunIDs, checkin.DogrunID.Int64) {
+			dogrunIDs = append(dogrunIDs, checkin.DogrunID.Int64)
+		}
+	}
+
+	//チェックインを公開している友達
+	friends, err := h.df.GetCheckinVisibleFriends(c, dogownerID)
+	if err != nil {
+		return nil, err
+	}
+	friendDogs := map[int64]dogDTO.DogSummaryRes{}
+	friendOf := map[int64][]int64{}
+	friendDogIDs := []int64{}
+	for _, friend := range friends {
+		friendDogID := friend.Friend.DogID
+		if _, exists := friendDogs[friendDogID]; !exists {
+			friendDogIDs = append(friendDogIDs, friendDogID)
+		}
+		friendDogs[friendDogID] = friend.Friend
+		friendOf[friendDogID] = append(friendOf[friendDogID], friend.DogID)
+	}
+
+	friendCheckins, err := h.r.GetTodayCheckinsByDogrunIDsAndDogIDs(c, dogrunIDs, friendDogIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	friendCheckinsRes := []dto.FriendCheckinsRes{}
+	for _, checkin := range friendCheckins {
+		friendDogID := checkin.DogID.Int64
+		friendCheckinsRes = append(friendCheckinsRes, dto.FriendCheckinsRes{
+			DogrunID:      checkin.DogrunID.Int64,
+			FriendDog:     friendDogs[friendDogID],
+			FriendOfDogID: friendOf[friendDogID],
+			CheckinAt:     checkin.CheckinAt.Time,
+			ReCheckinAt:   checkin.ReCheckinAt.Time,
+		})
+	}
+	return friendCheckinsRes, nil
+}
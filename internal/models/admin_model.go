@@ -0,0 +1,58 @@
+package model
+
+import (
+	"database/sql"
+)
+
+// 運営者の操作内容。監査ログ(audit_logs)のactionとして記録する
+const (
+	ADMIN_ACTION_SUSPEND         = "suspend"         // アカウントの利用停止
+	ADMIN_ACTION_UNSUSPEND       = "unsuspend"       // アカウントの利用停止の解除
+	ADMIN_ACTION_MERGE_DOGRUN    = "merge_dogrun"    // 重複したドッグランの統合
+	ADMIN_ACTION_CREATE_MST      = "create_mst"      // マスターの登録
+	ADMIN_ACTION_UPDATE_MST      = "update_mst"      // マスターの更新
+	ADMIN_ACTION_DELETE_MST      = "delete_mst"      // マスターの削除
+	ADMIN_ACTION_MODERATE        = "moderate"        // ユーザー投稿の非表示・削除
+	ADMIN_ACTION_CREATE_OPERATOR = "create_operator" // 運営者の登録
+)
+
+// 運営者の操作対象の種別。監査ログ(audit_logs)のtarget_typeとして記録する
+const (
+	ADMIN_TARGET_DOGOWNER        = "dogowner"
+	ADMIN_TARGET_DOGRUNMG        = "dogrunmg"
+	ADMIN_TARGET_DOGRUN          = "dogrun"
+	ADMIN_TARGET_TAG_MST         = "tag_mst"
+	ADMIN_TARGET_DOG_TYPE_MST    = "dog_type_mst"
+	ADMIN_TARGET_DOGRUN_EVENT    = "dogrun_event"
+	ADMIN_TARGET_DOGRUN_IMAGE    = "dogrun_image"
+	ADMIN_TARGET_DOG_PROFILE     = "dog_profile"
+	ADMIN_TARGET_DOG_IMAGE       = "dog_image"
+	ADMIN_TARGET_DOGOWNER_IMAGE  = "dogowner_image"
+	ADMIN_TARGET_SYSTEM_OPERATOR = "system_operator"
+)
+
+type SystemOperator struct {
+	SystemOperatorID sql.NullInt64  `gorm:"primaryKey;column:system_operator_id;autoIncrement"`
+	Name             sql.NullString `gorm:"size:128;column:name;not null"`
+	Email            sql.NullString `gorm:"size:255;column:email;not null"`
+	Password         sql.NullString `gorm:"size:256;column:password;not null"`
+	JwtID            sql.NullString `gorm:"size:45;column:jwt_id"`
+	IsActive         sql.NullBool   `gorm:"column:is_active;not null;default:true"`
+	LoginAt          sql.NullTime   `gorm:"column:login_at"`
+	CreateAt         sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt         sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
+}
+
+/*
+SystemOperatorが空かの判定
+*/
+func (so *SystemOperator) IsEmpty() bool {
+	return !so.SystemOperatorID.Valid
+}
+
+/*
+SystemOperatorが有効か
+*/
+func (so *SystemOperator) IsActiveOperator() bool {
+	return so.IsActive.Valid && so.IsActive.Bool
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"database/sql"
+)
+
+// 監査ログの操作内容
+const (
+	AUDIT_ACTION_LOGIN       = "login"       // ログイン
+	AUDIT_ACTION_REVOKE      = "revoke"      // トークンの無効化
+	AUDIT_ACTION_SIGNUP      = "signup"      // アカウントの登録
+	AUDIT_ACTION_DELETE_FILE = "delete_file" // ファイルの削除
+	AUDIT_ACTION_DELETE_DOG  = "delete_dog"  // dogの削除
+)
+
+// 監査ログの操作結果
+const (
+	AUDIT_RESULT_SUCCESS = "success"
+	AUDIT_RESULT_FAILURE = "failure"
+)
+
+// 監査ログの操作対象の種別
+const (
+	AUDIT_TARGET_DOGOWNER        = "dogowner"
+	AUDIT_TARGET_DOGRUNMG        = "dogrunmg"
+	AUDIT_TARGET_SYSTEM_OPERATOR = "system_operator"
+	AUDIT_TARGET_ORG             = "org"
+	AUDIT_TARGET_DOG             = "dog"
+	AUDIT_TARGET_FILE            = "file"
+)
+
+type AuditLog struct {
+	AuditLogID sql.NullInt64  `gorm:"primaryKey;column:audit_log_id;autoIncrement"`
+	ActorID    sql.NullInt64  `gorm:"column:actor_id"`
+	ActorRole  sql.NullInt64  `gorm:"column:actor_role"`
+	Action     sql.NullString `gorm:"size:32;column:action;not null"`
+	Result     sql.NullString `gorm:"size:16;column:result;not null"`
+	TargetType sql.NullString `gorm:"size:32;column:target_type;not null"`
+	TargetID   sql.NullString `gorm:"size:256;column:target_id"`
+	RequestID  sql.NullString `gorm:"size:64;column:request_id"`
+	IPAddress  sql.NullString `gorm:"size:64;column:ip_address"`
+	BeforeData sql.NullString `gorm:"type:text;column:before_data"` // 変更前の値(JSON)
+	AfterData  sql.NullString `gorm:"type:text;column:after_data"`  // 変更後の値(JSON)
+	CreateAt   sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+}
@@ -12,14 +12,34 @@ type S3FileInfo struct {
 	S3VersionID  sql.NullString  `gorm:"size:256;column:s3_version_id"`                   // S3のバージョンID
 	FileSize     sql.NullInt64   `gorm:"column:file_size;not null"`                       // ファイルサイズ
 	S3ObjectKey  sql.NullString  `gorm:"size:256;column:s3_object_key"`                   // S3のオブジェクトキー
+	ContentType  sql.NullString  `gorm:"size:64;column:content_type"`                     // MIMEタイプ
 	CreateAt     util.CustomTime `gorm:"column:reg_at;not null;autoCreateTime"`           // 登録日時
 	UpdateAt     util.CustomTime `gorm:"column:upd_at;not null;autoCreateTime"`           // 更新日時
 
-	// DogOwnerとのリレーション
-	DogOwner   DogOwner      `gorm:"foreignKey:DogOwnerID;references:DogOwnerID"`
-	DogOwnerID sql.NullInt64 `gorm:"column:dog_owner_id;not null"` // dog_ownersのFK
+	// 所有者(dogowner, dogrunmg, org)
+	OwnerType sql.NullString `gorm:"size:16;column:owner_type;not null"` // 所有者の種別
+	OwnerID   sql.NullInt64  `gorm:"column:owner_id;not null"`           // 所有者のID
+
+	// サムネイルとのリレーション
+	Variants []S3FileVariant `gorm:"foreignKey:S3FileInfoID;references:S3FileInfoID"`
 }
 
 func (S3FileInfo) TableName() string {
 	return "s3_file_info" // 明示的にテーブル名を指定
 }
+
+type S3FileVariant struct {
+	S3FileVariantID sql.NullInt64   `gorm:"primaryKey;column:s3_file_variant_id;autoIncrement"` // PK
+	S3FileInfoID    sql.NullInt64   `gorm:"column:s3_file_info_id;not null"`                    // s3_file_infoのFK
+	Variant         sql.NullString  `gorm:"size:32;column:variant;not null"`                    // バリエーション名(small, medium, large)
+	Format          sql.NullString  `gorm:"size:16;column:format;not null"`                     // 画像形式(jpeg, webp)
+	Width           sql.NullInt64   `gorm:"column:width"`                                       // 幅(px)
+	Height          sql.NullInt64   `gorm:"column:height"`                                      // 高さ(px)
+	FileSize        sql.NullInt64   `gorm:"column:file_size;not null"`                          // ファイルサイズ
+	S3ObjectKey     sql.NullString  `gorm:"size:256;column:s3_object_key;not null"`             // S3のオブジェクトキー
+	CreateAt        util.CustomTime `gorm:"column:reg_at;not null;autoCreateTime"`              // 登録日時
+}
+
+func (S3FileVariant) TableName() string {
+	return "s3_file_variants"
+}
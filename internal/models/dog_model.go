@@ -2,21 +2,59 @@ package model
 
 import (
 	"database/sql"
+	"slices"
+	"time"
+)
+
+// 体重から判定するサイズ区分
+const (
+	DOG_SIZE_SMALL  = "small"  // 小型犬
+	DOG_SIZE_MEDIUM = "medium" // 中型犬
+	DOG_SIZE_LARGE  = "large"  // 大型犬
+
+	DOG_SIZE_MEDIUM_MIN_WEIGHT = 10 // 中型犬の最低体重(kg)
+	DOG_SIZE_LARGE_MIN_WEIGHT  = 25 // 大型犬の最低体重(kg)
+)
+
+// dogに対する権限
+const (
+	DOG_PERMISSION_OWNER    = "owner"    // 主たる飼い主
+	DOG_PERMISSION_CO_OWNER = "co_owner" // 共同飼い主
+)
+
+// 共同飼い主の招待・譲渡の種別
+const (
+	OWNERSHIP_INVITATION_TYPE_CO_OWNER = "co_owner" // 共同飼い主の招待
+	OWNERSHIP_INVITATION_TYPE_TRANSFER = "transfer" // 主たる飼い主の譲渡
+)
+
+// 共同飼い主の招待・譲渡のステータス
+const (
+	OWNERSHIP_INVITATION_STATUS_PENDING  = "pending"  // 承認待ち
+	OWNERSHIP_INVITATION_STATUS_ACCEPTED = "accepted" // 承認
+	OWNERSHIP_INVITATION_STATUS_DECLINED = "declined" // 辞退
+	OWNERSHIP_INVITATION_STATUS_CANCELED = "canceled" // 取消
 )
 
 type Dog struct {
-	DogID      sql.NullInt64  `gorm:"primaryKey;column:dog_id;autoIncrement"`
-	DogOwnerID sql.NullInt64  `gorm:"column:dog_owner_id;not null;foreignKey:DogOwnerID"`
-	Name       sql.NullString `gorm:"size:128;column:name;not null"`
-	DogTypeID  sql.NullInt64  `gorm:"column:dog_type_id"`
-	Weight     sql.NullInt64  `gorm:"column:weight"`
-	Sex        sql.NullString `gorm:"size:1;column:sex"`
-	Image      sql.NullString `gorm:"column:image"`
-	CreateAt   sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
-	UpdateAt   sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
+	DogID       sql.NullInt64  `gorm:"primaryKey;column:dog_id;autoIncrement"`
+	DogOwnerID  sql.NullInt64  `gorm:"column:dog_owner_id;not null;foreignKey:DogOwnerID"`
+	Name        sql.NullString `gorm:"size:128;column:name;not null"`
+	Weight      sql.NullInt64  `gorm:"column:weight"`
+	Sex         sql.NullString `gorm:"size:1;column:sex"`
+	Image       sql.NullString `gorm:"column:image"`
+	BirthDate   sql.NullTime   `gorm:"type:date;column:birth_date"`
+	Neutered    sql.NullBool   `gorm:"column:neutered"`
+	MicrochipID sql.NullString `gorm:"size:15;column:microchip_id"`
+	CreateAt    sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt    sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
 
 	//リレーション
-	DogOwner DogOwner `gorm:"foreignKey:DogOwnerID;references:DogOwnerID"`
+	DogOwner         DogOwner         `gorm:"foreignKey:DogOwnerID;references:DogOwnerID"`
+	DogDogTypes      []DogDogType     `gorm:"foreignKey:DogID;references:DogID"`
+	DogTemperaments  []DogTemperament `gorm:"foreignKey:DogID;references:DogID"`
+	DogCoOwners      []DogCoOwner     `gorm:"foreignKey:DogID;references:DogID"`
+	DogSocialProfile DogSocialProfile `gorm:"foreignKey:DogID;references:DogID"`
 }
 
 // dogが空かの判定
@@ -24,6 +62,132 @@ func (d *Dog) IsEmpty() bool {
 	return !d.DogID.Valid
 }
 
+// 犬種IDの一覧
+func (d *Dog) DogTypeIDs() []int64 {
+	dogTypeIDs := []int64{}
+	for _, dogDogType := range d.DogDogTypes {
+		dogTypeIDs = append(dogTypeIDs, dogDogType.DogTypeID.Int64)
+	}
+	return dogTypeIDs
+}
+
+// 性格タグIDの一覧
+func (d *Dog) TemperamentIDs() []int64 {
+	temperamentIDs := []int64{}
+	for _, dogTemperament := range d.DogTemperaments {
+		temperamentIDs = append(temperamentIDs, dogTemperament.TemperamentID.Int64)
+	}
+	return temperamentIDs
+}
+
+// 共同飼い主のdogOwnerIDの一覧
+func (d *Dog) CoOwnerIDs() []int64 {
+	coOwnerIDs := []int64{}
+	for _, dogCoOwner := range d.DogCoOwners {
+		coOwnerIDs = append(coOwnerIDs, dogCoOwner.DogOwnerID.Int64)
+	}
+	return coOwnerIDs
+}
+
+// dogOwnerのdogに対する権限。権限がない場合は空文字
+func (d *Dog) PermissionOf(dogOwnerID int64) string {
+	if d.DogOwnerID.Valid && d.DogOwnerID.Int64 == dogOwnerID {
+		return DOG_PERMISSION_OWNER
+	}
+	if slices.Contains(d.CoOwnerIDs(), dogOwnerID) {
+		return DOG_PERMISSION_CO_OWNER
+	}
+	return ""
+}
+
+// 体重から判定したサイズ区分。体重未登録の場合は空文字
+func (d *Dog) SizeClass() string {
+	if !d.Weight.Valid {
+		return ""
+	}
+	return DogSizeClassByWeight(d.Weight.Int64)
+}
+
+// 基準日時点の月齢。誕生日未登録の場合はfalse
+func (d *Dog) AgeMonths(now time.Time) (int, bool) {
+	if !d.BirthDate.Valid {
+		return 0, false
+	}
+	birth := d.BirthDate.Time
+	months := (now.Year()-birth.Year())*12 + int(now.Month()-birth.Month())
+	if now.Day() < birth.Day() {
+		months--
+	}
+	if months < 0 {
+		months = 0
+	}
+	return months, true
+}
+
+// 体重からサイズ区分を判定
+func DogSizeClassByWeight(weight int64) string {
+	switch {
+	case weight >= DOG_SIZE_LARGE_MIN_WEIGHT:
+		return DOG_SIZE_LARGE
+	case weight >= DOG_SIZE_MEDIUM_MIN_WEIGHT:
+		return DOG_SIZE_MEDIUM
+	default:
+		return DOG_SIZE_SMALL
+	}
+}
+
+type DogDogType struct {
+	DogDogTypeID sql.NullInt64 `gorm:"primaryKey;column:dog_dog_type_id;autoIncrement"`
+	DogID        sql.NullInt64 `gorm:"column:dog_id;not null"`
+	DogTypeID    sql.NullInt64 `gorm:"column:dog_type_id;not null"`
+}
+
+type DogTemperament struct {
+	DogTemperamentID sql.NullInt64 `gorm:"primaryKey;column:dog_temperament_id;autoIncrement"`
+	DogID            sql.NullInt64 `gorm:"column:dog_id;not null"`
+	TemperamentID    sql.NullInt64 `gorm:"column:temperament_id;not null"`
+}
+
+type DogCoOwner struct {
+	DogCoOwnerID sql.NullInt64 `gorm:"primaryKey;column:dog_co_owner_id;autoIncrement"`
+	DogID        sql.NullInt64 `gorm:"column:dog_id;not null"`
+	DogOwnerID   sql.NullInt64 `gorm:"column:dog_owner_id;not null"`
+	CreateAt     sql.NullTime  `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt     sql.NullTime  `gorm:"column:upd_at;not null;autoUpdateTime"`
+
+	//リレーション
+	DogOwner DogOwner `gorm:"foreignKey:DogOwnerID;references:DogOwnerID"`
+}
+
+type DogOwnershipInvitation struct {
+	DogOwnershipInvitationID sql.NullInt64  `gorm:"primaryKey;column:dog_ownership_invitation_id;autoIncrement"`
+	DogID                    sql.NullInt64  `gorm:"column:dog_id;not null"`
+	InviterID                sql.NullInt64  `gorm:"column:inviter_id;not null"`
+	InviteeID                sql.NullInt64  `gorm:"column:invitee_id;not null"`
+	InvitationType           sql.NullString `gorm:"size:16;column:invitation_type;not null"`
+	KeepAsCoOwner            sql.NullBool   `gorm:"column:keep_as_co_owner;not null"`
+	Status                   sql.NullString `gorm:"size:16;column:status;not null"`
+	ExpiresAt                sql.NullTime   `gorm:"column:expires_at;not null"`
+	RespondedAt              sql.NullTime   `gorm:"column:responded_at"`
+	CreateAt                 sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt                 sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
+
+	//リレーション
+	Dog     Dog      `gorm:"foreignKey:DogID;references:DogID"`
+	Inviter DogOwner `gorm:"foreignKey:InviterID;references:DogOwnerID"`
+	Invitee DogOwner `gorm:"foreignKey:InviteeID;references:DogOwnerID"`
+}
+
+// 招待が空かの判定
+func (i *DogOwnershipInvitation) IsEmpty() bool {
+	return !i.DogOwnershipInvitationID.Valid
+}
+
+// 承認待ちかつ期限内かの判定
+func (i *DogOwnershipInvitation) IsPending(now time.Time) bool {
+	return i.Status.String == OWNERSHIP_INVITATION_STATUS_PENDING && now.Before(i.ExpiresAt.Time)
+}
+
 type DogTypeMst struct {
 	DogTypeID int    `gorm:"primaryKey;column:dog_type_id"`
 	Name      string `gorm:"column:name;not null"`
@@ -33,3 +197,14 @@ type DogTypeMst struct {
 func (DogTypeMst) TableName() string {
 	return "dog_type_mst"
 }
+
+type TemperamentMst struct {
+	TemperamentID int            `gorm:"primaryKey;column:temperament_id"`
+	Name          string         `gorm:"column:name;not null"`
+	Description   sql.NullString `gorm:"type:text;column:description"`
+}
+
+// GORMにテーブル名を指定
+func (TemperamentMst) TableName() string {
+	return "temperament_mst"
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"database/sql"
+)
+
+// プロフィール、チェックインの公開範囲
+const (
+	DOG_VISIBILITY_PUBLIC  = "public"  // 全体に公開
+	DOG_VISIBILITY_FRIENDS = "friends" // 友達のみ公開
+	DOG_VISIBILITY_PRIVATE = "private" // 非公開
+)
+
+// 友達申請のステータス
+const (
+	DOG_FRIENDSHIP_STATUS_PENDING  = "pending"  // 承認待ち
+	DOG_FRIENDSHIP_STATUS_ACCEPTED = "accepted" // 友達
+	DOG_FRIENDSHIP_STATUS_DECLINED = "declined" // 辞退
+)
+
+type DogSocialProfile struct {
+	DogID               sql.NullInt64  `gorm:"primaryKey;column:dog_id"`
+	Bio                 sql.NullString `gorm:"size:500;column:bio"`
+	ProfileVisibility   sql.NullString `gorm:"size:16;column:profile_visibility;not null"`
+	CheckinVisibility   sql.NullString `gorm:"size:16;column:checkin_visibility;not null"`
+	AllowFriendRequests sql.NullBool   `gorm:"column:allow_friend_requests;not null"`
+	CreateAt            sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt            sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
+}
+
+// 公開設定が空(未登録)かの判定
+func (p *DogSocialProfile) IsEmpty() bool {
+	return !p.DogID.Valid
+}
+
+// プロフィールの公開範囲。未登録の場合は非公開
+func (p *DogSocialProfile) ProfileVisibilityOrDefault() string {
+	if p.IsEmpty() || !p.ProfileVisibility.Valid {
+		return DOG_VISIBILITY_PRIVATE
+	}
+	return p.ProfileVisibility.String
+}
+
+// チェックインの公開範囲。未登録の場合は友達のみ
+func (p *DogSocialProfile) CheckinVisibilityOrDefault() string {
+	if p.IsEmpty() || !p.CheckinVisibility.Valid {
+		return DOG_VISIBILITY_FRIENDS
+	}
+	return p.CheckinVisibility.String
+}
+
+// 友達申請を受け付けるか。公開プロフィールのdogのみ受け付ける
+func (p *DogSocialProfile) AcceptsFriendRequests() bool {
+	return p.ProfileVisibilityOrDefault() == DOG_VISIBILITY_PUBLIC && p.AllowFriendRequests.Bool
+}
+
+type DogFriendship struct {
+	DogFriendshipID sql.NullInt64  `gorm:"primaryKey;column:dog_friendship_id;autoIncrement"`
+	RequesterDogID  sql.NullInt64  `gorm:"column:requester_dog_id;not null"`
+	AddresseeDogID  sql.NullInt64  `gorm:"column:addressee_dog_id;not null"`
+	Status          sql.NullString `gorm:"size:16;column:status;not null"`
+	RespondedAt     sql.NullTime   `gorm:"column:responded_at"`
+	CreateAt        sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt        sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
+
+	//リレーション
+	RequesterDog Dog `gorm:"foreignKey:RequesterDogID;references:DogID"`
+	AddresseeDog Dog `gorm:"foreignKey:AddresseeDogID;references:DogID"`
+}
+
+// 友達申請が空かの判定
+func (f *DogFriendship) IsEmpty() bool {
+	return !f.DogFriendshipID.Valid
+}
+
+// 友達になっているかの判定
+func (f *DogFriendship) IsAccepted() bool {
+	return f.Status.String == DOG_FRIENDSHIP_STATUS_ACCEPTED
+}
+
+// 相手側のdog。dogIDがどちらでもない場合は空
+func (f *DogFriendship) OtherDog(dogID int64) Dog {
+	switch dogID {
+	case f.RequesterDogID.Int64:
+		return f.AddresseeDog
+	case f.AddresseeDogID.Int64:
+		return f.RequesterDog
+	}
+	return Dog{}
+}
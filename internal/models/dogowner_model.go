@@ -13,9 +13,18 @@ type DogOwner struct {
 	Sex        sql.NullString  `json:"sex" gorm:"size:1;column:sex"`
 	CreateAt   util.CustomTime `json:"createAt" gorm:"column:reg_at;not null;autoCreateTime"`
 	UpdateAt   util.CustomTime `json:"updateAt" gorm:"column:upd_at;not null;autoCreateTime"`
+
+	// 利用停止
+	SuspendedAt      sql.NullTime   `json:"-" gorm:"column:suspended_at"`
+	SuspensionReason sql.NullString `json:"-" gorm:"size:512;column:suspension_reason"`
 }
 
 // dogownerが空かの判定
 func (do *DogOwner) IsEmpty() bool {
 	return !do.DogOwnerID.Valid
 }
+
+// 利用停止中かの判定
+func (do *DogOwner) IsSuspended() bool {
+	return do.SuspendedAt.Valid
+}
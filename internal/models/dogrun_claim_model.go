@@ -0,0 +1,84 @@
+package model
+
+import (
+	"database/sql"
+)
+
+// 管理申請のステータス
+const (
+	DOGRUN_CLAIM_STATUS_PENDING   = "pending"   // 審査中
+	DOGRUN_CLAIM_STATUS_APPROVED  = "approved"  // 承認済み
+	DOGRUN_CLAIM_STATUS_REJECTED  = "rejected"  // 却下
+	DOGRUN_CLAIM_STATUS_WITHDRAWN = "withdrawn" // 取り下げ
+)
+
+// 管理申請の履歴の操作
+const (
+	DOGRUN_CLAIM_ACTION_SUBMIT    = "submit"    // 申請
+	DOGRUN_CLAIM_ACTION_APPROVE   = "approve"   // 承認
+	DOGRUN_CLAIM_ACTION_REJECT    = "reject"    // 却下
+	DOGRUN_CLAIM_ACTION_WITHDRAW  = "withdraw"  // 取り下げ
+	DOGRUN_CLAIM_ACTION_SUPERSEDE = "supersede" // 他の申請の承認による却下
+)
+
+type DogrunClaim struct {
+	DogrunClaimID sql.NullInt64  `gorm:"primaryKey;column:dogrun_claim_id;autoIncrement"`
+	DogrunID      sql.NullInt64  `gorm:"column:dogrun_id;not null"`
+	DogrunmgID    sql.NullInt64  `gorm:"column:dogrun_manager_id;not null"`
+	Status        sql.NullString `gorm:"size:16;column:status;not null"`
+	EvidenceNote  sql.NullString `gorm:"size:1024;column:evidence_note;not null"`
+	ReviewNote    sql.NullString `gorm:"size:512;column:review_note"`
+	ReviewedBy    sql.NullInt64  `gorm:"column:reviewed_by"`
+	ReviewedAt    sql.NullTime   `gorm:"column:reviewed_at"`
+	CreateAt      sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt      sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
+
+	//リレーション
+	Dogrun               Dogrun                `gorm:"foreignKey:DogrunID;references:DogrunID"`
+	Dogrunmg             Dogrunmg              `gorm:"foreignKey:DogrunmgID;references:DogrunmgID"`
+	DogrunClaimDocuments []DogrunClaimDocument `gorm:"foreignKey:DogrunClaimID;references:DogrunClaimID"`
+	DogrunClaimHistories []DogrunClaimHistory  `gorm:"foreignKey:DogrunClaimID;references:DogrunClaimID"`
+}
+
+type DogrunClaimDocument struct {
+	DogrunClaimDocumentID sql.NullInt64  `gorm:"primaryKey;column:dogrun_claim_document_id;autoIncrement"`
+	DogrunClaimID         sql.NullInt64  `gorm:"column:dogrun_claim_id;not null"`
+	FileID                sql.NullString `gorm:"size:64;column:file_id;not null"`
+}
+
+type DogrunClaimHistory struct {
+	DogrunClaimHistoryID sql.NullInt64  `gorm:"primaryKey;column:dogrun_claim_history_id;autoIncrement"`
+	DogrunClaimID        sql.NullInt64  `gorm:"column:dogrun_claim_id;not null"`
+	Action               sql.NullString `gorm:"size:16;column:action;not null"`
+	FromStatus           sql.NullString `gorm:"size:16;column:from_status"`
+	ToStatus             sql.NullString `gorm:"size:16;column:to_status;not null"`
+	ActorRole            sql.NullInt64  `gorm:"column:actor_role;not null"`
+	ActorID              sql.NullInt64  `gorm:"column:actor_id;not null"`
+	Note                 sql.NullString `gorm:"size:512;column:note"`
+	CreateAt             sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+}
+
+/*
+管理申請が空かの判定
+*/
+func (cl *DogrunClaim) IsEmpty() bool {
+	return !cl.DogrunClaimID.Valid
+}
+
+/*
+審査中かの判定
+*/
+func (cl *DogrunClaim) IsPending() bool {
+	return cl.Status.String == DOGRUN_CLAIM_STATUS_PENDING
+}
+
+/*
+証拠書類のfileIDの一覧
+*/
+func (cl *DogrunClaim) DocumentFileIDs() []string {
+	ids := []string{}
+	for _, document := range cl.DogrunClaimDocuments {
+		ids = append(ids, document.FileID.String)
+	}
+	return ids
+}
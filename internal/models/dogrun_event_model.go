@@ -0,0 +1,93 @@
+package model
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// イベントの種類
+const (
+	DOGRUN_EVENT_TYPE_MEETUP   = "meetup"   // dogownerの主催するオフ会
+	DOGRUN_EVENT_TYPE_OFFICIAL = "official" // ドッグランマネージャーの公式イベント
+)
+
+// イベントのステータス
+const (
+	DOGRUN_EVENT_STATUS_SCHEDULED = "scheduled" // 開催予定
+	DOGRUN_EVENT_STATUS_CANCELLED = "cancelled" // 中止
+)
+
+type DogrunEvent struct {
+	DogrunEventID            sql.NullInt64  `gorm:"primaryKey;column:dogrun_event_id;autoIncrement"`
+	DogrunID                 sql.NullInt64  `gorm:"column:dogrun_id;not null"`
+	EventType                sql.NullString `gorm:"size:16;column:event_type;not null"`
+	OrganizerDogOwnerID      sql.NullInt64  `gorm:"column:organizer_dog_owner_id"`
+	OrganizerDogrunManagerID sql.NullInt64  `gorm:"column:organizer_dogrun_manager_id"`
+	Title                    sql.NullString `gorm:"size:128;column:title;not null"`
+	Description              sql.NullString `gorm:"type:text;column:description"`
+	StartAt                  sql.NullTime   `gorm:"column:start_at;not null"`
+	EndAt                    sql.NullTime   `gorm:"column:end_at;not null"`
+	Capacity                 sql.NullInt64  `gorm:"column:capacity"`                     // nullは制限なし
+	AllowedSizeClasses       sql.NullString `gorm:"size:64;column:allowed_size_classes"` // カンマ区切り。nullは制限なし
+	SpecialBusinessHourID    sql.NullInt64  `gorm:"column:special_business_hours_id"`
+	Status                   sql.NullString `gorm:"size:16;column:status;not null"`
+	CreateAt                 sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt                 sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
+
+	//リレーション
+	DogrunEventRsvps []DogrunEventRsvp `gorm:"foreignKey:DogrunEventID;references:DogrunEventID"`
+}
+
+/*
+DogrunEventが空かの判定
+*/
+func (e *DogrunEvent) IsEmpty() bool {
+	return !e.DogrunEventID.Valid
+}
+
+/*
+公式イベントかの判定
+*/
+func (e *DogrunEvent) IsOfficial() bool {
+	return e.EventType.String == DOGRUN_EVENT_TYPE_OFFICIAL
+}
+
+/*
+参加表明を受け付けているか。開催予定かつ終了前のみ受け付ける
+*/
+func (e *DogrunEvent) IsOpenForRsvp(now time.Time) bool {
+	return e.Status.String == DOGRUN_EVENT_STATUS_SCHEDULED && now.Before(e.EndAt.Time)
+}
+
+/*
+参加可能なサイズ区分の一覧。空の場合は制限なし
+*/
+func (e *DogrunEvent) AllowedSizeClassList() []string {
+	if !e.AllowedSizeClasses.Valid || e.AllowedSizeClasses.String == "" {
+		return []string{}
+	}
+	return strings.Split(e.AllowedSizeClasses.String, ",")
+}
+
+/*
+参加表明済みのdogIDの一覧
+*/
+func (e *DogrunEvent) RsvpDogIDs() []int64 {
+	ids := []int64{}
+	for _, rsvp := range e.DogrunEventRsvps {
+		ids = append(ids, rsvp.DogID.Int64)
+	}
+	return ids
+}
+
+type DogrunEventRsvp struct {
+	DogrunEventRsvpID sql.NullInt64 `gorm:"primaryKey;column:dogrun_event_rsvp_id;autoIncrement"`
+	DogrunEventID     sql.NullInt64 `gorm:"column:dogrun_event_id;not null"`
+	DogID             sql.NullInt64 `gorm:"column:dog_id;not null"`
+	DogOwnerID        sql.NullInt64 `gorm:"column:dog_owner_id;not null"`
+	CreateAt          sql.NullTime  `gorm:"column:reg_at;not null;autoCreateTime"`
+
+	//リレーション
+	Dog Dog `gorm:"foreignKey:DogID;references:DogID"`
+}
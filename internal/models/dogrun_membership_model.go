@@ -0,0 +1,95 @@
+package model
+
+import (
+	"database/sql"
+)
+
+// 会員登録のステータス
+const (
+	DOGRUN_MEMBERSHIP_STATUS_PENDING   = "pending"   // 審査中
+	DOGRUN_MEMBERSHIP_STATUS_APPROVED  = "approved"  // 承認済み
+	DOGRUN_MEMBERSHIP_STATUS_REJECTED  = "rejected"  // 却下
+	DOGRUN_MEMBERSHIP_STATUS_WITHDRAWN = "withdrawn" // 取り下げ
+)
+
+// 有効な(取り下げ可能な)会員登録のステータス
+var DOGRUN_MEMBERSHIP_ACTIVE_STATUSES = []string{
+	DOGRUN_MEMBERSHIP_STATUS_PENDING,
+	DOGRUN_MEMBERSHIP_STATUS_APPROVED,
+}
+
+type DogrunMembership struct {
+	DogrunMembershipID sql.NullInt64  `gorm:"primaryKey;column:dogrun_membership_id;autoIncrement"`
+	DogrunID           sql.NullInt64  `gorm:"column:dogrun_id;not null"`
+	DogOwnerID         sql.NullInt64  `gorm:"column:dog_owner_id;not null"`
+	Status             sql.NullString `gorm:"size:16;column:status;not null"`
+	MembershipNumber   sql.NullString `gorm:"size:32;column:membership_number"`
+	ApplicationNote    sql.NullString `gorm:"size:512;column:application_note"`
+	ReviewNote         sql.NullString `gorm:"size:512;column:review_note"`
+	ReviewedAt         sql.NullTime   `gorm:"column:reviewed_at"`
+	CreateAt           sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt           sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
+
+	//リレーション
+	DogOwner                  DogOwner                   `gorm:"foreignKey:DogOwnerID;references:DogOwnerID"`
+	DogrunMembershipDogs      []DogrunMembershipDog      `gorm:"foreignKey:DogrunMembershipID;references:DogrunMembershipID"`
+	DogrunMembershipDocuments []DogrunMembershipDocument `gorm:"foreignKey:DogrunMembershipID;references:DogrunMembershipID"`
+}
+
+/*
+会員登録が空かの判定
+*/
+func (m *DogrunMembership) IsEmpty() bool {
+	return !m.DogrunMembershipID.Valid
+}
+
+/*
+審査中かの判定
+*/
+func (m *DogrunMembership) IsPending() bool {
+	return m.Status.String == DOGRUN_MEMBERSHIP_STATUS_PENDING
+}
+
+/*
+承認済みかの判定
+*/
+func (m *DogrunMembership) IsApproved() bool {
+	return m.Status.String == DOGRUN_MEMBERSHIP_STATUS_APPROVED
+}
+
+/*
+会員登録したdogIDの一覧
+*/
+func (m *DogrunMembership) DogIDs() []int64 {
+	ids := []int64{}
+	for _, membershipDog := range m.DogrunMembershipDogs {
+		ids = append(ids, membershipDog.DogID.Int64)
+	}
+	return ids
+}
+
+/*
+添付書類のfileIDの一覧
+*/
+func (m *DogrunMembership) DocumentFileIDs() []string {
+	ids := []string{}
+	for _, document := range m.DogrunMembershipDocuments {
+		ids = append(ids, document.FileID.String)
+	}
+	return ids
+}
+
+type DogrunMembershipDog struct {
+	DogrunMembershipDogID sql.NullInt64 `gorm:"primaryKey;column:dogrun_membership_dog_id;autoIncrement"`
+	DogrunMembershipID    sql.NullInt64 `gorm:"column:dogrun_membership_id;not null"`
+	DogID                 sql.NullInt64 `gorm:"column:dog_id;not null"`
+
+	//リレーション
+	Dog Dog `gorm:"foreignKey:DogID;references:DogID"`
+}
+
+type DogrunMembershipDocument struct {
+	DogrunMembershipDocumentID sql.NullInt64  `gorm:"primaryKey;column:dogrun_membership_document_id;autoIncrement"`
+	DogrunMembershipID         sql.NullInt64  `gorm:"column:dogrun_membership_id;not null"`
+	FileID                     sql.NullString `gorm:"size:64;column:file_id;not null"`
+}
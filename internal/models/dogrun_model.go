@@ -2,6 +2,9 @@ package model
 
 import (
 	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -21,13 +24,26 @@ type Dogrun struct {
 	Longitude       sql.NullFloat64 `gorm:"column:longitude"`
 	Description     sql.NullString  `gorm:"type:text;column:description"`
 	IsManaged       sql.NullBool    `gorm:"column:is_managed"`
+	MergedIntoID    sql.NullInt64   `gorm:"column:merged_into_dogrun_id"` // 重複により統合された場合の統合先
 	CreateAt        sql.NullTime    `gorm:"column:reg_at;not null;autoCreateTime"`
 	UpdateAt        sql.NullTime    `gorm:"column:upd_at;not null;autoUpdateTime"`
 
+	// google place情報の同期結果
+	BusinessStatus        sql.NullString  `gorm:"size:32;column:business_status"`
+	IsClosedPermanently   sql.NullBool    `gorm:"column:is_closed_permanently"`
+	GoogleRating          sql.NullFloat64 `gorm:"column:google_rating"`
+	GoogleUserRatingCount sql.NullInt64   `gorm:"column:google_user_rating_count"`
+	GoogleOpeningHours    sql.NullString  `gorm:"type:text;column:google_opening_hours"` // 営業時間(JSON)
+	PlaceSyncedAt         sql.NullTime    `gorm:"column:place_synced_at"`
+
+	GeocodeAttemptedAt sql.NullTime `gorm:"column:geocode_attempted_at"` // 最後にジオコーディングでの補完を試みた日時
+
 	//リレーション
-	DogrunTags           []DogrunTag           `gorm:"foreignKey:DogrunID;references:DogrunID"`
-	RegularBusinessHours []RegularBusinessHour `gorm:"foreignKey:DogrunID;references:DogrunID"`
-	SpecialBusinessHours []SpecialBusinessHour `gorm:"foreignKey:DogrunID;references:DogrunID"`
+	DogrunTags           []DogrunTag             `gorm:"foreignKey:DogrunID;references:DogrunID"`
+	RegularBusinessHours []RegularBusinessHour   `gorm:"foreignKey:DogrunID;references:DogrunID"`
+	SpecialBusinessHours []SpecialBusinessHour   `gorm:"foreignKey:DogrunID;references:DogrunID"`
+	DogrunImages         []DogrunImage           `gorm:"foreignKey:DogrunID;references:DogrunID"`
+	FieldProvenances     []DogrunFieldProvenance `gorm:"foreignKey:DogrunID;references:DogrunID"`
 }
 
 /*
@@ -37,6 +53,37 @@ func (d *Dogrun) IsEmpty() bool {
 	return !d.DogrunID.Valid
 }
 
+/*
+dogrunが他のドッグランに統合済みかの判定
+*/
+func (d *Dogrun) IsMerged() bool {
+	return d.MergedIntoID.Valid
+}
+
+/*
+dogrunがgoogleで閉業と報告されているかの判定
+*/
+func (d *Dogrun) IsClosed() bool {
+	return d.IsClosedPermanently.Valid && d.IsClosedPermanently.Bool
+}
+
+/*
+項目の出所と最終確認日時
+出所の記録がない項目は、管理されているドッグランはマネージャー、それ以外は取り込みによる値とする
+更新日時は他の項目の更新でも変わるため、記録がない項目の最終確認日時は不明(ゼロ値)とする
+*/
+func (d *Dogrun) FieldProvenance(fieldName string) (string, time.Time) {
+	for _, fp := range d.FieldProvenances {
+		if fp.FieldName.String == fieldName {
+			return fp.Source.String, fp.VerifiedAt.Time
+		}
+	}
+	if d.IsManaged.Bool {
+		return FIELD_SOURCE_MANAGER, time.Time{}
+	}
+	return FIELD_SOURCE_IMPORTED, time.Time{}
+}
+
 /*
 dogrunが空でないかの判定
 */
@@ -86,6 +133,13 @@ func (d *Dogrun) IsSpecialBusinessHoursNotEmpty() bool {
 	return !d.IsSpecialBusinessHoursEmpty()
 }
 
+/*
+ドッグラン画像情報が空かの判定
+*/
+func (d *Dogrun) IsDogrunImagesEmpty() bool {
+	return len(d.DogrunImages) == 0
+}
+
 /*
 対象のドッグランの通常営業時時間データから、指定されたの曜日(数値:0~6)の営業時間データを返す
 */
@@ -110,6 +164,81 @@ func (d *Dogrun) FetchTargetDateSpecialBusinessHour(date time.Time) SpecialBusin
 	return SpecialBusinessHour{}
 }
 
+// 指定日の営業時間帯
+type BusinessWindow struct {
+	OpenAt    time.Time
+	CloseAt   time.Time
+	IsClosed  bool // 休業日
+	IsUnknown bool // 営業時間の登録なし
+}
+
+/*
+開始日時から終了日時までが営業時間内か。営業時間の登録がない場合は制限なし
+*/
+func (w BusinessWindow) Covers(start time.Time, end time.Time) bool {
+	if w.IsUnknown {
+		return true
+	}
+	if w.IsClosed {
+		return false
+	}
+	return !start.Before(w.OpenAt) && !end.After(w.CloseAt)
+}
+
+/*
+指定日の営業時間帯を返す。特別営業時間の登録がある場合は通常営業時間より優先する
+*/
+func (d *Dogrun) BusinessWindowOn(date time.Time) BusinessWindow {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+
+	for _, v := range d.SpecialBusinessHours {
+		if v.IsValid() && v.Date.Time.Format(time.DateOnly) == day.Format(time.DateOnly) {
+			return resolveBusinessWindow(day, v.IsAllDay.Bool, v.IsClosed.Bool, v.OpenTime, v.CloseTime)
+		}
+	}
+
+	regularBusinessHour := d.FetchTargetRegularBusinessHour(int(day.Weekday()))
+	if regularBusinessHour.IsValid() {
+		return resolveBusinessWindow(day, regularBusinessHour.IsAllDay.Bool, regularBusinessHour.IsClosed.Bool, regularBusinessHour.OpenTime, regularBusinessHour.CloseTime)
+	}
+	return BusinessWindow{IsUnknown: true}
+}
+
+/*
+営業時間データから営業時間帯を組み立てる
+終了時間が開始時間以前の場合は翌日までの営業とする
+*/
+func resolveBusinessWindow(day time.Time, isAllDay bool, isClosed bool, openTime sql.NullString, closeTime sql.NullString) BusinessWindow {
+	if isAllDay {
+		return BusinessWindow{OpenAt: day, CloseAt: day.AddDate(0, 0, 1)}
+	}
+	if isClosed || !openTime.Valid || !closeTime.Valid {
+		return BusinessWindow{IsClosed: true}
+	}
+
+	openAt, okOpen := clockOn(day, openTime.String)
+	closeAt, okClose := clockOn(day, closeTime.String)
+	if !okOpen || !okClose {
+		return BusinessWindow{IsClosed: true}
+	}
+	if !closeAt.After(openAt) {
+		closeAt = closeAt.AddDate(0, 0, 1)
+	}
+	return BusinessWindow{OpenAt: openAt, CloseAt: closeAt}
+}
+
+/*
+"HH:mm:ss"または"HH:mm"の時刻を指定日の日時にする
+*/
+func clockOn(day time.Time, clock string) (time.Time, bool) {
+	for _, layout := range []string{time.TimeOnly, "15:04"} {
+		if t, err := time.Parse(layout, clock); err == nil {
+			return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), t.Second(), 0, day.Location()), true
+		}
+	}
+	return time.Time{}, false
+}
+
 type RegularBusinessHour struct {
 	RegularBusinessHourID sql.NullInt64  `gorm:"primaryKey;column:regular_business_hours_id;autoIncrement"`
 	DogrunID              sql.NullInt64  `gorm:"not null;column:dogrun_id"`
@@ -195,12 +324,25 @@ type DogrunTag struct {
 	DogrunTagID sql.NullInt64 `gorm:"primaryKey;column:dogrun_tag_id;autoIncrement"`
 	DogrunID    sql.NullInt64 `gorm:"column:dogrun_id;not null"`
 	TagID       sql.NullInt64 `gorm:"column:tag_id;not null"`
+
+	//リレーション
+	TagMst TagMst `gorm:"foreignKey:TagID;references:TagID"`
 }
 
+// タグの分類
+const (
+	TAG_CATEGORY_FACILITY  = "facility"  // 設備
+	TAG_CATEGORY_GROUND    = "ground"    // 地面
+	TAG_CATEGORY_SIZE_ZONE = "size_zone" // 犬の大きさ別エリア
+	TAG_CATEGORY_RULE      = "rule"      // 利用ルール
+	TAG_CATEGORY_OTHER     = "other"     // その他
+)
+
 type TagMst struct {
 	TagID       sql.NullInt64  `gorm:"primaryKey;column:tag_id;autoIncrement"`
 	TagName     sql.NullString `gorm:"size:64;column:tag_name;not null"`
 	Description sql.NullString `gorm:"type:text;column:description"`
+	Category    sql.NullString `gorm:"size:32;column:category;not null"`
 }
 
 // GORMにテーブル名を指定
@@ -209,12 +351,117 @@ func (TagMst) TableName() string {
 }
 
 type DogrunImage struct {
-	DogrunImageID int64         `gorm:"primaryKey;column:dogrun_image_id;autoIncrement"`
-	DogrunID      int64         `gorm:"column:dogrun_id;not null"`
-	Image         string        `gorm:"type:text;column:image;not null"`
-	SortOrder     sql.NullInt64 `gorm:"column:sort_order"`
-	UploadAt      sql.NullTime  `gorm:"column:upload_at"`
+	DogrunImageID sql.NullInt64  `gorm:"primaryKey;column:dogrun_image_id;autoIncrement"`
+	DogrunID      sql.NullInt64  `gorm:"column:dogrun_id;not null"`
+	Image         sql.NullString `gorm:"type:text;column:image;not null"` // cmsのファイルID
+	SortOrder     sql.NullInt64  `gorm:"column:sort_order"`
+	UploadAt      sql.NullTime   `gorm:"column:upload_at"`
+}
 
-	//リレーション
-	Dogrun Dogrun `gorm:"foreignKey:DogrunID"`
+/*
+DogrunImageが空かの判定
+*/
+func (di *DogrunImage) IsEmpty() bool {
+	return !di.DogrunImageID.Valid
+}
+
+/*
+DogrunImageが空でないかの判定
+*/
+func (di *DogrunImage) IsNotEmpty() bool {
+	return !di.IsEmpty()
+}
+
+type DogrunEntryCriteria struct {
+	DogrunID           sql.NullInt64  `gorm:"primaryKey;column:dogrun_id"`
+	AllowedSizeClasses sql.NullString `gorm:"size:64;column:allowed_size_classes"` // カンマ区切り。nullは制限なし
+	NeuteredRequired   sql.NullBool   `gorm:"column:neutered_required;not null"`
+	MicrochipRequired  sql.NullBool   `gorm:"column:microchip_required;not null"`
+	MinAgeMonths       sql.NullInt64  `gorm:"column:min_age_months"`
+	MembersOnly        sql.NullBool   `gorm:"column:members_only;not null"`
+	CreateAt           sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt           sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
+}
+
+func (DogrunEntryCriteria) TableName() string {
+	return "dogrun_entry_criteria"
+}
+
+/*
+入場条件が空(未設定)かの判定
+*/
+func (ec *DogrunEntryCriteria) IsEmpty() bool {
+	return !ec.DogrunID.Valid
+}
+
+/*
+入場可能なサイズ区分の一覧。空の場合は制限なし
+*/
+func (ec *DogrunEntryCriteria) AllowedSizeClassList() []string {
+	if !ec.AllowedSizeClasses.Valid || ec.AllowedSizeClasses.String == "" {
+		return []string{}
+	}
+	return strings.Split(ec.AllowedSizeClasses.String, ",")
+}
+
+/*
+dogが満たしていない入場条件の一覧。空の場合は入場可能
+*/
+func (ec *DogrunEntryCriteria) UnmetReasons(dog Dog, now time.Time) []string {
+	reasons := []string{}
+	if ec.IsEmpty() {
+		return reasons
+	}
+
+	if allowed := ec.AllowedSizeClassList(); len(allowed) > 0 && !slices.Contains(allowed, dog.SizeClass()) {
+		reasons = append(reasons, fmt.Sprintf("入場可能なサイズは%sです", strings.Join(allowed, ",")))
+	}
+	if ec.NeuteredRequired.Bool && !dog.Neutered.Bool {
+		reasons = append(reasons, "去勢・避妊済みである必要があります")
+	}
+	if ec.MicrochipRequired.Bool && (!dog.MicrochipID.Valid || dog.MicrochipID.String == "") {
+		reasons = append(reasons, "マイクロチップの登録が必要です")
+	}
+	if ec.MinAgeMonths.Valid {
+		if ageMonths, ok := dog.AgeMonths(now); !ok || int64(ageMonths) < ec.MinAgeMonths.Int64 {
+			reasons = append(reasons, fmt.Sprintf("月齢%dヶ月以上である必要があります", ec.MinAgeMonths.Int64))
+		}
+	}
+	return reasons
+}
+
+// google place情報の同期による変更履歴
+type DogrunPlaceSyncHistory struct {
+	DogrunPlaceSyncHistoryID sql.NullInt64  `gorm:"primaryKey;column:dogrun_place_sync_history_id;autoIncrement"`
+	DogrunID                 sql.NullInt64  `gorm:"column:dogrun_id;not null"`
+	PlaceId                  sql.NullString `gorm:"size:256;column:place_id;not null"`
+	FieldName                sql.NullString `gorm:"size:64;column:field_name;not null"` // dogrunsのカラム名
+	BeforeValue              sql.NullString `gorm:"type:text;column:before_value"`
+	AfterValue               sql.NullString `gorm:"type:text;column:after_value"`
+	CreateAt                 sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+}
+
+// 項目の値の出所
+const (
+	FIELD_SOURCE_MANAGER  = "manager"  // ドッグランマネージャーによる編集
+	FIELD_SOURCE_IMPORTED = "imported" // 取り込み・ジオコーディングによる登録
+	FIELD_SOURCE_GOOGLE   = "google"   // google place情報
+)
+
+// 出所を記録する項目(座標は緯度・経度をまとめてlocation)
+const (
+	PROVENANCE_FIELD_NAME        = "name"
+	PROVENANCE_FIELD_ADDRESS     = "address"
+	PROVENANCE_FIELD_POSTCODE    = "postcode"
+	PROVENANCE_FIELD_LOCATION    = "location"
+	PROVENANCE_FIELD_DESCRIPTION = "description"
+)
+
+// ドッグラン情報の項目ごとの出所
+type DogrunFieldProvenance struct {
+	DogrunFieldProvenanceID sql.NullInt64  `gorm:"primaryKey;column:dogrun_field_provenance_id;autoIncrement"`
+	DogrunID                sql.NullInt64  `gorm:"column:dogrun_id;not null"`
+	FieldName               sql.NullString `gorm:"size:32;column:field_name;not null"`
+	Source                  sql.NullString `gorm:"size:16;column:source;not null"`
+	VerifiedAt              sql.NullTime   `gorm:"column:verified_at;not null"` // 最終確認日時
 }
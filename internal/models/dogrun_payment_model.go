@@ -0,0 +1,125 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// 料金プランの種別
+const (
+	DOGRUN_FEE_TYPE_PER_VISIT  = "per_visit"  // 1回ごと
+	DOGRUN_FEE_TYPE_PER_DOG    = "per_dog"    // 1頭ごと
+	DOGRUN_FEE_TYPE_MEMBERSHIP = "membership" // 会員(有効期間内は入場可)
+)
+
+// 支払いのステータス
+const (
+	DOGRUN_PAYMENT_STATUS_PENDING   = "pending"   // 決済中
+	DOGRUN_PAYMENT_STATUS_SUCCEEDED = "succeeded" // 支払い完了
+	DOGRUN_PAYMENT_STATUS_FAILED    = "failed"    // 決済失敗
+)
+
+type DogrunFeePlan struct {
+	DogrunFeePlanID sql.NullInt64  `gorm:"primaryKey;column:dogrun_fee_plan_id;autoIncrement"`
+	DogrunID        sql.NullInt64  `gorm:"column:dogrun_id;not null"`
+	Name            sql.NullString `gorm:"size:64;column:name;not null"`
+	FeeType         sql.NullString `gorm:"size:16;column:fee_type;not null"`
+	Amount          sql.NullInt64  `gorm:"column:amount;not null"`
+	MembershipDays  sql.NullInt64  `gorm:"column:membership_days"`
+	IsActive        sql.NullBool   `gorm:"column:is_active;not null"`
+	CreateAt        sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt        sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
+}
+
+/*
+料金プランが空かの判定
+*/
+func (p *DogrunFeePlan) IsEmpty() bool {
+	return !p.DogrunFeePlanID.Valid
+}
+
+/*
+支払い金額の計算。1頭ごとのプランはdogの数を掛ける
+*/
+func (p *DogrunFeePlan) CalcAmount(dogCount int64) int64 {
+	if p.FeeType.String == DOGRUN_FEE_TYPE_PER_DOG {
+		return p.Amount.Int64 * dogCount
+	}
+	return p.Amount.Int64
+}
+
+/*
+支払い完了時点からの入場に使える期限。会員は有効日数後、それ以外は当日中
+*/
+func (p *DogrunFeePlan) ValidUntil(paidAt time.Time) time.Time {
+	if p.FeeType.String == DOGRUN_FEE_TYPE_MEMBERSHIP {
+		return paidAt.AddDate(0, 0, int(p.MembershipDays.Int64))
+	}
+	return time.Date(paidAt.Year(), paidAt.Month(), paidAt.Day(), 0, 0, 0, 0, paidAt.Location()).AddDate(0, 0, 1)
+}
+
+type DogrunPaymentSetting struct {
+	DogrunID                   sql.NullInt64 `gorm:"primaryKey;column:dogrun_id"`
+	CheckinPaymentRequired     sql.NullBool  `gorm:"column:checkin_payment_required;not null"`
+	ReservationPaymentRequired sql.NullBool  `gorm:"column:reservation_payment_required;not null"`
+	CreateAt                   sql.NullTime  `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt                   sql.NullTime  `gorm:"column:upd_at;not null;autoUpdateTime"`
+}
+
+type DogrunPayment struct {
+	DogrunPaymentID     sql.NullInt64  `gorm:"primaryKey;column:dogrun_payment_id;autoIncrement"`
+	DogrunID            sql.NullInt64  `gorm:"column:dogrun_id;not null"`
+	DogrunFeePlanID     sql.NullInt64  `gorm:"column:dogrun_fee_plan_id;not null"`
+	DogOwnerID          sql.NullInt64  `gorm:"column:dog_owner_id;not null"`
+	FeeType             sql.NullString `gorm:"size:16;column:fee_type;not null"`
+	Amount              sql.NullInt64  `gorm:"column:amount;not null"`
+	DogCount            sql.NullInt64  `gorm:"column:dog_count;not null"`
+	DogrunReservationID sql.NullInt64  `gorm:"column:dogrun_reservation_id"`
+	Provider            sql.NullString `gorm:"size:32;column:provider;not null"`
+	ProviderPaymentID   sql.NullString `gorm:"size:128;column:provider_payment_id"`
+	Status              sql.NullString `gorm:"size:16;column:status;not null"`
+	FailureReason       sql.NullString `gorm:"size:256;column:failure_reason"`
+	IdempotencyKey      sql.NullString `gorm:"size:64;column:idempotency_key;not null"`
+	PaidAt              sql.NullTime   `gorm:"column:paid_at"`
+	ValidUntil          sql.NullTime   `gorm:"column:valid_until"`
+	CreateAt            sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt            sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
+
+	//リレーション
+	DogrunFeePlan DogrunFeePlan `gorm:"foreignKey:DogrunFeePlanID;references:DogrunFeePlanID"`
+	DogOwner      DogOwner      `gorm:"foreignKey:DogOwnerID;references:DogOwnerID"`
+}
+
+/*
+支払いが空かの判定
+*/
+func (p *DogrunPayment) IsEmpty() bool {
+	return !p.DogrunPaymentID.Valid
+}
+
+/*
+支払いが完了しているかの判定
+*/
+func (p *DogrunPayment) IsSucceeded() bool {
+	return p.Status.String == DOGRUN_PAYMENT_STATUS_SUCCEEDED
+}
+
+/*
+会員の支払いかの判定
+*/
+func (p *DogrunPayment) IsMembership() bool {
+	return p.FeeType.String == DOGRUN_FEE_TYPE_MEMBERSHIP
+}
+
+/*
+指定のdogの数の入場に使えるか。1頭ごとの支払いは支払った頭数まで
+*/
+func (p *DogrunPayment) CoversEntry(dogCount int64, now time.Time) bool {
+	if !p.IsSucceeded() || !p.ValidUntil.Valid || !now.Before(p.ValidUntil.Time) {
+		return false
+	}
+	if p.FeeType.String == DOGRUN_FEE_TYPE_PER_DOG {
+		return p.DogCount.Int64 >= dogCount
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package model
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestDogrunFeePlanCalcAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		feeType  string
+		amount   int64
+		dogCount int64
+		want     int64
+	}{
+		{name: "1回ごと", feeType: DOGRUN_FEE_TYPE_PER_VISIT, amount: 500, dogCount: 3, want: 500},
+		{name: "1頭ごと", feeType: DOGRUN_FEE_TYPE_PER_DOG, amount: 500, dogCount: 3, want: 1500},
+		{name: "1頭ごとで1頭", feeType: DOGRUN_FEE_TYPE_PER_DOG, amount: 500, dogCount: 1, want: 500},
+		{name: "会員", feeType: DOGRUN_FEE_TYPE_MEMBERSHIP, amount: 3000, dogCount: 2, want: 3000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feePlan := DogrunFeePlan{
+				FeeType: sql.NullString{String: tt.feeType, Valid: true},
+				Amount:  sql.NullInt64{Int64: tt.amount, Valid: true},
+			}
+			if got := feePlan.CalcAmount(tt.dogCount); got != tt.want {
+				t.Errorf("CalcAmount(%d) = %d, want %d", tt.dogCount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDogrunFeePlanValidUntil(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name           string
+		feeType        string
+		membershipDays int64
+		paidAt         time.Time
+		want           time.Time
+	}{
+		{
+			name:    "1回ごとは当日中",
+			feeType: DOGRUN_FEE_TYPE_PER_VISIT,
+			paidAt:  time.Date(2026, 5, 1, 10, 30, 0, 0, jst),
+			want:    time.Date(2026, 5, 2, 0, 0, 0, 0, jst),
+		},
+		{
+			name:    "1頭ごとは当日中",
+			feeType: DOGRUN_FEE_TYPE_PER_DOG,
+			paidAt:  time.Date(2026, 5, 1, 23, 59, 59, 0, jst),
+			want:    time.Date(2026, 5, 2, 0, 0, 0, 0, jst),
+		},
+		{
+			name:    "月末は翌月の初日まで",
+			feeType: DOGRUN_FEE_TYPE_PER_VISIT,
+			paidAt:  time.Date(2026, 12, 31, 8, 0, 0, 0, jst),
+			want:    time.Date(2027, 1, 1, 0, 0, 0, 0, jst),
+		},
+		{
+			name:           "会員は有効日数後",
+			feeType:        DOGRUN_FEE_TYPE_MEMBERSHIP,
+			membershipDays: 30,
+			paidAt:         time.Date(2026, 5, 1, 10, 30, 0, 0, jst),
+			want:           time.Date(2026, 5, 31, 10, 30, 0, 0, jst),
+		},
+		{
+			name:           "会員のうるう年",
+			feeType:        DOGRUN_FEE_TYPE_MEMBERSHIP,
+			membershipDays: 365,
+			paidAt:         time.Date(2028, 1, 1, 0, 0, 0, 0, jst),
+			want:           time.Date(2028, 12, 31, 0, 0, 0, 0, jst),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feePlan := DogrunFeePlan{
+				FeeType:        sql.NullString{String: tt.feeType, Valid: true},
+				MembershipDays: sql.NullInt64{Int64: tt.membershipDays, Valid: tt.membershipDays != 0},
+			}
+			if got := feePlan.ValidUntil(tt.paidAt); !got.Equal(tt.want) {
+				t.Errorf("ValidUntil(%v) = %v, want %v", tt.paidAt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDogrunPaymentCoversEntry(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	validUntil := sql.NullTime{Time: now.Add(time.Hour), Valid: true}
+
+	tests := []struct {
+		name       string
+		status     string
+		feeType    string
+		dogCount   int64
+		validUntil sql.NullTime
+		entryDogs  int64
+		want       bool
+	}{
+		{name: "1回ごと", status: DOGRUN_PAYMENT_STATUS_SUCCEEDED, feeType: DOGRUN_FEE_TYPE_PER_VISIT, dogCount: 1, validUntil: validUntil, entryDogs: 3, want: true},
+		{name: "1頭ごとで頭数内", status: DOGRUN_PAYMENT_STATUS_SUCCEEDED, feeType: DOGRUN_FEE_TYPE_PER_DOG, dogCount: 2, validUntil: validUntil, entryDogs: 2, want: true},
+		{name: "1頭ごとで頭数超過", status: DOGRUN_PAYMENT_STATUS_SUCCEEDED, feeType: DOGRUN_FEE_TYPE_PER_DOG, dogCount: 2, validUntil: validUntil, entryDogs: 3, want: false},
+		{name: "会員", status: DOGRUN_PAYMENT_STATUS_SUCCEEDED, feeType: DOGRUN_FEE_TYPE_MEMBERSHIP, dogCount: 1, validUntil: validUntil, entryDogs: 5, want: true},
+		{name: "決済中", status: DOGRUN_PAYMENT_STATUS_PENDING, feeType: DOGRUN_FEE_TYPE_PER_VISIT, dogCount: 1, validUntil: validUntil, entryDogs: 1, want: false},
+		{name: "決済失敗", status: DOGRUN_PAYMENT_STATUS_FAILED, feeType: DOGRUN_FEE_TYPE_PER_VISIT, dogCount: 1, validUntil: validUntil, entryDogs: 1, want: false},
+		{name: "期限なし", status: DOGRUN_PAYMENT_STATUS_SUCCEEDED, feeType: DOGRUN_FEE_TYPE_PER_VISIT, dogCount: 1, entryDogs: 1, want: false},
+		{name: "期限ちょうど", status: DOGRUN_PAYMENT_STATUS_SUCCEEDED, feeType: DOGRUN_FEE_TYPE_PER_VISIT, dogCount: 1, validUntil: sql.NullTime{Time: now, Valid: true}, entryDogs: 1, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment := DogrunPayment{
+				Status:     sql.NullString{String: tt.status, Valid: true},
+				FeeType:    sql.NullString{String: tt.feeType, Valid: true},
+				DogCount:   sql.NullInt64{Int64: tt.dogCount, Valid: true},
+				ValidUntil: tt.validUntil,
+			}
+			if got := payment.CoversEntry(tt.entryDogs, now); got != tt.want {
+				t.Errorf("CoversEntry(%d) = %v, want %v", tt.entryDogs, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// 予約の種別
+const (
+	DOGRUN_RESERVATION_TYPE_NORMAL  = "normal"  // 通常予約
+	DOGRUN_RESERVATION_TYPE_PRIVATE = "private" // 貸切予約
+)
+
+// 予約のステータス
+const (
+	DOGRUN_RESERVATION_STATUS_RESERVED  = "reserved"  // 予約中
+	DOGRUN_RESERVATION_STATUS_CANCELLED = "cancelled" // キャンセル
+	DOGRUN_RESERVATION_STATUS_NO_SHOW   = "no_show"   // 無断キャンセル
+)
+
+type DogrunBookingSetting struct {
+	DogrunID           sql.NullInt64 `gorm:"primaryKey;column:dogrun_id"`
+	SlotMinutes        sql.NullInt64 `gorm:"column:slot_minutes;not null"`
+	SlotCapacity       sql.NullInt64 `gorm:"column:slot_capacity;not null"`
+	AllowPrivateRental sql.NullBool  `gorm:"column:allow_private_rental;not null"`
+	CreateAt           sql.NullTime  `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt           sql.NullTime  `gorm:"column:upd_at;not null;autoUpdateTime"`
+}
+
+/*
+予約設定が空(予約を受け付けていない)かの判定
+*/
+func (s *DogrunBookingSetting) IsEmpty() bool {
+	return !s.DogrunID.Valid
+}
+
+type DogrunBookingSlot struct {
+	DogrunBookingSlotID sql.NullInt64 `gorm:"primaryKey;column:dogrun_booking_slot_id;autoIncrement"`
+	DogrunID            sql.NullInt64 `gorm:"column:dogrun_id;not null"`
+	StartAt             sql.NullTime  `gorm:"column:start_at;not null"`
+	EndAt               sql.NullTime  `gorm:"column:end_at;not null"`
+	Capacity            sql.NullInt64 `gorm:"column:capacity;not null"`
+	ReservedCount       sql.NullInt64 `gorm:"column:reserved_count;not null"`
+	IsPrivate           sql.NullBool  `gorm:"column:is_private;not null"`
+	CreateAt            sql.NullTime  `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt            sql.NullTime  `gorm:"column:upd_at;not null;autoUpdateTime"`
+}
+
+/*
+予約枠が空かの判定
+*/
+func (s *DogrunBookingSlot) IsEmpty() bool {
+	return !s.DogrunBookingSlotID.Valid
+}
+
+/*
+予約できる残りのdogの数。貸切予約済みの場合は0
+*/
+func (s *DogrunBookingSlot) Remaining() int64 {
+	if s.IsPrivate.Bool {
+		return 0
+	}
+	if remaining := s.Capacity.Int64 - s.ReservedCount.Int64; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+/*
+予約を受け付けているか。開始前のみ受け付ける
+*/
+func (s *DogrunBookingSlot) IsBookable(now time.Time) bool {
+	return now.Before(s.StartAt.Time)
+}
+
+/*
+キャンセル・dogの除外で空いたdogの数を予約枠に戻す。貸切予約の場合は貸切を解除する
+*/
+func (s *DogrunBookingSlot) Release(dogCount int64, private bool) {
+	s.ReservedCount.Int64 = max(s.ReservedCount.Int64-dogCount, 0)
+	if private {
+		s.IsPrivate = sql.NullBool{Bool: false, Valid: true}
+	}
+}
+
+type DogrunReservation struct {
+	DogrunReservationID sql.NullInt64  `gorm:"primaryKey;column:dogrun_reservation_id;autoIncrement"`
+	DogrunBookingSlotID sql.NullInt64  `gorm:"column:dogrun_booking_slot_id;not null"`
+	DogrunID            sql.NullInt64  `gorm:"column:dogrun_id;not null"`
+	DogOwnerID          sql.NullInt64  `gorm:"column:dog_owner_id;not null"`
+	ReservationType     sql.NullString `gorm:"size:16;column:reservation_type;not null"`
+	DogCount            sql.NullInt64  `gorm:"column:dog_count;not null"`
+	Status              sql.NullString `gorm:"size:16;column:status;not null"`
+	CancelledAt         sql.NullTime   `gorm:"column:cancelled_at"`
+	NoShowAt            sql.NullTime   `gorm:"column:no_show_at"`
+	CreateAt            sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt            sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
+
+	//リレーション
+	DogrunBookingSlot     DogrunBookingSlot      `gorm:"foreignKey:DogrunBookingSlotID;references:DogrunBookingSlotID"`
+	DogOwner              DogOwner               `gorm:"foreignKey:DogOwnerID;references:DogOwnerID"`
+	DogrunReservationDogs []DogrunReservationDog `gorm:"foreignKey:DogrunReservationID;references:DogrunReservationID"`
+}
+
+/*
+予約が空かの判定
+*/
+func (r *DogrunReservation) IsEmpty() bool {
+	return !r.DogrunReservationID.Valid
+}
+
+/*
+予約中かの判定
+*/
+func (r *DogrunReservation) IsReserved() bool {
+	return r.Status.String == DOGRUN_RESERVATION_STATUS_RESERVED
+}
+
+/*
+貸切予約かの判定
+*/
+func (r *DogrunReservation) IsPrivate() bool {
+	return r.ReservationType.String == DOGRUN_RESERVATION_TYPE_PRIVATE
+}
+
+/*
+予約したdogIDの一覧
+*/
+func (r *DogrunReservation) DogIDs() []int64 {
+	ids := []int64{}
+	for _, reservationDog := range r.DogrunReservationDogs {
+		ids = append(ids, reservationDog.DogID.Int64)
+	}
+	return ids
+}
+
+type DogrunReservationDog struct {
+	DogrunReservationDogID sql.NullInt64 `gorm:"primaryKey;column:dogrun_reservation_dog_id;autoIncrement"`
+	DogrunReservationID    sql.NullInt64 `gorm:"column:dogrun_reservation_id;not null"`
+	DogID                  sql.NullInt64 `gorm:"column:dog_id;not null"`
+
+	//リレーション
+	Dog Dog `gorm:"foreignKey:DogID;references:DogID"`
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestDogrunBookingSlotRemaining(t *testing.T) {
+	tests := []struct {
+		name          string
+		capacity      int64
+		reservedCount int64
+		isPrivate     bool
+		want          int64
+	}{
+		{name: "予約なし", capacity: 10, reservedCount: 0, want: 10},
+		{name: "一部予約済み", capacity: 10, reservedCount: 3, want: 7},
+		{name: "満枠", capacity: 10, reservedCount: 10, want: 0},
+		{name: "定員の引き下げで超過", capacity: 5, reservedCount: 8, want: 0},
+		{name: "貸切予約済み", capacity: 10, reservedCount: 1, isPrivate: true, want: 0},
+		{name: "貸切予約済みで予約数0", capacity: 10, reservedCount: 0, isPrivate: true, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slot := DogrunBookingSlot{
+				Capacity:      sql.NullInt64{Int64: tt.capacity, Valid: true},
+				ReservedCount: sql.NullInt64{Int64: tt.reservedCount, Valid: true},
+				IsPrivate:     sql.NullBool{Bool: tt.isPrivate, Valid: true},
+			}
+			if got := slot.Remaining(); got != tt.want {
+				t.Errorf("Remaining() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDogrunBookingSlotIsBookable(t *testing.T) {
+	startAt := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{name: "開始前", now: startAt.Add(-time.Hour), want: true},
+		{name: "開始直前", now: startAt.Add(-time.Nanosecond), want: true},
+		{name: "開始時刻ちょうど", now: startAt, want: false},
+		{name: "開始後", now: startAt.Add(time.Minute), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slot := DogrunBookingSlot{
+				StartAt: sql.NullTime{Time: startAt, Valid: true},
+			}
+			if got := slot.IsBookable(tt.now); got != tt.want {
+				t.Errorf("IsBookable(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDogrunBookingSlotRelease(t *testing.T) {
+	tests := []struct {
+		name          string
+		reservedCount int64
+		isPrivate     bool
+		dogCount      int64
+		private       bool
+		wantReserved  int64
+		wantPrivate   bool
+	}{
+		{name: "通常予約のキャンセル", reservedCount: 5, dogCount: 2, wantReserved: 3},
+		{name: "dog1頭の除外", reservedCount: 5, dogCount: 1, wantReserved: 4},
+		{name: "予約数を下回らない", reservedCount: 1, dogCount: 3, wantReserved: 0},
+		{name: "貸切予約のキャンセル", reservedCount: 2, isPrivate: true, dogCount: 2, private: true, wantReserved: 0, wantPrivate: false},
+		{name: "貸切予約からのdogの除外", reservedCount: 2, isPrivate: true, dogCount: 1, private: false, wantReserved: 1, wantPrivate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slot := DogrunBookingSlot{
+				ReservedCount: sql.NullInt64{Int64: tt.reservedCount, Valid: true},
+				IsPrivate:     sql.NullBool{Bool: tt.isPrivate, Valid: true},
+			}
+			slot.Release(tt.dogCount, tt.private)
+			if slot.ReservedCount.Int64 != tt.wantReserved {
+				t.Errorf("ReservedCount = %d, want %d", slot.ReservedCount.Int64, tt.wantReserved)
+			}
+			if slot.IsPrivate.Bool != tt.wantPrivate {
+				t.Errorf("IsPrivate = %v, want %v", slot.IsPrivate.Bool, tt.wantPrivate)
+			}
+		})
+	}
+}
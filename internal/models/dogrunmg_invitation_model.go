@@ -0,0 +1,53 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// マネージャー招待のステータス
+const (
+	DOGRUNMG_INVITATION_STATUS_PENDING   = "pending"   // 承諾待ち
+	DOGRUNMG_INVITATION_STATUS_ACCEPTED  = "accepted"  // 承諾済み
+	DOGRUNMG_INVITATION_STATUS_CANCELLED = "cancelled" // 取り消し
+)
+
+type DogrunmgInvitation struct {
+	DogrunmgInvitationID sql.NullInt64  `gorm:"primaryKey;column:dogrun_manager_invitation_id;autoIncrement"`
+	OrganizationID       sql.NullInt64  `gorm:"column:organization_id;not null"`
+	Email                sql.NullString `gorm:"size:255;column:email;not null"`
+	IsAdmin              sql.NullBool   `gorm:"column:is_admin;not null"`
+	TokenHash            sql.NullString `gorm:"size:64;column:token_hash;not null"`
+	InviterID            sql.NullInt64  `gorm:"column:inviter_id;not null"`
+	Status               sql.NullString `gorm:"size:16;column:status;not null"`
+	ExpiresAt            sql.NullTime   `gorm:"column:expires_at;not null"`
+	AcceptedAt           sql.NullTime   `gorm:"column:accepted_at"`
+	DogrunmgID           sql.NullInt64  `gorm:"column:dogrun_manager_id"`
+	CreateAt             sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt             sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
+}
+
+func (DogrunmgInvitation) TableName() string {
+	return "dogrun_manager_invitations"
+}
+
+/*
+招待が空かの判定
+*/
+func (i *DogrunmgInvitation) IsEmpty() bool {
+	return !i.DogrunmgInvitationID.Valid
+}
+
+/*
+承諾待ちかの判定
+*/
+func (i *DogrunmgInvitation) IsPending() bool {
+	return i.Status.String == DOGRUNMG_INVITATION_STATUS_PENDING
+}
+
+/*
+有効期限切れかの判定
+*/
+func (i *DogrunmgInvitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt.Time)
+}
@@ -11,9 +11,14 @@ type Dogrunmg struct {
 	Name       sql.NullString  `gorm:"size:128;column:name;not null"`
 	Image      sql.NullString  `json:"image" gorm:"type:text;column:image"`
 	Sex        sql.NullString  `gorm:"size:1;column:sex"`
+	IsActive   sql.NullBool    `gorm:"column:is_active;not null;default:true"`
 	CreateAt   util.CustomTime `gorm:"column:reg_at;not null;autoCreateTime"`
 	UpdateAt   util.CustomTime `gorm:"column:upd_at;not null;autoUpdateTime"`
 
+	// 利用停止
+	SuspendedAt      sql.NullTime   `gorm:"column:suspended_at"`
+	SuspensionReason sql.NullString `gorm:"size:512;column:suspension_reason"`
+
 	// Orgとのリレーション
 	Organization   Organization  `gorm:"foreignKey:OrganizationID;references:OrganizationID"`
 	OrganizationID sql.NullInt64 `gorm:"column:organization_id;not null"` // 外部キー
@@ -36,3 +41,17 @@ Dogrunmgが空でないか
 func (dm *Dogrunmg) IsNotEmpty() bool {
 	return dm.DogrunmgID.Valid
 }
+
+/*
+Dogrunmgが有効か
+*/
+func (dm *Dogrunmg) IsActiveManager() bool {
+	return dm.IsActive.Valid && dm.IsActive.Bool
+}
+
+/*
+Dogrunmgが利用停止中か
+*/
+func (dm *Dogrunmg) IsSuspended() bool {
+	return dm.SuspendedAt.Valid
+}
@@ -5,10 +5,13 @@ import (
 )
 
 type DogrunBookmark struct {
-	DogrunBookmarkID sql.NullInt64 `gorm:"column:dogrun_bookmark_id;primaryKey"`
-	DogOwnerID       sql.NullInt64 `gorm:"column:dog_owner_id;not null"`
-	DogrunID         sql.NullInt64 `gorm:"column:dogrun_id;not null"`
-	SavedAt          sql.NullTime  `gorm:"column:saved_at;autoCreateTime"`
+	DogrunBookmarkID sql.NullInt64  `gorm:"column:dogrun_bookmark_id;primaryKey"`
+	DogOwnerID       sql.NullInt64  `gorm:"column:dog_owner_id;not null"`
+	DogrunID         sql.NullInt64  `gorm:"column:dogrun_id;not null"`
+	BookmarkFolderID sql.NullInt64  `gorm:"column:bookmark_folder_id"` // 未分類の場合はNULL
+	Note             sql.NullString `gorm:"type:text;column:note"`     // ユーザーの個人メモ
+	SortOrder        sql.NullInt64  `gorm:"column:sort_order"`
+	SavedAt          sql.NullTime   `gorm:"column:saved_at;autoCreateTime"`
 }
 
 /*
@@ -25,6 +28,29 @@ func (b *DogrunBookmark) IsNotEmpty() bool {
 	return b.DogrunBookmarkID.Valid
 }
 
+type BookmarkFolder struct {
+	BookmarkFolderID sql.NullInt64  `gorm:"column:bookmark_folder_id;primaryKey"`
+	DogOwnerID       sql.NullInt64  `gorm:"column:dog_owner_id;not null"`
+	Name             sql.NullString `gorm:"size:64;column:name;not null"`
+	SortOrder        sql.NullInt64  `gorm:"column:sort_order"`
+	CreateAt         sql.NullTime   `gorm:"column:reg_at;not null;autoCreateTime"`
+	UpdateAt         sql.NullTime   `gorm:"column:upd_at;not null;autoUpdateTime"`
+}
+
+/*
+BookmarkFolderが空であるか
+*/
+func (f *BookmarkFolder) IsEmpty() bool {
+	return !f.IsNotEmpty()
+}
+
+/*
+BookmarkFolderが空でないか
+*/
+func (f *BookmarkFolder) IsNotEmpty() bool {
+	return f.BookmarkFolderID.Valid
+}
+
 type DogrunCheckin struct {
 	DogrunCheckinID sql.NullInt64 `gorm:"column:dogrun_checkin_id;primaryKey"`
 	DogrunID        sql.NullInt64 `gorm:"column:dogrun_id;not null"`
@@ -13,6 +13,7 @@ type Organization struct {
 	PhoneNumber    sql.NullString  `gorm:"size:15;column:phone_number"`
 	Address        sql.NullString  `gorm:"size:256;column:address"`
 	Description    sql.NullString  `gorm:"size:512;column:description"`
+	ClosedAt       sql.NullTime    `gorm:"column:closed_at"`
 	CreateAt       util.CustomTime `gorm:"column:reg_at;not null;autoCreateTime"`
 	UpdateAt       util.CustomTime `gorm:"column:upd_at;not null;autoCreateTime"`
 }
@@ -21,3 +22,8 @@ type Organization struct {
 func (o *Organization) IsEmpty() bool {
 	return !o.OrganizationID.Valid
 }
+
+// 退会済みかの判定
+func (o *Organization) IsClosed() bool {
+	return o.ClosedAt.Valid
+}
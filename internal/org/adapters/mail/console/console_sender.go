@@ -0,0 +1,35 @@
+package console
+
+import (
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/internal/org/adapters/mail"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+)
+
+// ConsoleMailSender: メールを送信せずログに出力する(開発・テスト用)
+type ConsoleMailSender struct{}
+
+func NewConsoleMailSender() *ConsoleMailSender {
+	return &ConsoleMailSender{}
+}
+
+var _ mail.IMailSender = (*ConsoleMailSender)(nil)
+
+// Send: メールの宛先と件名をログに出力
+// 本文には招待トークンなどの秘匿情報が含まれるため、文字数のみ出力する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - mail.Mail: 送信するメール
+//
+// return:
+//   - error: error情報
+func (cs *ConsoleMailSender) Send(c echo.Context, m mail.Mail) error {
+	logger := log.GetLogger(c).Sugar()
+
+	logger.Infof("Mail to: %s, subject: %s, body: (masked, %d chars)", m.To, m.Subject, utf8.RuneCountInString(m.Body))
+
+	return nil
+}
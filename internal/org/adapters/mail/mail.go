@@ -0,0 +1,20 @@
+package mail
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	SENDER_TYPE_CONSOLE = "console" // ログへの出力のみ(開発・テスト用)
+)
+
+// 送信するメール
+type Mail struct {
+	To      string // 宛先
+	Subject string // 件名
+	Body    string // 本文
+}
+
+type IMailSender interface {
+	Send(c echo.Context, m Mail) error
+}
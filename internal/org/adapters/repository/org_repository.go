@@ -1,10 +1,31 @@
 package repository
 
 import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
 	"gorm.io/gorm"
 )
 
 type IOrgRepository interface {
+	FindOrgByID(c echo.Context, orgID int64) (model.Organization, error)
+	UpdateOrg(c echo.Context, orgID int64, updates map[string]any) error
+	CloseOrg(c echo.Context, orgID int64) (bool, error)
+	FindManagerCredential(c echo.Context, dmID int64) (model.DogrunmgCredential, error)
+	FindManagerCredentialsByOrgID(c echo.Context, orgID int64) ([]model.DogrunmgCredential, error)
+	CountDogrunsByManagerIDs(c echo.Context, dmIDs []int64) (map[int64]int64, error)
+	DeactivateDogrunmg(c echo.Context, dmID int64) (bool, error)
+	PromoteDogrunmg(c echo.Context, dmID int64) (bool, error)
+	FindDogrunByID(c echo.Context, dogrunID int64) (model.Dogrun, error)
+	TransferDogrun(c echo.Context, dogrunID int64, fromDmID int64, toDmID int64) (bool, error)
+	CreateInvitation(c echo.Context, invitation *model.DogrunmgInvitation) error
+	FindInvitationByID(c echo.Context, invitationID int64) (model.DogrunmgInvitation, error)
+	FindPendingInvitation(c echo.Context, orgID int64, email string) (model.DogrunmgInvitation, error)
+	FindInvitationsByOrgID(c echo.Context, orgID int64) ([]model.DogrunmgInvitation, error)
+	CancelInvitation(c echo.Context, invitationID int64) (bool, error)
 }
 
 type orgRepository struct {
@@ -16,3 +37,471 @@ func NewOrgRepository(db *gorm.DB) IOrgRepository {
 		db: db,
 	}
 }
+
+// FindOrgByID: organizationの取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: organizationのID
+//
+// return:
+//   - model.Organization: organization情報。存在しない場合は空
+//   - error: error情報
+func (or *orgRepository) FindOrgByID(c echo.Context, orgID int64) (model.Organization, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	org := model.Organization{}
+	if err := or.db.Where("organization_id = ?", orgID).Find(&org).Error; err != nil {
+		logger.Error("Failed to find Organization: ", err)
+		return model.Organization{}, wrErrors.NewWRError(
+			err,
+			"Organizationの取得に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return org, nil
+}
+
+// UpdateOrg: organizationの更新
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: organizationのID
+//   - map[string]any: 更新内容
+//
+// return:
+//   - error: error情報
+func (or *orgRepository) UpdateOrg(c echo.Context, orgID int64, updates map[string]any) error {
+	logger := log.GetLogger(c).Sugar()
+
+	updates["upd_at"] = time.Now()
+	if err := or.db.Model(&model.Organization{}).
+		Where("organization_id = ?", orgID).
+		Updates(updates).Error; err != nil {
+		logger.Error("Failed to update Organization: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"Organizationの更新に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return nil
+}
+
+// CloseOrg: organizationの退会
+// 所属する全てのdogrunmgを無効化してJWT IDを削除し、承諾待ちの招待を取り消す
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: organizationのID
+//
+// return:
+//   - bool: 退会したか(既に退会済みの場合はfalse)
+//   - error: error情報
+func (or *orgRepository) CloseOrg(c echo.Context, orgID int64) (bool, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	closed := false
+	err := or.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		result := tx.Model(&model.Organization{}).
+			Where("organization_id = ? AND closed_at IS NULL", orgID).
+			Updates(map[string]any{"closed_at": now, "upd_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		closed = true
+
+		if err := tx.Model(&model.Dogrunmg{}).
+			Where("organization_id = ?", orgID).
+			Updates(map[string]any{"is_active": false, "upd_at": now}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&model.AuthDogrunmg{}).
+			Where("dogrun_manager_id IN (?)", tx.Model(&model.Dogrunmg{}).Select("dogrun_manager_id").Where("organization_id = ?", orgID)).
+			Update("jwt_id", nil).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.DogrunmgInvitation{}).
+			Where("organization_id = ? AND status = ?", orgID, model.DOGRUNMG_INVITATION_STATUS_PENDING).
+			Update("status", model.DOGRUNMG_INVITATION_STATUS_CANCELLED).Error
+	})
+	if err != nil {
+		logger.Error("Failed to close Organization: ", err)
+		return false, wrErrors.NewWRError(
+			err,
+			"Organizationの退会に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return closed, nil
+}
+
+// FindManagerCredential: dogrunmgのクレデンシャル情報の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogrunmgのID
+//
+// return:
+//   - model.DogrunmgCredential: authDogrunmg、dogrunmgを含むクレデンシャル情報。存在しない場合は空
+//   - error: error情報
+func (or *orgRepository) FindManagerCredential(c echo.Context, dmID int64) (model.DogrunmgCredential, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	credential := model.DogrunmgCredential{}
+	if err := joinManagerCredentials(or.db).
+		Where("dogrun_managers.dogrun_manager_id = ?", dmID).
+		Find(&credential).Error; err != nil {
+		logger.Error("Failed to find Dogrunmg: ", err)
+		return model.DogrunmgCredential{}, wrErrors.NewWRError(
+			err,
+			"マネージャーの取得に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return credential, nil
+}
+
+// FindManagerCredentialsByOrgID: organizationに所属するdogrunmgのクレデンシャル情報一覧の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: organizationのID
+//
+// return:
+//   - []model.DogrunmgCredential: 登録順のクレデンシャル情報
+//   - error: error情報
+func (or *orgRepository) FindManagerCredentialsByOrgID(c echo.Context, orgID int64) ([]model.DogrunmgCredential, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	credentials := []model.DogrunmgCredential{}
+	if err := joinManagerCredentials(or.db).
+		Where("dogrun_managers.organization_id = ?", orgID).
+		Order("dogrun_managers.dogrun_manager_id").
+		Find(&credentials).Error; err != nil {
+		logger.Error("Failed to find Dogrunmgs: ", err)
+		return nil, wrErrors.NewWRError(
+			err,
+			"マネージャー一覧の取得に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return credentials, nil
+}
+
+// CountDogrunsByManagerIDs: dogrunmgごとの管理しているdogrun数の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - []int64: dogrunmgのID
+//
+// return:
+//   - map[int64]int64: dogrunmgのIDごとのdogrun数
+//   - error: error情報
+func (or *orgRepository) CountDogrunsByManagerIDs(c echo.Context, dmIDs []int64) (map[int64]int64, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	counts := map[int64]int64{}
+	if len(dmIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		DogrunManagerID int64
+		Count           int64
+	}
+	if err := or.db.Model(&model.Dogrun{}).
+		Select("dogrun_manager_id, COUNT(*) AS count").
+		Where("dogrun_manager_id IN ?", dmIDs).
+		Group("dogrun_manager_id").
+		Scan(&rows).Error; err != nil {
+		logger.Error("Failed to count Dogruns: ", err)
+		return nil, wrErrors.NewWRError(
+			err,
+			"管理しているドッグラン数の取得に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	for _, row := range rows {
+		counts[row.DogrunManagerID] = row.Count
+	}
+	return counts, nil
+}
+
+// DeactivateDogrunmg: dogrunmgの無効化
+// 発行済みのJWTを使えなくするため、JWT IDも削除する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogrunmgのID
+//
+// return:
+//   - bool: 無効化したか(既に無効の場合はfalse)
+//   - error: error情報
+func (or *orgRepository) DeactivateDogrunmg(c echo.Context, dmID int64) (bool, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	deactivated := false
+	err := or.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Dogrunmg{}).
+			Where("dogrun_manager_id = ? AND is_active = ?", dmID, true).
+			Updates(map[string]any{"is_active": false, "upd_at": time.Now()})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		deactivated = true
+
+		return tx.Model(&model.AuthDogrunmg{}).
+			Where("dogrun_manager_id = ?", dmID).
+			Update("jwt_id", nil).Error
+	})
+	if err != nil {
+		logger.Error("Failed to deactivate Dogrunmg: ", err)
+		return false, wrErrors.NewWRError(
+			err,
+			"マネージャーの無効化に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return deactivated, nil
+}
+
+// PromoteDogrunmg: dogrunmgのadminへの昇格
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogrunmgのID
+//
+// return:
+//   - bool: 昇格したか(既にadminの場合はfalse)
+//   - error: error情報
+func (or *orgRepository) PromoteDogrunmg(c echo.Context, dmID int64) (bool, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	result := or.db.Model(&model.AuthDogrunmg{}).
+		Where("dogrun_manager_id = ? AND is_admin IS NOT TRUE", dmID).
+		Update("is_admin", true)
+	if result.Error != nil {
+		logger.Error("Failed to promote Dogrunmg: ", result.Error)
+		return false, wrErrors.NewWRError(
+			result.Error,
+			"マネージャーの昇格に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// FindDogrunByID: dogrunの取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogrunのID
+//
+// return:
+//   - model.Dogrun: dogrun情報。存在しない場合は空
+//   - error: error情報
+func (or *orgRepository) FindDogrunByID(c echo.Context, dogrunID int64) (model.Dogrun, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	dogrun := model.Dogrun{}
+	if err := or.db.Where("dogrun_id = ?", dogrunID).Find(&dogrun).Error; err != nil {
+		logger.Error("Failed to find Dogrun: ", err)
+		return model.Dogrun{}, wrErrors.NewWRError(
+			err,
+			"ドッグランの取得に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return dogrun, nil
+}
+
+// TransferDogrun: dogrunの管理者の変更
+// 現在の管理者が指定のdogrunmgの場合のみ更新する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogrunのID
+//   - int64: 現在の管理者のdogrunmgのID
+//   - int64: 変更先のdogrunmgのID
+//
+// return:
+//   - bool: 変更したか
+//   - error: error情報
+func (or *orgRepository) TransferDogrun(c echo.Context, dogrunID int64, fromDmID int64, toDmID int64) (bool, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	result := or.db.Model(&model.Dogrun{}).
+		Where("dogrun_id = ? AND dogrun_manager_id = ?", dogrunID, fromDmID).
+		Updates(map[string]any{"dogrun_manager_id": toDmID, "upd_at": time.Now()})
+	if result.Error != nil {
+		logger.Error("Failed to transfer Dogrun: ", result.Error)
+		return false, wrErrors.NewWRError(
+			result.Error,
+			"ドッグランの管理者の変更に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// CreateInvitation: マネージャー招待の登録
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - *model.DogrunmgInvitation: 招待情報
+//
+// return:
+//   - error: error情報
+func (or *orgRepository) CreateInvitation(c echo.Context, invitation *model.DogrunmgInvitation) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := or.db.Create(invitation).Error; err != nil {
+		logger.Error("Failed to create DogrunmgInvitation: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"マネージャーの招待に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return nil
+}
+
+// FindInvitationByID: マネージャー招待の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: 招待のID
+//
+// return:
+//   - model.DogrunmgInvitation: 招待情報。存在しない場合は空
+//   - error: error情報
+func (or *orgRepository) FindInvitationByID(c echo.Context, invitationID int64) (model.DogrunmgInvitation, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	invitation := model.DogrunmgInvitation{}
+	if err := or.db.Where("dogrun_manager_invitation_id = ?", invitationID).Find(&invitation).Error; err != nil {
+		logger.Error("Failed to find DogrunmgInvitation: ", err)
+		return model.DogrunmgInvitation{}, wrErrors.NewWRError(
+			err,
+			"招待の取得に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return invitation, nil
+}
+
+// FindPendingInvitation: 同じメールアドレスへの承諾待ちの招待の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: organizationのID
+//   - string: 招待先のメールアドレス
+//
+// return:
+//   - model.DogrunmgInvitation: 招待情報。存在しない場合は空
+//   - error: error情報
+func (or *orgRepository) FindPendingInvitation(c echo.Context, orgID int64, email string) (model.DogrunmgInvitation, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	invitation := model.DogrunmgInvitation{}
+	if err := or.db.
+		Where("organization_id = ? AND email = ? AND status = ?", orgID, email, model.DOGRUNMG_INVITATION_STATUS_PENDING).
+		Find(&invitation).Error; err != nil {
+		logger.Error("Failed to find DogrunmgInvitation: ", err)
+		return model.DogrunmgInvitation{}, wrErrors.NewWRError(
+			err,
+			"招待の取得に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return invitation, nil
+}
+
+// FindInvitationsByOrgID: organizationの招待一覧の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: organizationのID
+//
+// return:
+//   - []model.DogrunmgInvitation: 招待の新しい順の招待情報
+//   - error: error情報
+func (or *orgRepository) FindInvitationsByOrgID(c echo.Context, orgID int64) ([]model.DogrunmgInvitation, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	invitations := []model.DogrunmgInvitation{}
+	if err := or.db.
+		Where("organization_id = ?", orgID).
+		Order("reg_at DESC").
+		Find(&invitations).Error; err != nil {
+		logger.Error("Failed to find DogrunmgInvitations: ", err)
+		return nil, wrErrors.NewWRError(
+			err,
+			"招待一覧の取得に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return invitations, nil
+}
+
+// CancelInvitation: 承諾待ちの招待の取り消し
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: 招待のID
+//
+// return:
+//   - bool: 取り消したか(承諾待ちでない場合はfalse)
+//   - error: error情報
+func (or *orgRepository) CancelInvitation(c echo.Context, invitationID int64) (bool, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	result := or.db.Model(&model.DogrunmgInvitation{}).
+		Where("dogrun_manager_invitation_id = ? AND status = ?", invitationID, model.DOGRUNMG_INVITATION_STATUS_PENDING).
+		Update("status", model.DOGRUNMG_INVITATION_STATUS_CANCELLED)
+	if result.Error != nil {
+		logger.Error("Failed to cancel DogrunmgInvitation: ", result.Error)
+		return false, wrErrors.NewWRError(
+			result.Error,
+			"招待の取り消しに失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+/*
+dogrunmgのクレデンシャルとauthDogrunmg、dogrunmgの結合
+*/
+func joinManagerCredentials(db *gorm.DB) *gorm.DB {
+	return db.Model(&model.DogrunmgCredential{}).
+		Joins("JOIN auth_dogrun_managers ON auth_dogrun_managers.auth_dogrun_manager_id = dogrun_manager_credentials.auth_dogrun_manager_id").
+		Joins("JOIN dogrun_managers ON dogrun_managers.dogrun_manager_id = auth_dogrun_managers.dogrun_manager_id").
+		Preload("AuthDogrunmg.Dogrunmg")
+}
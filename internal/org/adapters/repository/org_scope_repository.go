@@ -2,16 +2,20 @@ package repository
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	model "github.com/wanrun-develop/wanrun/internal/models"
 	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
 	"github.com/wanrun-develop/wanrun/pkg/log"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IOrgScopeRepository interface {
 	CreateOrg(tx *gorm.DB, c echo.Context, o *model.Organization) (sql.NullInt64, error)
+	FindInvitationByTokenHashForUpdate(tx *gorm.DB, c echo.Context, tokenHash string) (model.DogrunmgInvitation, error)
+	AcceptInvitation(tx *gorm.DB, c echo.Context, invitationID int64, dmID int64) error
 }
 
 type orgScopeRepository struct {
@@ -52,3 +56,71 @@ func (or *orgScopeRepository) CreateOrg(
 
 	return o.OrganizationID, nil
 }
+
+// FindInvitationByTokenHashForUpdate: 招待トークンのハッシュからの招待の取得(行ロック)
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - string: 招待トークンのハッシュ
+//
+// return:
+//   - model.DogrunmgInvitation: 招待情報。存在しない場合は空
+//   - error: error情報
+func (or *orgScopeRepository) FindInvitationByTokenHashForUpdate(
+	tx *gorm.DB,
+	c echo.Context,
+	tokenHash string,
+) (model.DogrunmgInvitation, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	invitation := model.DogrunmgInvitation{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		Find(&invitation).Error; err != nil {
+		logger.Error("Failed to find DogrunmgInvitation: ", err)
+		return model.DogrunmgInvitation{}, wrErrors.NewWRError(
+			err,
+			"招待の取得に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return invitation, nil
+}
+
+// AcceptInvitation: 招待の承諾済みへの更新
+//
+// args:
+//   - *gorm.DB: トランザクションを張っているtx情報
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: 招待のID
+//   - int64: 承諾して登録されたdogrunmgのID
+//
+// return:
+//   - error: error情報
+func (or *orgScopeRepository) AcceptInvitation(
+	tx *gorm.DB,
+	c echo.Context,
+	invitationID int64,
+	dmID int64,
+) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := tx.Model(&model.DogrunmgInvitation{}).
+		Where("dogrun_manager_invitation_id = ?", invitationID).
+		Updates(map[string]any{
+			"status":            model.DOGRUNMG_INVITATION_STATUS_ACCEPTED,
+			"accepted_at":       time.Now(),
+			"dogrun_manager_id": dmID,
+		}).Error; err != nil {
+		logger.Error("Failed to accept DogrunmgInvitation: ", err)
+		return wrErrors.NewWRError(
+			err,
+			"招待の承諾に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -13,17 +14,31 @@ import (
 
 type IOrgController interface {
 	OrgSignUp(c echo.Context) error
+	GetOrg(c echo.Context) error
+	UpdateOrg(c echo.Context) error
+	CloseOrg(c echo.Context) error
+	GetManagers(c echo.Context) error
+	DeactivateManager(c echo.Context) error
+	PromoteManager(c echo.Context) error
+	TransferDogrun(c echo.Context) error
+	InviteManager(c echo.Context) error
+	GetInvitations(c echo.Context) error
+	CancelInvitation(c echo.Context) error
+	AcceptInvitation(c echo.Context) error
 }
 
 type orgController struct {
-	oh orgHandler.IOrgHandler
+	oh  orgHandler.IOrgHandler
+	omh orgHandler.IOrgManagerHandler
 }
 
 func NewOrgController(
 	oh orgHandler.IOrgHandler,
+	omh orgHandler.IOrgManagerHandler,
 ) IOrgController {
 	return &orgController{
-		oh: oh,
+		oh:  oh,
+		omh: omh,
 	}
 }
 
@@ -67,3 +82,214 @@ func (o *orgController) OrgSignUp(c echo.Context) error {
 		"accessToken": token,
 	})
 }
+
+// GetOrg: 所属するorganization情報の取得
+func (o *orgController) GetOrg(c echo.Context) error {
+	org, wrErr := o.oh.GetOrg(c)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, org)
+}
+
+// UpdateOrg: organization情報の更新
+func (o *orgController) UpdateOrg(c echo.Context) error {
+	orgReq := dto.OrgUpdateReq{}
+
+	if err := bindAndValidate(c, &orgReq); err != nil {
+		return err
+	}
+
+	org, wrErr := o.oh.UpdateOrg(c, orgReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, org)
+}
+
+// CloseOrg: organizationの退会
+func (o *orgController) CloseOrg(c echo.Context) error {
+	if wrErr := o.oh.CloseOrg(c); wrErr != nil {
+		return wrErr
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetManagers: 所属するdogrunmgの一覧の取得
+func (o *orgController) GetManagers(c echo.Context) error {
+	managers, wrErr := o.omh.GetManagers(c)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, managers)
+}
+
+// DeactivateManager: dogrunmgの無効化
+func (o *orgController) DeactivateManager(c echo.Context) error {
+	dmID, err := parseIDParam(c, "managerId")
+
+	if err != nil {
+		return err
+	}
+
+	manager, wrErr := o.omh.DeactivateManager(c, dmID)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, manager)
+}
+
+// PromoteManager: dogrunmgのadminへの昇格
+func (o *orgController) PromoteManager(c echo.Context) error {
+	dmID, err := parseIDParam(c, "managerId")
+
+	if err != nil {
+		return err
+	}
+
+	manager, wrErr := o.omh.PromoteManager(c, dmID)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, manager)
+}
+
+// TransferDogrun: ドッグランの管理者の変更
+func (o *orgController) TransferDogrun(c echo.Context) error {
+	dogrunID, err := parseIDParam(c, "dogrunId")
+
+	if err != nil {
+		return err
+	}
+
+	transferReq := dto.OrgDogrunTransferReq{}
+
+	if err := bindAndValidate(c, &transferReq); err != nil {
+		return err
+	}
+
+	if wrErr := o.omh.TransferDogrun(c, dogrunID, transferReq); wrErr != nil {
+		return wrErr
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// InviteManager: dogrunmgの招待
+func (o *orgController) InviteManager(c echo.Context) error {
+	invitationReq := dto.OrgInvitationReq{}
+
+	if err := bindAndValidate(c, &invitationReq); err != nil {
+		return err
+	}
+
+	invitation, wrErr := o.omh.InviteManager(c, invitationReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusCreated, invitation)
+}
+
+// GetInvitations: 招待一覧の取得
+func (o *orgController) GetInvitations(c echo.Context) error {
+	invitations, wrErr := o.omh.GetInvitations(c)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusOK, invitations)
+}
+
+// CancelInvitation: 招待の取り消し
+func (o *orgController) CancelInvitation(c echo.Context) error {
+	invitationID, err := parseIDParam(c, "invitationId")
+
+	if err != nil {
+		return err
+	}
+
+	if wrErr := o.omh.CancelInvitation(c, invitationID); wrErr != nil {
+		return wrErr
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// AcceptInvitation: 招待の承諾。登録したdogrunmgのトークンを返す
+func (o *orgController) AcceptInvitation(c echo.Context) error {
+	acceptReq := dto.OrgInvitationAcceptReq{}
+
+	if err := bindAndValidate(c, &acceptReq); err != nil {
+		return err
+	}
+
+	token, wrErr := o.omh.AcceptInvitation(c, acceptReq)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	return c.JSON(http.StatusCreated, map[string]string{
+		"accessToken": token,
+	})
+}
+
+/*
+リクエストボディのバインドとバリデーション
+*/
+func bindAndValidate(c echo.Context, req any) error {
+	logger := log.GetLogger(c).Sugar()
+
+	if err := c.Bind(req); err != nil {
+		wrErr := errors.NewWRError(
+			err,
+			"入力項目に不正があります。",
+			errors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		wrErr := errors.NewWRError(
+			err,
+			"必須の項目に不正があります。",
+			errors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	return nil
+}
+
+/*
+パスパラメータのIDの変換
+*/
+func parseIDParam(c echo.Context, name string) (int64, error) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id <= 0 {
+		wrErr := errors.NewWRError(
+			err,
+			"パスパラメータのIDが不正です。",
+			errors.NewOrgClientErrorEType(),
+		)
+		log.GetLogger(c).Sugar().Error(wrErr)
+		return 0, wrErr
+	}
+	return id, nil
+}
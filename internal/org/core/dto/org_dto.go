@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type OrgReq struct {
 	OrgName      string `json:"organizationName" validate:"required"`
 	ContactEmail string `json:"contactEmail" validate:"required"`
@@ -8,3 +10,56 @@ type OrgReq struct {
 	Description  string `json:"description"`
 	Password     string `json:"password" validate:"required"`
 }
+
+type OrgUpdateReq struct {
+	OrgName      string `json:"organizationName" validate:"required,max=128"`
+	ContactEmail string `json:"contactEmail" validate:"required,email,max=256"`
+	PhoneNumber  string `json:"phoneNumber" validate:"required,max=15"`
+	Address      string `json:"address" validate:"required,max=256"`
+	Description  string `json:"description" validate:"max=512"`
+}
+
+type OrgRes struct {
+	OrganizationID int64  `json:"organizationId"`
+	OrgName        string `json:"organizationName"`
+	ContactEmail   string `json:"contactEmail"`
+	PhoneNumber    string `json:"phoneNumber"`
+	Address        string `json:"address"`
+	Description    string `json:"description"`
+}
+
+type OrgManagerRes struct {
+	DogrunmgID  int64  `json:"dogrunmgId"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	IsAdmin     bool   `json:"isAdmin"`
+	IsActive    bool   `json:"isActive"`
+	DogrunCount int64  `json:"dogrunCount"` // 管理しているドッグラン数
+}
+
+type OrgInvitationReq struct {
+	Email   string `json:"email" validate:"required,email,max=255"`
+	IsAdmin bool   `json:"isAdmin"`
+}
+
+type OrgInvitationAcceptReq struct {
+	Token    string `json:"token" validate:"required"`
+	Name     string `json:"name" validate:"required,max=128"`
+	Password string `json:"password" validate:"required"`
+}
+
+type OrgInvitationRes struct {
+	InvitationID int64      `json:"invitationId"`
+	Email        string     `json:"email"`
+	IsAdmin      bool       `json:"isAdmin"`
+	Status       string     `json:"status"`
+	InviterID    int64      `json:"inviterId"`
+	ExpiresAt    time.Time  `json:"expiresAt"`
+	AcceptedAt   *time.Time `json:"acceptedAt,omitempty"`
+	DogrunmgID   int64      `json:"dogrunmgId,omitempty"` // 承諾して登録されたマネージャー
+	CreateAt     time.Time  `json:"createAt"`
+}
+
+type OrgDogrunTransferReq struct {
+	DogrunmgID int64 `json:"dogrunmgId" validate:"required,gt=0"`
+}
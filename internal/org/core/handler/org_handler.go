@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"strconv"
+
 	"github.com/labstack/echo/v4"
+	auditDTO "github.com/wanrun-develop/wanrun/internal/audit/core/dto"
+	auditFacade "github.com/wanrun-develop/wanrun/internal/audit/facade"
 	authRepository "github.com/wanrun-develop/wanrun/internal/auth/adapters/repository"
 	"github.com/wanrun-develop/wanrun/internal/auth/core"
 	authDTO "github.com/wanrun-develop/wanrun/internal/auth/core/dto"
@@ -12,6 +16,7 @@ import (
 	orgRepository "github.com/wanrun-develop/wanrun/internal/org/adapters/repository"
 	"github.com/wanrun-develop/wanrun/internal/org/core/dto"
 	"github.com/wanrun-develop/wanrun/internal/transaction"
+	"github.com/wanrun-develop/wanrun/internal/wrcontext"
 	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
 	"github.com/wanrun-develop/wanrun/pkg/log"
 	wrUtil "github.com/wanrun-develop/wanrun/pkg/util"
@@ -21,29 +26,38 @@ import (
 
 type IOrgHandler interface {
 	OrgSignUp(c echo.Context, orgReq dto.OrgReq) (string, error)
+	GetOrg(c echo.Context) (dto.OrgRes, error)
+	UpdateOrg(c echo.Context, orgReq dto.OrgUpdateReq) (dto.OrgRes, error)
+	CloseOrg(c echo.Context) error
 }
 
 type orgHandler struct {
+	or   orgRepository.IOrgRepository
 	osr  orgRepository.IOrgScopeRepository
 	tm   transaction.ITransactionManager
 	dmsr dogrunmgRepository.IDogrunmgScopeRepository
 	asr  authRepository.IAuthScopeRepository
 	af   authFacade.IAuthFacade
+	auf  auditFacade.IAuditFacade
 }
 
 func NewOrgHandler(
+	or orgRepository.IOrgRepository,
 	osr orgRepository.IOrgScopeRepository,
 	tm transaction.ITransactionManager,
 	dmsr dogrunmgRepository.IDogrunmgScopeRepository,
 	asr authRepository.IAuthScopeRepository,
 	af authFacade.IAuthFacade,
+	auf auditFacade.IAuditFacade,
 ) IOrgHandler {
 	return &orgHandler{
+		or:   or,
 		osr:  osr,
 		tm:   tm,
 		dmsr: dmsr,
 		asr:  asr,
 		af:   af,
+		auf:  auf,
 	}
 }
 
@@ -140,8 +154,20 @@ func (oh *orgHandler) OrgSignUp(
 			return wrErr
 		}
 
-		// 正常に完了
-		return nil
+		// 登録の監査ログ
+		return oh.auf.Record(tx, c, auditDTO.AuditEntry{
+			ActorID:    dmID.Int64,
+			ActorRole:  core.DOGRUNMG_ADMIN_ROLE,
+			Action:     model.AUDIT_ACTION_SIGNUP,
+			Result:     model.AUDIT_RESULT_SUCCESS,
+			TargetType: model.AUDIT_TARGET_ORG,
+			TargetID:   strconv.FormatInt(orgID.Int64, 10),
+			After: map[string]any{
+				"orgName":      orgReq.OrgName,
+				"contactEmail": orgReq.ContactEmail,
+				"dogrunmgId":   dmID.Int64,
+			},
+		})
 
 	}); err != nil {
 		logger.Error("Transaction failed:", err)
@@ -166,3 +192,195 @@ func (oh *orgHandler) OrgSignUp(
 
 	return token, nil
 }
+
+// GetOrg: ログイン中のdogrunmgが所属するorganizationの取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//
+// return:
+//   - dto.OrgRes: organization情報
+//   - error: error情報
+func (oh *orgHandler) GetOrg(c echo.Context) (dto.OrgRes, error) {
+	org, wrErr := oh.findLoginOrg(c)
+
+	if wrErr != nil {
+		return dto.OrgRes{}, wrErr
+	}
+
+	return toOrgRes(org), nil
+}
+
+// UpdateOrg: organization情報の更新
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.OrgUpdateReq: organizationの更新リクエスト情報
+//
+// return:
+//   - dto.OrgRes: 更新後のorganization情報
+//   - error: error情報
+func (oh *orgHandler) UpdateOrg(c echo.Context, orgReq dto.OrgUpdateReq) (dto.OrgRes, error) {
+	org, wrErr := oh.findLoginOrg(c)
+
+	if wrErr != nil {
+		return dto.OrgRes{}, wrErr
+	}
+
+	if wrErr := oh.or.UpdateOrg(c, org.OrganizationID.Int64, map[string]any{
+		"organization_name": orgReq.OrgName,
+		"contact_email":     orgReq.ContactEmail,
+		"phone_number":      orgReq.PhoneNumber,
+		"address":           orgReq.Address,
+		"description":       wrUtil.NewSqlNullString(orgReq.Description),
+	}); wrErr != nil {
+		return dto.OrgRes{}, wrErr
+	}
+
+	return oh.GetOrg(c)
+}
+
+// CloseOrg: organizationの退会
+// 管理しているドッグランが残っている場合は退会できない。退会後は所属する全てのdogrunmgがログインできなくなる
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//
+// return:
+//   - error: error情報
+func (oh *orgHandler) CloseOrg(c echo.Context) error {
+	logger := log.GetLogger(c).Sugar()
+
+	org, wrErr := oh.findLoginOrg(c)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	// 所属するdogrunmgが管理しているドッグラン数の確認
+	credentials, wrErr := oh.or.FindManagerCredentialsByOrgID(c, org.OrganizationID.Int64)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	dmIDs := []int64{}
+	for _, credential := range credentials {
+		dmIDs = append(dmIDs, credential.AuthDogrunmg.DogrunmgID.Int64)
+	}
+
+	counts, wrErr := oh.or.CountDogrunsByManagerIDs(c, dmIDs)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	for _, count := range counts {
+		if count > 0 {
+			wrErr := wrErrors.NewWRError(
+				nil,
+				"管理しているドッグランがあるため退会できません。",
+				wrErrors.NewOrgClientErrorEType(),
+			)
+			logger.Error(wrErr)
+			return wrErr
+		}
+	}
+
+	closed, wrErr := oh.or.CloseOrg(c, org.OrganizationID.Int64)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	if !closed {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"既に退会済みです。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	logger.Infof("Closed Organization: %d", org.OrganizationID.Int64)
+
+	return nil
+}
+
+/*
+ログイン中のdogrunmgが所属するorganizationの取得
+*/
+func (oh *orgHandler) findLoginOrg(c echo.Context) (model.Organization, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	loginDm, wrErr := findLoginDogrunmg(c, oh.or)
+
+	if wrErr != nil {
+		return model.Organization{}, wrErr
+	}
+
+	org, wrErr := oh.or.FindOrgByID(c, loginDm.OrganizationID.Int64)
+
+	if wrErr != nil {
+		return model.Organization{}, wrErr
+	}
+
+	if org.IsEmpty() || org.IsClosed() {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"Organizationが存在しません。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return model.Organization{}, wrErr
+	}
+
+	return org, nil
+}
+
+/*
+ログイン中のdogrunmgの取得
+*/
+func findLoginDogrunmg(c echo.Context, or orgRepository.IOrgRepository) (model.Dogrunmg, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	userID, wrErr := wrcontext.GetLoginUserID(c)
+
+	if wrErr != nil {
+		return model.Dogrunmg{}, wrErr
+	}
+
+	credential, wrErr := or.FindManagerCredential(c, userID)
+
+	if wrErr != nil {
+		return model.Dogrunmg{}, wrErr
+	}
+
+	loginDm := credential.AuthDogrunmg.Dogrunmg
+	if loginDm.IsEmpty() || !loginDm.IsActiveManager() {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"ログイン中のマネージャーが存在しません。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return model.Dogrunmg{}, wrErr
+	}
+
+	return loginDm, nil
+}
+
+/*
+organizationのレスポンスへの変換
+*/
+func toOrgRes(org model.Organization) dto.OrgRes {
+	return dto.OrgRes{
+		OrganizationID: org.OrganizationID.Int64,
+		OrgName:        org.Name.String,
+		ContactEmail:   org.ContactEmail.String,
+		PhoneNumber:    org.PhoneNumber.String,
+		Address:        org.Address.String,
+		Description:    org.Description.String,
+	}
+}
@@ -0,0 +1,786 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/configs"
+	auditDTO "github.com/wanrun-develop/wanrun/internal/audit/core/dto"
+	auditFacade "github.com/wanrun-develop/wanrun/internal/audit/facade"
+	authRepository "github.com/wanrun-develop/wanrun/internal/auth/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/auth/core"
+	authDTO "github.com/wanrun-develop/wanrun/internal/auth/core/dto"
+	authFacade "github.com/wanrun-develop/wanrun/internal/auth/core/facade"
+	authHandler "github.com/wanrun-develop/wanrun/internal/auth/core/handler"
+	dogrunmgRepository "github.com/wanrun-develop/wanrun/internal/dogrunmg/adapters/repository"
+	model "github.com/wanrun-develop/wanrun/internal/models"
+	"github.com/wanrun-develop/wanrun/internal/org/adapters/mail"
+	orgRepository "github.com/wanrun-develop/wanrun/internal/org/adapters/repository"
+	"github.com/wanrun-develop/wanrun/internal/org/core/dto"
+	"github.com/wanrun-develop/wanrun/internal/transaction"
+	wrErrors "github.com/wanrun-develop/wanrun/pkg/errors"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+	wrUtil "github.com/wanrun-develop/wanrun/pkg/util"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	INVITATION_EXPIRES      = 7 * 24 * time.Hour // 招待の有効期間
+	INVITATION_TOKEN_LENGTH = 32                 // 招待トークンのバイト数
+)
+
+type IOrgManagerHandler interface {
+	GetManagers(c echo.Context) ([]dto.OrgManagerRes, error)
+	DeactivateManager(c echo.Context, dmID int64) (dto.OrgManagerRes, error)
+	PromoteManager(c echo.Context, dmID int64) (dto.OrgManagerRes, error)
+	TransferDogrun(c echo.Context, dogrunID int64, req dto.OrgDogrunTransferReq) error
+	InviteManager(c echo.Context, req dto.OrgInvitationReq) (dto.OrgInvitationRes, error)
+	GetInvitations(c echo.Context) ([]dto.OrgInvitationRes, error)
+	CancelInvitation(c echo.Context, invitationID int64) error
+	AcceptInvitation(c echo.Context, req dto.OrgInvitationAcceptReq) (string, error)
+}
+
+type orgManagerHandler struct {
+	or   orgRepository.IOrgRepository
+	osr  orgRepository.IOrgScopeRepository
+	tm   transaction.ITransactionManager
+	dmsr dogrunmgRepository.IDogrunmgScopeRepository
+	asr  authRepository.IAuthScopeRepository
+	af   authFacade.IAuthFacade
+	ms   mail.IMailSender
+	auf  auditFacade.IAuditFacade
+}
+
+func NewOrgManagerHandler(
+	or orgRepository.IOrgRepository,
+	osr orgRepository.IOrgScopeRepository,
+	tm transaction.ITransactionManager,
+	dmsr dogrunmgRepository.IDogrunmgScopeRepository,
+	asr authRepository.IAuthScopeRepository,
+	af authFacade.IAuthFacade,
+	ms mail.IMailSender,
+	auf auditFacade.IAuditFacade,
+) IOrgManagerHandler {
+	return &orgManagerHandler{
+		or:   or,
+		osr:  osr,
+		tm:   tm,
+		dmsr: dmsr,
+		asr:  asr,
+		af:   af,
+		ms:   ms,
+		auf:  auf,
+	}
+}
+
+// GetManagers: organizationに所属するdogrunmgの一覧の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//
+// return:
+//   - []dto.OrgManagerRes: dogrunmgの一覧(無効化されたdogrunmgを含む)
+//   - error: error情報
+func (omh *orgManagerHandler) GetManagers(c echo.Context) ([]dto.OrgManagerRes, error) {
+	loginDm, wrErr := findLoginDogrunmg(c, omh.or)
+
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	credentials, wrErr := omh.or.FindManagerCredentialsByOrgID(c, loginDm.OrganizationID.Int64)
+
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	dmIDs := []int64{}
+	for _, credential := range credentials {
+		dmIDs = append(dmIDs, credential.AuthDogrunmg.DogrunmgID.Int64)
+	}
+
+	counts, wrErr := omh.or.CountDogrunsByManagerIDs(c, dmIDs)
+
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	managers := []dto.OrgManagerRes{}
+	for _, credential := range credentials {
+		managers = append(managers, toOrgManagerRes(credential, counts[credential.AuthDogrunmg.DogrunmgID.Int64]))
+	}
+
+	return managers, nil
+}
+
+// DeactivateManager: dogrunmgの無効化
+// 自分自身と、ドッグランを管理しているdogrunmgは無効化できない(先に管理者を変更する)
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogrunmgのID
+//
+// return:
+//   - dto.OrgManagerRes: 無効化後のdogrunmg
+//   - error: error情報
+func (omh *orgManagerHandler) DeactivateManager(c echo.Context, dmID int64) (dto.OrgManagerRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	loginDm, wrErr := findLoginDogrunmg(c, omh.or)
+
+	if wrErr != nil {
+		return dto.OrgManagerRes{}, wrErr
+	}
+
+	if loginDm.DogrunmgID.Int64 == dmID {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"自分自身は無効化できません。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return dto.OrgManagerRes{}, wrErr
+	}
+
+	if _, wrErr := omh.findOrgManager(c, loginDm, dmID); wrErr != nil {
+		return dto.OrgManagerRes{}, wrErr
+	}
+
+	counts, wrErr := omh.or.CountDogrunsByManagerIDs(c, []int64{dmID})
+
+	if wrErr != nil {
+		return dto.OrgManagerRes{}, wrErr
+	}
+
+	if counts[dmID] > 0 {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"管理しているドッグランがあるため無効化できません。先にドッグランの管理者を変更してください。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return dto.OrgManagerRes{}, wrErr
+	}
+
+	deactivated, wrErr := omh.or.DeactivateDogrunmg(c, dmID)
+
+	if wrErr != nil {
+		return dto.OrgManagerRes{}, wrErr
+	}
+
+	if !deactivated {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"既に無効化されています。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return dto.OrgManagerRes{}, wrErr
+	}
+
+	return omh.getManager(c, loginDm, dmID)
+}
+
+// PromoteManager: dogrunmgのadminへの昇格
+// 昇格したdogrunmgの権限は次回のログインから反映される
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogrunmgのID
+//
+// return:
+//   - dto.OrgManagerRes: 昇格後のdogrunmg
+//   - error: error情報
+func (omh *orgManagerHandler) PromoteManager(c echo.Context, dmID int64) (dto.OrgManagerRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	loginDm, wrErr := findLoginDogrunmg(c, omh.or)
+
+	if wrErr != nil {
+		return dto.OrgManagerRes{}, wrErr
+	}
+
+	target, wrErr := omh.findOrgManager(c, loginDm, dmID)
+
+	if wrErr != nil {
+		return dto.OrgManagerRes{}, wrErr
+	}
+
+	if !target.AuthDogrunmg.Dogrunmg.IsActiveManager() {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"無効化されたマネージャーは昇格できません。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return dto.OrgManagerRes{}, wrErr
+	}
+
+	promoted, wrErr := omh.or.PromoteDogrunmg(c, dmID)
+
+	if wrErr != nil {
+		return dto.OrgManagerRes{}, wrErr
+	}
+
+	if !promoted {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"既にadminです。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return dto.OrgManagerRes{}, wrErr
+	}
+
+	return omh.getManager(c, loginDm, dmID)
+}
+
+// TransferDogrun: organization内でのドッグランの管理者の変更
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: dogrunのID
+//   - dto.OrgDogrunTransferReq: 変更先のdogrunmg
+//
+// return:
+//   - error: error情報
+func (omh *orgManagerHandler) TransferDogrun(c echo.Context, dogrunID int64, req dto.OrgDogrunTransferReq) error {
+	logger := log.GetLogger(c).Sugar()
+
+	loginDm, wrErr := findLoginDogrunmg(c, omh.or)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	dogrun, wrErr := omh.or.FindDogrunByID(c, dogrunID)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	if dogrun.IsEmpty() || !dogrun.DogrunManagerID.Valid {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"管理しているドッグランが存在しません。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	// 現在の管理者が同じorganizationに所属しているか
+	fromDmID := dogrun.DogrunManagerID.Int64
+	if _, wrErr := omh.findOrgManager(c, loginDm, fromDmID); wrErr != nil {
+		return wrErr
+	}
+
+	if fromDmID == req.DogrunmgID {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"既に指定のマネージャーが管理しています。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	target, wrErr := omh.findOrgManager(c, loginDm, req.DogrunmgID)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	if !target.AuthDogrunmg.Dogrunmg.IsActiveManager() {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"無効化されたマネージャーには変更できません。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	transferred, wrErr := omh.or.TransferDogrun(c, dogrunID, fromDmID, req.DogrunmgID)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	// 同時に変更された場合
+	if !transferred {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"ドッグランの管理者が変更されています。再度お試しください。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	logger.Infof("Transferred Dogrun %d: %d -> %d", dogrunID, fromDmID, req.DogrunmgID)
+
+	return nil
+}
+
+// InviteManager: メールアドレスへのマネージャーの招待
+// 同じメールアドレスへの有効期限切れの招待は取り消して再招待する
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.OrgInvitationReq: 招待のリクエスト情報
+//
+// return:
+//   - dto.OrgInvitationRes: 招待情報
+//   - error: error情報
+func (omh *orgManagerHandler) InviteManager(c echo.Context, req dto.OrgInvitationReq) (dto.OrgInvitationRes, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	loginDm, wrErr := findLoginDogrunmg(c, omh.or)
+
+	if wrErr != nil {
+		return dto.OrgInvitationRes{}, wrErr
+	}
+
+	org, wrErr := omh.or.FindOrgByID(c, loginDm.OrganizationID.Int64)
+
+	if wrErr != nil {
+		return dto.OrgInvitationRes{}, wrErr
+	}
+
+	// 登録済みのEmailは招待できない
+	if wrErr := omh.af.OrgEmailValidate(c, req.Email); wrErr != nil {
+		return dto.OrgInvitationRes{}, wrErr
+	}
+
+	pending, wrErr := omh.or.FindPendingInvitation(c, org.OrganizationID.Int64, req.Email)
+
+	if wrErr != nil {
+		return dto.OrgInvitationRes{}, wrErr
+	}
+
+	if !pending.IsEmpty() {
+		if !pending.IsExpired(time.Now()) {
+			wrErr := wrErrors.NewWRError(
+				nil,
+				fmt.Sprintf("%sは既に招待済みです。", req.Email),
+				wrErrors.NewOrgClientErrorEType(),
+			)
+			logger.Error(wrErr)
+			return dto.OrgInvitationRes{}, wrErr
+		}
+		if _, wrErr := omh.or.CancelInvitation(c, pending.DogrunmgInvitationID.Int64); wrErr != nil {
+			return dto.OrgInvitationRes{}, wrErr
+		}
+	}
+
+	token, wrErr := generateInvitationToken(c)
+
+	if wrErr != nil {
+		return dto.OrgInvitationRes{}, wrErr
+	}
+
+	invitation := model.DogrunmgInvitation{
+		OrganizationID: org.OrganizationID,
+		Email:          wrUtil.NewSqlNullString(req.Email),
+		IsAdmin:        wrUtil.NewSqlNullBool(req.IsAdmin),
+		TokenHash:      wrUtil.NewSqlNullString(hashInvitationToken(token)),
+		InviterID:      loginDm.DogrunmgID,
+		Status:         wrUtil.NewSqlNullString(model.DOGRUNMG_INVITATION_STATUS_PENDING),
+		ExpiresAt:      wrUtil.NewSqlNullTime(time.Now().Add(INVITATION_EXPIRES)),
+	}
+
+	if wrErr := omh.or.CreateInvitation(c, &invitation); wrErr != nil {
+		return dto.OrgInvitationRes{}, wrErr
+	}
+
+	// 招待メールの送信。送信できなかった場合は招待を取り消す
+	if err := omh.ms.Send(c, mail.Mail{
+		To:      req.Email,
+		Subject: fmt.Sprintf("[wanrun] %sからマネージャーへの招待が届いています", org.Name.String),
+		Body: fmt.Sprintf(
+			"%sからドッグランのマネージャーとして招待されました。\n以下のURLから%sまでに登録してください。\n%s?token=%s",
+			org.Name.String,
+			invitation.ExpiresAt.Time.Format("2006-01-02 15:04"),
+			configs.FetchConfigStr("org.invitation.url"),
+			token,
+		),
+	}); err != nil {
+		if _, wrErr := omh.or.CancelInvitation(c, invitation.DogrunmgInvitationID.Int64); wrErr != nil {
+			return dto.OrgInvitationRes{}, wrErr
+		}
+		wrErr := wrErrors.NewWRError(
+			err,
+			"招待メールの送信に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+		logger.Error(wrErr)
+		return dto.OrgInvitationRes{}, wrErr
+	}
+
+	return toOrgInvitationRes(invitation), nil
+}
+
+// GetInvitations: organizationの招待一覧の取得
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//
+// return:
+//   - []dto.OrgInvitationRes: 招待の新しい順の招待情報
+//   - error: error情報
+func (omh *orgManagerHandler) GetInvitations(c echo.Context) ([]dto.OrgInvitationRes, error) {
+	loginDm, wrErr := findLoginDogrunmg(c, omh.or)
+
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	invitations, wrErr := omh.or.FindInvitationsByOrgID(c, loginDm.OrganizationID.Int64)
+
+	if wrErr != nil {
+		return nil, wrErr
+	}
+
+	res := []dto.OrgInvitationRes{}
+	for _, invitation := range invitations {
+		res = append(res, toOrgInvitationRes(invitation))
+	}
+
+	return res, nil
+}
+
+// CancelInvitation: 承諾待ちの招待の取り消し
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - int64: 招待のID
+//
+// return:
+//   - error: error情報
+func (omh *orgManagerHandler) CancelInvitation(c echo.Context, invitationID int64) error {
+	logger := log.GetLogger(c).Sugar()
+
+	loginDm, wrErr := findLoginDogrunmg(c, omh.or)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	invitation, wrErr := omh.or.FindInvitationByID(c, invitationID)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	if invitation.IsEmpty() || invitation.OrganizationID.Int64 != loginDm.OrganizationID.Int64 {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"招待が存在しません。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	cancelled, wrErr := omh.or.CancelInvitation(c, invitationID)
+
+	if wrErr != nil {
+		return wrErr
+	}
+
+	if !cancelled {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"承諾待ちの招待ではありません。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return wrErr
+	}
+
+	return nil
+}
+
+// AcceptInvitation: 招待の承諾。dogrunmgを登録し、署名済みjwtを返す
+//
+// args:
+//   - echo.Context: Echoのコンテキスト。リクエストやレスポンスにアクセスするために使用
+//   - dto.OrgInvitationAcceptReq: 承諾のリクエスト情報
+//
+// return:
+//   - string: 署名済みのjwt
+//   - error: error情報
+func (omh *orgManagerHandler) AcceptInvitation(c echo.Context, req dto.OrgInvitationAcceptReq) (string, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	// パスワードのハッシュ化
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+
+	if err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"パスワードに不正な文字列が入っています。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return "", wrErr
+	}
+
+	// JWT IDの生成
+	jwtID, wrErr := authHandler.GenerateJwtID(c)
+
+	if wrErr != nil {
+		return "", wrErr
+	}
+
+	credential := model.DogrunmgCredential{}
+	ctx := c.Request().Context()
+
+	// dogrunmgの作成トランザクション
+	if err := omh.tm.DoInTransaction(c, ctx, func(tx *gorm.DB) error {
+
+		// 同時に承諾されないよう招待をロックして取得
+		invitation, wrErr := omh.osr.FindInvitationByTokenHashForUpdate(tx, c, hashInvitationToken(req.Token))
+
+		if wrErr != nil {
+			return wrErr
+		}
+
+		if wrErr := validateAcceptableInvitation(c, invitation); wrErr != nil {
+			return wrErr
+		}
+
+		// 招待後に同じEmailが登録されていないか
+		if wrErr := omh.af.OrgEmailValidate(c, invitation.Email.String); wrErr != nil {
+			return wrErr
+		}
+
+		credential = model.DogrunmgCredential{
+			Email:    invitation.Email,
+			Password: wrUtil.NewSqlNullString(string(hash)),
+			AuthDogrunmg: model.AuthDogrunmg{
+				JwtID:   wrUtil.NewSqlNullString(jwtID),
+				IsAdmin: invitation.IsAdmin,
+				Dogrunmg: model.Dogrunmg{
+					Name:           wrUtil.NewSqlNullString(req.Name),
+					OrganizationID: invitation.OrganizationID,
+				},
+			},
+		}
+
+		// dogrunmgの作成
+		dmID, wrErr := omh.dmsr.CreateDogrunmg(tx, c, &credential.AuthDogrunmg.Dogrunmg)
+
+		if wrErr != nil {
+			return wrErr
+		}
+
+		credential.AuthDogrunmg.DogrunmgID = dmID
+
+		// AuthDogrunmgの作成
+		admID, wrErr := omh.asr.CreateAuthDogrunmg(tx, c, &credential.AuthDogrunmg)
+
+		if wrErr != nil {
+			return wrErr
+		}
+
+		credential.AuthDogrunmgID = admID
+
+		// DogrunmgのCredentialsの作成
+		if wrErr := omh.asr.CreateDogrunmgCredential(tx, c, &credential); wrErr != nil {
+			return wrErr
+		}
+
+		// 登録の監査ログ
+		roleID := core.DOGRUNMG_ROLE
+		if credential.AuthDogrunmg.IsAdmin.Bool {
+			roleID = core.DOGRUNMG_ADMIN_ROLE
+		}
+		if wrErr := omh.auf.Record(tx, c, auditDTO.AuditEntry{
+			ActorID:    dmID.Int64,
+			ActorRole:  roleID,
+			Action:     model.AUDIT_ACTION_SIGNUP,
+			Result:     model.AUDIT_RESULT_SUCCESS,
+			TargetType: model.AUDIT_TARGET_DOGRUNMG,
+			TargetID:   strconv.FormatInt(dmID.Int64, 10),
+			After: map[string]any{
+				"name":                 req.Name,
+				"email":                invitation.Email.String,
+				"organizationId":       invitation.OrganizationID.Int64,
+				"dogrunmgInvitationId": invitation.DogrunmgInvitationID.Int64,
+			},
+		}); wrErr != nil {
+			return wrErr
+		}
+
+		return omh.osr.AcceptInvitation(tx, c, invitation.DogrunmgInvitationID.Int64, dmID.Int64)
+
+	}); err != nil {
+		logger.Error("Transaction failed:", err)
+		return "", err
+	}
+
+	roleID := core.DOGRUNMG_ROLE
+	if credential.AuthDogrunmg.IsAdmin.Bool {
+		roleID = core.DOGRUNMG_ADMIN_ROLE
+	}
+
+	// 作成したdogrunmgの情報をdto詰め替え
+	dogrunmgDetail := authDTO.UserAuthInfoDTO{
+		UserID: credential.AuthDogrunmg.DogrunmgID.Int64,
+		JwtID:  jwtID,
+		RoleID: roleID,
+	}
+
+	logger.Infof("dogrunmgDetail: %v", dogrunmgDetail)
+
+	// 署名済みのjwt token取得
+	token, wrErr := authHandler.GetSignedJwt(c, dogrunmgDetail)
+
+	if wrErr != nil {
+		return "", wrErr
+	}
+
+	return token, nil
+}
+
+/*
+ログイン中のdogrunmgと同じorganizationに所属するdogrunmgの取得
+*/
+func (omh *orgManagerHandler) findOrgManager(c echo.Context, loginDm model.Dogrunmg, dmID int64) (model.DogrunmgCredential, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	credential, wrErr := omh.or.FindManagerCredential(c, dmID)
+
+	if wrErr != nil {
+		return model.DogrunmgCredential{}, wrErr
+	}
+
+	dm := credential.AuthDogrunmg.Dogrunmg
+	if dm.IsEmpty() || dm.OrganizationID.Int64 != loginDm.OrganizationID.Int64 {
+		wrErr := wrErrors.NewWRError(
+			nil,
+			"マネージャーが存在しません。",
+			wrErrors.NewOrgClientErrorEType(),
+		)
+		logger.Error(wrErr)
+		return model.DogrunmgCredential{}, wrErr
+	}
+
+	return credential, nil
+}
+
+/*
+更新後のdogrunmgのレスポンスの取得
+*/
+func (omh *orgManagerHandler) getManager(c echo.Context, loginDm model.Dogrunmg, dmID int64) (dto.OrgManagerRes, error) {
+	credential, wrErr := omh.findOrgManager(c, loginDm, dmID)
+
+	if wrErr != nil {
+		return dto.OrgManagerRes{}, wrErr
+	}
+
+	counts, wrErr := omh.or.CountDogrunsByManagerIDs(c, []int64{dmID})
+
+	if wrErr != nil {
+		return dto.OrgManagerRes{}, wrErr
+	}
+
+	return toOrgManagerRes(credential, counts[dmID]), nil
+}
+
+/*
+承諾できる招待かの確認
+*/
+func validateAcceptableInvitation(c echo.Context, invitation model.DogrunmgInvitation) error {
+	logger := log.GetLogger(c).Sugar()
+
+	var message string
+	switch {
+	case invitation.IsEmpty():
+		message = "招待が存在しません。"
+	case !invitation.IsPending():
+		message = "この招待は無効です。"
+	case invitation.IsExpired(time.Now()):
+		message = "招待の有効期限が切れています。"
+	default:
+		return nil
+	}
+
+	wrErr := wrErrors.NewWRError(
+		nil,
+		message,
+		wrErrors.NewOrgClientErrorEType(),
+	)
+	logger.Error(wrErr)
+	return wrErr
+}
+
+/*
+招待トークンの生成
+*/
+func generateInvitationToken(c echo.Context) (string, error) {
+	logger := log.GetLogger(c).Sugar()
+
+	b := make([]byte, INVITATION_TOKEN_LENGTH)
+	if _, err := rand.Read(b); err != nil {
+		wrErr := wrErrors.NewWRError(
+			err,
+			"招待トークンの生成に失敗しました。",
+			wrErrors.NewOrgServerErrorEType(),
+		)
+		logger.Error(wrErr)
+		return "", wrErr
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+/*
+招待トークンのハッシュ化。DBにはハッシュのみ保存する
+*/
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+/*
+dogrunmgのレスポンスへの変換
+*/
+func toOrgManagerRes(credential model.DogrunmgCredential, dogrunCount int64) dto.OrgManagerRes {
+	return dto.OrgManagerRes{
+		DogrunmgID:  credential.AuthDogrunmg.DogrunmgID.Int64,
+		Name:        credential.AuthDogrunmg.Dogrunmg.Name.String,
+		Email:       credential.Email.String,
+		IsAdmin:     credential.AuthDogrunmg.IsAdmin.Bool,
+		IsActive:    credential.AuthDogrunmg.Dogrunmg.IsActiveManager(),
+		DogrunCount: dogrunCount,
+	}
+}
+
+/*
+招待のレスポンスへの変換
+*/
+func toOrgInvitationRes(invitation model.DogrunmgInvitation) dto.OrgInvitationRes {
+	res := dto.OrgInvitationRes{
+		InvitationID: invitation.DogrunmgInvitationID.Int64,
+		Email:        invitation.Email.String,
+		IsAdmin:      invitation.IsAdmin.Bool,
+		Status:       invitation.Status.String,
+		InviterID:    invitation.InviterID.Int64,
+		ExpiresAt:    invitation.ExpiresAt.Time,
+		DogrunmgID:   invitation.DogrunmgID.Int64,
+		CreateAt:     invitation.CreateAt.Time,
+	}
+	if invitation.AcceptedAt.Valid {
+		res.AcceptedAt = &invitation.AcceptedAt.Time
+	}
+	return res
+}
@@ -0,0 +1,54 @@
+package wrcontext
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wanrun-develop/wanrun/pkg/log"
+)
+
+// ジョブ用のコンテキストの生成に使用する(ルーティングは持たない)
+var jobEcho = echo.New()
+
+// NewJobContext: HTTPリクエスト以外(定期実行のジョブなど)で、ハンドラーやリポジトリを呼び出すためのコンテキストを生成する
+// loggerとジョブの実行IDを設定し、ctxのキャンセルはc.Request().Context()で参照できる
+//
+// args:
+//   - context.Context:	ジョブのコンテキスト。停止時にキャンセルされる
+//   - string:	ジョブの実行ID。ログのrequest_idとして出力する
+//
+// return:
+//   - echo.Context:	ジョブ用のコンテキスト
+func NewJobContext(ctx context.Context, jobID string) echo.Context {
+	req := (&http.Request{
+		Method: http.MethodPost,
+		URL:    &url.URL{Path: "/"},
+		Header: http.Header{},
+	}).WithContext(ctx)
+
+	w := &discardResponseWriter{header: http.Header{}}
+	w.header.Set(echo.HeaderXRequestID, jobID)
+
+	c := jobEcho.NewContext(req, w)
+	c.Set("logger", log.NewJobLogger(jobID))
+	return c
+}
+
+/*
+ジョブ用のレスポンス。書き込みは破棄する
+*/
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w *discardResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *discardResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (w *discardResponseWriter) WriteHeader(int) {}
@@ -0,0 +1,5 @@
+ALTER TABLE dogrun_bookmarks DROP COLUMN IF EXISTS bookmark_folder_id;
+ALTER TABLE dogrun_bookmarks DROP COLUMN IF EXISTS note;
+ALTER TABLE dogrun_bookmarks DROP COLUMN IF EXISTS sort_order;
+
+DROP TABLE IF EXISTS bookmark_folders CASCADE;
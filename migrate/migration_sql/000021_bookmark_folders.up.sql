@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS bookmark_folders (
+    bookmark_folder_id serial primary key,
+    dog_owner_id bigint not null,
+    name varchar(64) not null,
+    sort_order int,
+    reg_at timestamp not null,
+    upd_at timestamp not null
+);
+
+CREATE INDEX idx_bookmark_folders_dogownerid
+ON bookmark_folders (dog_owner_id);
+
+ALTER TABLE dogrun_bookmarks ADD COLUMN IF NOT EXISTS bookmark_folder_id bigint;
+ALTER TABLE dogrun_bookmarks ADD COLUMN IF NOT EXISTS note text;
+ALTER TABLE dogrun_bookmarks ADD COLUMN IF NOT EXISTS sort_order int;
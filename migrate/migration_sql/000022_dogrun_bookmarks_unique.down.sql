@@ -0,0 +1 @@
+DROP INDEX IF EXISTS uq_dogrun_bookmarks_dogownerid_dogrunid;
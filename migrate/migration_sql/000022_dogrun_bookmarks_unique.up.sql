@@ -0,0 +1,9 @@
+-- 重複しているブックマークは古いものを残して削除
+DELETE FROM dogrun_bookmarks a
+USING dogrun_bookmarks b
+WHERE a.dog_owner_id = b.dog_owner_id
+  AND a.dogrun_id = b.dogrun_id
+  AND a.dogrun_bookmark_id > b.dogrun_bookmark_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_bookmarks_dogownerid_dogrunid
+ON dogrun_bookmarks (dog_owner_id, dogrun_id);
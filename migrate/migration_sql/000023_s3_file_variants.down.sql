@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS s3_file_variants CASCADE;
+
+ALTER TABLE s3_file_info DROP COLUMN IF EXISTS content_type;
@@ -0,0 +1,15 @@
+ALTER TABLE s3_file_info ADD COLUMN IF NOT EXISTS content_type VARCHAR(64);    -- MIMEタイプ
+
+CREATE TABLE IF NOT EXISTS s3_file_variants (
+    s3_file_variant_id serial primary key,          -- PK
+    s3_file_info_id INT NOT NULL,                   -- s3_file_infoのFK
+    variant VARCHAR(32) NOT NULL,                   -- バリエーション名
+    format VARCHAR(16) NOT NULL,                    -- 画像形式
+    width INT,                                      -- 幅(px)
+    height INT,                                     -- 高さ(px)
+    file_size BIGINT NOT NULL,                      -- ファイルサイズ
+    s3_object_key VARCHAR(256) NOT NULL,            -- S3のオブジェクトキー
+    reg_at timestamp not null                       -- 登録日
+);
+
+CREATE INDEX IF NOT EXISTS idx_s3_file_variants_s3_file_info_id ON s3_file_variants (s3_file_info_id);
@@ -0,0 +1,13 @@
+ALTER TABLE s3_file_info ADD COLUMN IF NOT EXISTS dog_owner_id INT;
+
+UPDATE s3_file_info SET dog_owner_id = owner_id WHERE owner_type = 'dogowner';
+
+-- dogowner以外の所有ファイルは戻せないため削除
+DELETE FROM s3_file_variants WHERE s3_file_info_id IN (SELECT s3_file_info_id FROM s3_file_info WHERE dog_owner_id IS NULL);
+DELETE FROM s3_file_info WHERE dog_owner_id IS NULL;
+
+ALTER TABLE s3_file_info ALTER COLUMN dog_owner_id SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_s3_file_info_owner;
+ALTER TABLE s3_file_info DROP COLUMN IF EXISTS owner_type;
+ALTER TABLE s3_file_info DROP COLUMN IF EXISTS owner_id;
@@ -0,0 +1,10 @@
+ALTER TABLE s3_file_info ADD COLUMN IF NOT EXISTS owner_type VARCHAR(16);       -- 所有者の種別(dogowner, dogrunmg, org)
+ALTER TABLE s3_file_info ADD COLUMN IF NOT EXISTS owner_id INT;                 -- 所有者のID
+
+UPDATE s3_file_info SET owner_type = 'dogowner', owner_id = dog_owner_id WHERE owner_type IS NULL;
+
+ALTER TABLE s3_file_info ALTER COLUMN owner_type SET NOT NULL;
+ALTER TABLE s3_file_info ALTER COLUMN owner_id SET NOT NULL;
+ALTER TABLE s3_file_info DROP COLUMN IF EXISTS dog_owner_id;
+
+CREATE INDEX IF NOT EXISTS idx_s3_file_info_owner ON s3_file_info (owner_type, owner_id);
@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS dog_temperaments CASCADE;
+DROP TABLE IF EXISTS temperament_mst CASCADE;
+
+DROP INDEX IF EXISTS uq_dogs_microchip_id;
+ALTER TABLE dogs DROP COLUMN IF EXISTS birth_date;
+ALTER TABLE dogs DROP COLUMN IF EXISTS neutered;
+ALTER TABLE dogs DROP COLUMN IF EXISTS microchip_id;
+
+-- 犬種は1つ目のみ戻す
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS dog_type_id int;
+UPDATE dogs d SET dog_type_id = (
+    SELECT MIN(ddt.dog_type_id) FROM dog_dog_types ddt WHERE ddt.dog_id = d.dog_id
+);
+
+DROP TABLE IF EXISTS dog_dog_types CASCADE;
@@ -0,0 +1,53 @@
+-- 犬種(ミックス犬のため複数)
+CREATE TABLE IF NOT EXISTS dog_dog_types (
+    dog_dog_type_id serial primary key,             -- PK
+    dog_id bigint not null,                         -- dogsのFK
+    dog_type_id int not null                        -- dog_type_mstのFK
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dog_dog_types_dogid_dogtypeid
+ON dog_dog_types (dog_id, dog_type_id);
+
+INSERT INTO dog_dog_types (dog_id, dog_type_id)
+SELECT dog_id, dog_type_id FROM dogs WHERE dog_type_id IS NOT NULL;
+
+ALTER TABLE dogs DROP COLUMN IF EXISTS dog_type_id;
+
+-- プロフィール項目
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS birth_date date;                -- 誕生日
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS neutered boolean;               -- 去勢・避妊済みか
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS microchip_id varchar(15);       -- マイクロチップ番号(15桁)
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogs_microchip_id
+ON dogs (microchip_id) WHERE microchip_id IS NOT NULL;
+
+-- 性格タグ
+CREATE TABLE IF NOT EXISTS temperament_mst (
+    temperament_id serial primary key,
+    name varchar(64) not null,
+    description text
+);
+
+CREATE TABLE IF NOT EXISTS dog_temperaments (
+    dog_temperament_id serial primary key,          -- PK
+    dog_id bigint not null,                         -- dogsのFK
+    temperament_id int not null                     -- temperament_mstのFK
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dog_temperaments_dogid_temperamentid
+ON dog_temperaments (dog_id, temperament_id);
+
+-- マスターデータ
+INSERT INTO temperament_mst (temperament_id, name, description) VALUES (1, '人懐っこい', '初対面の人にもフレンドリーです。');
+INSERT INTO temperament_mst (temperament_id, name, description) VALUES (2, '犬好き', 'ほかのワンちゃんと遊ぶのが好きです。');
+INSERT INTO temperament_mst (temperament_id, name, description) VALUES (3, '怖がり', '大きな音や知らない相手が苦手です。');
+INSERT INTO temperament_mst (temperament_id, name, description) VALUES (4, '人見知り', '慣れるまで少し時間がかかります。');
+INSERT INTO temperament_mst (temperament_id, name, description) VALUES (5, '活発', '走り回るのが大好きです。');
+INSERT INTO temperament_mst (temperament_id, name, description) VALUES (6, 'おっとり', 'マイペースで落ち着いています。');
+INSERT INTO temperament_mst (temperament_id, name, description) VALUES (7, '遊び好き', 'ボールやおもちゃで遊ぶのが好きです。');
+INSERT INTO temperament_mst (temperament_id, name, description) VALUES (8, '吠えやすい', '興奮すると吠えることがあります。');
+INSERT INTO temperament_mst (temperament_id, name, description) VALUES (9, '大型犬が苦手', '大きなワンちゃんが苦手です。');
+INSERT INTO temperament_mst (temperament_id, name, description) VALUES (10, '小型犬が苦手', '小さなワンちゃんが苦手です。');
+
+-- 初期データを考慮して、シーケンスの初期値を設定
+ALTER SEQUENCE temperament_mst_temperament_id_seq RESTART WITH 1000;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS dogrun_entry_criteria CASCADE;
@@ -0,0 +1,10 @@
+-- ドッグランの入場条件(ドッグランごとに1レコード)
+CREATE TABLE IF NOT EXISTS dogrun_entry_criteria (
+    dogrun_id bigint primary key,                   -- dogrunsのFK
+    allowed_size_classes varchar(64),               -- 入場可能なサイズ(カンマ区切り。nullは制限なし)
+    neutered_required boolean not null default false,   -- 去勢・避妊必須
+    microchip_required boolean not null default false,  -- マイクロチップ必須
+    min_age_months int,                             -- 入場可能な月齢
+    reg_at timestamp not null,                      -- 登録日
+    upd_at timestamp not null                       -- 更新日
+);
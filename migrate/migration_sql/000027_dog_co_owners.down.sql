@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS dog_ownership_invitations;
+DROP TABLE IF EXISTS dog_co_owners;
@@ -0,0 +1,33 @@
+-- dogの共同飼い主(主たる飼い主はdogs.dog_owner_id)
+CREATE TABLE IF NOT EXISTS dog_co_owners (
+    dog_co_owner_id serial primary key,     -- PK
+    dog_id bigint not null,                 -- dogsのFK
+    dog_owner_id bigint not null,           -- dog_ownersのFK
+    reg_at timestamp not null,              -- 登録日
+    upd_at timestamp not null               -- 更新日
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dog_co_owners_dogid_dogownerid
+ON dog_co_owners (dog_id, dog_owner_id);
+CREATE INDEX IF NOT EXISTS idx_dog_co_owners_dogownerid
+ON dog_co_owners (dog_owner_id);
+
+-- 共同飼い主の招待と譲渡の申請。招待されたdog_ownerの承認で反映する
+CREATE TABLE IF NOT EXISTS dog_ownership_invitations (
+    dog_ownership_invitation_id serial primary key, -- PK
+    dog_id bigint not null,                         -- dogsのFK
+    inviter_id bigint not null,                     -- 招待したdog_owner
+    invitee_id bigint not null,                     -- 招待されたdog_owner
+    invitation_type varchar(16) not null,           -- co_owner: 共同飼い主の招待, transfer: 譲渡
+    keep_as_co_owner boolean not null default false, -- 譲渡後に元の飼い主を共同飼い主として残すか
+    status varchar(16) not null default 'pending',  -- pending, accepted, declined, canceled
+    expires_at timestamp not null,                  -- 承認期限
+    responded_at timestamp,                         -- 承認、辞退、取消の日時
+    reg_at timestamp not null,                      -- 登録日
+    upd_at timestamp not null                       -- 更新日
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dog_ownership_invitations_pending
+ON dog_ownership_invitations (dog_id, invitee_id, invitation_type) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_dog_ownership_invitations_inviteeid_status
+ON dog_ownership_invitations (invitee_id, status);
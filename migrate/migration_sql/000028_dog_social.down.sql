@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS dog_friendships;
+DROP TABLE IF EXISTS dog_social_profiles;
@@ -0,0 +1,27 @@
+-- dogの公開プロフィールと公開設定(未登録のdogは非公開)
+CREATE TABLE IF NOT EXISTS dog_social_profiles (
+    dog_id bigint primary key,                              -- dogsのFK
+    bio varchar(500),                                       -- 自己紹介
+    profile_visibility varchar(16) not null default 'private', -- プロフィールの公開範囲(public, friends, private)
+    checkin_visibility varchar(16) not null default 'friends', -- チェックインの公開範囲(friends, private)
+    allow_friend_requests boolean not null default true,    -- 友達申請を受け付けるか
+    reg_at timestamp not null,                              -- 登録日
+    upd_at timestamp not null                               -- 更新日
+);
+
+-- dog同士の友達(申請中を含む)
+CREATE TABLE IF NOT EXISTS dog_friendships (
+    dog_friendship_id serial primary key,           -- PK
+    requester_dog_id bigint not null,               -- 申請したdog
+    addressee_dog_id bigint not null,               -- 申請されたdog
+    status varchar(16) not null default 'pending',  -- pending, accepted, declined
+    responded_at timestamp,                         -- 承認、辞退の日時
+    reg_at timestamp not null,                      -- 登録日
+    upd_at timestamp not null                       -- 更新日
+);
+
+-- 同じ組み合わせは向きに関係なく1レコード
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dog_friendships_dogids
+ON dog_friendships (LEAST(requester_dog_id, addressee_dog_id), GREATEST(requester_dog_id, addressee_dog_id));
+CREATE INDEX IF NOT EXISTS idx_dog_friendships_addresseedogid_status
+ON dog_friendships (addressee_dog_id, status);
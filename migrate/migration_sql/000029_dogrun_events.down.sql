@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS dogrun_event_rsvps;
+DROP TABLE IF EXISTS dogrun_events;
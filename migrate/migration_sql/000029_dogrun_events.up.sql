@@ -0,0 +1,33 @@
+-- ドッグランのイベント(dogownerの主催するオフ会、ドッグランマネージャーの公式イベント)
+CREATE TABLE IF NOT EXISTS dogrun_events (
+    dogrun_event_id serial primary key,             -- PK
+    dogrun_id bigint not null,                      -- dogrunsのFK
+    event_type varchar(16) not null,                -- meetup: オフ会, official: 公式イベント
+    organizer_dog_owner_id bigint,                  -- オフ会の主催者
+    organizer_dogrun_manager_id bigint,             -- 公式イベントの主催者
+    title varchar(128) not null,                    -- タイトル
+    description text,                               -- 説明
+    start_at timestamp not null,                    -- 開始日時
+    end_at timestamp not null,                      -- 終了日時
+    capacity int,                                   -- 参加可能なdogの数。nullは制限なし
+    allowed_size_classes varchar(64),               -- 参加可能なサイズ区分(カンマ区切り)。nullは制限なし
+    special_business_hours_id bigint,               -- 公式イベントに合わせて設定した特別営業時間
+    status varchar(16) not null default 'scheduled', -- scheduled, cancelled
+    reg_at timestamp not null,                      -- 登録日
+    upd_at timestamp not null                       -- 更新日
+);
+
+CREATE INDEX IF NOT EXISTS idx_dogrun_events_dogrunid_startat
+ON dogrun_events (dogrun_id, start_at);
+
+-- イベントへの参加表明(dog単位)
+CREATE TABLE IF NOT EXISTS dogrun_event_rsvps (
+    dogrun_event_rsvp_id serial primary key,        -- PK
+    dogrun_event_id bigint not null,                -- dogrun_eventsのFK
+    dog_id bigint not null,                         -- 参加するdog
+    dog_owner_id bigint not null,                   -- 参加表明したdogowner
+    reg_at timestamp not null                       -- 登録日
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_event_rsvps_dogruneventid_dogid
+ON dogrun_event_rsvps (dogrun_event_id, dog_id);
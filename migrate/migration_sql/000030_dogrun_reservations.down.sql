@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS dogrun_reservation_dogs;
+DROP TABLE IF EXISTS dogrun_reservations;
+DROP TABLE IF EXISTS dogrun_booking_slots;
+DROP TABLE IF EXISTS dogrun_booking_settings;
@@ -0,0 +1,55 @@
+-- 予約を受け付ける管理ドッグランの予約設定
+CREATE TABLE IF NOT EXISTS dogrun_booking_settings (
+    dogrun_id bigint primary key,                       -- dogrunsのFK
+    slot_minutes int not null,                          -- 1枠の時間(分)
+    slot_capacity int not null,                         -- 1枠に予約できるdogの数
+    allow_private_rental boolean not null default false, -- 貸切予約を受け付けるか
+    reg_at timestamp not null,                          -- 登録日
+    upd_at timestamp not null                           -- 更新日
+);
+
+-- 予約枠(通常営業時間から生成)
+CREATE TABLE IF NOT EXISTS dogrun_booking_slots (
+    dogrun_booking_slot_id serial primary key,      -- PK
+    dogrun_id bigint not null,                      -- dogrunsのFK
+    start_at timestamp not null,                    -- 開始日時
+    end_at timestamp not null,                      -- 終了日時
+    capacity int not null,                          -- 予約できるdogの数
+    reserved_count int not null default 0,          -- 予約済みのdogの数
+    is_private boolean not null default false,      -- 貸切予約済みか
+    reg_at timestamp not null,                      -- 登録日
+    upd_at timestamp not null                       -- 更新日
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_booking_slots_dogrunid_startat
+ON dogrun_booking_slots (dogrun_id, start_at);
+
+-- 予約
+CREATE TABLE IF NOT EXISTS dogrun_reservations (
+    dogrun_reservation_id serial primary key,           -- PK
+    dogrun_booking_slot_id bigint not null,             -- dogrun_booking_slotsのFK
+    dogrun_id bigint not null,                          -- dogrunsのFK
+    dog_owner_id bigint not null,                       -- 予約したdogowner
+    reservation_type varchar(16) not null,              -- normal: 通常予約, private: 貸切予約
+    dog_count int not null,                             -- 予約したdogの数
+    status varchar(16) not null default 'reserved',     -- reserved, cancelled, no_show
+    cancelled_at timestamp,                             -- キャンセル日時
+    no_show_at timestamp,                               -- 無断キャンセルの記録日時
+    reg_at timestamp not null,                          -- 登録日
+    upd_at timestamp not null                           -- 更新日
+);
+
+CREATE INDEX IF NOT EXISTS idx_dogrun_reservations_dogownerid_status
+ON dogrun_reservations (dog_owner_id, status);
+CREATE INDEX IF NOT EXISTS idx_dogrun_reservations_dogrunbookingslotid
+ON dogrun_reservations (dogrun_booking_slot_id);
+
+-- 予約したdog
+CREATE TABLE IF NOT EXISTS dogrun_reservation_dogs (
+    dogrun_reservation_dog_id serial primary key,   -- PK
+    dogrun_reservation_id bigint not null,          -- dogrun_reservationsのFK
+    dog_id bigint not null                          -- dogsのFK
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_reservation_dogs_dogrunreservationid_dogid
+ON dogrun_reservation_dogs (dogrun_reservation_id, dog_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS dogrun_payments;
+DROP TABLE IF EXISTS dogrun_payment_settings;
+DROP TABLE IF EXISTS dogrun_fee_plans;
@@ -0,0 +1,54 @@
+-- ドッグランの料金プラン
+CREATE TABLE IF NOT EXISTS dogrun_fee_plans (
+    dogrun_fee_plan_id serial primary key,          -- PK
+    dogrun_id bigint not null,                      -- dogrunsのFK
+    name varchar(64) not null,                      -- プラン名
+    fee_type varchar(16) not null,                  -- per_visit: 1回ごと, per_dog: 1頭ごと, membership: 会員
+    amount int not null,                            -- 料金(円)
+    membership_days int,                            -- 会員の有効日数(membershipのみ)
+    is_active boolean not null default true,        -- 販売中か
+    reg_at timestamp not null,                      -- 登録日
+    upd_at timestamp not null                       -- 更新日
+);
+
+CREATE INDEX IF NOT EXISTS idx_dogrun_fee_plans_dogrunid
+ON dogrun_fee_plans (dogrun_id);
+
+-- ドッグランの支払い設定
+CREATE TABLE IF NOT EXISTS dogrun_payment_settings (
+    dogrun_id bigint primary key,                               -- dogrunsのFK
+    checkin_payment_required boolean not null default false,     -- チェックインに支払いを必須とするか
+    reservation_payment_required boolean not null default false, -- 予約に支払いを必須とするか
+    reg_at timestamp not null,                                  -- 登録日
+    upd_at timestamp not null                                   -- 更新日
+);
+
+-- 支払い
+CREATE TABLE IF NOT EXISTS dogrun_payments (
+    dogrun_payment_id serial primary key,           -- PK
+    dogrun_id bigint not null,                      -- dogrunsのFK
+    dogrun_fee_plan_id bigint not null,             -- dogrun_fee_plansのFK
+    dog_owner_id bigint not null,                   -- 支払ったdogowner
+    fee_type varchar(16) not null,                  -- 支払い時点の料金プランの種別
+    amount int not null,                            -- 支払い金額(円)
+    dog_count int not null,                         -- 対象のdogの数
+    dogrun_reservation_id bigint,                   -- 支払いを充てた予約
+    provider varchar(32) not null,                  -- 決済プロバイダ
+    provider_payment_id varchar(128),               -- 決済プロバイダ側の決済ID
+    status varchar(16) not null default 'pending',  -- pending, succeeded, failed
+    failure_reason varchar(256),                    -- 失敗理由
+    idempotency_key varchar(64) not null,           -- 二重決済防止のキー(dogownerごと)
+    paid_at timestamp,                              -- 支払い完了日時
+    valid_until timestamp,                          -- 入場に使える期限
+    reg_at timestamp not null,                      -- 登録日
+    upd_at timestamp not null                       -- 更新日
+);
+
+CREATE INDEX IF NOT EXISTS idx_dogrun_payments_dogrunid_regat
+ON dogrun_payments (dogrun_id, reg_at);
+CREATE INDEX IF NOT EXISTS idx_dogrun_payments_dogownerid_dogrunid
+ON dogrun_payments (dog_owner_id, dogrun_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_payments_dogrunreservationid
+ON dogrun_payments (dogrun_reservation_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_payments_dogownerid_idempotencykey
+ON dogrun_payments (dog_owner_id, idempotency_key);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS dogrun_membership_documents;
+DROP TABLE IF EXISTS dogrun_membership_dogs;
+DROP TABLE IF EXISTS dogrun_memberships;
+ALTER TABLE dogrun_entry_criteria DROP COLUMN IF EXISTS members_only;
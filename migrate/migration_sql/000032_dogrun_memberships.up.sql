@@ -0,0 +1,46 @@
+-- 会員限定のドッグランか
+ALTER TABLE dogrun_entry_criteria ADD COLUMN IF NOT EXISTS members_only boolean not null default false;
+
+-- ドッグランの会員登録
+CREATE TABLE IF NOT EXISTS dogrun_memberships (
+    dogrun_membership_id serial primary key,        -- PK
+    dogrun_id bigint not null,                      -- dogrunsのFK
+    dog_owner_id bigint not null,                   -- 申請したdogowner
+    status varchar(16) not null default 'pending',  -- pending, approved, rejected, withdrawn
+    membership_number varchar(32),                  -- 承認時に発行する会員番号
+    application_note varchar(512),                  -- 申請時のメモ
+    review_note varchar(512),                       -- 審査時のメモ(却下理由など)
+    reviewed_at timestamp,                          -- 審査日時
+    reg_at timestamp not null,                      -- 登録日
+    upd_at timestamp not null                       -- 更新日
+);
+
+CREATE INDEX IF NOT EXISTS idx_dogrun_memberships_dogrunid_status
+ON dogrun_memberships (dogrun_id, status);
+CREATE INDEX IF NOT EXISTS idx_dogrun_memberships_dogownerid
+ON dogrun_memberships (dog_owner_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_memberships_membershipnumber
+ON dogrun_memberships (membership_number);
+-- 審査中・承認済みの申請は1ドッグランにつき1件まで
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_memberships_dogrunid_dogownerid
+ON dogrun_memberships (dogrun_id, dog_owner_id) WHERE status IN ('pending', 'approved');
+
+-- 会員登録の対象のdog
+CREATE TABLE IF NOT EXISTS dogrun_membership_dogs (
+    dogrun_membership_dog_id serial primary key,    -- PK
+    dogrun_membership_id bigint not null,           -- dogrun_membershipsのFK
+    dog_id bigint not null                          -- dogsのFK
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_membership_dogs_dogrunmembershipid_dogid
+ON dogrun_membership_dogs (dogrun_membership_id, dog_id);
+
+-- 会員登録の添付書類(ワクチン証明書など)
+CREATE TABLE IF NOT EXISTS dogrun_membership_documents (
+    dogrun_membership_document_id serial primary key, -- PK
+    dogrun_membership_id bigint not null,             -- dogrun_membershipsのFK
+    file_id varchar(64) not null                      -- s3_file_infoのfile_id
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_membership_documents_dogrunmembershipid_fileid
+ON dogrun_membership_documents (dogrun_membership_id, file_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS dogrun_manager_invitations;
+ALTER TABLE dogrun_managers DROP COLUMN IF EXISTS is_active;
+ALTER TABLE organizations DROP COLUMN IF EXISTS closed_at;
@@ -0,0 +1,29 @@
+-- 組織の退会日時
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS closed_at timestamp;
+
+-- マネージャーが有効か(無効化されたマネージャーはログイン不可)
+ALTER TABLE dogrun_managers ADD COLUMN IF NOT EXISTS is_active boolean not null default true;
+
+-- マネージャーの招待
+CREATE TABLE IF NOT EXISTS dogrun_manager_invitations (
+    dogrun_manager_invitation_id serial primary key, -- PK
+    organization_id bigint not null,                 -- organizationsのFK
+    email varchar(255) not null,                     -- 招待先のメールアドレス
+    is_admin boolean not null default false,         -- adminとして招待するか
+    token_hash varchar(64) not null,                 -- 招待トークンのハッシュ(SHA-256)
+    inviter_id bigint not null,                      -- 招待したマネージャー
+    status varchar(16) not null default 'pending',   -- pending, accepted, cancelled
+    expires_at timestamp not null,                   -- 招待の有効期限
+    accepted_at timestamp,                           -- 承諾日時
+    dogrun_manager_id bigint,                        -- 承諾して登録されたマネージャー
+    reg_at timestamp not null,                       -- 登録日
+    upd_at timestamp not null                        -- 更新日
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_manager_invitations_tokenhash
+ON dogrun_manager_invitations (token_hash);
+CREATE INDEX IF NOT EXISTS idx_dogrun_manager_invitations_organizationid
+ON dogrun_manager_invitations (organization_id);
+-- 同じ組織から同じメールアドレスへの承諾待ちの招待は1件まで
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_manager_invitations_pending
+ON dogrun_manager_invitations (organization_id, email) WHERE status = 'pending';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS dogrun_claim_histories;
+DROP TABLE IF EXISTS dogrun_claim_documents;
+DROP TABLE IF EXISTS dogrun_claims;
@@ -0,0 +1,47 @@
+-- ドッグランの管理申請(既存のドッグランをorganizationの管理下にする)
+CREATE TABLE IF NOT EXISTS dogrun_claims (
+    dogrun_claim_id serial primary key,             -- PK
+    dogrun_id bigint not null,                      -- dogrunsのFK
+    dogrun_manager_id bigint not null,              -- 申請したマネージャー(承認後の管理者)
+    status varchar(16) not null default 'pending',  -- pending, approved, rejected, withdrawn
+    evidence_note varchar(1024) not null,           -- 管理者であることの説明
+    review_note varchar(512),                       -- 審査時のメモ(却下理由など)
+    reviewed_by bigint,                             -- 審査したシステムユーザー
+    reviewed_at timestamp,                          -- 審査日時
+    reg_at timestamp not null,                      -- 登録日
+    upd_at timestamp not null                       -- 更新日
+);
+
+CREATE INDEX IF NOT EXISTS idx_dogrun_claims_status
+ON dogrun_claims (status);
+CREATE INDEX IF NOT EXISTS idx_dogrun_claims_dogrunmanagerid
+ON dogrun_claims (dogrun_manager_id);
+-- 審査中の申請は1ドッグランにつきマネージャーごとに1件まで
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_claims_dogrunid_dogrunmanagerid
+ON dogrun_claims (dogrun_id, dogrun_manager_id) WHERE status = 'pending';
+
+-- 管理申請の証拠書類(営業許可証など)
+CREATE TABLE IF NOT EXISTS dogrun_claim_documents (
+    dogrun_claim_document_id serial primary key,    -- PK
+    dogrun_claim_id bigint not null,                -- dogrun_claimsのFK
+    file_id varchar(64) not null                    -- s3_file_infoのfile_id
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_claim_documents_dogrunclaimid_fileid
+ON dogrun_claim_documents (dogrun_claim_id, file_id);
+
+-- 管理申請の履歴(申請、審査、取り下げの操作記録)
+CREATE TABLE IF NOT EXISTS dogrun_claim_histories (
+    dogrun_claim_history_id serial primary key,     -- PK
+    dogrun_claim_id bigint not null,                -- dogrun_claimsのFK
+    action varchar(16) not null,                    -- submit, approve, reject, withdraw, supersede
+    from_status varchar(16),                        -- 操作前のステータス
+    to_status varchar(16) not null,                 -- 操作後のステータス
+    actor_role int not null,                        -- 操作したユーザーのロール
+    actor_id bigint not null,                       -- 操作したユーザーのID
+    note varchar(512),                              -- 操作時のメモ
+    reg_at timestamp not null                       -- 登録日
+);
+
+CREATE INDEX IF NOT EXISTS idx_dogrun_claim_histories_dogrunclaimid
+ON dogrun_claim_histories (dogrun_claim_id);
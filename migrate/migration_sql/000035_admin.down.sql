@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS admin_action_logs;
+DROP INDEX IF EXISTS idx_dogruns_mergedintodogrunid;
+ALTER TABLE dogruns DROP COLUMN IF EXISTS merged_into_dogrun_id;
+ALTER TABLE dogrun_managers DROP COLUMN IF EXISTS suspension_reason;
+ALTER TABLE dogrun_managers DROP COLUMN IF EXISTS suspended_at;
+ALTER TABLE dog_owners DROP COLUMN IF EXISTS suspension_reason;
+ALTER TABLE dog_owners DROP COLUMN IF EXISTS suspended_at;
@@ -0,0 +1,29 @@
+-- アカウントの利用停止(利用停止中はログイン不可)
+ALTER TABLE dog_owners ADD COLUMN IF NOT EXISTS suspended_at timestamp;
+ALTER TABLE dog_owners ADD COLUMN IF NOT EXISTS suspension_reason varchar(512);
+ALTER TABLE dogrun_managers ADD COLUMN IF NOT EXISTS suspended_at timestamp;
+ALTER TABLE dogrun_managers ADD COLUMN IF NOT EXISTS suspension_reason varchar(512);
+
+-- 重複したドッグランの統合先(統合されたドッグランは検索対象外)
+ALTER TABLE dogruns ADD COLUMN IF NOT EXISTS merged_into_dogrun_id bigint;
+
+CREATE INDEX IF NOT EXISTS idx_dogruns_mergedintodogrunid
+ON dogruns (merged_into_dogrun_id);
+
+-- 運営者の操作記録
+CREATE TABLE IF NOT EXISTS admin_action_logs (
+    admin_action_log_id serial primary key,         -- PK
+    system_operator_id bigint not null,             -- 操作した運営者
+    action varchar(32) not null,                    -- 操作内容(suspend, merge_dogrun, moderate など)
+    target_type varchar(32) not null,               -- 操作対象の種別(dogowner, dogrun, tag_mst など)
+    target_id varchar(64) not null,                 -- 操作対象のID
+    note varchar(512),                              -- 操作理由
+    detail text,                                    -- 操作内容の詳細(JSON)
+    reg_at timestamp not null                       -- 登録日
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_action_logs_targettype_targetid
+ON admin_action_logs (target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_admin_action_logs_systemoperatorid
+ON admin_action_logs (system_operator_id);
+
@@ -0,0 +1,34 @@
+-- セキュリティ関連の操作の監査ログ(追記のみ)
+CREATE TABLE IF NOT EXISTS audit_logs (
+    audit_log_id bigserial primary key,             -- PK
+    actor_id bigint,                                -- 操作したユーザーのID(未ログインの場合はnull)
+    actor_role int,                                 -- 操作したユーザーのロール
+    action varchar(32) not null,                    -- 操作内容(login, revoke, signup, delete_file など)
+    result varchar(16) not null,                    -- 結果(success, failure)
+    target_type varchar(32) not null,               -- 操作対象の種別(dogowner, dogrunmg, dog, file など)
+    target_id varchar(256),                         -- 操作対象のID(ログイン失敗時は入力されたメールアドレスなど)
+    request_id varchar(64),                         -- リクエストID(X-Request-ID)
+    ip_address varchar(64),                         -- 接続元のIPアドレス
+    before_data text,                               -- 変更前の値(変更された項目のみのJSON)
+    after_data text,                                -- 変更後の値(変更された項目のみのJSON)
+    reg_at timestamp not null                       -- 登録日
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actorid_actorrole
+ON audit_logs (actor_id, actor_role);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_targettype_targetid
+ON audit_logs (target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_regat
+ON audit_logs (reg_at);
+
+-- 監査ログの更新・削除の禁止
+CREATE OR REPLACE FUNCTION reject_audit_log_modification() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_audit_logs_append_only ON audit_logs;
+CREATE TRIGGER trg_audit_logs_append_only
+BEFORE UPDATE OR DELETE ON audit_logs
+FOR EACH ROW EXECUTE FUNCTION reject_audit_log_modification();
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_dogrun_tags_tagid;
+DROP INDEX IF EXISTS uq_dogrun_tags_dogrunid_tagid;
+DROP INDEX IF EXISTS idx_tag_mst_category;
+ALTER TABLE tag_mst DROP COLUMN IF EXISTS category;
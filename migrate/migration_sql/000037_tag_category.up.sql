@@ -0,0 +1,21 @@
+-- タグの分類(facility: 設備, ground: 地面, size_zone: 犬の大きさ別エリア, rule: 利用ルール, other: その他)
+ALTER TABLE tag_mst ADD COLUMN IF NOT EXISTS category varchar(32) not null default 'other';
+
+UPDATE tag_mst SET category = 'facility' WHERE tag_id IN (1, 2, 7, 8, 9, 16, 18, 19, 20, 26);
+UPDATE tag_mst SET category = 'ground' WHERE tag_id IN (14);
+UPDATE tag_mst SET category = 'size_zone' WHERE tag_id IN (10, 11, 12, 13);
+UPDATE tag_mst SET category = 'rule' WHERE tag_id IN (3, 4, 5, 6, 22, 23);
+
+CREATE INDEX IF NOT EXISTS idx_tag_mst_category
+ON tag_mst (category);
+
+-- 同じドッグランに同じタグは1つのみ
+DELETE FROM dogrun_tags s WHERE EXISTS (
+    SELECT 1 FROM dogrun_tags t
+    WHERE t.dogrun_id = s.dogrun_id AND t.tag_id = s.tag_id AND t.dogrun_tag_id < s.dogrun_tag_id
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_tags_dogrunid_tagid
+ON dogrun_tags (dogrun_id, tag_id);
+-- タグごとの件数集計用
+CREATE INDEX IF NOT EXISTS idx_dogrun_tags_tagid
+ON dogrun_tags (tag_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_dogruns_search_address;
+DROP INDEX IF EXISTS idx_dogruns_search_text;
+DROP FUNCTION IF EXISTS normalize_search_text(text);
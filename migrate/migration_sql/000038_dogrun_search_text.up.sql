@@ -0,0 +1,21 @@
+-- 全文検索(部分一致)用の拡張
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- 検索用の文字列の正規化
+-- 全角英数・半角カナなどをNFKCで統一し、小文字化・カタカナをひらがな・異体字を常用字に寄せる
+CREATE OR REPLACE FUNCTION normalize_search_text(t text) RETURNS text AS $$
+    SELECT translate(
+        lower(normalize(coalesce(t, ''), NFKC)),
+        'ァアィイゥウェエォオカガキギクグケゲコゴサザシジスズセゼソゾタダチヂッツヅテデトドナニヌネノハバパヒビピフブプヘベペホボポマミムメモャヤュユョヨラリルレロヮワヰヱヲンヴヵヶ髙﨑嶋邉邊澤濱齋齊櫻廣眞國',
+        'ぁあぃいぅうぇえぉおかがきぎくぐけげこごさざしじすずせぜそぞただちぢっつづてでとどなにぬねのはばぱひびぴふぶぷへべぺほぼぽまみむめもゃやゅゆょよらりるれろゎわゐゑをんゔゕゖ高崎島辺辺沢浜斎斉桜広真国'
+    );
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
+-- ドッグランの名前・住所・説明のキーワード検索用
+CREATE INDEX IF NOT EXISTS idx_dogruns_search_text
+ON dogruns USING gin (
+    normalize_search_text(coalesce(name, '') || ' ' || coalesce(address, '') || ' ' || coalesce(description, '')) gin_trgm_ops
+);
+-- 都道府県での住所検索用
+CREATE INDEX IF NOT EXISTS idx_dogruns_search_address
+ON dogruns USING gin (normalize_search_text(address) gin_trgm_ops);
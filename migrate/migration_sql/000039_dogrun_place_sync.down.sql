@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS dogrun_place_sync_histories;
+DROP INDEX IF EXISTS idx_dogruns_placesyncedat;
+ALTER TABLE dogruns DROP COLUMN IF EXISTS place_synced_at;
+ALTER TABLE dogruns DROP COLUMN IF EXISTS google_opening_hours;
+ALTER TABLE dogruns DROP COLUMN IF EXISTS google_user_rating_count;
+ALTER TABLE dogruns DROP COLUMN IF EXISTS google_rating;
+ALTER TABLE dogruns DROP COLUMN IF EXISTS is_closed_permanently;
+ALTER TABLE dogruns DROP COLUMN IF EXISTS business_status;
@@ -0,0 +1,25 @@
+-- google place情報の同期
+ALTER TABLE dogruns ADD COLUMN IF NOT EXISTS business_status varchar(32);                          -- 営業状況(OPERATIONAL, CLOSED_TEMPORARILY, CLOSED_PERMANENTLY)
+ALTER TABLE dogruns ADD COLUMN IF NOT EXISTS is_closed_permanently boolean not null default false;  -- googleで閉業と報告されたか
+ALTER TABLE dogruns ADD COLUMN IF NOT EXISTS google_rating decimal(2, 1);                           -- googleの評価
+ALTER TABLE dogruns ADD COLUMN IF NOT EXISTS google_user_rating_count int;                          -- googleの評価件数
+ALTER TABLE dogruns ADD COLUMN IF NOT EXISTS google_opening_hours text;                             -- googleの営業時間(JSON)
+ALTER TABLE dogruns ADD COLUMN IF NOT EXISTS place_synced_at timestamp;                             -- google place情報の最終同期日時
+
+-- 同期対象の選択用(最終同期日時の古い順)
+CREATE INDEX IF NOT EXISTS idx_dogruns_placesyncedat
+ON dogruns (place_synced_at NULLS FIRST) WHERE place_id IS NOT NULL;
+
+-- google place情報の同期による変更履歴(変更された項目ごと)
+CREATE TABLE IF NOT EXISTS dogrun_place_sync_histories (
+    dogrun_place_sync_history_id bigserial primary key, -- PK
+    dogrun_id bigint not null,                          -- dogrunsのFK
+    place_id varchar(256) not null,                     -- 同期したplaceId
+    field_name varchar(64) not null,                    -- 変更された項目(dogrunsのカラム名)
+    before_value text,                                  -- 変更前の値
+    after_value text,                                   -- 変更後の値
+    reg_at timestamp not null                           -- 同期日時
+);
+
+CREATE INDEX IF NOT EXISTS idx_dogrun_place_sync_histories_dogrunid
+ON dogrun_place_sync_histories (dogrun_id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS dogrun_field_provenances;
@@ -0,0 +1,20 @@
+-- ドッグラン情報の項目ごとの出所(マージの優先度と、最終確認日時の表示に使用)
+CREATE TABLE IF NOT EXISTS dogrun_field_provenances (
+    dogrun_field_provenance_id bigserial primary key, -- PK
+    dogrun_id bigint not null,                        -- dogrunsのFK
+    field_name varchar(32) not null,                  -- 項目(name, address, postcode, location, description)
+    source varchar(16) not null,                      -- 出所(manager, imported, google)
+    verified_at timestamp not null                    -- 最終確認日時
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_dogrun_field_provenances_dogrunid_fieldname
+ON dogrun_field_provenances (dogrun_id, field_name);
+
+-- google place情報の同期で更新済みの項目(管理されていないドッグランのみ同期で更新していた)
+INSERT INTO dogrun_field_provenances (dogrun_id, field_name, source, verified_at)
+SELECT d.dogrun_id, f.field_name, 'google', d.place_synced_at
+FROM dogruns d
+CROSS JOIN (VALUES ('name'), ('address'), ('postcode'), ('location')) AS f (field_name)
+WHERE d.place_synced_at IS NOT NULL
+AND d.is_managed IS NOT TRUE
+ON CONFLICT (dogrun_id, field_name) DO NOTHING;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_dogruns_geocodeattemptedat;
+ALTER TABLE dogruns DROP COLUMN IF EXISTS geocode_attempted_at;
@@ -0,0 +1,6 @@
+-- ジオコーディングによる座標・郵便番号の補完
+ALTER TABLE dogruns ADD COLUMN IF NOT EXISTS geocode_attempted_at timestamp; -- 最後に補完を試みた日時
+
+-- 補完対象の選択用(最後に補完を試みた日時の古い順)
+CREATE INDEX IF NOT EXISTS idx_dogruns_geocodeattemptedat
+ON dogruns (geocode_attempted_at NULLS FIRST) WHERE place_id IS NULL;
@@ -0,0 +1,16 @@
+-- 移行した操作記録は監査ログに残したまま、テーブルのみ戻す
+CREATE TABLE IF NOT EXISTS admin_action_logs (
+    admin_action_log_id serial primary key,         -- PK
+    system_operator_id bigint not null,             -- 操作した運営者
+    action varchar(32) not null,                    -- 操作内容(suspend, merge_dogrun, moderate など)
+    target_type varchar(32) not null,               -- 操作対象の種別(dogowner, dogrun, tag_mst など)
+    target_id varchar(64) not null,                 -- 操作対象のID
+    note varchar(512),                              -- 操作理由
+    detail text,                                    -- 操作内容の詳細(JSON)
+    reg_at timestamp not null                       -- 登録日
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_action_logs_targettype_targetid
+ON admin_action_logs (target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_admin_action_logs_systemoperatorid
+ON admin_action_logs (system_operator_id);
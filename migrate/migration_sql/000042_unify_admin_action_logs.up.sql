@@ -0,0 +1,16 @@
+-- 運営者の操作記録は監査ログ(audit_logs)に統一する
+-- 既存の操作記録はSYSTEM(0)のロールの監査ログとして移行する
+INSERT INTO audit_logs (actor_id, actor_role, action, result, target_type, target_id, after_data, reg_at)
+SELECT
+    system_operator_id,
+    0,
+    action,
+    'success',
+    target_type,
+    target_id,
+    jsonb_strip_nulls(jsonb_build_object('note', NULLIF(note, ''), 'detail', detail::jsonb))::text,
+    reg_at
+FROM admin_action_logs
+ORDER BY admin_action_log_id;
+
+DROP TABLE IF EXISTS admin_action_logs;
@@ -0,0 +1,12 @@
+-- システムユーザー(運営者)
+CREATE TABLE IF NOT EXISTS system_operators (
+    system_operator_id serial primary key,          -- PK
+    name varchar(128) not null,                     -- 運営者名
+    email varchar(255) unique not null,             -- ログイン用のメールアドレス
+    password varchar(256) not null,                 -- パスワード(bcrypt)
+    jwt_id varchar(45),                             -- 発行済みトークンのjwt_id
+    is_active boolean not null default true,        -- 有効か(無効化された運営者はログイン不可)
+    login_at timestamp,                             -- 最後のログイン時間
+    reg_at timestamp not null,                      -- 登録日
+    upd_at timestamp not null                       -- 更新日
+);
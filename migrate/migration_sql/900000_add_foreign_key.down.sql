@@ -1,5 +1,72 @@
 alter table dogs drop constraint dev_dogs_dog_owner_id_fkey;
-alter table dogs drop constraint dev_dogs_dog_type_id_fkey;
+
+alter table dog_dog_types drop constraint dev_dog_dog_types_dog_id_fkey;
+alter table dog_dog_types drop constraint dev_dog_dog_types_dog_type_id_fkey;
+
+alter table dog_temperaments drop constraint dev_dog_temperaments_dog_id_fkey;
+alter table dog_temperaments drop constraint dev_dog_temperaments_temperament_id_fkey;
+
+alter table dog_co_owners drop constraint dev_dog_co_owners_dog_id_fkey;
+alter table dog_co_owners drop constraint dev_dog_co_owners_dog_owner_id_fkey;
+
+alter table dog_ownership_invitations drop constraint dev_dog_ownership_invitations_dog_id_fkey;
+alter table dog_ownership_invitations drop constraint dev_dog_ownership_invitations_inviter_id_fkey;
+alter table dog_ownership_invitations drop constraint dev_dog_ownership_invitations_invitee_id_fkey;
+
+alter table dog_social_profiles drop constraint dev_dog_social_profiles_dog_id_fkey;
+
+alter table dog_friendships drop constraint dev_dog_friendships_requester_dog_id_fkey;
+alter table dog_friendships drop constraint dev_dog_friendships_addressee_dog_id_fkey;
+
+alter table dogrun_events drop constraint dev_dogrun_events_dogrun_id_fkey;
+alter table dogrun_events drop constraint dev_dogrun_events_organizer_dog_owner_id_fkey;
+alter table dogrun_events drop constraint dev_dogrun_events_organizer_dogrun_manager_id_fkey;
+alter table dogrun_events drop constraint dev_dogrun_events_special_business_hours_id_fkey;
+
+alter table dogrun_event_rsvps drop constraint dev_dogrun_event_rsvps_dogrun_event_id_fkey;
+alter table dogrun_event_rsvps drop constraint dev_dogrun_event_rsvps_dog_id_fkey;
+alter table dogrun_event_rsvps drop constraint dev_dogrun_event_rsvps_dog_owner_id_fkey;
+
+alter table dogrun_booking_settings drop constraint dev_dogrun_booking_settings_dogrun_id_fkey;
+
+alter table dogrun_booking_slots drop constraint dev_dogrun_booking_slots_dogrun_id_fkey;
+
+alter table dogrun_reservations drop constraint dev_dogrun_reservations_dogrun_booking_slot_id_fkey;
+alter table dogrun_reservations drop constraint dev_dogrun_reservations_dogrun_id_fkey;
+alter table dogrun_reservations drop constraint dev_dogrun_reservations_dog_owner_id_fkey;
+
+alter table dogrun_reservation_dogs drop constraint dev_dogrun_reservation_dogs_dogrun_reservation_id_fkey;
+alter table dogrun_reservation_dogs drop constraint dev_dogrun_reservation_dogs_dog_id_fkey;
+
+alter table dogrun_fee_plans drop constraint dev_dogrun_fee_plans_dogrun_id_fkey;
+
+alter table dogrun_payment_settings drop constraint dev_dogrun_payment_settings_dogrun_id_fkey;
+
+alter table dogrun_payments drop constraint dev_dogrun_payments_dogrun_id_fkey;
+alter table dogrun_payments drop constraint dev_dogrun_payments_dogrun_fee_plan_id_fkey;
+alter table dogrun_payments drop constraint dev_dogrun_payments_dog_owner_id_fkey;
+alter table dogrun_payments drop constraint dev_dogrun_payments_dogrun_reservation_id_fkey;
+
+alter table dogrun_memberships drop constraint dev_dogrun_memberships_dogrun_id_fkey;
+alter table dogrun_memberships drop constraint dev_dogrun_memberships_dog_owner_id_fkey;
+
+alter table dogrun_membership_dogs drop constraint dev_dogrun_membership_dogs_dogrun_membership_id_fkey;
+alter table dogrun_membership_dogs drop constraint dev_dogrun_membership_dogs_dog_id_fkey;
+
+alter table dogrun_membership_documents drop constraint dev_dogrun_membership_documents_dogrun_membership_id_fkey;
+
+alter table dogrun_manager_invitations drop constraint dev_dogrun_manager_invitations_organization_id_fkey;
+alter table dogrun_manager_invitations drop constraint dev_dogrun_manager_invitations_inviter_id_fkey;
+alter table dogrun_manager_invitations drop constraint dev_dogrun_manager_invitations_dogrun_manager_id_fkey;
+
+alter table dogrun_claims drop constraint dev_dogrun_claims_dogrun_id_fkey;
+alter table dogrun_claims drop constraint dev_dogrun_claims_dogrun_manager_id_fkey;
+alter table dogrun_claim_documents drop constraint dev_dogrun_claim_documents_dogrun_claim_id_fkey;
+alter table dogrun_claim_histories drop constraint dev_dogrun_claim_histories_dogrun_claim_id_fkey;
+alter table dogrun_place_sync_histories drop constraint dev_dogrun_place_sync_histories_dogrun_id_fkey;
+alter table dogrun_field_provenances drop constraint dev_dogrun_field_provenances_dogrun_id_fkey;
+
+alter table dogruns drop constraint dev_dogruns_merged_into_dogrun_id_fkey;
 
 alter table injection_certifications drop constraint dev_injection_certifications_dog_id_fkey;
 
@@ -11,6 +78,8 @@ alter table special_business_hours drop constraint dev_special_business_hours_do
 
 alter table dogrun_images drop constraint dev_dogrun_images_dogrun_id_fkey;
 
+alter table dogrun_entry_criteria drop constraint dev_dogrun_entry_criteria_dogrun_id_fkey;
+
 alter table dogrun_tags drop constraint dev_dogrun_tags_dogrun_id_fkey;
 alter table dogrun_tags drop constraint dev_dogrun_tags_tag_id_fkey;
 
@@ -20,9 +89,14 @@ alter table auth_dogrun_managers drop constraint dev_auth_dogrun_managers_dogrun
 
 alter table dogrun_bookmarks drop constraint dev_dogrun_bookmarks_dogrun_id_fkey;
 alter table dogrun_bookmarks drop constraint dev_dogrun_bookmarks_dog_owner_id_fkey; 
+alter table dogrun_bookmarks drop constraint dev_dogrun_bookmarks_bookmark_folder_id_fkey;
+
+alter table bookmark_folders drop constraint dev_bookmark_folders_dog_owner_id_fkey;
 
 alter table dogrun_checkin drop constraint dev_dogrun_checkin_dogrun_id_fkey;
 alter table dogrun_checkin drop constraint dev_dogrun_checkin_dog_id_fkey; 
 
 alter table dogrun_checkout drop constraint dev_dogrun_checkout_dogrun_id_fkey;
-alter table dogrun_checkout drop constraint dev_dogrun_checkout_dog_id_fkey; 
\ No newline at end of file
+alter table dogrun_checkout drop constraint dev_dogrun_checkout_dog_id_fkey; 
+
+alter table s3_file_variants drop constraint dev_s3_file_variants_s3_file_info_id_fkey;
@@ -1,5 +1,72 @@
 alter table dogs add constraint dev_dogs_dog_owner_id_fkey foreign key (dog_owner_id) references dog_owners (dog_owner_id);
-alter table dogs add constraint dev_dogs_dog_type_id_fkey foreign key (dog_type_id) references dog_type_mst (dog_type_id);
+
+alter table dog_dog_types add constraint dev_dog_dog_types_dog_id_fkey foreign key (dog_id) references dogs (dog_id);
+alter table dog_dog_types add constraint dev_dog_dog_types_dog_type_id_fkey foreign key (dog_type_id) references dog_type_mst (dog_type_id);
+
+alter table dog_temperaments add constraint dev_dog_temperaments_dog_id_fkey foreign key (dog_id) references dogs (dog_id);
+alter table dog_temperaments add constraint dev_dog_temperaments_temperament_id_fkey foreign key (temperament_id) references temperament_mst (temperament_id);
+
+alter table dog_co_owners add constraint dev_dog_co_owners_dog_id_fkey foreign key (dog_id) references dogs (dog_id);
+alter table dog_co_owners add constraint dev_dog_co_owners_dog_owner_id_fkey foreign key (dog_owner_id) references dog_owners (dog_owner_id);
+
+alter table dog_ownership_invitations add constraint dev_dog_ownership_invitations_dog_id_fkey foreign key (dog_id) references dogs (dog_id);
+alter table dog_ownership_invitations add constraint dev_dog_ownership_invitations_inviter_id_fkey foreign key (inviter_id) references dog_owners (dog_owner_id);
+alter table dog_ownership_invitations add constraint dev_dog_ownership_invitations_invitee_id_fkey foreign key (invitee_id) references dog_owners (dog_owner_id);
+
+alter table dog_social_profiles add constraint dev_dog_social_profiles_dog_id_fkey foreign key (dog_id) references dogs (dog_id);
+
+alter table dog_friendships add constraint dev_dog_friendships_requester_dog_id_fkey foreign key (requester_dog_id) references dogs (dog_id);
+alter table dog_friendships add constraint dev_dog_friendships_addressee_dog_id_fkey foreign key (addressee_dog_id) references dogs (dog_id);
+
+alter table dogrun_events add constraint dev_dogrun_events_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
+alter table dogrun_events add constraint dev_dogrun_events_organizer_dog_owner_id_fkey foreign key (organizer_dog_owner_id) references dog_owners (dog_owner_id);
+alter table dogrun_events add constraint dev_dogrun_events_organizer_dogrun_manager_id_fkey foreign key (organizer_dogrun_manager_id) references dogrun_managers (dogrun_manager_id);
+alter table dogrun_events add constraint dev_dogrun_events_special_business_hours_id_fkey foreign key (special_business_hours_id) references special_business_hours (special_business_hours_id);
+
+alter table dogrun_event_rsvps add constraint dev_dogrun_event_rsvps_dogrun_event_id_fkey foreign key (dogrun_event_id) references dogrun_events (dogrun_event_id);
+alter table dogrun_event_rsvps add constraint dev_dogrun_event_rsvps_dog_id_fkey foreign key (dog_id) references dogs (dog_id);
+alter table dogrun_event_rsvps add constraint dev_dogrun_event_rsvps_dog_owner_id_fkey foreign key (dog_owner_id) references dog_owners (dog_owner_id);
+
+alter table dogrun_booking_settings add constraint dev_dogrun_booking_settings_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
+
+alter table dogrun_booking_slots add constraint dev_dogrun_booking_slots_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
+
+alter table dogrun_reservations add constraint dev_dogrun_reservations_dogrun_booking_slot_id_fkey foreign key (dogrun_booking_slot_id) references dogrun_booking_slots (dogrun_booking_slot_id);
+alter table dogrun_reservations add constraint dev_dogrun_reservations_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
+alter table dogrun_reservations add constraint dev_dogrun_reservations_dog_owner_id_fkey foreign key (dog_owner_id) references dog_owners (dog_owner_id);
+
+alter table dogrun_reservation_dogs add constraint dev_dogrun_reservation_dogs_dogrun_reservation_id_fkey foreign key (dogrun_reservation_id) references dogrun_reservations (dogrun_reservation_id);
+alter table dogrun_reservation_dogs add constraint dev_dogrun_reservation_dogs_dog_id_fkey foreign key (dog_id) references dogs (dog_id);
+
+alter table dogrun_fee_plans add constraint dev_dogrun_fee_plans_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
+
+alter table dogrun_payment_settings add constraint dev_dogrun_payment_settings_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
+
+alter table dogrun_payments add constraint dev_dogrun_payments_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
+alter table dogrun_payments add constraint dev_dogrun_payments_dogrun_fee_plan_id_fkey foreign key (dogrun_fee_plan_id) references dogrun_fee_plans (dogrun_fee_plan_id);
+alter table dogrun_payments add constraint dev_dogrun_payments_dog_owner_id_fkey foreign key (dog_owner_id) references dog_owners (dog_owner_id);
+alter table dogrun_payments add constraint dev_dogrun_payments_dogrun_reservation_id_fkey foreign key (dogrun_reservation_id) references dogrun_reservations (dogrun_reservation_id);
+
+alter table dogrun_memberships add constraint dev_dogrun_memberships_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
+alter table dogrun_memberships add constraint dev_dogrun_memberships_dog_owner_id_fkey foreign key (dog_owner_id) references dog_owners (dog_owner_id);
+
+alter table dogrun_membership_dogs add constraint dev_dogrun_membership_dogs_dogrun_membership_id_fkey foreign key (dogrun_membership_id) references dogrun_memberships (dogrun_membership_id);
+alter table dogrun_membership_dogs add constraint dev_dogrun_membership_dogs_dog_id_fkey foreign key (dog_id) references dogs (dog_id);
+
+alter table dogrun_membership_documents add constraint dev_dogrun_membership_documents_dogrun_membership_id_fkey foreign key (dogrun_membership_id) references dogrun_memberships (dogrun_membership_id);
+
+alter table dogrun_manager_invitations add constraint dev_dogrun_manager_invitations_organization_id_fkey foreign key (organization_id) references organizations (organization_id);
+alter table dogrun_manager_invitations add constraint dev_dogrun_manager_invitations_inviter_id_fkey foreign key (inviter_id) references dogrun_managers (dogrun_manager_id);
+alter table dogrun_manager_invitations add constraint dev_dogrun_manager_invitations_dogrun_manager_id_fkey foreign key (dogrun_manager_id) references dogrun_managers (dogrun_manager_id);
+
+alter table dogrun_claims add constraint dev_dogrun_claims_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
+alter table dogrun_claims add constraint dev_dogrun_claims_dogrun_manager_id_fkey foreign key (dogrun_manager_id) references dogrun_managers (dogrun_manager_id);
+alter table dogrun_claim_documents add constraint dev_dogrun_claim_documents_dogrun_claim_id_fkey foreign key (dogrun_claim_id) references dogrun_claims (dogrun_claim_id);
+alter table dogrun_claim_histories add constraint dev_dogrun_claim_histories_dogrun_claim_id_fkey foreign key (dogrun_claim_id) references dogrun_claims (dogrun_claim_id);
+alter table dogrun_place_sync_histories add constraint dev_dogrun_place_sync_histories_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
+alter table dogrun_field_provenances add constraint dev_dogrun_field_provenances_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
+
+alter table dogruns add constraint dev_dogruns_merged_into_dogrun_id_fkey foreign key (merged_into_dogrun_id) references dogruns (dogrun_id);
 
 alter table injection_certifications add constraint dev_injection_certifications_dog_id_fkey foreign key (dog_id) references dogs (dog_id);
 
@@ -11,6 +78,8 @@ alter table special_business_hours add constraint dev_special_business_hours_dog
 
 alter table dogrun_images add constraint dev_dogrun_images_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
 
+alter table dogrun_entry_criteria add constraint dev_dogrun_entry_criteria_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
+
 alter table dogrun_tags add constraint dev_dogrun_tags_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
 alter table dogrun_tags add constraint dev_dogrun_tags_tag_id_fkey foreign key (tag_id) references tag_mst (tag_id);
 
@@ -20,8 +89,9 @@ alter table dog_owner_credentials add constraint dev_dog_owner_credentials_id_fk
 
 alter table dogrun_bookmarks add constraint dev_dogrun_bookmarks_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
 alter table dogrun_bookmarks add constraint dev_dogrun_bookmarks_dog_owner_id_fkey foreign key (dog_owner_id) references dog_owners (dog_owner_id);
+alter table dogrun_bookmarks add constraint dev_dogrun_bookmarks_bookmark_folder_id_fkey foreign key (bookmark_folder_id) references bookmark_folders (bookmark_folder_id);
 
-alter table s3_file_info add constraint dev_s3_file_info_dog_owners_id_fkey foreign key (dog_owner_id) references dog_owners(dog_owner_id);
+alter table bookmark_folders add constraint dev_bookmark_folders_dog_owner_id_fkey foreign key (dog_owner_id) references dog_owners (dog_owner_id);
 
 -- `organizations`と`dogrun_managers`のリレーション
 alter table dogrun_managers add constraint dev_dogrun_managers_organization_id_fkey foreign key (organization_id) references organizations (organization_id);
@@ -36,4 +106,6 @@ alter table dogrun_checkin add constraint dev_dogrun_checkin_dogrun_id_fkey fore
 alter table dogrun_checkin add constraint dev_dogrun_checkin_dog_id_fkey foreign key (dog_id) references dogs (dog_id);
 
 alter table dogrun_checkout add constraint dev_dogrun_checkout_dogrun_id_fkey foreign key (dogrun_id) references dogruns (dogrun_id);
-alter table dogrun_checkout add constraint dev_dogrun_checkout_dog_id_fkey foreign key (dog_id) references dogs (dog_id);
\ No newline at end of file
+alter table dogrun_checkout add constraint dev_dogrun_checkout_dog_id_fkey foreign key (dog_id) references dogs (dog_id);
+
+alter table s3_file_variants add constraint dev_s3_file_variants_s3_file_info_id_fkey foreign key (s3_file_info_id) references s3_file_info (s3_file_info_id);
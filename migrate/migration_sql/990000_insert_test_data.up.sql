@@ -21,13 +21,21 @@ INSERT INTO dog_owner_credentials (auth_dog_owner_id, provider_name, grant_type,
 (4, 'google', 'oauth', 'dev@example.com', NULL, 'google_user_4', NULL, NOW());
 
 -- dogs テーブルに追加のテストデータを挿入
-INSERT INTO dogs (dog_owner_id, name, dog_type_id, weight, sex, image, reg_at, upd_at) VALUES
-(1, 'Charlie', 1, 28, 'M', 'https://example.com/images/charlie.jpg', NOW(), NOW()),
-(1, 'Daisy', 2, 22, 'F', 'https://example.com/images/daisy.jpg', NOW(), NOW()),
-(2, 'Rocky', 3, 34, 'M', 'https://example.com/images/rocky.jpg', NOW(), NOW()),
-(3, 'Sophie', 1, 30, 'F', 'https://example.com/images/sophie.jpg', NOW(), NOW()),
-(4, 'Cooper', 2, 26, 'M', 'https://example.com/images/cooper.jpg', NOW(), NOW()),
-(4, 'Chloe', 4, 15, 'F', 'https://example.com/images/chloe.jpg', NOW(), NOW());
+INSERT INTO dogs (dog_owner_id, name, weight, sex, image, birth_date, neutered, microchip_id, reg_at, upd_at) VALUES
+(1, 'Charlie', 28, 'M', 'https://example.com/images/charlie.jpg', '2020-04-01', true, '392000000000001', NOW(), NOW()),
+(1, 'Daisy', 22, 'F', 'https://example.com/images/daisy.jpg', '2021-08-15', true, NULL, NOW(), NOW()),
+(2, 'Rocky', 34, 'M', 'https://example.com/images/rocky.jpg', '2019-01-20', false, '392000000000003', NOW(), NOW()),
+(3, 'Sophie', 30, 'F', 'https://example.com/images/sophie.jpg', NULL, NULL, NULL, NOW(), NOW()),
+(4, 'Cooper', 26, 'M', 'https://example.com/images/cooper.jpg', '2023-06-10', false, NULL, NOW(), NOW()),
+(4, 'Chloe', 15, 'F', 'https://example.com/images/chloe.jpg', '2022-11-03', true, '392000000000006', NOW(), NOW());
+
+-- dog_dog_types テーブルに追加のテストデータを挿入
+INSERT INTO dog_dog_types (dog_id, dog_type_id) VALUES
+(1, 1), (2, 2), (3, 3), (4, 1), (5, 2), (6, 4), (6, 20);
+
+-- dog_temperaments テーブルに追加のテストデータを挿入
+INSERT INTO dog_temperaments (dog_id, temperament_id) VALUES
+(1, 1), (1, 5), (2, 2), (3, 8), (6, 3), (6, 9);
 
 -- dogruns テーブルに追加のテストデータを挿入
 INSERT INTO dogruns (place_id, dogrun_manager_id, name, address, postcode, latitude, longitude, description, is_managed, reg_at, upd_at) VALUES
@@ -112,3 +120,7 @@ INSERT INTO dogrun_tags (dogrun_id, tag_id) VALUES
 (4, 26);
 
 
+
+-- system_operators テーブルにテストデータを挿入
+INSERT INTO system_operators (name, email, password, is_active, reg_at, upd_at) VALUES
+('Operator', 'operator@example.com', '$2a$10$dfdZ5z74pRE2.7RzwSmHtuU7x1Ir8ul0nD/jwakDg/Pd5uE8/f36C', true, NOW(), NOW());
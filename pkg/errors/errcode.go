@@ -12,6 +12,8 @@ const (
 	ORG         int = 6
 	DOGRUNMG    int = 7
 	INTERACTION int = 8
+	ADMIN       int = 9
+	AUDIT       int = 10
 )
 
 const (
@@ -147,3 +149,31 @@ dogrunmg機能のサーバーエラー
 func NewDogrunmgServerErrorEType() eType {
 	return eType{DOGRUNMG, SERVER}
 }
+
+/*
+admin機能のクライアントエラー
+*/
+func NewAdminClientErrorEType() eType {
+	return eType{ADMIN, CLIENT}
+}
+
+/*
+admin機能のサーバーエラー
+*/
+func NewAdminServerErrorEType() eType {
+	return eType{ADMIN, SERVER}
+}
+
+/*
+audit機能のクライアントエラー
+*/
+func NewAuditClientErrorEType() eType {
+	return eType{AUDIT, CLIENT}
+}
+
+/*
+audit機能のサーバーエラー
+*/
+func NewAuditServerErrorEType() eType {
+	return eType{AUDIT, SERVER}
+}
@@ -58,6 +58,14 @@ func GetLogger(c echo.Context) *zap.Logger {
 	return logger
 }
 
+/*
+HTTPリクエスト以外(定期実行のジョブなど)のloggerを生成
+ジョブの実行IDをrequest_idとして出力する
+*/
+func NewJobLogger(jobID string) *zap.Logger {
+	return gLogger.With(zap.String("request_id", jobID))
+}
+
 // 大元のzap.logger
 var gLogger *zap.Logger
 
@@ -78,6 +78,24 @@ func NewSqlNullInt64(value int64) sql.NullInt64 {
 	}
 }
 
+/*
+float64型の値をsql.NullFloat64に変換
+*/
+func NewSqlNullFloat64(value float64) sql.NullFloat64 {
+	// 値がゼロの場合は未設定として `Valid: false` と設定
+	if value == 0 {
+		return sql.NullFloat64{
+			Float64: 0,
+			Valid:   false,
+		}
+	}
+	// 有効な値の場合は `Valid: true` として設定
+	return sql.NullFloat64{
+		Float64: value,
+		Valid:   true,
+	}
+}
+
 /*
 bool型の値をsql.NullBoolに変換
 */
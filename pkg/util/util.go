@@ -2,6 +2,7 @@ package util
 
 import (
 	"database/sql"
+	"math"
 	"strings"
 	"time"
 
@@ -135,3 +136,22 @@ func ConvertStringPointer(ptr *string) string {
 	}
 	return *ptr
 }
+
+// CalcDistance: 2点間の距離をハーバーサイン公式で求める
+//
+// args:
+//   - float64:	1点目の緯度
+//   - float64:	1点目の経度
+//   - float64:	2点目の緯度
+//   - float64:	2点目の経度
+//
+// return:
+//   - float64:	距離(m)
+func CalcDistance(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadius = 6371000
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLng := (lng2 - lng1) * math.Pi / 180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*math.Pi/180)*math.Cos(lat2*math.Pi/180)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return earthRadius * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}